@@ -3,13 +3,13 @@ package fake
 import (
 	"flamingo.me/form/application"
 	"flamingo.me/form/domain"
-	"flamingo.me/form/domain/mocks"
+	"flamingo.me/form/domain/extensions"
 )
 
 type (
 	// formHandlerBuilderImpl defines faked implementation of FormHandlerBuilder interface used for unit testing
 	formHandlerBuilderImpl struct {
-		formHandler *mocks.FormHandler
+		formHandler domain.FormHandler
 	}
 )
 
@@ -65,6 +65,76 @@ func (b *formHandlerBuilderImpl) AddFormExtension(formExtension domain.FormExten
 	return nil
 }
 
+// EnableCSRF fakes storing of CSRF token form extension into mocked instance of domain.FormHandler.
+func (b *formHandlerBuilderImpl) EnableCSRF() error {
+	return nil
+}
+
+// EnableSpamProtection fakes storing of spam protection form extension into mocked instance of domain.FormHandler.
+func (b *formHandlerBuilderImpl) EnableSpamProtection(options extensions.SpamProtectionOptions) error {
+	return nil
+}
+
+// AddPrefillProvider fakes storing of prefill provider into mocked instance of domain.FormHandler.
+func (b *formHandlerBuilderImpl) AddPrefillProvider(prefillProvider domain.PrefillProvider) application.FormHandlerBuilder {
+	return b
+}
+
+// AddPostProcessor fakes storing of post processor into mocked instance of domain.FormHandler.
+func (b *formHandlerBuilderImpl) AddPostProcessor(postProcessor domain.PostProcessor) application.FormHandlerBuilder {
+	return b
+}
+
+// BindChoices fakes storing of choice provider into mocked instance of domain.FormHandler.
+func (b *formHandlerBuilderImpl) BindChoices(fieldName string, choiceProvider domain.ChoiceProvider) application.FormHandlerBuilder {
+	return b
+}
+
+// SetValidationMode fakes storing of validation mode into mocked instance of domain.FormHandler.
+func (b *formHandlerBuilderImpl) SetValidationMode(validationMode domain.ValidationMode) application.FormHandlerBuilder {
+	return b
+}
+
+// SetValidationModeOverride fakes storing of overriding validation mode into mocked instance of domain.FormHandler.
+func (b *formHandlerBuilderImpl) SetValidationModeOverride(name string, validationMode domain.ValidationMode) application.FormHandlerBuilder {
+	return b
+}
+
+// SetActiveSteps fakes storing of active form steps into mocked instance of domain.FormHandler.
+func (b *formHandlerBuilderImpl) SetActiveSteps(steps ...string) application.FormHandlerBuilder {
+	return b
+}
+
+// SetFormSessionStore fakes storing of form session store into mocked instance of domain.FormHandler.
+func (b *formHandlerBuilderImpl) SetFormSessionStore(formSessionStore application.FormSessionStore, formIdentifier string) application.FormHandlerBuilder {
+	return b
+}
+
+// SetSubmissionGuard fakes storing of submission guard into mocked instance of domain.FormHandler.
+func (b *formHandlerBuilderImpl) SetSubmissionGuard(submissionGuard application.SubmissionGuard, formIdentifier string) application.FormHandlerBuilder {
+	return b
+}
+
+// EnableStatePersistence fakes storing of form state store into mocked instance of domain.FormHandler.
+func (b *formHandlerBuilderImpl) EnableStatePersistence(stateStore domain.FormStateStore) application.FormHandlerBuilder {
+	return b
+}
+
+// SetNamespace fakes storing of form namespace into mocked instance of domain.FormHandler.
+func (b *formHandlerBuilderImpl) SetNamespace(namespace string) application.FormHandlerBuilder {
+	return b
+}
+
+// SetSubmitDetector fakes storing of submit detector into mocked instance of domain.FormHandler.
+func (b *formHandlerBuilderImpl) SetSubmitDetector(submitDetector domain.SubmitDetector) application.FormHandlerBuilder {
+	return b
+}
+
+// SetStageTimeouts fakes storing of stage timeouts into mocked instance of domain.FormHandler.
+func (b *formHandlerBuilderImpl) SetStageTimeouts(timeouts application.StageTimeouts) application.FormHandlerBuilder {
+	return b
+}
+
 // Must fakes storing wrapping of methods that can returns error message.
 func (b *formHandlerBuilderImpl) Must(error) application.FormHandlerBuilder {
 	return b
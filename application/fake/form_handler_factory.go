@@ -3,18 +3,18 @@ package fake
 import (
 	"flamingo.me/form/application"
 	"flamingo.me/form/domain"
-	"flamingo.me/form/domain/mocks"
 )
 
 type (
 	// FormHandlerFactoryImpl defines faked implementation of FormHandlerFactory interface used for unit testing
 	FormHandlerFactoryImpl struct {
-		formHandler *mocks.FormHandler
+		formHandler domain.FormHandler
 	}
 )
 
-// New returns faked implementation of FormHandlerFactory interface which should deliver mocked domain.FormHandler instance
-func New(formHandler *mocks.FormHandler) application.FormHandlerFactory {
+// New returns faked implementation of FormHandlerFactory interface which should deliver mocked domain.FormHandler instance.
+// Besides instance of mocks.FormHandler, it accepts any other faked domain.FormHandler, like formtest.FakeFormHandler.
+func New(formHandler domain.FormHandler) application.FormHandlerFactory {
 	return &FormHandlerFactoryImpl{
 		formHandler: formHandler,
 	}
@@ -0,0 +1,142 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+const (
+	// invalidChoiceMessageKey as message key of field error for submitted value which is not one of field's options
+	invalidChoiceMessageKey = "formError.invalidChoice"
+	// invalidChoiceTag as validation tag of field error for submitted value which is not one of field's options
+	invalidChoiceTag = "invalidChoice"
+)
+
+// loadChoices as method for setting options of all enumerated fields into the form, by using bound choice providers
+func (h *formHandlerImpl) loadChoices(ctx context.Context, req *web.Request, form *domain.Form) {
+	for _, fieldName := range h.getChoiceFieldNames() {
+		form.SetChoices(fieldName, h.choiceProviders[fieldName].Options(ctx, req))
+	}
+}
+
+// validateChoices as method for validating submitted values of enumerated fields against their options.
+// Each element of multi-select fields (like []string) has to be one of options. Empty (zero) values are not validated,
+// so they are left to "required" validation rule. Disabled options can't be submitted.
+func (h *formHandlerImpl) validateChoices(form *domain.Form) domain.ValidationInfo {
+	validationInfo := domain.ValidationInfo{}
+
+	for _, fieldName := range h.getChoiceFieldNames() {
+		allowed := map[string]bool{}
+		for _, value := range domain.ChoiceValues(form.ChoicesFor(fieldName)) {
+			allowed[value] = true
+		}
+
+		for _, value := range h.getChoiceValues(form.Data, fieldName) {
+			if !allowed[value] {
+				// field gets one error, no matter how many of its values are invalid
+				validationInfo.AddFieldErrorWithParams(fieldName, invalidChoiceMessageKey, "invalid choice", map[string]string{
+					"tag":   invalidChoiceTag,
+					"field": fieldName,
+				})
+				break
+			}
+		}
+	}
+
+	return validationInfo
+}
+
+// getChoiceFieldNames as method for getting sorted names of all fields with bound choice providers,
+// so options are loaded and validated in deterministic order
+func (h *formHandlerImpl) getChoiceFieldNames() []string {
+	fieldNames := make([]string, 0, len(h.choiceProviders))
+	for fieldName := range h.choiceProviders {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	return fieldNames
+}
+
+// getChoiceValues as method for finding submitted values of enumerated field in form data, by following its
+// form field name (like "address.country") through nested structs and maps with string keys
+func (h *formHandlerImpl) getChoiceValues(formData interface{}, fieldName string) []string {
+	value := reflect.ValueOf(formData)
+
+	for _, name := range strings.Split(fieldName, ".") {
+		value = h.indirectChoiceValue(value)
+
+		switch value.Kind() {
+		case reflect.Map:
+			if value.Type().Key().Kind() != reflect.String {
+				return nil
+			}
+			value = value.MapIndex(reflect.ValueOf(name).Convert(value.Type().Key()))
+		case reflect.Struct:
+			// fields are walked on addressable copy, since only settable fields are visited
+			copied := reflect.New(value.Type()).Elem()
+			copied.Set(value)
+
+			found := reflect.Value{}
+			_ = h.walkFormFields(copied, "", func(_ reflect.StructField, fieldValue reflect.Value, formName string, _ string) error {
+				if formName == name {
+					found = fieldValue
+				}
+				return nil
+			})
+			value = found
+		default:
+			return nil
+		}
+
+		if !value.IsValid() {
+			return nil
+		}
+	}
+
+	return h.collectChoiceValues(value)
+}
+
+// collectChoiceValues as method for converting field value into list of submitted values, where slices and arrays
+// contain one value per element. Values of types which can't be presented as options are ignored.
+func (h *formHandlerImpl) collectChoiceValues(value reflect.Value) []string {
+	value = h.indirectChoiceValue(value)
+
+	switch value.Kind() {
+	case reflect.Slice, reflect.Array:
+		var values []string
+		for i := 0; i < value.Len(); i++ {
+			values = append(values, h.collectChoiceValues(value.Index(i))...)
+		}
+
+		return values
+	case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		// zero value means that nothing is chosen
+		if value.IsZero() {
+			return nil
+		}
+
+		return []string{fmt.Sprint(value.Interface())}
+	}
+
+	return nil
+}
+
+// indirectChoiceValue as method for dereferencing pointers and interfaces, where nil ones result with invalid value
+func (h *formHandlerImpl) indirectChoiceValue(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+
+	return value
+}
@@ -0,0 +1,294 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/mocks"
+)
+
+type (
+	FormChoicesTestSuite struct {
+		suite.Suite
+
+		handler *formHandlerImpl
+
+		provider          *mocks.FormDataProvider
+		decoder           *mocks.FormDataDecoder
+		validator         *mocks.FormDataValidator
+		validatorProvider *mocks.ValidatorProvider
+
+		context context.Context
+	}
+
+	formChoicesProfileTestData struct {
+		Country   string                     `form:"country"`
+		Interests []string                   `form:"interests"`
+		Address   formChoicesAddressTestData `form:"address"`
+	}
+
+	formChoicesAddressTestData struct {
+		Country *string `form:"country"`
+		Floor   int
+	}
+
+	formChoicesStaticProvider struct {
+		choices []domain.Choice
+	}
+
+	formChoicesCountryProvider struct{}
+)
+
+// Options provides the same options for all requests
+func (p *formChoicesStaticProvider) Options(context.Context, *web.Request) []domain.Choice {
+	return p.choices
+}
+
+// Options provides countries depending on language of the request
+func (p *formChoicesCountryProvider) Options(_ context.Context, req *web.Request) []domain.Choice {
+	if req.Request().Header.Get("Accept-Language") == "de" {
+		return []domain.Choice{
+			{Value: "DE", Label: "Deutschland"},
+			{Value: "AT", Label: "Österreich"},
+			{Value: "CH", Label: "Schweiz"},
+		}
+	}
+
+	return []domain.Choice{
+		{Value: "DE", Label: "Germany"},
+		{Value: "US", Label: "United States"},
+	}
+}
+
+func TestFormChoicesTestSuite(t *testing.T) {
+	suite.Run(t, &FormChoicesTestSuite{})
+}
+
+func (t *FormChoicesTestSuite) SetupTest() {
+	t.provider = &mocks.FormDataProvider{}
+	t.decoder = &mocks.FormDataDecoder{}
+	t.validator = &mocks.FormDataValidator{}
+	t.validatorProvider = &mocks.ValidatorProvider{}
+
+	t.handler = &formHandlerImpl{
+		formDataProvider:  t.provider,
+		formDataDecoder:   t.decoder,
+		formDataValidator: t.validator,
+		choiceProviders: map[string]domain.ChoiceProvider{
+			"country": &formChoicesCountryProvider{},
+			"interests": &formChoicesStaticProvider{
+				choices: []domain.Choice{
+					{Value: "sports", Label: "Sports"},
+					{Value: "music", Label: "Music"},
+					{Value: "cooking", Label: "Cooking", Disabled: true},
+				},
+			},
+		},
+		validatorProvider: t.validatorProvider,
+		logger:            &flamingo.NullLogger{},
+	}
+
+	t.context = domain.ContextWithAttachments(context.Background(), domain.NewAttachments())
+}
+
+func (t *FormChoicesTestSuite) TearDownTest() {
+	t.provider.AssertExpectations(t.T())
+	t.decoder.AssertExpectations(t.T())
+	t.validator.AssertExpectations(t.T())
+	t.validatorProvider.AssertExpectations(t.T())
+}
+
+func (t *FormChoicesTestSuite) TestHandleUnsubmittedForm_Choices() {
+	request := t.createRequest("de")
+	t.provider.On("GetFormData", t.context, request).Return(formChoicesProfileTestData{}, nil).Once()
+
+	form, err := t.handler.HandleUnsubmittedForm(t.context, request)
+	t.NoError(err)
+	t.Equal([]domain.Choice{
+		{Value: "DE", Label: "Deutschland"},
+		{Value: "AT", Label: "Österreich"},
+		{Value: "CH", Label: "Schweiz"},
+	}, form.ChoicesFor("country"))
+	t.Equal([]string{"sports", "music"}, domain.ChoiceValues(form.ChoicesFor("interests")))
+	t.Nil(form.ChoicesFor("address.country"))
+}
+
+func (t *FormChoicesTestSuite) TestHandleSubmittedForm_InvalidChoice() {
+	form := t.handleSubmittedForm("en", formChoicesProfileTestData{
+		Country: "XX",
+	})
+	t.False(form.IsValidAndSubmitted())
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.invalidChoice",
+			DefaultLabel: "invalid choice",
+			Parameters: map[string]string{
+				"tag":   "invalidChoice",
+				"field": "country",
+			},
+		},
+	}, form.GetErrorsForField("country"))
+	t.Len(form.ChoicesFor("country"), 2)
+
+	// empty value is left to "required" validation rule
+	form = t.handleSubmittedForm("en", formChoicesProfileTestData{})
+	t.True(form.IsValidAndSubmitted())
+}
+
+func (t *FormChoicesTestSuite) TestHandleSubmittedForm_RequestDependentChoices() {
+	form := t.handleSubmittedForm("de", formChoicesProfileTestData{
+		Country: "AT",
+	})
+	t.True(form.IsValidAndSubmitted())
+
+	form = t.handleSubmittedForm("en", formChoicesProfileTestData{
+		Country: "AT",
+	})
+	t.False(form.IsValid())
+	t.True(form.HasErrorForField("country"))
+}
+
+func (t *FormChoicesTestSuite) TestHandleSubmittedForm_MultiSelect() {
+	testCases := []struct {
+		Name      string
+		Interests []string
+		Valid     bool
+	}{
+		{
+			Name:      "all valid",
+			Interests: []string{"sports", "music"},
+			Valid:     true,
+		},
+		{
+			Name:      "one invalid",
+			Interests: []string{"sports", "gaming", "hacking"},
+		},
+		{
+			Name:      "disabled",
+			Interests: []string{"music", "cooking"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		form := t.handleSubmittedForm("en", formChoicesProfileTestData{
+			Interests: testCase.Interests,
+		})
+		t.Equal(testCase.Valid, form.IsValid(), testCase.Name)
+		if !testCase.Valid {
+			t.Len(form.GetErrorsForField("interests"), 1, testCase.Name)
+		}
+	}
+}
+
+func (t *FormChoicesTestSuite) TestValidateData_Choices() {
+	request := t.createRequest("en")
+	t.validator.On("Validate", t.context, request, t.validatorProvider, mock.Anything).Return(&domain.ValidationInfo{}, nil).Once()
+
+	validationInfo, err := t.handler.ValidateData(t.context, request, formChoicesProfileTestData{
+		Country: "XX",
+	})
+	t.NoError(err)
+	t.True(validationInfo.HasErrorsForField("country"))
+}
+
+func (t *FormChoicesTestSuite) TestGetChoiceValues() {
+	country := "DE"
+
+	testCases := []struct {
+		Name      string
+		FormData  interface{}
+		FieldName string
+		Result    []string
+	}{
+		{
+			Name: "nested pointer",
+			FormData: &formChoicesProfileTestData{
+				Address: formChoicesAddressTestData{
+					Country: &country,
+				},
+			},
+			FieldName: "address.country",
+			Result:    []string{"DE"},
+		},
+		{
+			Name:      "nil pointer",
+			FormData:  formChoicesProfileTestData{},
+			FieldName: "address.country",
+		},
+		{
+			Name: "field without form tag",
+			FormData: formChoicesProfileTestData{
+				Address: formChoicesAddressTestData{
+					Floor: 3,
+				},
+			},
+			FieldName: "address.Floor",
+			Result:    []string{"3"},
+		},
+		{
+			Name: "map",
+			FormData: map[string]interface{}{
+				"country": "US",
+			},
+			FieldName: "country",
+			Result:    []string{"US"},
+		},
+		{
+			Name: "slice",
+			FormData: formChoicesProfileTestData{
+				Interests: []string{"sports", "", "music"},
+			},
+			FieldName: "interests",
+			Result:    []string{"sports", "music"},
+		},
+		{
+			Name:      "unknown field",
+			FormData:  formChoicesProfileTestData{},
+			FieldName: "unknown",
+		},
+		{
+			Name:      "not a struct",
+			FormData:  "DE",
+			FieldName: "country",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Equal(testCase.Result, t.handler.getChoiceValues(testCase.FormData, testCase.FieldName), testCase.Name)
+	}
+}
+
+// handleSubmittedForm handles form submitted by request with provided language, which is decoded into provided form data
+func (t *FormChoicesTestSuite) handleSubmittedForm(language string, formData formChoicesProfileTestData) *domain.Form {
+	request := t.createRequest(language)
+	request.Request().Method = http.MethodPost
+	request.Request().PostForm = url.Values{
+		"country": []string{formData.Country},
+	}
+
+	t.provider.On("GetFormData", t.context, request).Return(formChoicesProfileTestData{}, nil).Once()
+	t.decoder.On("Decode", t.context, request, request.Request().PostForm, formChoicesProfileTestData{}).Return(formData, nil).Once()
+	t.validator.On("Validate", t.context, request, t.validatorProvider, formData).Return(&domain.ValidationInfo{}, nil).Once()
+
+	form, err := t.handler.HandleSubmittedForm(t.context, request)
+	t.NoError(err)
+
+	return form
+}
+
+// createRequest creates request with provided language in "Accept-Language" header
+func (t *FormChoicesTestSuite) createRequest(language string) *web.Request {
+	return web.CreateRequest(&http.Request{
+		Header: http.Header{
+			"Accept-Language": []string{language},
+		},
+	}, nil)
+}
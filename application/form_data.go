@@ -0,0 +1,54 @@
+package application
+
+import (
+	"reflect"
+
+	"flamingo.me/form/domain"
+)
+
+// FormDataOf returns form data as instance of type T, instead of using type assertion which panics if form data
+// provider delivers data of different type. Form data can be stored either as T or as *T, and T can be pointer type
+// as well. It returns error which contains expected and actual type of form data if they don't match.
+func FormDataOf[T any](form *domain.Form) (T, error) {
+	var result T
+
+	expected := reflect.TypeOf((*T)(nil)).Elem()
+
+	if form == nil {
+		return result, domain.NewFormErrorf("form is nil, expected form data of type %s", expected)
+	}
+
+	switch data := form.Data.(type) {
+	case nil:
+		return result, domain.NewFormErrorf("form data is nil, expected %s", expected)
+	case T:
+		if value := reflect.ValueOf(data); value.Kind() == reflect.Ptr && value.IsNil() {
+			return result, domain.NewFormErrorf("form data is nil, expected %s", expected)
+		}
+		return data, nil
+	case *T:
+		if data == nil {
+			return result, domain.NewFormErrorf("form data is nil, expected %s", expected)
+		}
+		return *data, nil
+	}
+
+	if expected.Kind() == reflect.Ptr && reflect.TypeOf(form.Data) == expected.Elem() {
+		copied := reflect.New(expected.Elem())
+		copied.Elem().Set(reflect.ValueOf(form.Data))
+		return copied.Interface().(T), nil
+	}
+
+	return result, domain.NewFormErrorf("form data of type %T can't be used as %s", form.Data, expected)
+}
+
+// MustFormDataOf returns form data as instance of type T, in the same way as FormDataOf does.
+// It panics if form data is not of type T.
+func MustFormDataOf[T any](form *domain.Form) T {
+	result, err := FormDataOf[T](form)
+	if err != nil {
+		panic(err)
+	}
+
+	return result
+}
@@ -0,0 +1,114 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/form/domain"
+)
+
+type (
+	FormDataTestSuite struct {
+		suite.Suite
+	}
+
+	formDataTestData struct {
+		Name string `form:"name"`
+	}
+
+	formDataOtherTestData struct {
+		Title string `form:"title"`
+	}
+)
+
+func TestFormDataTestSuite(t *testing.T) {
+	suite.Run(t, &FormDataTestSuite{})
+}
+
+func (t *FormDataTestSuite) TestFormDataOf_Value() {
+	form := &domain.Form{
+		Data: formDataTestData{
+			Name: "John",
+		},
+	}
+
+	value, err := FormDataOf[formDataTestData](form)
+	t.NoError(err)
+	t.Equal(formDataTestData{
+		Name: "John",
+	}, value)
+
+	pointer, err := FormDataOf[*formDataTestData](form)
+	t.NoError(err)
+	t.Equal(&formDataTestData{
+		Name: "John",
+	}, pointer)
+}
+
+func (t *FormDataTestSuite) TestFormDataOf_Pointer() {
+	data := &formDataTestData{
+		Name: "John",
+	}
+	form := &domain.Form{
+		Data: data,
+	}
+
+	value, err := FormDataOf[formDataTestData](form)
+	t.NoError(err)
+	t.Equal(formDataTestData{
+		Name: "John",
+	}, value)
+
+	pointer, err := FormDataOf[*formDataTestData](form)
+	t.NoError(err)
+	t.True(data == pointer)
+}
+
+func (t *FormDataTestSuite) TestFormDataOf_Nil() {
+	_, err := FormDataOf[formDataTestData](nil)
+	t.EqualError(err, "FormError: form is nil, expected form data of type application.formDataTestData")
+
+	_, err = FormDataOf[formDataTestData](&domain.Form{})
+	t.EqualError(err, "FormError: form data is nil, expected application.formDataTestData")
+
+	_, err = FormDataOf[formDataTestData](&domain.Form{
+		Data: (*formDataTestData)(nil),
+	})
+	t.EqualError(err, "FormError: form data is nil, expected application.formDataTestData")
+
+	pointer, err := FormDataOf[*formDataTestData](&domain.Form{
+		Data: (*formDataTestData)(nil),
+	})
+	t.EqualError(err, "FormError: form data is nil, expected *application.formDataTestData")
+	t.Nil(pointer)
+}
+
+func (t *FormDataTestSuite) TestFormDataOf_Mismatch() {
+	value, err := FormDataOf[formDataTestData](&domain.Form{
+		Data: formDataOtherTestData{},
+	})
+	t.EqualError(err, "FormError: form data of type application.formDataOtherTestData can't be used as application.formDataTestData")
+	t.Equal(formDataTestData{}, value)
+
+	_, err = FormDataOf[*formDataTestData](&domain.Form{
+		Data: &formDataOtherTestData{},
+	})
+	t.EqualError(err, "FormError: form data of type *application.formDataOtherTestData can't be used as *application.formDataTestData")
+}
+
+func (t *FormDataTestSuite) TestMustFormDataOf() {
+	t.Equal(formDataTestData{
+		Name: "John",
+	}, MustFormDataOf[formDataTestData](&domain.Form{
+		Data: &formDataTestData{
+			Name: "John",
+		},
+	}))
+
+	t.Panics(func() {
+		MustFormDataOf[formDataTestData](&domain.Form{
+			Data: map[string]string{},
+		})
+	})
+}
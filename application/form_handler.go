@@ -2,212 +2,580 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"mime"
 	"net/http"
 	"net/url"
 	"reflect"
-	"strings"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.opencensus.io/trace"
 
 	"flamingo.me/flamingo/v3/framework/flamingo"
 	"flamingo.me/flamingo/v3/framework/web"
 	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/formdata"
 )
 
 type (
 	// formHandlerImpl as actual implementation of FormHandler interface
 	formHandlerImpl struct {
-		formDataProvider         domain.FormDataProvider
-		formDataDecoder          domain.FormDataDecoder
-		formDataValidator        domain.FormDataValidator
-		defaultFormDataProvider  domain.DefaultFormDataProvider
-		defaultFormDataDecoder   domain.DefaultFormDataDecoder
-		defaultFormDataValidator domain.DefaultFormDataValidator
-		formExtensions           map[string]domain.FormExtension
-		validatorProvider        domain.ValidatorProvider
-		logger                   flamingo.Logger
+		formDataProvider          domain.FormDataProvider
+		formDataDecoder           domain.FormDataDecoder
+		formDataValidator         domain.FormDataValidator
+		defaultFormDataProvider   domain.DefaultFormDataProvider
+		defaultFormDataDecoder    domain.DefaultFormDataDecoder
+		defaultFormDataValidator  domain.DefaultFormDataValidator
+		formExtensions            map[string]domain.FormExtension
+		prefillProviders          []domain.PrefillProvider
+		postProcessors            []domain.PostProcessor
+		choiceProviders           map[string]domain.ChoiceProvider
+		validationMode            domain.ValidationMode
+		validationModeOverride    *validationModeOverride
+		activeSteps               []string
+		formSessionStore          FormSessionStore
+		formIdentifier            string
+		submissionGuard           SubmissionGuard
+		submissionIdentifier      string
+		stateStore                domain.FormStateStore
+		namespace                 string
+		submitDetector            domain.SubmitDetector
+		validatorProvider         domain.ValidatorProvider
+		validationRuleTranslators map[string]domain.ValidationRuleTranslator
+		metricsEnabled            bool
+		fieldNotation             domain.FieldNotation
+		stageTimeouts             StageTimeouts
+		maxBodySize               int64
+		maxFormKeys               int
+		maxMemory                 int64
+		logger                    flamingo.Logger
+	}
+
+	// validationModeOverride as struct which defines validation mode used when request contains flag with defined name
+	validationModeOverride struct {
+		name string
+		mode domain.ValidationMode
 	}
 )
 
 var _ domain.FormHandler = &formHandlerImpl{}
 
 // HandleForm as method for returning Form instance with state depending on fact if there was form submission or not, via POST request
+// In case when submit detector is defined, it decides if form is submitted, so also GET requests can be submissions.
+// In case when namespace is defined, form is submitted only if request contains values from that namespace.
+// Request with empty JSON body is handled same as unsubmitted form, since there is nothing to decode.
 func (h *formHandlerImpl) HandleForm(ctx context.Context, req *web.Request) (*domain.Form, error) {
-	submitted := req.Request().Method == http.MethodPost
+	submitted := h.wasSubmitted(ctx, req) && h.isNamespaceSubmitted(req) && !h.isEmptyJSONBody(req)
+
+	return h.handle(ctx, req, submitted, func(ctx context.Context, form *domain.Form) (*domain.Form, error) {
+		if submitted {
+			method := http.MethodPost
+			if req.Request().Method == http.MethodGet {
+				method = http.MethodGet
+			}
+
+			return h.handleSubmittedForm(ctx, req, form, method)
+		}
+
+		return h.restoreForm(ctx, req, form), nil
+	})
+}
+
+// HandleUnsubmittedForm as method for returning Form instance which is not submitted
+func (h *formHandlerImpl) HandleUnsubmittedForm(ctx context.Context, req *web.Request) (*domain.Form, error) {
+	return h.handle(ctx, req, false, func(ctx context.Context, form *domain.Form) (*domain.Form, error) {
+		err := h.processExtensions(ctx, req, url.Values{}, form)
+		if err != nil {
+			h.getLogger("formExtensions").Error(err.Error())
+			return nil, domain.NewWrappedFormError(err)
+		}
+
+		return h.restoreForm(ctx, req, form), nil
+	})
+}
+
+// handle as method for building new form and processing it with passed function.
+// In case when metrics are enabled, it records outcome and duration of form handling, within trace span named after form type.
+func (h *formHandlerImpl) handle(ctx context.Context, req *web.Request, submitted bool, process func(ctx context.Context, form *domain.Form) (*domain.Form, error)) (*domain.Form, error) {
+	start := time.Now()
+	process = h.withSubmissionID(req, process)
+	process = h.withStatePersistence(req, process)
+
+	// attachments which are already defined in context (like by controller which collects values of multiple forms)
+	// are shared with form services, instead of new ones
+	attachments, ok := domain.AttachmentsFromContext(ctx)
+	if !ok {
+		attachments = domain.NewAttachments()
+		ctx = domain.ContextWithAttachments(ctx, attachments)
+	}
+	process = h.withAttachments(attachments, process)
 
 	form, err := h.buildForm(ctx, req, submitted)
+	if !h.metricsEnabled {
+		if err != nil {
+			return nil, err
+		}
+
+		return process(ctx, form)
+	}
+
+	formType := unknownFormType
+	if err == nil {
+		formType = getFormType(form.Data)
+	}
+
+	ctx, span := startFormSpan(ctx, formType)
+	defer span.End()
+
+	if err == nil {
+		form, err = process(ctx, form)
+	}
+
+	outcome := getFormOutcome(submitted, form, err)
+	if outcome == FormOutcomeError {
+		span.SetStatus(trace.Status{Code: trace.StatusCodeUnknown, Message: err.Error()})
+	}
+	span.AddAttributes(trace.StringAttribute("form_outcome", outcome))
+
+	recordOutcome(ctx, outcome)
+	recordDuration(ctx, FormPhaseTotal, start)
+
 	if err != nil {
 		return nil, err
 	}
 
-	if submitted {
-		return h.handleSubmittedForm(ctx, req, form, http.MethodPost)
+	return form, nil
+}
+
+// withSubmissionID as method for wrapping form processing, so each form which is presented again (unsubmitted form,
+// or submitted form which is not valid) gets fresh submission ID from submission guard, if it's defined.
+func (h *formHandlerImpl) withSubmissionID(req *web.Request, process func(ctx context.Context, form *domain.Form) (*domain.Form, error)) func(ctx context.Context, form *domain.Form) (*domain.Form, error) {
+	if h.submissionGuard == nil {
+		return process
 	}
 
-	return form, nil
+	return func(ctx context.Context, form *domain.Form) (*domain.Form, error) {
+		form, err := process(ctx, form)
+		if err != nil || form.IsDuplicateSubmission() || form.IsValidAndSubmitted() {
+			return form, err
+		}
+
+		submissionID, err := h.submissionGuard.Generate(ctx, req.Session(), h.submissionIdentifier)
+		if err != nil {
+			h.getLogger("submissionGuard").Error(err.Error())
+			return nil, domain.NewWrappedFormError(err)
+		}
+		form.SetSubmissionID(h.getSubmissionFieldName(), submissionID)
+
+		return form, nil
+	}
 }
 
-// HandleUnsubmittedForm as method for returning Form instance which is not submitted
-func (h *formHandlerImpl) HandleUnsubmittedForm(ctx context.Context, req *web.Request) (*domain.Form, error) {
-	form, err := h.buildForm(ctx, req, false)
-	if err != nil {
-		return nil, err
+// withAttachments as method for wrapping form processing, so values attached by form services via context
+// are attached to returned form, regardless if it's the same form instance which is built at the beginning.
+// Form without any attached value keeps nil attachments.
+func (h *formHandlerImpl) withAttachments(attachments *domain.Attachments, process func(ctx context.Context, form *domain.Form) (*domain.Form, error)) func(ctx context.Context, form *domain.Form) (*domain.Form, error) {
+	return func(ctx context.Context, form *domain.Form) (*domain.Form, error) {
+		form, err := process(ctx, form)
+		if form != nil && len(attachments.Keys()) > 0 {
+			form.SetAttachments(attachments)
+		}
+
+		return form, err
 	}
+}
 
-	err = h.processExtensions(ctx, req, url.Values{}, form)
-	if err != nil {
-		h.getLogger("formExtensions").Error(err.Error())
-		return nil, domain.NewFormError(err.Error())
+// consumeSubmission as method for consuming submission ID sent with submitted form. ID is taken from query of GET requests,
+// and from url encoded or JSON body of all other requests, in handler's namespace if it's defined.
+func (h *formHandlerImpl) consumeSubmission(ctx context.Context, req *web.Request) SubmissionStatus {
+	method := http.MethodPost
+	if req.Request().Method == http.MethodGet {
+		method = http.MethodGet
 	}
 
-	return form, nil
+	fieldName := h.submissionGuard.FieldName()
+	submissionID := ""
+
+	values, err := h.getURLValues(req, method)
+	if err == nil {
+		submissionID = h.stripNamespacedValues(h.filterNamespacedValues(*values)).Get(fieldName)
+	}
+
+	if submissionID == "" && method != http.MethodGet {
+		jsonValues, err := h.getJSONValues(req, nil)
+		if err == nil {
+			submissionID = h.stripNamespacedValues(h.filterNamespacedValues(jsonValues)).Get(fieldName)
+		}
+	}
+
+	return h.submissionGuard.Consume(ctx, req.Session(), h.submissionIdentifier, submissionID)
 }
 
-// HandleSubmittedForm as method for returning Form instance which is submitted via POST request
-func (h *formHandlerImpl) HandleSubmittedForm(ctx context.Context, req *web.Request) (*domain.Form, error) {
-	form, err := h.buildForm(ctx, req, true)
-	if err != nil {
-		return nil, err
+// getSubmissionFieldName as method for defining name of hidden field with submission ID, including handler's namespace
+func (h *formHandlerImpl) getSubmissionFieldName() string {
+	fieldName := h.submissionGuard.FieldName()
+	if h.namespace == "" {
+		return fieldName
+	}
+
+	return h.fieldNotation.FormatFieldName(h.namespace + "." + fieldName)
+}
+
+// startPhase as method for starting trace span for decoding or validating phase, if metrics are enabled.
+// It returns function which ends span and records duration of phase.
+func (h *formHandlerImpl) startPhase(ctx context.Context, phase string) (context.Context, func()) {
+	if !h.metricsEnabled {
+		return ctx, func() {}
 	}
 
-	return h.handleSubmittedForm(ctx, req, form, http.MethodPost)
+	return startPhaseSpan(ctx, phase)
+}
+
+// restoreForm as method for restoring validation info and original values from form session store, which are stored
+// after previous submission (like in Post/Redirect/Get pattern). Form data is kept as it's provided for unsubmitted form.
+func (h *formHandlerImpl) restoreForm(ctx context.Context, req *web.Request, form *domain.Form) *domain.Form {
+	if h.formSessionStore == nil || req == nil {
+		return form
+	}
+
+	stored, ok := h.formSessionStore.Load(ctx, req.Session(), h.formIdentifier)
+	if !ok {
+		return form
+	}
+
+	restored := domain.NewForm(stored.IsSubmitted(), form.GetValidationRules())
+	restored.Data = form.Data
+	restored.FormExtensionsData = form.FormExtensionsData
+	restored.ValidationInfo = stored.ValidationInfo
+	restored.SetOriginalValues(stored.OriginalValues())
+	for fieldName := range h.choiceProviders {
+		restored.SetChoices(fieldName, form.ChoicesFor(fieldName))
+	}
+
+	return &restored
+}
+
+// HandleSubmittedForm as method for returning Form instance which is submitted via POST request
+func (h *formHandlerImpl) HandleSubmittedForm(ctx context.Context, req *web.Request) (*domain.Form, error) {
+	return h.handle(ctx, req, true, func(ctx context.Context, form *domain.Form) (*domain.Form, error) {
+		return h.handleSubmittedForm(ctx, req, form, http.MethodPost)
+	})
 }
 
 // HandleSubmittedGETForm as method for returning Form instance which is submitted via GET request
 func (h *formHandlerImpl) HandleSubmittedGETForm(ctx context.Context, req *web.Request) (*domain.Form, error) {
-	form, err := h.buildForm(ctx, req, true)
-	if err != nil {
-		return nil, err
+	return h.handle(ctx, req, true, func(ctx context.Context, form *domain.Form) (*domain.Form, error) {
+		return h.handleSubmittedForm(ctx, req, form, http.MethodGet)
+	})
+}
+
+// ValidateData as method for validating form data which is changed programmatically after form handling (like by applying
+// voucher or normalizing address), without new request. Form data is validated in the same way as submitted one,
+// with the same form data validator, validator provider, validation mode and active steps, and field errors are named
+// the same way as in handled form. Returned validation info is not set into the form, so it should be used
+// explicitly, like by using domain.Form.UpdateData.
+func (h *formHandlerImpl) ValidateData(ctx context.Context, req *web.Request, data interface{}) (*domain.ValidationInfo, error) {
+	form := domain.NewForm(true, nil)
+	form.Data = data
+	h.loadChoices(ctx, req, &form)
+
+	validateCtx, endValidate := h.startPhase(ctx, FormPhaseValidate)
+	validationInfo, err := h.validateFormData(validateCtx, req, nil, &form)
+	endValidate()
+	if err != nil && !h.recoverError(&form.ValidationInfo, "dataValidation", err) {
+		h.getLogger("dataValidation").Error(err.Error())
+		return nil, domain.NewWrappedFormError(err)
 	}
+	if validationInfo != nil {
+		h.appendValidationInfo(&form, *validationInfo)
+	}
+
+	h.addNamespaceToFieldErrors(&form)
+	result := h.fieldNotation.FormatValidationInfo(form.ValidationInfo)
 
-	return h.handleSubmittedForm(ctx, req, form, http.MethodGet)
+	return &result, nil
 }
 
-// buildForm as method for creating new instance of Form domain
+// buildForm as method for creating new instance of Form domain.
+// Recoverable errors of form data provider and prefill providers are presented as general errors of the form,
+// with form data which is returned by provider, or without prefilled values.
 func (h *formHandlerImpl) buildForm(ctx context.Context, req *web.Request, submitted bool) (*domain.Form, error) {
-	formData, err := h.getFormData(ctx, req, h.formDataProvider)
-	if err != nil {
+	validationInfo := domain.ValidationInfo{}
+
+	// submission ID is consumed before any form service is used, so duplicate submission doesn't touch them
+	if submitted && h.submissionGuard != nil {
+		switch h.consumeSubmission(ctx, req) {
+		case SubmissionDuplicate:
+			form := domain.NewForm(true, nil)
+			form.SetDuplicateSubmission(true)
+			form.ValidationInfo.AddGeneralError("formError.duplicateSubmission", "form is already submitted")
+
+			return &form, nil
+		case SubmissionExpired:
+			validationInfo.AddGeneralError("formError.submissionExpired", "form submission is expired, please submit the form again")
+		}
+	}
+
+	// form data provider and prefill providers share the same stage, so timeout limits both of them together
+	provideCtx, cancelProvide := h.withStageTimeout(ctx, FormStageProvide)
+	defer cancelProvide()
+
+	formData, err := runStage(provideCtx, FormStageProvide, h.stageTimeouts.Provide, func(ctx context.Context) (interface{}, error) {
+		return h.getFormData(ctx, req, h.formDataProvider)
+	})
+	if err != nil && !h.recoverError(&validationInfo, "formBuilding", err) {
 		h.getLogger("formBuilding").Error(err.Error())
-		return nil, domain.NewFormError(err.Error())
+		return nil, domain.NewWrappedFormError(err)
+	}
+
+	// prefill providers are not used when form data provider failed, and persisted form state is restored
+	// before them only for unsubmitted form, so explicitly prefilled values win over persisted ones
+	if err == nil {
+		prefilled, err := runStage(provideCtx, FormStageProvide, h.stageTimeouts.Provide, func(ctx context.Context) (interface{}, error) {
+			restored := formData
+			if !submitted {
+				restored = h.restoreState(ctx, req, restored)
+			}
+
+			return h.prefill(ctx, req, restored)
+		})
+		if err != nil && !h.recoverError(&validationInfo, "formPrefilling", err) {
+			h.getLogger("formPrefilling").Error(err.Error())
+			return nil, domain.NewWrappedFormError(err)
+		} else if err == nil {
+			formData = prefilled
+		}
 	}
 
 	form := domain.NewForm(submitted, h.extractValidationRules(formData))
 	form.Data = formData
+	h.loadChoices(ctx, req, &form)
+	if !validationInfo.IsValid() {
+		form.ValidationInfo = validationInfo
+	}
 
 	return &form, nil
 }
 
+// recoverError as method for presenting recoverable error as general error in validation info.
+// It returns false if error doesn't implement domain.RecoverableError, so form handling should fail.
+func (h *formHandlerImpl) recoverError(validationInfo *domain.ValidationInfo, area string, err error) bool {
+	var recoverable domain.RecoverableError
+	if !errors.As(err, &recoverable) {
+		return false
+	}
+
+	h.getLogger(area).Warn(err.Error())
+	validationInfo.AddGeneralError(recoverable.MessageKey(), err.Error())
+
+	return true
+}
+
 // handleSubmittedForm as method for processing
 func (h *formHandlerImpl) handleSubmittedForm(ctx context.Context, req *web.Request, form *domain.Form, method string) (*domain.Form, error) {
-	values, err := h.getURLValues(req, method)
+	// duplicate submission is presented as it is, without decoding, validation, form extensions and post processors
+	if form.IsDuplicateSubmission() {
+		return form, nil
+	}
+
+	rawValues, err := h.getURLValues(req, method)
 	if err != nil {
+		// request which exceeds limits is not decoded, same as when decoder rejects it
+		if h.recoverLimitError(&form.ValidationInfo, err) {
+			return h.finishSubmittedForm(ctx, req, url.Values{}, form)
+		}
+
 		h.getLogger("postValueProcessing").Error(err.Error())
-		return nil, domain.NewFormError(err.Error())
+		return nil, domain.NewWrappedFormError(err)
 	}
 
-	formData, err := h.decode(ctx, req, *values, form.Data, h.formDataDecoder)
-	if err != nil {
-		h.getLogger("formDecoding").Error(err.Error())
-		return nil, domain.NewFormError(err.Error())
+	// in case of namespace, only values from namespace are used, and they are decoded without namespace prefix
+	namespacedValues := h.filterNamespacedValues(*rawValues)
+	values := h.stripNamespacedValues(namespacedValues)
+
+	// raw values are set before decoding, so they are available even if decoding fails
+	form.SetOriginalValues(h.getOriginalValues(req, namespacedValues, form.Data))
+
+	// in case when form data provider failed without providing form data, there is nothing to decode into
+	if form.Data == nil && !form.ValidationInfo.IsValid() {
+		return h.finishSubmittedForm(ctx, req, values, form)
 	}
-	form.Data = formData
 
-	validationInfo, err := h.validate(ctx, req, h.validatorProvider, formData, h.formDataValidator)
-	if err != nil {
-		h.getLogger("formValidation").Error(err.Error())
-		return nil, domain.NewFormError(err.Error())
-	} else if validationInfo == nil {
-		validationInfo = &domain.ValidationInfo{}
+	decodeCtx, endDecode := h.startPhase(ctx, FormPhaseDecode)
+	decodeCtx, cancelDecode := h.withStageTimeout(decodeCtx, FormStageDecode)
+	formData, err := runStage(decodeCtx, FormStageDecode, h.stageTimeouts.Decode, func(ctx context.Context) (interface{}, error) {
+		return h.decode(ctx, req, values, form.Data, h.formDataDecoder)
+	})
+	cancelDecode()
+	endDecode()
+	h.addMultipartOriginalValues(req, form)
+	decodeError, isDecodeError := err.(*domain.DecodeError)
+	if err != nil && !isDecodeError {
+		if !h.recoverError(&form.ValidationInfo, "formDecoding", err) {
+			h.getLogger("formDecoding").Error(err.Error())
+			return nil, domain.NewWrappedFormError(err)
+		}
+
+		// in case of recoverable error, form data is validated as it's decoded, or as it's provided
+		if formData == nil {
+			formData = form.Data
+		}
+	}
+
+	if isDecodeError {
+		// submitted content which can't be decoded is presented as validation errors
+		h.appendValidationInfo(form, decodeError.ValidationInfo)
 	}
-	form.ValidationInfo = *validationInfo
 
-	err = h.processExtensions(ctx, req, *values, form)
+	// in case when nothing is decoded, there is no form data to validate
+	if !isDecodeError || formData != nil {
+		if len(h.prefillProviders) > 0 {
+			// prefilled values are kept for all fields which are not submitted
+			formData = h.mergeSubmittedData(form.Data, formData, formdata.NormalizeValues(h.stripNamespacedValues(form.OriginalValues()), form.Data))
+		}
+		form.Data = formData
+
+		validateCtx, endValidate := h.startPhase(ctx, FormPhaseValidate)
+		validationInfo, err := h.validateFormData(validateCtx, req, values, form)
+		endValidate()
+		if err != nil && !h.recoverError(&form.ValidationInfo, "formValidation", err) {
+			h.getLogger("formValidation").Error(err.Error())
+			return nil, domain.NewWrappedFormError(err)
+		}
+		if validationInfo == nil {
+			validationInfo = &domain.ValidationInfo{}
+		}
+
+		h.appendValidationInfo(form, *validationInfo)
+	}
+
+	return h.finishSubmittedForm(ctx, req, values, form)
+}
+
+// appendValidationInfo as method for adding validation info to the form. It's used as form's validation info
+// as it is, unless form already contains errors or warnings (like decoding errors), in which case they are appended.
+func (h *formHandlerImpl) appendValidationInfo(form *domain.Form, validationInfo domain.ValidationInfo) {
+	if form.ValidationInfo.IsValid() && !form.ValidationInfo.HasWarnings() {
+		form.ValidationInfo = validationInfo
+		return
+	}
+
+	form.ValidationInfo.Merge(validationInfo)
+}
+
+// finishSubmittedForm as method for processing form extensions and post processors of submitted form
+func (h *formHandlerImpl) finishSubmittedForm(ctx context.Context, req *web.Request, values url.Values, form *domain.Form) (*domain.Form, error) {
+	err := h.processExtensions(ctx, req, values, form)
 	if err != nil {
 		h.getLogger("formExtensions").Error(err.Error())
-		return nil, domain.NewFormError(err.Error())
+		return nil, domain.NewWrappedFormError(err)
+	}
+
+	// field errors are named same as submitted fields, so templates can attribute them to the right form
+	h.addNamespaceToFieldErrors(form)
+	form.ValidationInfo = h.fieldNotation.FormatValidationInfo(form.ValidationInfo)
+
+	err = h.processPostProcessors(ctx, req, form)
+	if err != nil {
+		h.getLogger("formPostProcessing").Error(err.Error())
+		return nil, err
+	}
+
+	// form extensions are finalized only if submission is successful, and not in case of partial validation
+	if form.IsValidAndSubmitted() && h.getValidationMode(req, values).IsFull() {
+		err = h.finalizeExtensions(ctx, req, form)
+		if err != nil {
+			h.getLogger("formExtensions").Error(err.Error())
+			return nil, domain.NewWrappedFormError(err)
+		}
 	}
 
 	return form, nil
 }
 
-// extractValidationRules as method for extracting form fields validation rules
-func (h *formHandlerImpl) extractValidationRules(formData interface{}) map[string][]domain.ValidationRule {
-	validationRules := map[string][]domain.ValidationRule{}
+// validateFormData as method for validating form data depending on validation mode used for current request.
+// In case of multi-step form, only fields of active steps are validated, and those steps are stored into the form.
+func (h *formHandlerImpl) validateFormData(ctx context.Context, req *web.Request, values url.Values, form *domain.Form) (*domain.ValidationInfo, error) {
+	validationMode := h.getValidationMode(req, values)
+	if !validationMode.ShouldValidate() {
+		return &domain.ValidationInfo{}, nil
+	}
 
-	if formData == nil {
-		return validationRules
+	ctx, steps, ok := h.withActiveSteps(ctx)
+	if ok {
+		form.SetValidatedSteps(steps)
 	}
 
-	valueOf := reflect.Indirect(reflect.ValueOf(formData))
-	typeOf := valueOf.Type()
+	validateCtx, cancelValidate := h.withStageTimeout(ctx, FormStageValidate)
+	defer cancelValidate()
 
-	if typeOf.Kind() != reflect.Struct {
-		return validationRules
+	// validation info collected before timeout is returned together with timeout error, so it can be kept
+	validationInfo, err := runStage(validateCtx, FormStageValidate, h.stageTimeouts.Validate, func(ctx context.Context) (*domain.ValidationInfo, error) {
+		return h.validate(ctx, req, h.validatorProvider, form.Data, h.formDataValidator)
+	})
+	if validationInfo == nil {
+		return nil, err
 	}
 
-	for i := 0; i < typeOf.NumField(); i++ {
-		fieldValue := valueOf.Field(i)
-		if fieldValue.Type().Kind() == reflect.Ptr && fieldValue.Type().Elem().Kind() == reflect.Struct {
-			fieldValue = reflect.Zero(fieldValue.Type().Elem())
-		}
+	// submitted values of enumerated fields are validated against options of their choice providers
+	if choicesInfo := h.validateChoices(form); !choicesInfo.IsValid() {
+		merged := domain.ValidationInfo{}
+		merged.Merge(*validationInfo)
+		merged.Merge(choicesInfo)
+		validationInfo = &merged
+	}
 
-		fieldType := typeOf.Field(i)
+	filtered := validationMode.Filter(*validationInfo)
 
-		name := fieldType.Tag.Get("form")
-		if name == "-" {
-			continue
-		}
-
-		if name == "" {
-			name = fieldType.Name
-		}
+	return &filtered, err
+}
 
-		if fieldValue.Kind() == reflect.Struct {
-			subRules := h.extractValidationRules(fieldValue.Interface())
-			for k, v := range subRules {
-				key := fmt.Sprintf("%s.%s", name, k)
-				validationRules[key] = v
-			}
+// withActiveSteps as method for getting context with active steps of multi-step form. Steps already defined in context
+// of current request (by using domain.ContextWithActiveSteps) are used instead of ones defined for form handler.
+func (h *formHandlerImpl) withActiveSteps(ctx context.Context) (context.Context, []string, bool) {
+	if steps, ok := domain.ActiveStepsFromContext(ctx); ok {
+		return ctx, steps, true
+	}
 
-			continue
-		}
+	if h.activeSteps == nil {
+		return ctx, nil, false
+	}
 
-		validationTag := fieldType.Tag.Get("validate")
-		if validationTag == "" {
-			continue
-		}
+	return domain.ContextWithActiveSteps(ctx, h.activeSteps...), h.activeSteps, true
+}
 
-		tags := strings.Split(validationTag, ",")
-		for _, tag := range tags {
-			values := strings.Split(tag, "=")
-			if len(values) == 0 {
-				continue
-			}
-			if values[0] == "omitempty" || values[0] == "" {
-				continue
-			}
+// getValidationMode as method for getting validation mode for current request.
+// Overriding validation mode is used if submitted values or request headers contain flag with true value (like "_draft=1").
+func (h *formHandlerImpl) getValidationMode(req *web.Request, values url.Values) domain.ValidationMode {
+	if h.validationModeOverride == nil {
+		return h.validationMode
+	}
 
-			validationRule := domain.ValidationRule{
-				Name: values[0],
-			}
-			if len(values) > 1 {
-				validationRule.Value = values[1]
-			}
+	flag := values.Get(h.validationModeOverride.name)
+	if flag == "" && req != nil && req.Request() != nil {
+		flag = req.Request().Header.Get(h.validationModeOverride.name)
+	}
 
-			validationRules[name] = append(validationRules[name], validationRule)
-		}
+	if enabled, err := strconv.ParseBool(flag); err == nil && enabled {
+		return h.validationModeOverride.mode
 	}
 
-	return validationRules
+	return h.validationMode
 }
 
-// getPostValues as method for extracting http request body
+// getPostValues as method for extracting http request body. Body is limited to configured maximum body size
+// before it's parsed for the first time, and number of submitted fields is checked at the same place,
+// so request which exceeds limits is never read completely.
 func (h *formHandlerImpl) getURLValues(r *web.Request, method string) (*url.Values, error) {
 	if method == http.MethodGet {
 		values := r.Request().URL.Query()
 		return &values, nil
 	}
 
-	err := r.Request().ParseForm()
+	err := formdata.ParseRequestForm(r, h.maxBodySize, h.maxFormKeys)
 	if err != nil {
 		return nil, err
 	}
@@ -215,18 +583,249 @@ func (h *formHandlerImpl) getURLValues(r *web.Request, method string) (*url.Valu
 	return &r.Request().Form, nil
 }
 
-// processExtensions as method for processing list of form extensions
+// getJSONValues as method for reading values from JSON http request body, limited to configured maximum body size
+func (h *formHandlerImpl) getJSONValues(r *web.Request, formData interface{}) (url.Values, error) {
+	maxBodySize := h.maxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = formdata.DefaultMaxBodySize
+	}
+
+	return formdata.GetNamespacedJSONValues(r, formData, h.namespace, maxBodySize)
+}
+
+// recoverLimitError as method for presenting error of http request which exceeds configured limits as general error,
+// with the same message keys as used by default form data decoder. It returns false for all other errors.
+func (h *formHandlerImpl) recoverLimitError(validationInfo *domain.ValidationInfo, err error) bool {
+	switch {
+	case errors.Is(err, formdata.ErrRequestTooLarge):
+		validationInfo.AddGeneralError("formError.requestTooLarge", err.Error())
+	case errors.Is(err, formdata.ErrTooManyFields):
+		validationInfo.AddGeneralErrorWithParams("formError.tooManyFields", err.Error(), map[string]string{
+			"max": strconv.Itoa(h.maxFormKeys),
+		})
+	default:
+		return false
+	}
+
+	h.getLogger("postValueProcessing").Warn(err.Error())
+
+	return true
+}
+
+// getOriginalValues as method for collecting raw submitted values, including values from JSON http request body
+func (h *formHandlerImpl) getOriginalValues(r *web.Request, values url.Values, formData interface{}) url.Values {
+	originalValues := make(url.Values, len(values))
+	for k, v := range values {
+		originalValues[k] = append([]string(nil), v...)
+	}
+
+	// malformed JSON http request body is reported by form data decoder
+	jsonValues, err := h.getJSONValues(r, formData)
+	if err == nil {
+		for k, v := range jsonValues {
+			originalValues[k] = v
+		}
+	}
+
+	return originalValues
+}
+
+// addMultipartOriginalValues as method for adding raw values from multipart http request body, which is parsed during decoding
+func (h *formHandlerImpl) addMultipartOriginalValues(r *web.Request, form *domain.Form) {
+	multipartForm := r.Request().MultipartForm
+	if multipartForm == nil {
+		return
+	}
+
+	originalValues := form.OriginalValues()
+	for k, v := range h.filterNamespacedValues(multipartForm.Value) {
+		if _, ok := originalValues[k]; !ok {
+			originalValues[k] = v
+		}
+	}
+
+	form.SetOriginalValues(originalValues)
+}
+
+// wasSubmitted as method for checking if form is submitted, by using submit detector if it's defined.
+// Otherwise, only POST requests are treated as submissions.
+func (h *formHandlerImpl) wasSubmitted(ctx context.Context, req *web.Request) bool {
+	if h.submitDetector != nil {
+		return h.submitDetector.WasSubmitted(ctx, req)
+	}
+
+	return req.Request().Method == http.MethodPost
+}
+
+// isNamespaceSubmitted as method for checking if request contains any submitted value from handler's namespace.
+// It's always true if there is no namespace defined.
+func (h *formHandlerImpl) isNamespaceSubmitted(req *web.Request) bool {
+	if h.namespace == "" {
+		return true
+	}
+
+	// request which exceeds limits is handled as submitted, so limit error is presented in the form
+	values, err := h.getURLValues(req, http.MethodPost)
+	if err != nil {
+		return errors.Is(err, formdata.ErrRequestTooLarge) || errors.Is(err, formdata.ErrTooManyFields)
+	}
+
+	if len(h.filterNamespacedValues(*values)) > 0 {
+		return true
+	}
+
+	// multipart http request body is parsed before decoding, so its values and files can be checked as well,
+	// while body which can't be parsed is handled as submitted, so parsing error is presented in the form
+	multipartForm, err := formdata.ParseMultipartRequestForm(req, h.maxMemory)
+	if err != nil {
+		return true
+	}
+	if multipartForm != nil && (len(h.filterNamespacedValues(multipartForm.Value)) > 0 || len(domain.StripNamespacedFiles(h.namespace, multipartForm.File)) > 0) {
+		return true
+	}
+
+	// JSON http request body belongs to namespace if it contains object under namespace key
+	jsonValues, err := h.getJSONValues(req, nil)
+	return err != nil || len(jsonValues) > 0
+}
+
+// isEmptyJSONBody as method for checking if http request body is sent as JSON, but it doesn't contain anything
+// except whitespace. Body of GET request is never used, so it's never treated as empty JSON body.
+func (h *formHandlerImpl) isEmptyJSONBody(req *web.Request) bool {
+	if req.Request().Method == http.MethodGet {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(req.Request().Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/json" {
+		return false
+	}
+
+	jsonValues, err := h.getJSONValues(req, nil)
+	return err == nil && jsonValues == nil
+}
+
+// filterNamespacedValues as method for filtering only values which belong to handler's namespace, like
+// "login.email" or "login[email]" for namespace "login". All values are returned if there is no namespace defined.
+func (h *formHandlerImpl) filterNamespacedValues(values url.Values) url.Values {
+	if h.namespace == "" {
+		return values
+	}
+
+	filtered := url.Values{}
+	for k, v := range values {
+		if _, ok := h.stripNamespace(k); ok {
+			filtered[k] = v
+		}
+	}
+
+	return filtered
+}
+
+// stripNamespacedValues as method for removing namespace prefix from names of values which belong to handler's namespace
+func (h *formHandlerImpl) stripNamespacedValues(values url.Values) url.Values {
+	return domain.StripNamespacedValues(h.namespace, values)
+}
+
+// stripNamespace as method for removing namespace prefix from field name, so "login.email" and "login[email]"
+// are both transformed into "email", and "login[items][0]" into "items[0]". It returns false if field doesn't belong to namespace.
+func (h *formHandlerImpl) stripNamespace(name string) (string, bool) {
+	return domain.StripNamespace(h.namespace, name)
+}
+
+// addNamespaceToFieldErrors as method for prefixing names of all fields with errors with handler's namespace
+func (h *formHandlerImpl) addNamespaceToFieldErrors(form *domain.Form) {
+	if h.namespace == "" || !form.ValidationInfo.HasAnyFieldErrors() {
+		return
+	}
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AppendWithPrefix(h.namespace, form.ValidationInfo)
+
+	form.ValidationInfo = validationInfo
+}
+
+// processExtensions as method for processing list of form extensions. Each form extension is limited by its own
+// stage timeout, so extension which doesn't finish in time doesn't prevent processing of remaining ones. Timeout
+// is presented as general error of the form, instead of failing form handling, while data and validation info
+// collected by timed out extension are kept as well.
 func (h *formHandlerImpl) processExtensions(ctx context.Context, req *web.Request, values url.Values, form *domain.Form) error {
-	for name, formExtension := range h.formExtensions {
-		err := h.processExtension(ctx, req, values, name, formExtension, form)
+	names := make([]string, 0, len(h.formExtensions))
+	for name := range h.formExtensions {
+		names = append(names, name)
+	}
+	// extensions are processed in the same order on each request, so merged validation info is deterministic
+	sort.Strings(names)
+
+	submitted := form.IsSubmitted()
+	for _, name := range names {
+		name, formExtension := name, h.formExtensions[name]
+
+		processed, err := h.processExtensionStage(ctx, func(ctx context.Context) (*domain.Form, error) {
+			processed := domain.NewForm(submitted, nil)
+			return &processed, h.processExtension(ctx, req, values, name, formExtension, &processed)
+		})
+
+		var timeoutError *stageTimeoutError
+		if errors.As(err, &timeoutError) {
+			h.getLogger("formExtensions").Warn(fmt.Sprintf("form extension %q: %s", name, err.Error()))
+			form.ValidationInfo.AddGeneralError(timeoutError.MessageKey(), err.Error())
+		} else if err != nil {
+			return err
+		}
+
+		if processed == nil {
+			continue
+		}
+
+		if form.FormExtensionsData == nil {
+			form.FormExtensionsData = map[string]interface{}{}
+		}
+		form.FormExtensionsData[name] = processed.FormExtensionsData[name]
+		form.ValidationInfo.Merge(processed.ValidationInfo)
+	}
+
+	return nil
+}
+
+// finalizeExtensions as method for finalizing form extensions which implement domain.FormExtensionFinalizer,
+// in the same order in which form extensions are processed
+func (h *formHandlerImpl) finalizeExtensions(ctx context.Context, req *web.Request, form *domain.Form) error {
+	names := make([]string, 0, len(h.formExtensions))
+	for name := range h.formExtensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		finalizer, ok := h.formExtensions[name].(domain.FormExtensionFinalizer)
+		if !ok {
+			continue
+		}
+
+		formExtensionData, ok := form.FormExtensionsData[name]
+		if !ok {
+			continue
+		}
+
+		formExtensionData, err := finalizer.Finalize(ctx, req, formExtensionData)
 		if err != nil {
 			return err
 		}
+		form.FormExtensionsData[name] = formExtensionData
 	}
 
 	return nil
 }
 
+// processExtensionStage as method for running stage of single form extension, limited by its own stage timeout
+func (h *formHandlerImpl) processExtensionStage(ctx context.Context, run func(ctx context.Context) (*domain.Form, error)) (*domain.Form, error) {
+	extensionCtx, cancelExtension := h.withStageTimeout(ctx, FormStageExtensions)
+	defer cancelExtension()
+
+	return runStage(extensionCtx, FormStageExtensions, h.stageTimeouts.Extensions, run)
+}
+
 // processExtension as method for processing single form extensions
 func (h *formHandlerImpl) processExtension(ctx context.Context, req *web.Request, values url.Values, name string, formExtension interface{}, form *domain.Form) error {
 	var formData interface{}
@@ -261,7 +860,12 @@ func (h *formHandlerImpl) processExtension(ctx context.Context, req *web.Request
 		formDataDecoder = decoder
 	}
 	formData, err = h.decode(ctx, req, values, formData, formDataDecoder)
-	if err != nil {
+	if decodeError, ok := err.(*domain.DecodeError); ok {
+		form.ValidationInfo.Merge(decodeError.ValidationInfo)
+		if formData == nil {
+			return nil
+		}
+	} else if err != nil {
 		return err
 	}
 
@@ -280,8 +884,37 @@ func (h *formHandlerImpl) processExtension(ctx context.Context, req *web.Request
 	}
 
 	// form validation errors from form extension is attached
-	form.ValidationInfo.AppendGeneralErrors(validationInfo.GetGeneralErrors())
-	form.ValidationInfo.AppendFieldErrors(validationInfo.GetErrorsForAllFields())
+	form.ValidationInfo.Merge(*validationInfo)
+
+	return nil
+}
+
+// processPostProcessors as method for processing list of post processors in order of registration.
+// Form data is passed as pointer, so post processors can modify it, together with validation info.
+func (h *formHandlerImpl) processPostProcessors(ctx context.Context, req *web.Request, form *domain.Form) error {
+	if len(h.postProcessors) == 0 {
+		return nil
+	}
+
+	formData := form.Data
+	valueOf := reflect.ValueOf(formData)
+	copied := formData != nil && valueOf.Kind() != reflect.Ptr
+	if copied {
+		pointer := reflect.New(valueOf.Type())
+		pointer.Elem().Set(valueOf)
+		formData = pointer.Interface()
+	}
+
+	for _, postProcessor := range h.postProcessors {
+		err := postProcessor.Process(ctx, req, formData, &form.ValidationInfo)
+		if err != nil {
+			return err
+		}
+	}
+
+	if copied {
+		form.Data = reflect.ValueOf(formData).Elem().Interface()
+	}
 
 	return nil
 }
@@ -306,6 +939,11 @@ func (h *formHandlerImpl) decode(ctx context.Context, req *web.Request, values u
 		formDataDecoder = h.defaultFormDataDecoder
 	}
 
+	// namespace is passed to decoder, so it decodes only parts of multipart or JSON http request body from namespace
+	if h.namespace != "" {
+		ctx = domain.ContextWithNamespace(ctx, h.namespace)
+	}
+
 	return formDataDecoder.Decode(ctx, req, values, formData)
 }
 
@@ -5,6 +5,7 @@ import (
 
 	"flamingo.me/flamingo/v3/framework/flamingo"
 	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/extensions"
 )
 
 type (
@@ -41,6 +42,48 @@ type (
 		// AddNamedFormExtension adds form extension by searching named extension via dingo injector.
 		// It returns error if there is no injected form extension with that name.
 		AddNamedFormExtension(name string) error
+		// EnableCSRF adds CSRF token form extension, which provides CSRF token and validates it on form submission.
+		// It returns error if there is no injected CSRF token form extension.
+		EnableCSRF() error
+		// EnableSpamProtection adds spam protection form extension, which provides honeypot field and signed timestamp,
+		// and rejects submissions with filled honeypot or submitted faster than minimum fill time.
+		// It returns error if there is no injected spam protection form extension.
+		EnableSpamProtection(options extensions.SpamProtectionOptions) error
+		// AddPrefillProvider adds prefill provider to the list of prefill providers, which are called in order of registration.
+		// Prefilled values are merged into form data, before submitted values are decoded over it.
+		AddPrefillProvider(prefillProvider domain.PrefillProvider) FormHandlerBuilder
+		// AddPostProcessor adds post processor to the list of post processors, which are called in order of registration.
+		AddPostProcessor(postProcessor domain.PostProcessor) FormHandlerBuilder
+		// BindChoices binds choice provider to enumerated field with form field name (like "country" or "address.country").
+		// Options are available via Form.ChoicesFor, and submitted values which are not one of options are reported
+		// as field error "formError.invalidChoice".
+		BindChoices(fieldName string, choiceProvider domain.ChoiceProvider) FormHandlerBuilder
+		// SetValidationMode sets validation mode used for validating submitted form data. Default one is domain.ValidationModeFull.
+		SetValidationMode(validationMode domain.ValidationMode) FormHandlerBuilder
+		// SetValidationModeOverride sets validation mode used instead of default one, if submitted form field
+		// or request header with provided name contains true value (like "_draft=1").
+		SetValidationModeOverride(name string, validationMode domain.ValidationMode) FormHandlerBuilder
+		// SetActiveSteps sets active steps of multi-step form, so only fields tagged with `formstep:"..."`
+		// of one of those steps, and fields without step, are validated.
+		SetActiveSteps(steps ...string) FormHandlerBuilder
+		// SetFormSessionStore sets form session store, which is used to restore validation info and original values
+		// of previous submission stored under form identifier, when form is handled as unsubmitted one.
+		SetFormSessionStore(formSessionStore FormSessionStore, formIdentifier string) FormHandlerBuilder
+		// SetSubmissionGuard sets submission guard, which issues one-time submission ID for form identifier
+		// and rejects second submission with the same ID, without decoding and validating it again.
+		SetSubmissionGuard(submissionGuard SubmissionGuard, formIdentifier string) FormHandlerBuilder
+		// EnableStatePersistence sets form state store, so form data of submitted forms which are not valid is persisted,
+		// and restored when form is presented again as unsubmitted one, until it's submitted successfully.
+		EnableStatePersistence(stateStore domain.FormStateStore) FormHandlerBuilder
+		// SetNamespace sets namespace of form, so only submitted values prefixed with namespace (like "login.email"
+		// or "login[email]") are decoded, which allows multiple forms on the same page. Field errors are prefixed with namespace.
+		SetNamespace(namespace string) FormHandlerBuilder
+		// SetSubmitDetector sets submit detector, which decides if form is submitted when it's handled by HandleForm method.
+		// By default, only POST requests are treated as submissions.
+		SetSubmitDetector(submitDetector domain.SubmitDetector) FormHandlerBuilder
+		// SetStageTimeouts sets timeouts of form handling stages, which override configured ones. Stage which doesn't
+		// finish in time is presented as general error "formError.validationTimeout" of returned form.
+		SetStageTimeouts(timeouts StageTimeouts) FormHandlerBuilder
 		// Must wraps builder method execution and returns instance of builder if there is no error.
 		// It panics if there is an error.
 		Must(err error) FormHandlerBuilder
@@ -50,21 +93,42 @@ type (
 
 	// formHandlerBuilderImpl as actual implementation of FormHandlerBuilder interface
 	formHandlerBuilderImpl struct {
-		namedFormServices        map[string]domain.FormService
-		namedFormDataProviders   map[string]domain.FormDataProvider
-		namedFormDataDecoders    map[string]domain.FormDataDecoder
-		namedFormDataValidators  map[string]domain.FormDataValidator
-		namedFormExtensions      map[string]domain.FormExtension
-		defaultFormDataProvider  domain.DefaultFormDataProvider
-		defaultFormDataDecoder   domain.DefaultFormDataDecoder
-		defaultFormDataValidator domain.DefaultFormDataValidator
-		validatorProvider        domain.ValidatorProvider
-		logger                   flamingo.Logger
+		namedFormServices         map[string]domain.FormService
+		namedFormDataProviders    map[string]domain.FormDataProvider
+		namedFormDataDecoders     map[string]domain.FormDataDecoder
+		namedFormDataValidators   map[string]domain.FormDataValidator
+		namedFormExtensions       map[string]domain.FormExtension
+		defaultFormDataProvider   domain.DefaultFormDataProvider
+		defaultFormDataDecoder    domain.DefaultFormDataDecoder
+		defaultFormDataValidator  domain.DefaultFormDataValidator
+		validatorProvider         domain.ValidatorProvider
+		validationRuleTranslators map[string]domain.ValidationRuleTranslator
+		metricsEnabled            bool
+		fieldNotation             domain.FieldNotation
+		stageTimeouts             StageTimeouts
+		maxBodySize               int64
+		maxFormKeys               int
+		maxMemory                 int64
+		logger                    flamingo.Logger
 
 		formDataProvider  domain.FormDataProvider
 		formDataDecoder   domain.FormDataDecoder
 		formDataValidator domain.FormDataValidator
 		formExtensions    map[string]domain.FormExtension
+		prefillProviders  []domain.PrefillProvider
+		postProcessors    []domain.PostProcessor
+		choiceProviders   map[string]domain.ChoiceProvider
+
+		validationMode         domain.ValidationMode
+		validationModeOverride *validationModeOverride
+		activeSteps            []string
+		formSessionStore       FormSessionStore
+		formIdentifier         string
+		submissionGuard        SubmissionGuard
+		submissionIdentifier   string
+		stateStore             domain.FormStateStore
+		namespace              string
+		submitDetector         domain.SubmitDetector
 	}
 )
 
@@ -182,6 +246,137 @@ func (b *formHandlerBuilderImpl) AddFormExtension(formExtension domain.FormExten
 	return b.addFormExtension(valueOf.Type().Name(), formExtension)
 }
 
+// EnableCSRF adds CSRF token form extension, which provides CSRF token and validates it on form submission.
+// It returns error if there is no injected CSRF token form extension.
+func (b *formHandlerBuilderImpl) EnableCSRF() error {
+	if service, ok := b.namedFormExtensions[extensions.CsrfTokenFormExtensionName]; ok {
+		return b.addFormExtension(extensions.CsrfTokenFormExtensionDataName, service)
+	}
+
+	return domain.NewFormErrorf(`there is no FormExtension with name "%q"`, extensions.CsrfTokenFormExtensionName)
+}
+
+// EnableSpamProtection adds spam protection form extension, which provides honeypot field and signed timestamp,
+// and rejects submissions with filled honeypot or submitted faster than minimum fill time.
+// It returns error if there is no injected spam protection form extension.
+func (b *formHandlerBuilderImpl) EnableSpamProtection(options extensions.SpamProtectionOptions) error {
+	service, ok := b.namedFormExtensions[extensions.SpamProtectionFormExtensionName]
+	if !ok {
+		return domain.NewFormErrorf(`there is no FormExtension with name "%q"`, extensions.SpamProtectionFormExtensionName)
+	}
+
+	// options are applied to copy of extension, so they don't affect other forms
+	if extension, isSpamProtection := service.(*extensions.SpamProtectionFormExtension); isSpamProtection {
+		service = extension.WithOptions(options)
+	}
+
+	return b.addFormExtension(extensions.SpamProtectionFormExtensionDataName, service)
+}
+
+// AddPrefillProvider adds prefill provider to the list of prefill providers, which are called in order of registration.
+// Prefilled values are merged into form data, before submitted values are decoded over it.
+func (b *formHandlerBuilderImpl) AddPrefillProvider(prefillProvider domain.PrefillProvider) FormHandlerBuilder {
+	b.prefillProviders = append(b.prefillProviders, prefillProvider)
+
+	return b
+}
+
+// AddPostProcessor adds post processor to the list of post processors, which are called in order of registration.
+func (b *formHandlerBuilderImpl) AddPostProcessor(postProcessor domain.PostProcessor) FormHandlerBuilder {
+	b.postProcessors = append(b.postProcessors, postProcessor)
+
+	return b
+}
+
+// BindChoices binds choice provider to enumerated field with form field name (like "country" or "address.country").
+// Options are available via Form.ChoicesFor, and submitted values which are not one of options are reported
+// as field error "formError.invalidChoice". Binding another provider to the same field replaces previous one.
+func (b *formHandlerBuilderImpl) BindChoices(fieldName string, choiceProvider domain.ChoiceProvider) FormHandlerBuilder {
+	if b.choiceProviders == nil {
+		b.choiceProviders = map[string]domain.ChoiceProvider{}
+	}
+
+	b.choiceProviders[fieldName] = choiceProvider
+
+	return b
+}
+
+// SetValidationMode sets validation mode used for validating submitted form data. Default one is domain.ValidationModeFull.
+func (b *formHandlerBuilderImpl) SetValidationMode(validationMode domain.ValidationMode) FormHandlerBuilder {
+	b.validationMode = validationMode
+
+	return b
+}
+
+// SetValidationModeOverride sets validation mode used instead of default one, if submitted form field
+// or request header with provided name contains true value (like "_draft=1").
+func (b *formHandlerBuilderImpl) SetValidationModeOverride(name string, validationMode domain.ValidationMode) FormHandlerBuilder {
+	b.validationModeOverride = &validationModeOverride{
+		name: name,
+		mode: validationMode,
+	}
+
+	return b
+}
+
+// SetActiveSteps sets active steps of multi-step form, so only fields tagged with `formstep:"..."`
+// of one of those steps, and fields without step, are validated.
+func (b *formHandlerBuilderImpl) SetActiveSteps(steps ...string) FormHandlerBuilder {
+	b.activeSteps = append([]string{}, steps...)
+
+	return b
+}
+
+// SetFormSessionStore sets form session store, which is used to restore validation info and original values
+// of previous submission stored under form identifier, when form is handled as unsubmitted one.
+func (b *formHandlerBuilderImpl) SetFormSessionStore(formSessionStore FormSessionStore, formIdentifier string) FormHandlerBuilder {
+	b.formSessionStore = formSessionStore
+	b.formIdentifier = formIdentifier
+
+	return b
+}
+
+// SetSubmissionGuard sets submission guard, which issues one-time submission ID for form identifier
+// and rejects second submission with the same ID, without decoding and validating it again.
+func (b *formHandlerBuilderImpl) SetSubmissionGuard(submissionGuard SubmissionGuard, formIdentifier string) FormHandlerBuilder {
+	b.submissionGuard = submissionGuard
+	b.submissionIdentifier = formIdentifier
+
+	return b
+}
+
+// EnableStatePersistence sets form state store, so form data of submitted forms which are not valid is persisted,
+// and restored when form is presented again as unsubmitted one, until it's submitted successfully.
+func (b *formHandlerBuilderImpl) EnableStatePersistence(stateStore domain.FormStateStore) FormHandlerBuilder {
+	b.stateStore = stateStore
+
+	return b
+}
+
+// SetNamespace sets namespace of form, so only submitted values prefixed with namespace (like "login.email"
+// or "login[email]") are decoded, which allows multiple forms on the same page. Field errors are prefixed with namespace.
+func (b *formHandlerBuilderImpl) SetNamespace(namespace string) FormHandlerBuilder {
+	b.namespace = namespace
+
+	return b
+}
+
+// SetSubmitDetector sets submit detector, which decides if form is submitted when it's handled by HandleForm method.
+// By default, only POST requests are treated as submissions.
+func (b *formHandlerBuilderImpl) SetSubmitDetector(submitDetector domain.SubmitDetector) FormHandlerBuilder {
+	b.submitDetector = submitDetector
+
+	return b
+}
+
+// SetStageTimeouts sets timeouts of form handling stages, which override configured ones. Stage which doesn't
+// finish in time is presented as general error "formError.validationTimeout" of returned form.
+func (b *formHandlerBuilderImpl) SetStageTimeouts(timeouts StageTimeouts) FormHandlerBuilder {
+	b.stageTimeouts = timeouts
+
+	return b
+}
+
 // Must wraps builder method execution and returns instance of builder if there is no error.
 // It panics if there is an error.
 func (b *formHandlerBuilderImpl) Must(err error) FormHandlerBuilder {
@@ -210,15 +405,35 @@ func (b *formHandlerBuilderImpl) Build() domain.FormHandler {
 	}
 
 	return &formHandlerImpl{
-		defaultFormDataProvider:  b.defaultFormDataProvider,
-		defaultFormDataDecoder:   b.defaultFormDataDecoder,
-		defaultFormDataValidator: b.defaultFormDataValidator,
-		formDataProvider:         b.formDataProvider,
-		formDataDecoder:          b.formDataDecoder,
-		formDataValidator:        b.formDataValidator,
-		formExtensions:           b.formExtensions,
-		validatorProvider:        b.validatorProvider,
-		logger:                   b.logger,
+		defaultFormDataProvider:   b.defaultFormDataProvider,
+		defaultFormDataDecoder:    b.defaultFormDataDecoder,
+		defaultFormDataValidator:  b.defaultFormDataValidator,
+		formDataProvider:          b.formDataProvider,
+		formDataDecoder:           b.formDataDecoder,
+		formDataValidator:         b.formDataValidator,
+		formExtensions:            b.formExtensions,
+		prefillProviders:          b.prefillProviders,
+		postProcessors:            b.postProcessors,
+		choiceProviders:           b.choiceProviders,
+		validationMode:            b.validationMode,
+		validationModeOverride:    b.validationModeOverride,
+		activeSteps:               b.activeSteps,
+		formSessionStore:          b.formSessionStore,
+		formIdentifier:            b.formIdentifier,
+		submissionGuard:           b.submissionGuard,
+		submissionIdentifier:      b.submissionIdentifier,
+		stateStore:                b.stateStore,
+		namespace:                 b.namespace,
+		submitDetector:            b.submitDetector,
+		validatorProvider:         b.validatorProvider,
+		validationRuleTranslators: b.validationRuleTranslators,
+		metricsEnabled:            b.metricsEnabled,
+		fieldNotation:             b.fieldNotation,
+		stageTimeouts:             b.stageTimeouts,
+		maxBodySize:               b.maxBodySize,
+		maxFormKeys:               b.maxFormKeys,
+		maxMemory:                 b.maxMemory,
+		logger:                    b.logger,
 	}
 }
 
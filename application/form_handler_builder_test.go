@@ -2,9 +2,11 @@ package application
 
 import (
 	"testing"
+	"time"
 
 	"flamingo.me/flamingo/v3/framework/flamingo"
 	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/extensions"
 	"flamingo.me/form/domain/mocks"
 	"github.com/stretchr/testify/suite"
 )
@@ -312,6 +314,188 @@ func (t *FormHandlerBuilderImplTestSuite) TestAddNamedFormExtension_CompleteForm
 	}, t.builder.formExtensions)
 }
 
+func (t *FormHandlerBuilderImplTestSuite) TestEnableCSRF_Panic() {
+	t.Panics(func() {
+		t.builder.Must(t.builder.EnableCSRF())
+	})
+}
+
+func (t *FormHandlerBuilderImplTestSuite) TestEnableCSRF_Success() {
+	t.Empty(t.builder.formExtensions)
+
+	csrfTokenExtension := &mocks.CompleteFormService{}
+	t.builder.namedFormExtensions[extensions.CsrfTokenFormExtensionName] = csrfTokenExtension
+
+	err := t.builder.EnableCSRF()
+	t.NoError(err)
+
+	t.Equal(map[string]domain.FormExtension{
+		"csrf": csrfTokenExtension,
+	}, t.builder.formExtensions)
+}
+
+func (t *FormHandlerBuilderImplTestSuite) TestEnableSpamProtection_Panic() {
+	t.Panics(func() {
+		t.builder.Must(t.builder.EnableSpamProtection(extensions.SpamProtectionOptions{}))
+	})
+}
+
+func (t *FormHandlerBuilderImplTestSuite) TestEnableSpamProtection_Success() {
+	t.Empty(t.builder.formExtensions)
+
+	spamProtectionExtension := &extensions.SpamProtectionFormExtension{}
+	spamProtectionExtension.Inject(&struct {
+		Secret      string `inject:"config:form.spamProtection.secret"`
+		MinFillTime string `inject:"config:form.spamProtection.minFillTime"`
+		MaxAge      string `inject:"config:form.spamProtection.maxAge"`
+		ClockSkew   string `inject:"config:form.spamProtection.clockSkew"`
+	}{
+		MinFillTime: "2s",
+		MaxAge:      "24h",
+		ClockSkew:   "1s",
+	}, nil)
+	t.builder.namedFormExtensions[extensions.SpamProtectionFormExtensionName] = spamProtectionExtension
+
+	err := t.builder.EnableSpamProtection(extensions.SpamProtectionOptions{
+		MinFillTime: 5 * time.Second,
+	})
+	t.NoError(err)
+
+	// each form gets its own copy of extension with applied options
+	t.Len(t.builder.formExtensions, 1)
+	t.IsType(&extensions.SpamProtectionFormExtension{}, t.builder.formExtensions["spamProtection"])
+	t.True(t.builder.formExtensions["spamProtection"] != spamProtectionExtension)
+
+	otherExtension := &mocks.CompleteFormService{}
+	t.builder.namedFormExtensions[extensions.SpamProtectionFormExtensionName] = otherExtension
+
+	err = t.builder.EnableSpamProtection(extensions.SpamProtectionOptions{})
+	t.NoError(err)
+	t.Equal(map[string]domain.FormExtension{
+		"spamProtection": otherExtension,
+	}, t.builder.formExtensions)
+}
+
+func (t *FormHandlerBuilderImplTestSuite) TestAddPrefillProvider() {
+	t.Empty(t.builder.prefillProviders)
+
+	firstPrefillProvider := &mocks.PrefillProvider{}
+	secondPrefillProvider := &mocks.PrefillProvider{}
+
+	t.builder.AddPrefillProvider(firstPrefillProvider).AddPrefillProvider(secondPrefillProvider)
+
+	t.Equal([]domain.PrefillProvider{
+		firstPrefillProvider,
+		secondPrefillProvider,
+	}, t.builder.prefillProviders)
+}
+
+func (t *FormHandlerBuilderImplTestSuite) TestAddPostProcessor() {
+	t.Empty(t.builder.postProcessors)
+
+	firstPostProcessor := &mocks.PostProcessor{}
+	secondPostProcessor := &mocks.PostProcessor{}
+
+	t.builder.AddPostProcessor(firstPostProcessor).AddPostProcessor(secondPostProcessor)
+
+	t.Equal([]domain.PostProcessor{
+		firstPostProcessor,
+		secondPostProcessor,
+	}, t.builder.postProcessors)
+}
+
+func (t *FormHandlerBuilderImplTestSuite) TestBindChoices() {
+	t.Nil(t.builder.choiceProviders)
+
+	firstChoiceProvider := &mocks.ChoiceProvider{}
+	secondChoiceProvider := &mocks.ChoiceProvider{}
+	thirdChoiceProvider := &mocks.ChoiceProvider{}
+
+	t.builder.BindChoices("country", firstChoiceProvider).
+		BindChoices("address.country", secondChoiceProvider).
+		BindChoices("country", thirdChoiceProvider)
+
+	t.Equal(map[string]domain.ChoiceProvider{
+		"country":         thirdChoiceProvider,
+		"address.country": secondChoiceProvider,
+	}, t.builder.choiceProviders)
+}
+
+func (t *FormHandlerBuilderImplTestSuite) TestSetValidationMode() {
+	t.Equal(domain.ValidationModeFull, t.builder.validationMode)
+
+	t.builder.SetValidationMode(domain.ValidationModeNone)
+	t.Equal(domain.ValidationModeNone, t.builder.validationMode)
+}
+
+func (t *FormHandlerBuilderImplTestSuite) TestSetValidationModeOverride() {
+	t.Nil(t.builder.validationModeOverride)
+
+	t.builder.SetValidationModeOverride("_draft", domain.ValidationModePartial("required"))
+	t.Equal(&validationModeOverride{
+		name: "_draft",
+		mode: domain.ValidationModePartial("required"),
+	}, t.builder.validationModeOverride)
+}
+
+func (t *FormHandlerBuilderImplTestSuite) TestSetActiveSteps() {
+	t.Nil(t.builder.activeSteps)
+
+	t.builder.SetActiveSteps("shipping", "payment")
+	t.Equal([]string{"shipping", "payment"}, t.builder.activeSteps)
+
+	t.builder.SetActiveSteps()
+	t.Equal([]string{}, t.builder.activeSteps)
+}
+
+func (t *FormHandlerBuilderImplTestSuite) TestSetFormSessionStore() {
+	t.Nil(t.builder.formSessionStore)
+	t.Empty(t.builder.formIdentifier)
+
+	formSessionStore := &FormSessionStoreImpl{}
+	t.builder.SetFormSessionStore(formSessionStore, "address")
+	t.Equal(formSessionStore, t.builder.formSessionStore)
+	t.Equal("address", t.builder.formIdentifier)
+}
+
+func (t *FormHandlerBuilderImplTestSuite) TestSetSubmissionGuard() {
+	t.Nil(t.builder.submissionGuard)
+	t.Empty(t.builder.submissionIdentifier)
+
+	submissionGuard := &SubmissionGuardImpl{}
+	t.builder.SetSubmissionGuard(submissionGuard, "order")
+	t.Equal(submissionGuard, t.builder.submissionGuard)
+	t.Equal("order", t.builder.submissionIdentifier)
+	t.Empty(t.builder.formIdentifier)
+}
+
+func (t *FormHandlerBuilderImplTestSuite) TestEnableStatePersistence() {
+	t.Nil(t.builder.stateStore)
+
+	stateStore := &FormStateStoreImpl{}
+	t.builder.EnableStatePersistence(stateStore)
+	t.Equal(stateStore, t.builder.stateStore)
+
+	handler, ok := t.builder.Build().(*formHandlerImpl)
+	t.True(ok)
+	t.Equal(stateStore, handler.stateStore)
+}
+
+func (t *FormHandlerBuilderImplTestSuite) TestSetNamespace() {
+	t.Empty(t.builder.namespace)
+
+	t.builder.SetNamespace("login")
+	t.Equal("login", t.builder.namespace)
+}
+
+func (t *FormHandlerBuilderImplTestSuite) TestSetSubmitDetector() {
+	t.Nil(t.builder.submitDetector)
+
+	submitDetector := NewDefaultSubmitDetector("submitted")
+	t.builder.SetSubmitDetector(submitDetector)
+	t.Equal(submitDetector, t.builder.submitDetector)
+}
+
 func (t *FormHandlerBuilderImplTestSuite) TestBuild_Empty() {
 	t.Equal(&formHandlerImpl{
 		defaultFormDataProvider:  t.defaultProvider,
@@ -328,6 +512,18 @@ func (t *FormHandlerBuilderImplTestSuite) TestBuild_Full() {
 	t.builder.SetFormDataDecoder(t.decoder)
 	t.builder.SetFormDataValidator(t.validator)
 	t.builder.AddFormExtension(t.service)
+	postProcessor := &mocks.PostProcessor{}
+	t.builder.AddPostProcessor(postProcessor)
+	choiceProvider := &mocks.ChoiceProvider{}
+	t.builder.BindChoices("country", choiceProvider)
+	t.builder.SetValidationMode(domain.ValidationModePartial("required"))
+	t.builder.SetValidationModeOverride("_draft", domain.ValidationModeNone)
+	t.builder.SetActiveSteps("shipping")
+	formSessionStore := &FormSessionStoreImpl{}
+	t.builder.SetFormSessionStore(formSessionStore, "address")
+	submissionGuard := &SubmissionGuardImpl{}
+	t.builder.SetSubmissionGuard(submissionGuard, "order")
+	t.builder.SetNamespace("login")
 
 	t.Equal(&formHandlerImpl{
 		defaultFormDataProvider:  t.defaultProvider,
@@ -339,7 +535,24 @@ func (t *FormHandlerBuilderImplTestSuite) TestBuild_Full() {
 		formExtensions: map[string]domain.FormExtension{
 			"CompleteFormService": t.service,
 		},
-		validatorProvider: t.validatorProvider,
-		logger:            t.logger,
+		postProcessors: []domain.PostProcessor{
+			postProcessor,
+		},
+		choiceProviders: map[string]domain.ChoiceProvider{
+			"country": choiceProvider,
+		},
+		validationMode: domain.ValidationModePartial("required"),
+		validationModeOverride: &validationModeOverride{
+			name: "_draft",
+			mode: domain.ValidationModeNone,
+		},
+		activeSteps:          []string{"shipping"},
+		formSessionStore:     formSessionStore,
+		formIdentifier:       "address",
+		submissionGuard:      submissionGuard,
+		submissionIdentifier: "order",
+		namespace:            "login",
+		validatorProvider:    t.validatorProvider,
+		logger:               t.logger,
 	}, t.builder.Build())
 }
@@ -33,22 +33,32 @@ type (
 
 	// FormHandlerFactoryImpl as actual implementation of FormHandlerFactory interface
 	FormHandlerFactoryImpl struct {
-		namedFormServices        map[string]domain.FormService
-		namedFormDataProviders   map[string]domain.FormDataProvider
-		namedFormDataDecoders    map[string]domain.FormDataDecoder
-		namedFormDataValidators  map[string]domain.FormDataValidator
-		namedFormExtensions      map[string]domain.FormExtension
-		defaultFormDataProvider  domain.DefaultFormDataProvider
-		defaultFormDataDecoder   domain.DefaultFormDataDecoder
-		defaultFormDataValidator domain.DefaultFormDataValidator
-		validatorProvider        domain.ValidatorProvider
-		logger                   flamingo.Logger
+		namedFormServices         map[string]domain.FormService
+		namedFormDataProviders    map[string]domain.FormDataProvider
+		namedFormDataDecoders     map[string]domain.FormDataDecoder
+		namedFormDataValidators   map[string]domain.FormDataValidator
+		namedFormExtensions       map[string]domain.FormExtension
+		defaultFormDataProvider   domain.DefaultFormDataProvider
+		defaultFormDataDecoder    domain.DefaultFormDataDecoder
+		defaultFormDataValidator  domain.DefaultFormDataValidator
+		validatorProvider         domain.ValidatorProvider
+		validationRuleTranslators map[string]domain.ValidationRuleTranslator
+		metricsEnabled            bool
+		fieldNotation             domain.FieldNotation
+		stageTimeouts             StageTimeouts
+		maxBodySize               int64
+		maxFormKeys               int
+		maxMemory                 int64
+		logger                    flamingo.Logger
 	}
 )
 
 var _ FormHandlerFactory = &FormHandlerFactoryImpl{}
 
-// Inject is method used to set all dependencies as local variables
+// Inject is method used to set all dependencies as local variables.
+// Metrics and trace spans of form handling are recorded only if they're enabled via configuration.
+// Timeouts of form handling stages are configured as durations (like "500ms"), and it panics if any of them is invalid.
+// Limits of http request body are shared with default form data decoder, since body is parsed before decoding.
 func (f *FormHandlerFactoryImpl) Inject(
 	s map[string]domain.FormService,
 	p map[string]domain.FormDataProvider,
@@ -59,7 +69,19 @@ func (f *FormHandlerFactoryImpl) Inject(
 	dd domain.DefaultFormDataDecoder,
 	dv domain.DefaultFormDataValidator,
 	vp domain.ValidatorProvider,
+	fv []domain.FieldValidator,
 	l flamingo.Logger,
+	cfg *struct {
+		MetricsEnabled    bool    `inject:"config:form.metrics.enabled"`
+		FieldNotation     string  `inject:"config:form.fieldNotation"`
+		ProvideTimeout    string  `inject:"config:form.handler.provideTimeout"`
+		DecodeTimeout     string  `inject:"config:form.handler.decodeTimeout"`
+		ValidateTimeout   string  `inject:"config:form.handler.validateTimeout"`
+		ExtensionsTimeout string  `inject:"config:form.handler.extensionsTimeout"`
+		MaxBodySize       float64 `inject:"config:form.decoder.maxBodySize"`
+		MaxFormKeys       float64 `inject:"config:form.decoder.maxFormKeys"`
+		MaxMemory         float64 `inject:"config:form.decoder.maxMemory"`
+	},
 ) {
 	f.namedFormServices = s
 	f.namedFormDataProviders = p
@@ -71,6 +93,29 @@ func (f *FormHandlerFactoryImpl) Inject(
 	f.defaultFormDataValidator = dv
 	f.validatorProvider = vp
 	f.logger = l
+
+	if cfg != nil {
+		f.metricsEnabled = cfg.MetricsEnabled
+		f.fieldNotation = domain.FieldNotation(cfg.FieldNotation)
+
+		stageTimeouts, err := ParseStageTimeouts(cfg.ProvideTimeout, cfg.DecodeTimeout, cfg.ValidateTimeout, cfg.ExtensionsTimeout)
+		if err != nil {
+			panic(err.Error())
+		}
+		f.stageTimeouts = stageTimeouts
+		f.maxBodySize = int64(cfg.MaxBodySize)
+		f.maxFormKeys = int(cfg.MaxFormKeys)
+		f.maxMemory = int64(cfg.MaxMemory)
+	}
+
+	for _, fieldValidator := range fv {
+		if translator, ok := fieldValidator.(domain.ValidationRuleTranslator); ok {
+			if f.validationRuleTranslators == nil {
+				f.validationRuleTranslators = map[string]domain.ValidationRuleTranslator{}
+			}
+			f.validationRuleTranslators[fieldValidator.ValidatorName()] = translator
+		}
+	}
 }
 
 // CreateSimpleFormHandler as method for creating the simplest form handler instance which uses
@@ -112,16 +157,23 @@ func (f *FormHandlerFactoryImpl) CreateFormHandlerWithFormServices(formDataProvi
 // GetFormHandlerBuilder returns FomHandlerBuilder for creating more complex instances of form handler.
 func (f *FormHandlerFactoryImpl) GetFormHandlerBuilder() FormHandlerBuilder {
 	return &formHandlerBuilderImpl{
-		namedFormServices:        f.namedFormServices,
-		namedFormDataProviders:   f.namedFormDataProviders,
-		namedFormDataDecoders:    f.namedFormDataDecoders,
-		namedFormDataValidators:  f.namedFormDataValidators,
-		namedFormExtensions:      f.namedFormExtensions,
-		defaultFormDataProvider:  f.defaultFormDataProvider,
-		defaultFormDataDecoder:   f.defaultFormDataDecoder,
-		defaultFormDataValidator: f.defaultFormDataValidator,
-		validatorProvider:        f.validatorProvider,
-		logger:                   f.logger,
+		namedFormServices:         f.namedFormServices,
+		namedFormDataProviders:    f.namedFormDataProviders,
+		namedFormDataDecoders:     f.namedFormDataDecoders,
+		namedFormDataValidators:   f.namedFormDataValidators,
+		namedFormExtensions:       f.namedFormExtensions,
+		defaultFormDataProvider:   f.defaultFormDataProvider,
+		defaultFormDataDecoder:    f.defaultFormDataDecoder,
+		defaultFormDataValidator:  f.defaultFormDataValidator,
+		validatorProvider:         f.validatorProvider,
+		validationRuleTranslators: f.validationRuleTranslators,
+		metricsEnabled:            f.metricsEnabled,
+		fieldNotation:             f.fieldNotation,
+		stageTimeouts:             f.stageTimeouts,
+		maxBodySize:               f.maxBodySize,
+		maxFormKeys:               f.maxFormKeys,
+		maxMemory:                 f.maxMemory,
+		logger:                    f.logger,
 	}
 }
 
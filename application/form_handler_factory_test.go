@@ -2,6 +2,7 @@ package application
 
 import (
 	"testing"
+	"time"
 
 	"flamingo.me/flamingo/v3/framework/flamingo"
 	"flamingo.me/form/domain"
@@ -10,6 +11,11 @@ import (
 )
 
 type (
+	translatableFieldValidator struct {
+		*mocks.FieldValidator
+		*mocks.ValidationRuleTranslator
+	}
+
 	FormHandlerFactoryImplTestSuite struct {
 		suite.Suite
 
@@ -41,6 +47,19 @@ type (
 
 		logger *flamingo.NullLogger
 	}
+
+	// formHandlerFactoryTestConfig as configuration injected into form handler factory
+	formHandlerFactoryTestConfig = struct {
+		MetricsEnabled    bool    `inject:"config:form.metrics.enabled"`
+		FieldNotation     string  `inject:"config:form.fieldNotation"`
+		ProvideTimeout    string  `inject:"config:form.handler.provideTimeout"`
+		DecodeTimeout     string  `inject:"config:form.handler.decodeTimeout"`
+		ValidateTimeout   string  `inject:"config:form.handler.validateTimeout"`
+		ExtensionsTimeout string  `inject:"config:form.handler.extensionsTimeout"`
+		MaxBodySize       float64 `inject:"config:form.decoder.maxBodySize"`
+		MaxFormKeys       float64 `inject:"config:form.decoder.maxFormKeys"`
+		MaxMemory         float64 `inject:"config:form.decoder.maxMemory"`
+	}
 )
 
 func TestFormHandlerFactoryImplTestSuite(t *testing.T) {
@@ -100,7 +119,9 @@ func (t *FormHandlerFactoryImplTestSuite) SetupTest() {
 		t.defaultDecoder,
 		t.defaultValidator,
 		t.validatorProvider,
+		nil,
 		t.logger,
+		nil,
 	)
 }
 
@@ -204,3 +225,85 @@ func (t *FormHandlerFactoryImplTestSuite) TestGetFormHandlerBuilder() {
 		logger:                   t.logger,
 	}, t.factory.GetFormHandlerBuilder())
 }
+
+func (t *FormHandlerFactoryImplTestSuite) TestInject_ValidationRuleTranslators() {
+	fieldValidator := &mocks.FieldValidator{}
+
+	translator := &translatableFieldValidator{
+		FieldValidator:           &mocks.FieldValidator{},
+		ValidationRuleTranslator: &mocks.ValidationRuleTranslator{},
+	}
+	translator.FieldValidator.On("ValidatorName").Return("regex_zip").Once()
+
+	factory := &FormHandlerFactoryImpl{}
+	factory.Inject(nil, nil, nil, nil, nil, t.defaultProvider, t.defaultDecoder, t.defaultValidator, t.validatorProvider, []domain.FieldValidator{
+		fieldValidator,
+		translator,
+	}, t.logger, nil)
+
+	t.Equal(map[string]domain.ValidationRuleTranslator{
+		"regex_zip": translator,
+	}, factory.GetFormHandlerBuilder().(*formHandlerBuilderImpl).validationRuleTranslators)
+	translator.FieldValidator.AssertExpectations(t.T())
+}
+
+func (t *FormHandlerFactoryImplTestSuite) TestInject_MetricsEnabled() {
+	t.False(t.factory.GetFormHandlerBuilder().Build().(*formHandlerImpl).metricsEnabled)
+
+	factory := &FormHandlerFactoryImpl{}
+	factory.Inject(nil, nil, nil, nil, nil, t.defaultProvider, t.defaultDecoder, t.defaultValidator, t.validatorProvider, nil, t.logger, &formHandlerFactoryTestConfig{
+		MetricsEnabled: true,
+	})
+
+	t.True(factory.GetFormHandlerBuilder().Build().(*formHandlerImpl).metricsEnabled)
+}
+
+func (t *FormHandlerFactoryImplTestSuite) TestInject_StageTimeouts() {
+	t.Equal(StageTimeouts{}, t.factory.GetFormHandlerBuilder().Build().(*formHandlerImpl).stageTimeouts)
+
+	factory := &FormHandlerFactoryImpl{}
+	factory.Inject(nil, nil, nil, nil, nil, t.defaultProvider, t.defaultDecoder, t.defaultValidator, t.validatorProvider, nil, t.logger, &formHandlerFactoryTestConfig{
+		ProvideTimeout:    "0s",
+		ValidateTimeout:   "2s",
+		ExtensionsTimeout: "500ms",
+	})
+
+	t.Equal(StageTimeouts{
+		Validate:   2 * time.Second,
+		Extensions: 500 * time.Millisecond,
+	}, factory.GetFormHandlerBuilder().Build().(*formHandlerImpl).stageTimeouts)
+
+	// timeouts set via builder override configured ones
+	t.Equal(StageTimeouts{
+		Decode: time.Second,
+	}, factory.GetFormHandlerBuilder().SetStageTimeouts(StageTimeouts{Decode: time.Second}).Build().(*formHandlerImpl).stageTimeouts)
+
+	t.Panics(func() {
+		factory.Inject(nil, nil, nil, nil, nil, t.defaultProvider, t.defaultDecoder, t.defaultValidator, t.validatorProvider, nil, t.logger, &formHandlerFactoryTestConfig{
+			ValidateTimeout: "forever",
+		})
+	})
+}
+
+func (t *FormHandlerFactoryImplTestSuite) TestInject_RequestLimits() {
+	factory := &FormHandlerFactoryImpl{}
+	factory.Inject(nil, nil, nil, nil, nil, t.defaultProvider, t.defaultDecoder, t.defaultValidator, t.validatorProvider, nil, t.logger, &formHandlerFactoryTestConfig{
+		MaxBodySize: 1024,
+		MaxFormKeys: 10,
+		MaxMemory:   2048,
+	})
+
+	handler := factory.GetFormHandlerBuilder().Build().(*formHandlerImpl)
+	t.Equal(int64(1024), handler.maxBodySize)
+	t.Equal(10, handler.maxFormKeys)
+	t.Equal(int64(2048), handler.maxMemory)
+}
+
+func (t *FormHandlerFactoryImplTestSuite) TestInject_FieldNotation() {
+	factory := &FormHandlerFactoryImpl{}
+	factory.Inject(nil, nil, nil, nil, nil, t.defaultProvider, t.defaultDecoder, t.defaultValidator, t.validatorProvider, nil, t.logger, &formHandlerFactoryTestConfig{
+		FieldNotation: "bracket",
+	})
+
+	t.Equal(domain.FieldNotationBracket, factory.GetFormHandlerBuilder().Build().(*formHandlerImpl).fieldNotation)
+}
@@ -1,16 +1,24 @@
 package application
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"flamingo.me/flamingo/v3/framework/flamingo"
 	"flamingo.me/flamingo/v3/framework/web"
 	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/extensions"
 	"flamingo.me/form/domain/mocks"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -43,7 +51,7 @@ func TestFormHandlerImplTestSuite(t *testing.T) {
 }
 
 func (t *FormHandlerImplTestSuite) SetupSuite() {
-	t.context = context.Background()
+	t.context = domain.ContextWithAttachments(context.Background(), domain.NewAttachments())
 }
 
 func (t *FormHandlerImplTestSuite) SetupTest() {
@@ -98,7 +106,7 @@ func (t *FormHandlerImplTestSuite) TestHandleUnsubmittedForm_Error() {
 	t.provider.On("GetFormData", t.context, t.request).Return(nil, errors.New("error")).Once()
 
 	result, err := t.handler.HandleUnsubmittedForm(t.context, t.request)
-	t.Equal(domain.FormError("error"), err)
+	t.Equal(domain.NewWrappedFormError(errors.New("error")), err)
 	t.Nil(result)
 }
 
@@ -307,6 +315,83 @@ func (t *FormHandlerImplTestSuite) TestExtractValidationRules_Struct() {
 	}{}))
 }
 
+func (t *FormHandlerImplTestSuite) TestExtractValidationRules_Translated() {
+	translator := &mocks.ValidationRuleTranslator{}
+	translator.On("TranslateValidationRule", "").Return(domain.ValidationRule{
+		Name:  "pattern",
+		Value: "^[0-9]{5}$",
+	}).Times(4)
+	defer translator.AssertExpectations(t.T())
+
+	t.handler.validationRuleTranslators = map[string]domain.ValidationRuleTranslator{
+		"regex_zip": translator,
+	}
+
+	type address struct {
+		Street string `form:"street" validate:"required,max=50"`
+		Zip    string `form:"zip" validate:"regex_zip"`
+	}
+
+	formData := struct {
+		Name      string    `form:"name" validate:"required,min=3,max=20"`
+		Code      string    `form:"code" validate:"len=4"`
+		Age       int       `form:"age" validate:"min=18,max=99"`
+		Email     string    `form:"email" validate:"omitempty,email"`
+		Equation  string    `form:"equation" validate:"contains=0x2C,excludes=a=b"`
+		Tags      []string  `form:"tags" validate:"min=1,dive,required"`
+		Address   *address  `form:"address"`
+		Addresses []address `form:"addresses" validate:"required"`
+	}{}
+
+	expected := map[string][]domain.ValidationRule{
+		"name": {
+			{Name: "required"},
+			{Name: "minlength", Value: "3"},
+			{Name: "maxlength", Value: "20"},
+		},
+		"code": {
+			{Name: "minlength", Value: "4"},
+			{Name: "maxlength", Value: "4"},
+		},
+		"age": {
+			{Name: "min", Value: "18"},
+			{Name: "max", Value: "99"},
+		},
+		"email": {
+			{Name: "email"},
+		},
+		"equation": {
+			{Name: "contains", Value: ","},
+			{Name: "excludes", Value: "a=b"},
+		},
+		"tags": {
+			{Name: "min", Value: "1"},
+		},
+		"address.street": {
+			{Name: "required"},
+			{Name: "maxlength", Value: "50"},
+		},
+		"address.zip": {
+			{Name: "pattern", Value: "^[0-9]{5}$"},
+		},
+		"addresses": {
+			{Name: "required"},
+		},
+		"addresses.street": {
+			{Name: "required"},
+			{Name: "maxlength", Value: "50"},
+		},
+		"addresses.zip": {
+			{Name: "pattern", Value: "^[0-9]{5}$"},
+		},
+	}
+
+	t.Equal(expected, t.handler.extractValidationRules(formData))
+
+	// parsed tags are cached per type, while translation is done each time rules are extracted
+	t.Equal(expected, t.handler.extractValidationRules(formData))
+}
+
 func (t *FormHandlerImplTestSuite) TestGetUrlValues_PostError() {
 	t.request.Request().Method = http.MethodPost
 
@@ -407,7 +492,7 @@ func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_GetFormDataError() {
 	t.provider.On("GetFormData", t.context, t.request).Return(nil, errors.New("error")).Once()
 
 	result, err := t.handler.HandleSubmittedForm(t.context, t.request)
-	t.Equal(domain.FormError("error"), err)
+	t.Equal(domain.NewWrappedFormError(errors.New("error")), err)
 	t.Nil(result)
 }
 
@@ -417,10 +502,58 @@ func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_PostValueProcessingEr
 	t.request.Request().Method = http.MethodPost
 
 	result, err := t.handler.HandleSubmittedForm(t.context, t.request)
-	t.Equal(domain.FormError("missing form body"), err)
+	t.Equal(domain.NewWrappedFormError(errors.New("missing form body")), err)
 	t.Nil(result)
 }
 
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_RequestLimits() {
+	t.handler.formExtensions = nil
+	t.handler.maxBodySize = 16
+	t.handler.maxFormKeys = 2
+
+	for _, testCase := range []struct {
+		name          string
+		body          string
+		expectedError domain.Error
+	}{
+		{
+			name: "body without declared length is not read beyond maximum body size",
+			body: "first=" + strings.Repeat("a", 1024),
+			expectedError: domain.Error{
+				MessageKey:   "formError.requestTooLarge",
+				DefaultLabel: "request body is too large",
+			},
+		},
+		{
+			name: "number of fields is checked right after parsing",
+			body: "a=1&b=2&c=3",
+			expectedError: domain.Error{
+				MessageKey:   "formError.tooManyFields",
+				DefaultLabel: "too many fields are submitted",
+				Parameters: map[string]string{
+					"max": "2",
+				},
+			},
+		},
+	} {
+		t.Run(testCase.name, func() {
+			httpRequest, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(testCase.body))
+			httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			httpRequest.ContentLength = -1
+			request := web.CreateRequest(httpRequest, nil)
+
+			t.provider.On("GetFormData", t.context, request).Return(map[string]string{}, nil).Once()
+
+			// request which exceeds limits is presented as invalid form, without decoding and validation
+			result, err := t.handler.HandleSubmittedForm(t.context, request)
+			t.NoError(err)
+			t.True(result.IsSubmitted())
+			t.False(result.IsValid())
+			t.Equal([]domain.Error{testCase.expectedError}, result.GetGeneralErrors())
+		})
+	}
+}
+
 func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_DecodeError() {
 	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
 
@@ -436,10 +569,100 @@ func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_DecodeError() {
 	}, map[string]string{}).Return(nil, errors.New("error")).Once()
 
 	result, err := t.handler.HandleSubmittedForm(t.context, t.request)
-	t.Equal(domain.FormError("error"), err)
+	t.Equal(domain.NewWrappedFormError(errors.New("error")), err)
 	t.Nil(result)
 }
 
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_DecodeValidationError() {
+	t.handler.formExtensions = nil
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"first": []string{"first"},
+	}
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddGeneralError("formError.invalidJSON", "unexpected EOF")
+
+	t.decoder.On("Decode", t.context, t.request, url.Values{
+		"first": []string{"first"},
+	}, map[string]string{}).Return(nil, domain.NewDecodeError(validationInfo)).Once()
+
+	result, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+
+	form := domain.NewForm(true, map[string][]domain.ValidationRule{})
+	form.Data = map[string]string{}
+	form.ValidationInfo = validationInfo
+	form.SetOriginalValues(url.Values{
+		"first": []string{"first"},
+	})
+
+	t.Equal(&form, result)
+	t.False(result.IsValid())
+	t.Equal("first", result.GetOriginalValue("first"))
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_DecodeValidationErrorWithData() {
+	t.handler.formExtensions = nil
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"first": []string{"first"},
+	}
+
+	decodeValidationInfo := domain.ValidationInfo{}
+	decodeValidationInfo.AddFieldError("second", "formError.invalidValue", "invalid value")
+
+	t.decoder.On("Decode", t.context, t.request, url.Values{
+		"first": []string{"first"},
+	}, map[string]string{}).Return(map[string]string{
+		"first": "first",
+	}, domain.NewDecodeError(decodeValidationInfo)).Once()
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldError("third", "formError.third.required", "third required")
+
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{
+		"first": "first",
+	}).Return(&validationInfo, nil).Once()
+
+	result, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.Equal(map[string]string{
+		"first": "first",
+	}, result.Data)
+	t.True(result.HasErrorForField("second"))
+	t.True(result.HasErrorForField("third"))
+	t.Equal("first", result.GetOriginalValue("first"))
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_JSONOriginalValues() {
+	t.handler.formExtensions = nil
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().Header = http.Header{
+		"Content-Type": []string{"application/json"},
+	}
+	t.request.Request().Body = ioutil.NopCloser(strings.NewReader(`{"age": "abc"}`))
+	t.request.Request().PostForm = url.Values{}
+
+	decodeValidationInfo := domain.ValidationInfo{}
+	decodeValidationInfo.AddFieldError("age", "formError.invalidValue", "invalid value")
+
+	t.decoder.On("Decode", t.context, t.request, url.Values{}, map[string]string{}).Return(nil, domain.NewDecodeError(decodeValidationInfo)).Once()
+
+	result, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.Equal(url.Values{
+		"age": []string{"abc"},
+	}, result.OriginalValues())
+	t.Equal("abc", result.GetOriginalValue("age"))
+}
+
 func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_ValidateError() {
 	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
 
@@ -463,7 +686,7 @@ func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_ValidateError() {
 	}).Return(nil, errors.New("error")).Once()
 
 	result, err := t.handler.HandleSubmittedForm(t.context, t.request)
-	t.Equal(domain.FormError("error"), err)
+	t.Equal(domain.NewWrappedFormError(errors.New("error")), err)
 	t.Nil(result)
 }
 
@@ -494,7 +717,7 @@ func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_FormExtensionError()
 	t.defaultProvider.On("GetFormData", t.context, t.request).Return(nil, errors.New("error")).Maybe()
 
 	result, err := t.handler.HandleSubmittedForm(t.context, t.request)
-	t.Equal(domain.FormError("error"), err)
+	t.Equal(domain.NewWrappedFormError(errors.New("error")), err)
 	t.Nil(result)
 }
 
@@ -556,6 +779,10 @@ func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_Success() {
 		"first":  "first",
 		"second": "second",
 	}
+	form.SetOriginalValues(url.Values{
+		"first":  []string{"first"},
+		"second": []string{"second"},
+	})
 	form.FormExtensionsData = map[string]interface{}{
 		"first":  map[string]int{},
 		"second": map[string]int{},
@@ -588,6 +815,388 @@ func (t *FormHandlerImplTestSuite) TestHandleForm_Unsubmitted() {
 	t.Equal(&form, result)
 }
 
+func (t *FormHandlerImplTestSuite) TestHandleForm_EmptyJSONBody() {
+	t.handler.formExtensions = nil
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().Header = http.Header{
+		"Content-Type": []string{"application/json; charset=utf-8"},
+	}
+	t.request.Request().Body = ioutil.NopCloser(strings.NewReader(" \n "))
+
+	result, err := t.handler.HandleForm(t.context, t.request)
+	t.NoError(err)
+	t.False(result.IsSubmitted())
+	t.Equal(map[string]string{}, result.Data)
+	t.True(result.ValidationInfo.IsValid())
+
+	// body is still available to controller
+	body, err := ioutil.ReadAll(t.request.Request().Body)
+	t.NoError(err)
+	t.Equal(" \n ", string(body))
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleForm_Restored() {
+	t.handler.formSessionStore = &FormSessionStoreImpl{
+		ttl: time.Minute,
+	}
+	t.handler.formIdentifier = "address"
+
+	submitted := domain.NewForm(true, nil)
+	submitted.ValidationInfo.AddFieldError("street", "formError.street.required", "street required")
+	submitted.SetOriginalValues(url.Values{
+		"street": []string{""},
+		"city":   []string{"Berlin"},
+	})
+	t.NoError(t.handler.formSessionStore.Save(t.context, t.request.Session(), "address", &submitted))
+
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Twice()
+
+	result, err := t.handler.HandleForm(t.context, t.request)
+	t.NoError(err)
+
+	form := domain.NewForm(true, map[string][]domain.ValidationRule{})
+	form.Data = map[string]string{}
+	form.ValidationInfo = submitted.ValidationInfo
+	form.SetOriginalValues(submitted.OriginalValues())
+	t.Equal(&form, result)
+
+	result, err = t.handler.HandleForm(t.context, t.request)
+	t.NoError(err)
+
+	form = domain.NewForm(false, map[string][]domain.ValidationRule{})
+	form.Data = map[string]string{}
+	t.Equal(&form, result)
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_SubmissionGuard() {
+	t.handler.formExtensions = nil
+	t.handler.submissionGuard = &SubmissionGuardImpl{
+		ttl:       time.Minute,
+		fieldName: "_submissionId",
+	}
+	t.handler.submissionIdentifier = "order"
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Twice()
+
+	form, err := t.handler.HandleUnsubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.Equal("_submissionId", form.SubmissionFieldName())
+	t.NotEmpty(form.SubmissionID())
+
+	values := url.Values{
+		"street":        []string{"Main Street"},
+		"_submissionId": []string{form.SubmissionID()},
+	}
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = values
+
+	t.decoder.On("Decode", t.context, t.request, values, map[string]string{}).Return(map[string]string{
+		"street": "Main Street",
+	}, nil).Once()
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{
+		"street": "Main Street",
+	}).Return(&domain.ValidationInfo{}, nil).Once()
+
+	form, err = t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsValidAndSubmitted())
+	t.False(form.IsDuplicateSubmission())
+	t.Empty(form.SubmissionID())
+
+	// second submission with the same ID doesn't use any form service
+	form, err = t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+
+	duplicate := domain.NewForm(true, nil)
+	duplicate.SetDuplicateSubmission(true)
+	duplicate.ValidationInfo.AddGeneralError("formError.duplicateSubmission", "form is already submitted")
+	t.Equal(&duplicate, form)
+	t.True(form.IsDuplicateSubmission())
+	t.False(form.IsValid())
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_SubmissionExpired() {
+	now := time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	guard := &SubmissionGuardImpl{
+		ttl:       time.Minute,
+		fieldName: "_submissionId",
+		now: func() time.Time {
+			return now
+		},
+	}
+	t.handler.formExtensions = nil
+	t.handler.submissionGuard = guard
+	t.handler.submissionIdentifier = "order"
+	t.handler.namespace = "checkout"
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+
+	submissionID, err := guard.Generate(t.context, t.request.Session(), "order")
+	t.NoError(err)
+	now = now.Add(2 * time.Minute)
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"checkout[street]":        []string{"Main Street"},
+		"checkout[_submissionId]": []string{submissionID},
+	}
+
+	values := url.Values{
+		"street":        []string{"Main Street"},
+		"_submissionId": []string{submissionID},
+	}
+	t.decoder.On("Decode", domain.ContextWithNamespace(t.context, "checkout"), t.request, values, map[string]string{}).Return(map[string]string{
+		"street": "Main Street",
+	}, nil).Once()
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{
+		"street": "Main Street",
+	}).Return(&domain.ValidationInfo{}, nil).Once()
+
+	form, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.False(form.IsDuplicateSubmission())
+	t.False(form.IsValid())
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.submissionExpired",
+			DefaultLabel: "form submission is expired, please submit the form again",
+		},
+	}, form.GetGeneralErrors())
+
+	// invalid form is presented again with fresh ID
+	t.Equal("checkout._submissionId", form.SubmissionFieldName())
+	t.NotEmpty(form.SubmissionID())
+	t.NotEqual(submissionID, form.SubmissionID())
+}
+
+func (t *FormHandlerImplTestSuite) TestStripNamespace() {
+	handler := &formHandlerImpl{
+		namespace: "login",
+	}
+
+	testCases := []struct {
+		name     string
+		stripped string
+		ok       bool
+	}{
+		{name: "login.email", stripped: "email", ok: true},
+		{name: "login[email]", stripped: "email", ok: true},
+		{name: "login[items][0]", stripped: "items[0]", ok: true},
+		{name: "login.address.city", stripped: "address.city", ok: true},
+		{name: "login", ok: false},
+		{name: "login.", ok: false},
+		{name: "login[]", ok: false},
+		{name: "login[email", ok: false},
+		{name: "loginemail", ok: false},
+		{name: "newsletter.email", ok: false},
+		{name: "email", ok: false},
+	}
+
+	for _, testCase := range testCases {
+		stripped, ok := handler.stripNamespace(testCase.name)
+		t.Equal(testCase.ok, ok, testCase.name)
+		t.Equal(testCase.stripped, stripped, testCase.name)
+	}
+}
+
+func (t *FormHandlerImplTestSuite) TestIsNamespaceSubmitted() {
+	handler := &formHandlerImpl{
+		namespace: "login",
+		maxMemory: 1024,
+	}
+
+	createMultipartRequest := func(values map[string]string, files map[string]string) *web.Request {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		for name, value := range values {
+			_ = writer.WriteField(name, value)
+		}
+		for name, fileName := range files {
+			part, _ := writer.CreateFormFile(name, fileName)
+			_, _ = part.Write([]byte("content"))
+		}
+		_ = writer.Close()
+
+		httpRequest, _ := http.NewRequest(http.MethodPost, "/", body)
+		httpRequest.Header.Set("Content-Type", writer.FormDataContentType())
+
+		return web.CreateRequest(httpRequest, nil)
+	}
+
+	createJSONRequest := func(body string) *web.Request {
+		httpRequest, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		httpRequest.Header.Set("Content-Type", "application/json")
+
+		return web.CreateRequest(httpRequest, nil)
+	}
+
+	testCases := []struct {
+		name      string
+		req       *web.Request
+		submitted bool
+	}{
+		{name: "multipart value", req: createMultipartRequest(map[string]string{"login.email": "email"}, nil), submitted: true},
+		{name: "multipart file", req: createMultipartRequest(nil, map[string]string{"login.avatar": "avatar.png"}), submitted: true},
+		{name: "multipart other namespace", req: createMultipartRequest(map[string]string{"newsletter.email": "email"}, map[string]string{"avatar": "avatar.png"}), submitted: false},
+		{name: "JSON", req: createJSONRequest(`{"login": {"email": "email"}}`), submitted: true},
+		{name: "JSON other namespace", req: createJSONRequest(`{"newsletter": {"email": "email"}, "email": "email"}`), submitted: false},
+		{name: "malformed JSON", req: createJSONRequest(`{"login": `), submitted: true},
+	}
+
+	for _, testCase := range testCases {
+		t.Equal(testCase.submitted, handler.isNamespaceSubmitted(testCase.req), testCase.name)
+	}
+
+	// multipart form parsed for checking is kept for decoding
+	req := createMultipartRequest(nil, map[string]string{"login.avatar": "avatar.png"})
+	handler.isNamespaceSubmitted(req)
+	t.NotNil(req.Request().MultipartForm)
+	t.Equal("avatar.png", req.Request().MultipartForm.File["login.avatar"][0].Filename)
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleForm_Namespaces() {
+	loginProvider := &mocks.FormDataProvider{}
+	loginDecoder := &mocks.FormDataDecoder{}
+	loginValidator := &mocks.FormDataValidator{}
+	newsletterProvider := &mocks.FormDataProvider{}
+	newsletterDecoder := &mocks.FormDataDecoder{}
+	newsletterValidator := &mocks.FormDataValidator{}
+	defer func() {
+		loginProvider.AssertExpectations(t.T())
+		loginDecoder.AssertExpectations(t.T())
+		loginValidator.AssertExpectations(t.T())
+		newsletterProvider.AssertExpectations(t.T())
+		newsletterDecoder.AssertExpectations(t.T())
+		newsletterValidator.AssertExpectations(t.T())
+	}()
+
+	loginHandler := &formHandlerImpl{
+		formDataProvider:  loginProvider,
+		formDataDecoder:   loginDecoder,
+		formDataValidator: loginValidator,
+		validatorProvider: t.validatorProvider,
+		namespace:         "login",
+		logger:            t.logger,
+	}
+	newsletterHandler := &formHandlerImpl{
+		formDataProvider:  newsletterProvider,
+		formDataDecoder:   newsletterDecoder,
+		formDataValidator: newsletterValidator,
+		validatorProvider: t.validatorProvider,
+		namespace:         "newsletter",
+		logger:            t.logger,
+	}
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"login.email":     []string{""},
+		"login[password]": []string{"secret"},
+		"email":           []string{"other@example.com"},
+	}
+
+	loginProvider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+	loginDecoder.On("Decode", domain.ContextWithNamespace(t.context, "login"), t.request, url.Values{
+		"email":    []string{""},
+		"password": []string{"secret"},
+	}, map[string]string{}).Return(map[string]string{
+		"email":    "",
+		"password": "secret",
+	}, nil).Once()
+
+	loginValidationInfo := &domain.ValidationInfo{}
+	loginValidationInfo.AddGeneralError("formError.login", "login failed")
+	loginValidationInfo.AddFieldError("email", "formError.email.required", "email required")
+	loginValidator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{
+		"email":    "",
+		"password": "secret",
+	}).Return(loginValidationInfo, nil).Once()
+
+	newsletterProvider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+
+	loginForm, err := loginHandler.HandleForm(t.context, t.request)
+	t.NoError(err)
+	t.True(loginForm.IsSubmitted())
+	t.False(loginForm.IsValid())
+	t.Equal(map[string]string{
+		"email":    "",
+		"password": "secret",
+	}, loginForm.Data)
+	t.Equal(url.Values{
+		"login.email":     []string{""},
+		"login[password]": []string{"secret"},
+	}, loginForm.OriginalValues())
+
+	expected := domain.ValidationInfo{}
+	expected.AddGeneralError("formError.login", "login failed")
+	expected.AddFieldError("login.email", "formError.email.required", "email required")
+	t.Equal(expected, loginForm.ValidationInfo)
+
+	newsletterForm, err := newsletterHandler.HandleForm(t.context, t.request)
+	t.NoError(err)
+	t.False(newsletterForm.IsSubmitted())
+	t.True(newsletterForm.IsValid())
+	t.Equal(map[string]string{}, newsletterForm.Data)
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedGETForm_Namespace() {
+	t.handler.namespace = "search"
+	t.handler.formExtensions = nil
+
+	t.request.Request().URL = &url.URL{
+		RawQuery: "search.query=shoes&search[sort]=price&page=2",
+	}
+
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+	t.decoder.On("Decode", domain.ContextWithNamespace(t.context, "search"), t.request, url.Values{
+		"query": []string{"shoes"},
+		"sort":  []string{"price"},
+	}, map[string]string{}).Return(map[string]string{
+		"query": "shoes",
+		"sort":  "price",
+	}, nil).Once()
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{
+		"query": "shoes",
+		"sort":  "price",
+	}).Return(&domain.ValidationInfo{}, nil).Once()
+
+	form, err := t.handler.HandleSubmittedGETForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsValidAndSubmitted())
+	t.Equal(url.Values{
+		"search.query": []string{"shoes"},
+		"search[sort]": []string{"price"},
+	}, form.OriginalValues())
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_BracketNotation() {
+	t.handler.namespace = "checkout"
+	t.handler.fieldNotation = domain.FieldNotationBracket
+	t.handler.formExtensions = nil
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"checkout[items][0][sku]": []string{""},
+	}
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddGeneralError("formError.checkout", "checkout failed")
+	validationInfo.AddFieldError("items[0].sku", "formError.items.sku.required", "sku required")
+
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+	t.decoder.On("Decode", domain.ContextWithNamespace(t.context, "checkout"), t.request, url.Values{
+		"items[0][sku]": []string{""},
+	}, map[string]string{}).Return(map[string]string{}, nil).Once()
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{}).Return(&validationInfo, nil).Once()
+
+	form, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+
+	expected := domain.ValidationInfo{}
+	expected.AddGeneralError("formError.checkout", "checkout failed")
+	expected.AddFieldError("checkout[items][0][sku]", "formError.items.sku.required", "sku required")
+	t.Equal(expected, form.ValidationInfo)
+}
+
 func (t *FormHandlerImplTestSuite) TestHandleForm_Submitted() {
 	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
 
@@ -646,6 +1255,10 @@ func (t *FormHandlerImplTestSuite) TestHandleForm_Submitted() {
 		"first":  "first",
 		"second": "second",
 	}
+	form.SetOriginalValues(url.Values{
+		"first":  []string{"first"},
+		"second": []string{"second"},
+	})
 	form.FormExtensionsData = map[string]interface{}{
 		"first":  map[string]int{},
 		"second": map[string]int{},
@@ -656,6 +1269,103 @@ func (t *FormHandlerImplTestSuite) TestHandleForm_Submitted() {
 	t.Equal(&form, result)
 }
 
+func (t *FormHandlerImplTestSuite) TestHandleForm_SubmitDetectorGETWithMarker() {
+	t.handler.formExtensions = nil
+	t.handler.submitDetector = NewDefaultSubmitDetector("submitted")
+
+	t.request.Request().Method = http.MethodGet
+	t.request.Request().URL = &url.URL{
+		RawQuery: "query=shoes&submitted=1",
+	}
+
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+	t.decoder.On("Decode", t.context, t.request, url.Values{
+		"query":     []string{"shoes"},
+		"submitted": []string{"1"},
+	}, map[string]string{}).Return(map[string]string{
+		"query": "shoes",
+	}, nil).Once()
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldError("sort", "formError.sort.required", "sort required")
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{
+		"query": "shoes",
+	}).Return(&validationInfo, nil).Once()
+
+	form, err := t.handler.HandleForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsSubmitted())
+	t.False(form.IsValid())
+	t.Equal(map[string]string{
+		"query": "shoes",
+	}, form.Data)
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleForm_SubmitDetectorGETWithoutMarker() {
+	t.handler.formExtensions = nil
+	t.handler.submitDetector = NewDefaultSubmitDetector("submitted")
+
+	prefill := &mocks.PrefillProvider{}
+	t.handler.prefillProviders = []domain.PrefillProvider{prefill}
+
+	t.request.Request().Method = http.MethodGet
+	t.request.Request().URL = &url.URL{
+		RawQuery: "query=shoes",
+	}
+
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+	prefill.On("Prefill", t.context, t.request, map[string]string{}).Return(map[string]interface{}{
+		"sort": "price",
+	}, nil).Once()
+
+	form, err := t.handler.HandleForm(t.context, t.request)
+	t.NoError(err)
+	t.False(form.IsSubmitted())
+	t.True(form.IsValid())
+	t.Equal(map[string]string{
+		"sort": "price",
+	}, form.Data)
+
+	prefill.AssertExpectations(t.T())
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleForm_SubmitDetectorPOST() {
+	t.handler.formExtensions = nil
+
+	submitDetector := &mocks.SubmitDetector{}
+	submitDetector.On("WasSubmitted", t.context, t.request).Return(false).Once()
+	t.handler.submitDetector = submitDetector
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"query": []string{"shoes"},
+	}
+
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+
+	form, err := t.handler.HandleForm(t.context, t.request)
+	t.NoError(err)
+	t.False(form.IsSubmitted())
+
+	t.handler.submitDetector = NewDefaultSubmitDetector("submitted")
+
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+	t.decoder.On("Decode", t.context, t.request, url.Values{
+		"query": []string{"shoes"},
+	}, map[string]string{}).Return(map[string]string{
+		"query": "shoes",
+	}, nil).Once()
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{
+		"query": "shoes",
+	}).Return(&domain.ValidationInfo{}, nil).Once()
+
+	form, err = t.handler.HandleForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsValidAndSubmitted())
+
+	submitDetector.AssertExpectations(t.T())
+}
+
 func (t *FormHandlerImplTestSuite) TestHandleSubmittedGETForm() {
 	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
 
@@ -716,6 +1426,10 @@ func (t *FormHandlerImplTestSuite) TestHandleSubmittedGETForm() {
 		"first":  "first",
 		"second": "second",
 	}
+	form.SetOriginalValues(url.Values{
+		"first":  []string{"first"},
+		"second": []string{"second"},
+	})
 	form.FormExtensionsData = map[string]interface{}{
 		"first":  map[string]int{},
 		"second": map[string]int{},
@@ -725,3 +1439,675 @@ func (t *FormHandlerImplTestSuite) TestHandleSubmittedGETForm() {
 
 	t.Equal(&form, result)
 }
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_PostProcessorError() {
+	t.handler.formExtensions = nil
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{}
+
+	t.decoder.On("Decode", t.context, t.request, url.Values{}, map[string]string{}).Return(map[string]string{}, nil).Once()
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{}).Return(&domain.ValidationInfo{}, nil).Once()
+
+	firstPostProcessor := &mocks.PostProcessor{}
+	firstPostProcessor.On("Process", t.context, t.request, &map[string]string{}, mock.Anything).Return(errors.New("rate limit")).Once()
+	secondPostProcessor := &mocks.PostProcessor{}
+	t.handler.postProcessors = []domain.PostProcessor{
+		firstPostProcessor,
+		secondPostProcessor,
+	}
+
+	result, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.EqualError(err, "rate limit")
+	t.Nil(result)
+
+	firstPostProcessor.AssertExpectations(t.T())
+	secondPostProcessor.AssertExpectations(t.T())
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_PostProcessorSuccess() {
+	t.handler.formExtensions = nil
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"phone": []string{"0176 1234567"},
+	}
+
+	t.decoder.On("Decode", t.context, t.request, url.Values{
+		"phone": []string{"0176 1234567"},
+	}, map[string]string{}).Return(map[string]string{
+		"phone": "0176 1234567",
+	}, nil).Once()
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldError("phone", "formError.phone.blocked", "phone blocked")
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{
+		"phone": "0176 1234567",
+	}).Return(&validationInfo, nil).Once()
+
+	firstPostProcessor := &mocks.PostProcessor{}
+	firstPostProcessor.On("Process", t.context, t.request, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		formData := args.Get(2).(*map[string]string)
+		(*formData)["phone"] = "+491761234567"
+	}).Return(nil).Once()
+	secondPostProcessor := &mocks.PostProcessor{}
+	secondPostProcessor.On("Process", t.context, t.request, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		validationInfo := args.Get(3).(*domain.ValidationInfo)
+		for _, err := range validationInfo.GetErrorsForField("phone") {
+			validationInfo.AddGeneralError(err.MessageKey, err.DefaultLabel)
+		}
+		validationInfo.RemoveAllFieldError("phone")
+	}).Return(nil).Once()
+	t.handler.postProcessors = []domain.PostProcessor{
+		firstPostProcessor,
+		secondPostProcessor,
+	}
+
+	result, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.Equal(map[string]string{
+		"phone": "+491761234567",
+	}, result.Data)
+	t.False(result.HasErrorForField("phone"))
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.phone.blocked",
+			DefaultLabel: "phone blocked",
+		},
+	}, result.GetGeneralErrors())
+
+	firstPostProcessor.AssertExpectations(t.T())
+	secondPostProcessor.AssertExpectations(t.T())
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_CSRFReplayedToken() {
+	csrfTokenExtension := &extensions.CsrfTokenFormExtension{}
+	csrfTokenExtension.Inject(&struct {
+		Secret string `inject:"config:form.csrf.secret"`
+		TTL    string `inject:"config:form.csrf.ttl"`
+	}{
+		Secret: "secret",
+		TTL:    "30m",
+	})
+	t.handler.formExtensions = map[string]domain.FormExtension{
+		"csrf": csrfTokenExtension,
+	}
+	t.request = web.CreateRequest(&http.Request{}, web.EmptySession())
+
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Times(3)
+
+	form, err := t.handler.HandleUnsubmittedForm(t.context, t.request)
+	t.NoError(err)
+	token := form.FormExtensionsData["csrf"].(*extensions.CsrfTokenData).Token
+
+	values := url.Values{
+		extensions.CsrfTokenFieldName: []string{token},
+	}
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = values
+
+	t.decoder.On("Decode", t.context, t.request, values, map[string]string{}).Return(map[string]string{}, nil).Twice()
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{}).Return(&domain.ValidationInfo{}, nil).Twice()
+
+	form, err = t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsValid())
+	t.NotEqual(token, form.FormExtensionsData["csrf"].(*extensions.CsrfTokenData).Token)
+
+	form, err = t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.False(form.IsValid())
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.csrf",
+			DefaultLabel: "invalid csrf token",
+		},
+	}, form.ValidationInfo.GetGeneralErrors())
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_CSRFInvalidForm() {
+	csrfTokenExtension := &extensions.CsrfTokenFormExtension{}
+	csrfTokenExtension.Inject(&struct {
+		Secret string `inject:"config:form.csrf.secret"`
+		TTL    string `inject:"config:form.csrf.ttl"`
+	}{
+		Secret: "secret",
+		TTL:    "30m",
+	})
+	t.handler.formExtensions = map[string]domain.FormExtension{
+		"csrf": csrfTokenExtension,
+	}
+	t.handler.validationModeOverride = &validationModeOverride{
+		name: "_draft",
+		mode: domain.ValidationModePartial("required"),
+	}
+	t.request = web.CreateRequest(&http.Request{}, web.EmptySession())
+
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Times(4)
+
+	form, err := t.handler.HandleUnsubmittedForm(t.context, t.request)
+	t.NoError(err)
+	token := form.FormExtensionsData["csrf"].(*extensions.CsrfTokenData).Token
+
+	invalid := domain.ValidationInfo{}
+	invalid.AddFieldError("email", "formError.email.required", "email required")
+
+	// token is kept for invalid submission and for submission with partial validation
+	values := url.Values{
+		extensions.CsrfTokenFieldName: []string{token},
+	}
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = values
+
+	t.decoder.On("Decode", t.context, t.request, values, map[string]string{}).Return(map[string]string{}, nil).Once()
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{}).Return(&invalid, nil).Once()
+
+	form, err = t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.False(form.IsValid())
+	t.Equal(token, form.FormExtensionsData["csrf"].(*extensions.CsrfTokenData).Token)
+
+	draftValues := url.Values{
+		extensions.CsrfTokenFieldName: []string{token},
+		"_draft":                      []string{"1"},
+	}
+	t.request.Request().PostForm = draftValues
+	t.request.Request().Form = nil
+
+	t.decoder.On("Decode", t.context, t.request, draftValues, map[string]string{}).Return(map[string]string{}, nil).Once()
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{}).Return(&domain.ValidationInfo{}, nil).Once()
+
+	form, err = t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsValid())
+	t.Equal(token, form.FormExtensionsData["csrf"].(*extensions.CsrfTokenData).Token)
+
+	// token is rotated only after successful submission
+	t.request.Request().PostForm = values
+	t.request.Request().Form = nil
+
+	t.decoder.On("Decode", t.context, t.request, values, map[string]string{}).Return(map[string]string{}, nil).Once()
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{}).Return(&domain.ValidationInfo{}, nil).Once()
+
+	form, err = t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsValid())
+	t.NotEqual(token, form.FormExtensionsData["csrf"].(*extensions.CsrfTokenData).Token)
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_ValidationModeOverride() {
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldErrorWithParams("email", "formError.email.email", "Email email", map[string]string{
+		"tag": "email",
+	})
+
+	testCases := []struct {
+		Values url.Values
+		Header http.Header
+		Valid  bool
+	}{
+		{
+			Values: url.Values{
+				"email":  []string{"wrong"},
+				"_draft": []string{"1"},
+			},
+			Valid: true,
+		},
+		{
+			Values: url.Values{
+				"email": []string{"wrong"},
+			},
+			Header: http.Header{
+				"_draft": []string{"true"},
+			},
+			Valid: true,
+		},
+		{
+			Values: url.Values{
+				"email":  []string{"wrong"},
+				"_draft": []string{"0"},
+			},
+			Valid: false,
+		},
+		{
+			Values: url.Values{
+				"email": []string{"wrong"},
+			},
+			Valid: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.SetupTest()
+		t.handler.formExtensions = nil
+		t.handler.validationModeOverride = &validationModeOverride{
+			name: "_draft",
+			mode: domain.ValidationModePartial("required"),
+		}
+
+		t.request.Request().Method = http.MethodPost
+		t.request.Request().PostForm = testCase.Values
+		t.request.Request().Header = testCase.Header
+
+		t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+		t.decoder.On("Decode", t.context, t.request, testCase.Values, map[string]string{}).Return(map[string]string{
+			"email": "wrong",
+		}, nil).Once()
+		t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{
+			"email": "wrong",
+		}).Return(&validationInfo, nil).Once()
+
+		form, err := t.handler.HandleSubmittedForm(t.context, t.request)
+		t.NoError(err)
+		t.True(form.IsSubmitted())
+		t.Equal(testCase.Valid, form.IsValid())
+		t.Equal(map[string]string{
+			"email": "wrong",
+		}, form.Data)
+
+		t.TearDownTest()
+	}
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_ValidationModeNone() {
+	t.handler.formExtensions = nil
+	t.handler.validationMode = domain.ValidationModeNone
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"email": []string{"wrong"},
+	}
+
+	t.decoder.On("Decode", t.context, t.request, url.Values{
+		"email": []string{"wrong"},
+	}, map[string]string{}).Return(map[string]string{
+		"email": "wrong",
+	}, nil).Once()
+
+	form, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsSubmitted())
+	t.True(form.IsValid())
+	t.Equal(map[string]string{
+		"email": "wrong",
+	}, form.Data)
+	t.Nil(form.ValidatedSteps())
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_ActiveSteps() {
+	t.handler.formExtensions = nil
+	t.handler.activeSteps = []string{"shipping"}
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"street": []string{"Main Street"},
+	}
+
+	t.decoder.On("Decode", t.context, t.request, url.Values{
+		"street": []string{"Main Street"},
+	}, map[string]string{}).Return(map[string]string{
+		"street": "Main Street",
+	}, nil).Once()
+	t.validator.On("Validate", mock.MatchedBy(func(ctx context.Context) bool {
+		steps, ok := domain.ActiveStepsFromContext(ctx)
+		return ok && len(steps) == 1 && steps[0] == "shipping"
+	}), t.request, t.validatorProvider, map[string]string{
+		"street": "Main Street",
+	}).Return(&domain.ValidationInfo{}, nil).Once()
+
+	form, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsValid())
+	t.Equal([]string{"shipping"}, form.ValidatedSteps())
+	t.True(form.IsStepValidated("shipping"))
+	t.False(form.IsStepValidated("payment"))
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_ActiveStepsFromContext() {
+	t.handler.formExtensions = nil
+	t.handler.activeSteps = []string{"shipping"}
+	ctx := domain.ContextWithActiveSteps(t.context, "shipping", "payment")
+	t.provider.On("GetFormData", ctx, t.request).Return(map[string]string{}, nil).Once()
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"iban": []string{"wrong"},
+	}
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldError("iban", "formError.iban.iban", "Iban iban")
+
+	t.decoder.On("Decode", ctx, t.request, url.Values{
+		"iban": []string{"wrong"},
+	}, map[string]string{}).Return(map[string]string{
+		"iban": "wrong",
+	}, nil).Once()
+	t.validator.On("Validate", ctx, t.request, t.validatorProvider, map[string]string{
+		"iban": "wrong",
+	}).Return(&validationInfo, nil).Once()
+
+	form, err := t.handler.HandleSubmittedForm(ctx, t.request)
+	t.NoError(err)
+	t.False(form.IsValid())
+	t.Equal([]string{"shipping", "payment"}, form.ValidatedSteps())
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_RecoverableGetFormDataError() {
+	t.handler.formExtensions = nil
+	t.provider.On("GetFormData", t.context, t.request).Return(nil, domain.NewRecoverableError("formError.address.notFound", errors.New("address not found"))).Once()
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"email": []string{"mail@example.com"},
+	}
+
+	form, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsSubmitted())
+	t.False(form.IsValid())
+	t.Nil(form.Data)
+	t.Equal("mail@example.com", form.GetOriginalValue("email"))
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.address.notFound",
+			DefaultLabel: "address not found",
+		},
+	}, form.GetGeneralErrors())
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_RecoverableGetFormDataErrorWithData() {
+	t.handler.formExtensions = nil
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{
+		"email": "",
+	}, fmt.Errorf("provider: %w", domain.NewRecoverableError("formError.address.notFound", errors.New("address not found")))).Once()
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"email": []string{"wrong"},
+	}
+
+	t.decoder.On("Decode", t.context, t.request, url.Values{
+		"email": []string{"wrong"},
+	}, map[string]string{
+		"email": "",
+	}).Return(map[string]string{
+		"email": "wrong",
+	}, nil).Once()
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldError("email", "formError.email.email", "invalid email")
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{
+		"email": "wrong",
+	}).Return(&validationInfo, nil).Once()
+
+	form, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsSubmitted())
+	t.Equal(map[string]string{
+		"email": "wrong",
+	}, form.Data)
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.address.notFound",
+			DefaultLabel: "provider: address not found",
+		},
+	}, form.GetGeneralErrors())
+	t.True(form.HasErrorForField("email"))
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_RecoverableDecodeError() {
+	t.handler.formExtensions = nil
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"email": []string{"mail@example.com"},
+	}
+
+	t.decoder.On("Decode", t.context, t.request, url.Values{
+		"email": []string{"mail@example.com"},
+	}, map[string]string{}).Return(nil, domain.NewRecoverableError("formError.unavailable", errors.New("service unavailable"))).Once()
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldError("email", "formError.email.required", "email required")
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{}).Return(&validationInfo, nil).Once()
+
+	form, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsSubmitted())
+	t.Equal(map[string]string{}, form.Data)
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.unavailable",
+			DefaultLabel: "service unavailable",
+		},
+	}, form.GetGeneralErrors())
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.email.required",
+			DefaultLabel: "email required",
+		},
+	}, form.GetErrorsForField("email"))
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_RecoverableValidateError() {
+	t.handler.formExtensions = nil
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"email": []string{"mail@example.com"},
+	}
+
+	t.decoder.On("Decode", t.context, t.request, url.Values{
+		"email": []string{"mail@example.com"},
+	}, map[string]string{}).Return(map[string]string{
+		"email": "mail@example.com",
+	}, nil).Once()
+
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{
+		"email": "mail@example.com",
+	}).Return(nil, domain.NewRecoverableError("formError.email.notVerified", errors.New("email can't be verified"))).Once()
+
+	form, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsSubmitted())
+	t.Equal(map[string]string{
+		"email": "mail@example.com",
+	}, form.Data)
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.email.notVerified",
+			DefaultLabel: "email can't be verified",
+		},
+	}, form.GetGeneralErrors())
+	t.False(form.HasAnyFieldErrors())
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleUnsubmittedForm_RecoverableError() {
+	t.handler.formExtensions = nil
+	t.provider.On("GetFormData", t.context, t.request).Return(nil, domain.NewRecoverableError("formError.address.notFound", errors.New("address not found"))).Once()
+
+	form, err := t.handler.HandleUnsubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.False(form.IsSubmitted())
+	t.True(form.HasGeneralErrors())
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_ErrorCause() {
+	cause := errors.New("database is down")
+	t.provider.On("GetFormData", t.context, t.request).Return(nil, fmt.Errorf("provider: %w", cause)).Once()
+
+	result, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.Nil(result)
+	t.EqualError(err, "FormError: provider: database is down")
+	t.True(errors.Is(err, cause))
+	t.True(errors.Is(err, domain.FormError("provider: database is down")))
+}
+
+func (t *FormHandlerImplTestSuite) TestValidateData() {
+	t.handler.formExtensions = nil
+	t.provider.On("GetFormData", t.context, t.request).Return(map[string]string{}, nil).Once()
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"email":   []string{"mail@example.com"},
+		"voucher": []string{"SUMMER"},
+	}
+
+	t.decoder.On("Decode", t.context, t.request, t.request.Request().PostForm, map[string]string{}).Return(map[string]string{
+		"email":   "mail@example.com",
+		"voucher": "SUMMER",
+	}, nil).Once()
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, map[string]string{
+		"email":   "mail@example.com",
+		"voucher": "SUMMER",
+	}).Return(&domain.ValidationInfo{}, nil).Once()
+
+	form, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsValidAndSubmitted())
+
+	// voucher is applied by controller, and it turns out to be expired
+	changed := map[string]string{
+		"email":   "mail@example.com",
+		"voucher": "EXPIRED",
+	}
+	voucherInfo := domain.ValidationInfo{}
+	voucherInfo.AddFieldError("voucher", "formError.voucher.expired", "Voucher expired")
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, changed).Return(&voucherInfo, nil).Once()
+
+	validationInfo, err := t.handler.ValidateData(t.context, t.request, changed)
+	t.NoError(err)
+	t.Equal(&voucherInfo, validationInfo)
+	t.True(form.IsValidAndSubmitted())
+
+	form.UpdateData(changed, validationInfo)
+	t.False(form.IsValidAndSubmitted())
+	t.Equal(changed, form.Data)
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.voucher.expired",
+			DefaultLabel: "Voucher expired",
+		},
+	}, form.GetErrorsForField("voucher"))
+}
+
+func (t *FormHandlerImplTestSuite) TestValidateData_HandlerConfiguration() {
+	t.handler.namespace = "checkout"
+	t.handler.activeSteps = []string{"payment"}
+	t.handler.validationMode = domain.ValidationModePartial("required")
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldErrorWithParams("iban", "formError.iban.required", "Iban required", map[string]string{
+		"tag": "required",
+	})
+	validationInfo.AddFieldErrorWithParams("bic", "formError.bic.bic", "Bic bic", map[string]string{
+		"tag": "bic",
+	})
+	t.validator.On("Validate", mock.MatchedBy(func(ctx context.Context) bool {
+		steps, ok := domain.ActiveStepsFromContext(ctx)
+		return ok && len(steps) == 1 && steps[0] == "payment"
+	}), (*web.Request)(nil), t.validatorProvider, map[string]string{}).Return(&validationInfo, nil).Once()
+
+	// request is not required, since nothing is read from it
+	result, err := t.handler.ValidateData(t.context, nil, map[string]string{})
+	t.NoError(err)
+	t.True(result.HasErrorsForField("checkout.iban"))
+	t.False(result.HasErrorsForField("checkout.bic"))
+	t.False(result.HasErrorsForField("iban"))
+}
+
+func (t *FormHandlerImplTestSuite) TestValidateData_Errors() {
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, "recoverable").Return(nil, domain.NewRecoverableError("formError.unavailable", errors.New("service unavailable"))).Once()
+
+	result, err := t.handler.ValidateData(t.context, t.request, "recoverable")
+	t.NoError(err)
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.unavailable",
+			DefaultLabel: "service unavailable",
+		},
+	}, result.GetGeneralErrors())
+
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, "failing").Return(nil, errors.New("error")).Once()
+
+	result, err = t.handler.ValidateData(t.context, t.request, "failing")
+	t.Equal(domain.NewWrappedFormError(errors.New("error")), err)
+	t.Nil(result)
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleSubmittedForm_Attachments() {
+	t.handler.formExtensions = nil
+
+	type addressSuggestion struct {
+		Street string
+		City   string
+	}
+
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"street": []string{"Main Str"},
+	}
+
+	t.provider.On("GetFormData", mock.Anything, t.request).Return(map[string]string{}, nil).Once()
+	t.decoder.On("Decode", mock.Anything, t.request, url.Values{
+		"street": []string{"Main Str"},
+	}, map[string]string{}).Return(map[string]string{
+		"street": "Main Str",
+	}, nil).Once()
+	// validator suggests corrected address, which controller can present to the user
+	t.validator.On("Validate", mock.Anything, t.request, t.validatorProvider, map[string]string{
+		"street": "Main Str",
+	}).Run(func(args mock.Arguments) {
+		t.True(domain.SetAttachment(args.Get(0).(context.Context), "addressSuggestion", addressSuggestion{
+			Street: "Main Street",
+			City:   "Berlin",
+		}))
+	}).Return(&domain.ValidationInfo{}, nil).Once()
+
+	form, err := t.handler.HandleSubmittedForm(context.Background(), t.request)
+	t.NoError(err)
+	t.True(form.IsValidAndSubmitted())
+
+	suggestion, ok := form.Attachment("addressSuggestion")
+	t.True(ok)
+	t.Equal(addressSuggestion{
+		Street: "Main Street",
+		City:   "Berlin",
+	}, suggestion)
+
+	_, ok = form.Attachment("unknown")
+	t.False(ok)
+
+	// attachments are not part of JSON representation
+	encoded, err := form.MarshalJSON()
+	t.NoError(err)
+	t.NotContains(string(encoded), "Main Street")
+}
+
+func (t *FormHandlerImplTestSuite) TestHandleUnsubmittedForm_AttachmentsFromContext() {
+	t.handler.formExtensions = nil
+	t.provider.On("GetFormData", mock.Anything, t.request).Run(func(args mock.Arguments) {
+		t.True(domain.SetAttachment(args.Get(0).(context.Context), "shippingCost", 4.99))
+	}).Return(map[string]string{}, nil).Once()
+
+	attachments := domain.NewAttachments()
+	attachments.Set("cart", "cart-id")
+
+	form, err := t.handler.HandleUnsubmittedForm(domain.ContextWithAttachments(context.Background(), attachments), t.request)
+	t.NoError(err)
+	t.True(form.Attachments() == attachments)
+	t.Equal([]string{"cart", "shippingCost"}, form.Attachments().Keys())
+
+	// form without any attached value has no attachments
+	t.provider.On("GetFormData", mock.Anything, t.request).Return(map[string]string{}, nil).Once()
+
+	form, err = t.handler.HandleUnsubmittedForm(context.Background(), t.request)
+	t.NoError(err)
+	t.Nil(form.Attachments())
+}
@@ -0,0 +1,153 @@
+package application
+
+import (
+	"context"
+	"path"
+	"reflect"
+	"regexp"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opencensus.io/trace"
+
+	"flamingo.me/flamingo/v3/framework/opencensus"
+	"flamingo.me/form/domain"
+)
+
+const (
+	// FormOutcomeValid as outcome of handling submitted form which is valid
+	FormOutcomeValid = "valid"
+	// FormOutcomeInvalid as outcome of handling submitted form which is invalid
+	FormOutcomeInvalid = "invalid"
+	// FormOutcomeUnsubmitted as outcome of handling form which is not submitted
+	FormOutcomeUnsubmitted = "unsubmitted"
+	// FormOutcomeError as outcome of handling form which failed with an error
+	FormOutcomeError = "error"
+
+	// FormPhaseTotal as phase which measures whole form handling
+	FormPhaseTotal = "total"
+	// FormPhaseDecode as phase which measures decoding of submitted values
+	FormPhaseDecode = "decode"
+	// FormPhaseValidate as phase which measures validation of form data
+	FormPhaseValidate = "validate"
+
+	// unknownFormType as form type used when form data is not provided
+	unknownFormType = "unknown"
+	// maxFormTypeLength as maximum length of form type, since opencensus tag values are limited
+	maxFormTypeLength = 128
+)
+
+var (
+	// formHandledCount counts handled forms, tagged with form type and outcome
+	formHandledCount = stats.Int64("flamingo/form/handled", "Count of handled forms", stats.UnitDimensionless)
+	// formHandlingDuration measures duration of form handling, tagged with form type and phase
+	formHandlingDuration = stats.Float64("flamingo/form/duration", "Duration of form handling", stats.UnitMilliseconds)
+
+	// KeyFormType as tag key which contains type of form data, like "checkout.AddressFormData"
+	KeyFormType, _ = tag.NewKey("form_type")
+	// KeyFormOutcome as tag key which contains outcome of form handling
+	KeyFormOutcome, _ = tag.NewKey("form_outcome")
+	// KeyFormPhase as tag key which contains measured phase of form handling
+	KeyFormPhase, _ = tag.NewKey("form_phase")
+
+	formTypeSanitizer = regexp.MustCompile(`[^a-zA-Z0-9_.]+`)
+)
+
+func init() {
+	if err := opencensus.View("flamingo/form/handled", formHandledCount, view.Count(), KeyFormType, KeyFormOutcome); err != nil {
+		panic(err)
+	}
+
+	if err := opencensus.View("flamingo/form/duration", formHandlingDuration, view.Distribution(1, 2, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000), KeyFormType, KeyFormPhase); err != nil {
+		panic(err)
+	}
+}
+
+// getFormType returns type of form data which is used as tag value for metrics and as trace span name.
+// Only type name with its package name is used (like "checkout.AddressFormData"), so number of different values is bounded.
+// Types without name (like maps) are presented by their kind.
+func getFormType(formData interface{}) string {
+	typeOf := reflect.TypeOf(formData)
+	for typeOf != nil && typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	if typeOf == nil {
+		return unknownFormType
+	}
+
+	if typeOf.Name() == "" {
+		return typeOf.Kind().String()
+	}
+
+	name := typeOf.Name()
+	if typeOf.PkgPath() != "" {
+		name = path.Base(typeOf.PkgPath()) + "." + name
+	}
+
+	name = formTypeSanitizer.ReplaceAllString(name, "_")
+	if len(name) > maxFormTypeLength {
+		name = name[:maxFormTypeLength]
+	}
+
+	return name
+}
+
+// getFormOutcome returns outcome of form handling
+func getFormOutcome(submitted bool, form *domain.Form, err error) string {
+	switch {
+	case err != nil || form == nil:
+		return FormOutcomeError
+	case !submitted:
+		return FormOutcomeUnsubmitted
+	case form.IsValid():
+		return FormOutcomeValid
+	}
+
+	return FormOutcomeInvalid
+}
+
+// startFormSpan adds form type to context tags and starts trace span named after form type
+func startFormSpan(ctx context.Context, formType string) (context.Context, *trace.Span) {
+	if tagged, err := tag.New(ctx, tag.Upsert(KeyFormType, formType)); err == nil {
+		ctx = tagged
+	}
+
+	return trace.StartSpan(ctx, "form/"+formType)
+}
+
+// startPhaseSpan starts trace span for phase of form handling, and returns function which ends it and records phase duration
+func startPhaseSpan(ctx context.Context, phase string) (context.Context, func()) {
+	formType, ok := tag.FromContext(ctx).Value(KeyFormType)
+	if !ok {
+		formType = unknownFormType
+	}
+
+	start := time.Now()
+	ctx, span := trace.StartSpan(ctx, "form/"+formType+"/"+phase)
+
+	return ctx, func() {
+		span.End()
+		recordDuration(ctx, phase, start)
+	}
+}
+
+// recordDuration records duration of form handling phase since start
+func recordDuration(ctx context.Context, phase string, start time.Time) {
+	if tagged, err := tag.New(ctx, tag.Upsert(KeyFormPhase, phase)); err == nil {
+		ctx = tagged
+	}
+
+	stats.Record(ctx, formHandlingDuration.M(float64(time.Since(start).Nanoseconds())/float64(time.Millisecond)))
+}
+
+// recordOutcome records handled form with its outcome
+func recordOutcome(ctx context.Context, outcome string) {
+	if tagged, err := tag.New(ctx, tag.Upsert(KeyFormOutcome, outcome)); err == nil {
+		ctx = tagged
+	}
+
+	stats.Record(ctx, formHandledCount.M(1))
+}
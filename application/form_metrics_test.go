@@ -0,0 +1,221 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/mocks"
+)
+
+type (
+	FormMetricsTestSuite struct {
+		suite.Suite
+
+		handler *formHandlerImpl
+
+		provider          *mocks.FormDataProvider
+		decoder           *mocks.FormDataDecoder
+		validator         *mocks.FormDataValidator
+		validatorProvider *mocks.ValidatorProvider
+
+		context context.Context
+		request *web.Request
+	}
+
+	formMetricsTestData struct {
+		Name string
+	}
+
+	formMetricsInvalidTestData struct {
+		Name string
+	}
+
+	formMetricsUnsubmittedTestData struct {
+		Name string
+	}
+
+	formMetricsErrorTestData struct {
+		Name string
+	}
+)
+
+func TestFormMetricsTestSuite(t *testing.T) {
+	suite.Run(t, &FormMetricsTestSuite{})
+}
+
+func (t *FormMetricsTestSuite) SetupTest() {
+	t.provider = &mocks.FormDataProvider{}
+	t.decoder = &mocks.FormDataDecoder{}
+	t.validator = &mocks.FormDataValidator{}
+	t.validatorProvider = &mocks.ValidatorProvider{}
+
+	t.handler = &formHandlerImpl{
+		formDataProvider:  t.provider,
+		formDataDecoder:   t.decoder,
+		formDataValidator: t.validator,
+		validatorProvider: t.validatorProvider,
+		metricsEnabled:    true,
+		logger:            &flamingo.NullLogger{},
+	}
+
+	t.context = context.Background()
+	httpRequest, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(""))
+	httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	t.request = web.CreateRequest(httpRequest, web.EmptySession())
+}
+
+func (t *FormMetricsTestSuite) TearDownTest() {
+	t.provider.AssertExpectations(t.T())
+	t.decoder.AssertExpectations(t.T())
+	t.validator.AssertExpectations(t.T())
+}
+
+func (t *FormMetricsTestSuite) TestGetFormType() {
+	t.Equal("application.formMetricsTestData", getFormType(formMetricsTestData{}))
+	t.Equal("application.formMetricsTestData", getFormType(&formMetricsTestData{}))
+	t.Equal("map", getFormType(map[string]string{}))
+	t.Equal("struct", getFormType(struct{}{}))
+	t.Equal("unknown", getFormType(nil))
+}
+
+func (t *FormMetricsTestSuite) TestGetFormOutcome() {
+	valid := domain.NewForm(true, nil)
+	t.Equal(FormOutcomeValid, getFormOutcome(true, &valid, nil))
+
+	invalid := domain.NewForm(true, nil)
+	invalid.ValidationInfo.AddGeneralError("formError.invalid", "invalid")
+	t.Equal(FormOutcomeInvalid, getFormOutcome(true, &invalid, nil))
+	t.Equal(FormOutcomeUnsubmitted, getFormOutcome(false, &invalid, nil))
+
+	t.Equal(FormOutcomeError, getFormOutcome(true, nil, errors.New("error")))
+	t.Equal(FormOutcomeError, getFormOutcome(true, nil, nil))
+}
+
+func (t *FormMetricsTestSuite) TestStartPhase_Disabled() {
+	t.handler.metricsEnabled = false
+
+	ctx, end := t.handler.startPhase(t.context, FormPhaseDecode)
+	t.Equal(t.context, ctx)
+	end()
+}
+
+func (t *FormMetricsTestSuite) TestHandleSubmittedForm_Metrics() {
+	for formData, validationInfo := range map[interface{}]*domain.ValidationInfo{
+		formMetricsTestData{}:        {},
+		formMetricsInvalidTestData{}: t.invalidValidationInfo(),
+	} {
+		t.provider.On("GetFormData", mock.Anything, t.request).Return(formData, nil).Once()
+		t.decoder.On("Decode", mock.Anything, t.request, url.Values{}, formData).Return(formData, nil).Once()
+		t.validator.On("Validate", mock.Anything, t.request, t.validatorProvider, formData).Return(validationInfo, nil).Once()
+
+		_, err := t.handler.HandleSubmittedForm(t.context, t.request)
+		t.NoError(err)
+	}
+
+	t.Equal(int64(1), t.count("application.formMetricsTestData", FormOutcomeValid))
+	t.Equal(int64(1), t.count("application.formMetricsInvalidTestData", FormOutcomeInvalid))
+
+	for _, phase := range []string{FormPhaseTotal, FormPhaseDecode, FormPhaseValidate} {
+		t.Equal(int64(1), t.durations("application.formMetricsTestData", phase), phase)
+	}
+}
+
+func (t *FormMetricsTestSuite) TestHandleUnsubmittedForm_Metrics() {
+	t.provider.On("GetFormData", mock.Anything, t.request).Return(formMetricsUnsubmittedTestData{}, nil).Once()
+
+	_, err := t.handler.HandleUnsubmittedForm(t.context, t.request)
+	t.NoError(err)
+
+	t.Equal(int64(1), t.count("application.formMetricsUnsubmittedTestData", FormOutcomeUnsubmitted))
+	t.Equal(int64(1), t.durations("application.formMetricsUnsubmittedTestData", FormPhaseTotal))
+	t.Equal(int64(0), t.durations("application.formMetricsUnsubmittedTestData", FormPhaseDecode))
+}
+
+func (t *FormMetricsTestSuite) TestHandleSubmittedForm_MetricsError() {
+	t.provider.On("GetFormData", mock.Anything, t.request).Return(nil, errors.New("error")).Once()
+
+	before := t.count(unknownFormType, FormOutcomeError)
+
+	_, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.Error(err)
+
+	t.Equal(before+1, t.count(unknownFormType, FormOutcomeError))
+}
+
+func (t *FormMetricsTestSuite) TestHandleSubmittedForm_MetricsDecodeError() {
+	formData := formMetricsErrorTestData{}
+	t.provider.On("GetFormData", mock.Anything, t.request).Return(formData, nil).Once()
+	t.decoder.On("Decode", mock.Anything, t.request, url.Values{}, formData).Return(nil, errors.New("error")).Once()
+
+	_, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.Error(err)
+
+	t.Equal(int64(1), t.count("application.formMetricsErrorTestData", FormOutcomeError))
+	t.Equal(int64(0), t.count("application.formMetricsErrorTestData", FormOutcomeInvalid))
+	t.Equal(int64(1), t.durations("application.formMetricsErrorTestData", FormPhaseDecode))
+}
+
+// invalidValidationInfo returns validation info with single general error
+func (t *FormMetricsTestSuite) invalidValidationInfo() *domain.ValidationInfo {
+	validationInfo := &domain.ValidationInfo{}
+	validationInfo.AddGeneralError("formError.invalid", "invalid")
+
+	return validationInfo
+}
+
+// count returns number of handled forms with form type and outcome, recorded by opencensus
+func (t *FormMetricsTestSuite) count(formType string, outcome string) int64 {
+	var count int64
+	for _, row := range t.rows("flamingo/form/handled", formType, KeyFormOutcome, outcome) {
+		if data, ok := row.Data.(*view.CountData); ok {
+			count += data.Value
+		}
+	}
+
+	return count
+}
+
+// durations returns number of recorded durations for form type and phase, recorded by opencensus
+func (t *FormMetricsTestSuite) durations(formType string, phase string) int64 {
+	var count int64
+	for _, row := range t.rows("flamingo/form/duration", formType, KeyFormPhase, phase) {
+		if data, ok := row.Data.(*view.DistributionData); ok {
+			count += data.Count
+		}
+	}
+
+	return count
+}
+
+// rows returns rows of opencensus view which are tagged with form type and additional tag
+func (t *FormMetricsTestSuite) rows(name string, formType string, key tag.Key, value string) []*view.Row {
+	rows, err := view.RetrieveData(name)
+	t.NoError(err)
+
+	var result []*view.Row
+	for _, row := range rows {
+		tags := map[tag.Key]string{}
+		for _, rowTag := range row.Tags {
+			tags[rowTag.Key] = rowTag.Value
+		}
+
+		if tags[KeyFormType] == formType && tags[key] == value {
+			result = append(result, row)
+		}
+	}
+
+	return result
+}
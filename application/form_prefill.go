@@ -0,0 +1,348 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+const (
+	// prefillTagKeep as value of "prefill" tag, which keeps prefilled value if submitted value is empty
+	prefillTagKeep = "keep"
+	// absentTagEmpty as value of "absent" tag, which replaces prefilled value with decoded one even if field is not submitted
+	absentTagEmpty = "empty"
+)
+
+// prefill as method for merging values from all prefill providers into form data, in order of registration
+func (h *formHandlerImpl) prefill(ctx context.Context, req *web.Request, formData interface{}) (interface{}, error) {
+	for _, prefillProvider := range h.prefillProviders {
+		prefillData, err := prefillProvider.Prefill(ctx, req, formData)
+		if err != nil {
+			return nil, err
+		}
+
+		formData, err = h.mergePrefillData(formData, prefillData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return formData, nil
+}
+
+// mergePrefillData as method for merging prefilled values into form data. Prefilled values can be provided as
+// partial form data of same type, where all non zero values are merged, or as map with values keyed by form field names.
+func (h *formHandlerImpl) mergePrefillData(formData interface{}, prefillData interface{}) (interface{}, error) {
+	if formData == nil || prefillData == nil {
+		return formData, nil
+	}
+
+	formDataValue := reflect.ValueOf(formData)
+	isPtr := formDataValue.Kind() == reflect.Ptr
+	formDataValue = reflect.Indirect(formDataValue)
+
+	result := reflect.New(formDataValue.Type()).Elem()
+	result.Set(formDataValue)
+
+	switch {
+	case result.Kind() == reflect.Map:
+		if err := h.mergePrefillMap(result, reflect.ValueOf(prefillData)); err != nil {
+			return nil, err
+		}
+	case result.Kind() == reflect.Struct:
+		if prefillMap, ok := prefillData.(map[string]interface{}); ok {
+			if err := h.mergePrefillValues(result, prefillMap, ""); err != nil {
+				return nil, err
+			}
+			break
+		}
+
+		prefillValue := reflect.Indirect(reflect.ValueOf(prefillData))
+		if prefillValue.Type() != result.Type() {
+			return nil, domain.NewFormErrorf("prefill data of type %s can't be merged into form data of type %s", prefillValue.Type(), result.Type())
+		}
+		h.mergePrefillStruct(result, prefillValue)
+	default:
+		return nil, domain.NewFormErrorf("prefill data can't be merged into form data of type %s", result.Type())
+	}
+
+	if isPtr {
+		return result.Addr().Interface(), nil
+	}
+
+	return result.Interface(), nil
+}
+
+// mergePrefillMap as method for merging prefilled map entries into form data defined as map
+func (h *formHandlerImpl) mergePrefillMap(result reflect.Value, prefillValue reflect.Value) error {
+	prefillValue = reflect.Indirect(prefillValue)
+	if prefillValue.Kind() != reflect.Map {
+		return domain.NewFormErrorf("prefill data of type %s can't be merged into form data of type %s", prefillValue.Type(), result.Type())
+	}
+
+	// map is copied, so prefilling doesn't modify instance created by form data provider
+	copied := reflect.MakeMap(result.Type())
+	for _, key := range result.MapKeys() {
+		copied.SetMapIndex(key, result.MapIndex(key))
+	}
+
+	for _, key := range prefillValue.MapKeys() {
+		value, err := h.convertPrefillValue(prefillValue.MapIndex(key), result.Type().Elem(), fmt.Sprint(key.Interface()))
+		if err != nil {
+			return err
+		}
+		copied.SetMapIndex(key.Convert(result.Type().Key()), value)
+	}
+
+	result.Set(copied)
+
+	return nil
+}
+
+// mergePrefillValues as method for merging prefilled values, keyed by form field names, into struct fields
+func (h *formHandlerImpl) mergePrefillValues(result reflect.Value, prefillValues map[string]interface{}, prefix string) error {
+	return h.walkFormFields(result, prefix, func(field reflect.StructField, fieldValue reflect.Value, name string, path string) error {
+		value, ok := prefillValues[name]
+		if !ok {
+			return nil
+		}
+
+		if nested, ok := value.(map[string]interface{}); ok && h.isNestedStruct(fieldValue.Type()) {
+			return h.mergePrefillValues(fieldValue, nested, path+".")
+		}
+
+		converted, err := h.convertPrefillValue(reflect.ValueOf(value), fieldValue.Type(), path)
+		if err != nil {
+			return err
+		}
+		fieldValue.Set(converted)
+
+		return nil
+	})
+}
+
+// mergePrefillStruct as method for merging all non zero values from partial form data into struct fields.
+// Nested structs are merged recursively, while all other values (including slices) are replaced.
+func (h *formHandlerImpl) mergePrefillStruct(result reflect.Value, prefillValue reflect.Value) {
+	for i := 0; i < result.NumField(); i++ {
+		field := result.Type().Field(i)
+		fieldValue := result.Field(i)
+		prefillFieldValue := prefillValue.Field(i)
+
+		// fields of anonymous embedded structs are settable, even if embedded struct is not exported
+		if h.isNestedStruct(field.Type) && (field.PkgPath == "" || field.Anonymous) {
+			h.mergePrefillStruct(fieldValue, prefillFieldValue)
+			continue
+		}
+
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		if !h.isZero(prefillFieldValue) {
+			fieldValue.Set(prefillFieldValue)
+		}
+	}
+}
+
+// mergeSubmittedData as method for merging decoded form data over prefilled one. Value of field is taken from decoded form data,
+// only if field is submitted, otherwise prefilled value is kept. In case when field is tagged with `prefill:"keep"`,
+// prefilled value is also kept if submitted value is empty. In case when field is tagged with `absent:"empty"` (like checkbox groups,
+// which are not submitted when no option is checked), decoded value is taken even if field is not submitted.
+func (h *formHandlerImpl) mergeSubmittedData(prefilled interface{}, decoded interface{}, values url.Values) interface{} {
+	prefilledValue := reflect.Indirect(reflect.ValueOf(prefilled))
+	decodedValue := reflect.ValueOf(decoded)
+	isPtr := decodedValue.Kind() == reflect.Ptr
+	decodedValue = reflect.Indirect(decodedValue)
+
+	if !prefilledValue.IsValid() || !decodedValue.IsValid() || prefilledValue.Type() != decodedValue.Type() {
+		return decoded
+	}
+
+	result := reflect.New(decodedValue.Type()).Elem()
+
+	switch result.Kind() {
+	case reflect.Map:
+		result.Set(reflect.MakeMap(result.Type()))
+		for _, key := range prefilledValue.MapKeys() {
+			result.SetMapIndex(key, prefilledValue.MapIndex(key))
+		}
+		for _, key := range decodedValue.MapKeys() {
+			result.SetMapIndex(key, decodedValue.MapIndex(key))
+		}
+	case reflect.Struct:
+		result.Set(prefilledValue)
+		h.mergeSubmittedStruct(result, decodedValue, values, "")
+	default:
+		return decoded
+	}
+
+	if isPtr {
+		return result.Addr().Interface()
+	}
+
+	return result.Interface()
+}
+
+// mergeSubmittedStruct as method for merging submitted struct fields from decoded form data
+func (h *formHandlerImpl) mergeSubmittedStruct(result reflect.Value, decodedValue reflect.Value, values url.Values, prefix string) {
+	// error is never returned from callback, so it can be ignored
+	_ = h.walkFormFields(result, prefix, func(field reflect.StructField, fieldValue reflect.Value, name string, path string) error {
+		// decoded pointer to embedded struct is nil, if none of its fields is submitted
+		decodedFieldValue, err := decodedValue.FieldByIndexErr(field.Index)
+		if err != nil {
+			decodedFieldValue = reflect.Zero(field.Type)
+		}
+
+		if h.isNestedStruct(field.Type) {
+			h.mergeSubmittedStruct(fieldValue, decodedFieldValue, values, path+".")
+			return nil
+		}
+
+		if !h.isSubmitted(values, path) && field.Tag.Get("absent") != absentTagEmpty {
+			return nil
+		}
+
+		if field.Tag.Get("prefill") == prefillTagKeep && h.isZero(decodedFieldValue) {
+			return nil
+		}
+
+		fieldValue.Set(decodedFieldValue)
+
+		return nil
+	})
+}
+
+// walkFormFields as method for calling callback for each exported struct field, together with its form field name and full path.
+// Fields of anonymous embedded structs are handled as fields of parent struct, same as during decoding.
+// Index of fields from embedded structs is extended with index of embedded struct, and it can contain pointers.
+func (h *formHandlerImpl) walkFormFields(value reflect.Value, prefix string, callback func(field reflect.StructField, fieldValue reflect.Value, name string, path string) error) error {
+	typeOf := value.Type()
+
+	for i := 0; i < typeOf.NumField(); i++ {
+		field := typeOf.Field(i)
+		fieldValue := value.Field(i)
+
+		name := strings.Split(field.Tag.Get("form"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			err := h.walkFormFields(fieldValue, prefix, func(embedded reflect.StructField, fieldValue reflect.Value, name string, path string) error {
+				// index of embedded field is relative to embedded struct, so it's extended with index of embedded struct
+				embedded.Index = append([]int{i}, embedded.Index...)
+				return callback(embedded, fieldValue, name, path)
+			})
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		// pointer to embedded struct is walked as copy, which is set back only if it's already allocated or
+		// any of its fields is set, so instances shared with form data providers are never modified
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Ptr && h.isNestedStruct(field.Type.Elem()) {
+			if !fieldValue.CanSet() {
+				continue
+			}
+
+			embeddedValue := reflect.New(field.Type.Elem())
+			if !fieldValue.IsNil() {
+				embeddedValue.Elem().Set(fieldValue.Elem())
+			}
+
+			err := h.walkFormFields(embeddedValue.Elem(), prefix, func(embedded reflect.StructField, fieldValue reflect.Value, name string, path string) error {
+				embedded.Index = append([]int{i}, embedded.Index...)
+				return callback(embedded, fieldValue, name, path)
+			})
+			if err != nil {
+				return err
+			}
+
+			if !fieldValue.IsNil() || !h.isZero(embeddedValue.Elem()) {
+				fieldValue.Set(embeddedValue)
+			}
+			continue
+		}
+
+		if !fieldValue.CanSet() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		if err := callback(field, fieldValue, name, prefix+name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// convertPrefillValue as method for converting prefilled value into type of form data field
+func (h *formHandlerImpl) convertPrefillValue(value reflect.Value, typeOf reflect.Type, name string) (reflect.Value, error) {
+	if !value.IsValid() {
+		return reflect.Zero(typeOf), nil
+	}
+
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+
+	switch {
+	case value.Type().AssignableTo(typeOf):
+		return value, nil
+	case typeOf.Kind() == reflect.String:
+		return reflect.ValueOf(fmt.Sprint(value.Interface())).Convert(typeOf), nil
+	case value.Type().ConvertibleTo(typeOf) && value.Kind() != reflect.String:
+		return value.Convert(typeOf), nil
+	case typeOf.Kind() == reflect.Ptr && value.Kind() != reflect.Ptr:
+		// optional fields (like *string) are prefilled with pointer to converted value
+		converted, err := h.convertPrefillValue(value, typeOf.Elem(), name)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		result := reflect.New(typeOf.Elem())
+		result.Elem().Set(converted)
+
+		return result, nil
+	}
+
+	return reflect.Value{}, domain.NewFormErrorf("prefill value of type %s for field %q can't be assigned to field of type %s", value.Type(), name, typeOf)
+}
+
+// isNestedStruct as method for checking if type is struct whose fields are decoded separately
+func (h *formHandlerImpl) isNestedStruct(typeOf reflect.Type) bool {
+	return typeOf.Kind() == reflect.Struct && typeOf != reflect.TypeOf(time.Time{})
+}
+
+// isSubmitted as method for checking if field with path (or any of its indices or nested fields) is submitted
+func (h *formHandlerImpl) isSubmitted(values url.Values, path string) bool {
+	for key := range values {
+		if key == path || strings.HasPrefix(key, path+"[") || strings.HasPrefix(key, path+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isZero as method for checking if value is zero value of its type
+func (h *formHandlerImpl) isZero(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map, reflect.Ptr, reflect.Interface:
+		return value.IsNil() || (value.Kind() != reflect.Ptr && value.Kind() != reflect.Interface && value.Len() == 0)
+	}
+
+	return reflect.DeepEqual(value.Interface(), reflect.Zero(value.Type()).Interface())
+}
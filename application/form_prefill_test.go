@@ -0,0 +1,520 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/mocks"
+)
+
+type (
+	FormPrefillTestSuite struct {
+		suite.Suite
+
+		handler *formHandlerImpl
+
+		provider          *mocks.FormDataProvider
+		decoder           *mocks.FormDataDecoder
+		validator         *mocks.FormDataValidator
+		firstPrefill      *mocks.PrefillProvider
+		secondPrefill     *mocks.PrefillProvider
+		validatorProvider *mocks.ValidatorProvider
+
+		context context.Context
+		request *web.Request
+	}
+
+	formPrefillProfileTestData struct {
+		Name     string                     `form:"name"`
+		Nickname string                     `form:"nickname" prefill:"keep"`
+		Age      int                        `form:"age"`
+		Tags     []string                   `form:"tags"`
+		Address  formPrefillAddressTestData `form:"address"`
+		formPrefillContactTestData
+	}
+
+	formPrefillAddressTestData struct {
+		Street string `form:"street"`
+		City   string `form:"city"`
+	}
+
+	formPrefillContactTestData struct {
+		Phone string `form:"phone"`
+	}
+
+	formPrefillAccountTestData struct {
+		Email string `form:"email"`
+		*FormPrefillBillingTestData
+	}
+
+	// FormPrefillBillingTestData is exported, since decoder can allocate only pointers of exported types for embedded fields
+	FormPrefillBillingTestData struct {
+		Iban  string `form:"iban"`
+		Owner string `form:"owner"`
+	}
+
+	formPrefillOptionalTestData struct {
+		Nickname *string    `form:"nickname"`
+		Age      *int       `form:"age"`
+		Active   *bool      `form:"active"`
+		Birthday *time.Time `form:"birthday"`
+	}
+
+	formPrefillInterestsTestData struct {
+		Interests []string `form:"interests" absent:"empty"`
+		Sizes     []int    `form:"sizes" absent:"empty"`
+		Colors    []string `form:"colors"`
+	}
+)
+
+func TestFormPrefillTestSuite(t *testing.T) {
+	suite.Run(t, &FormPrefillTestSuite{})
+}
+
+func (t *FormPrefillTestSuite) SetupTest() {
+	t.provider = &mocks.FormDataProvider{}
+	t.decoder = &mocks.FormDataDecoder{}
+	t.validator = &mocks.FormDataValidator{}
+	t.firstPrefill = &mocks.PrefillProvider{}
+	t.secondPrefill = &mocks.PrefillProvider{}
+	t.validatorProvider = &mocks.ValidatorProvider{}
+
+	t.handler = &formHandlerImpl{
+		formDataProvider:  t.provider,
+		formDataDecoder:   t.decoder,
+		formDataValidator: t.validator,
+		prefillProviders: []domain.PrefillProvider{
+			t.firstPrefill,
+			t.secondPrefill,
+		},
+		validatorProvider: t.validatorProvider,
+		logger:            &flamingo.NullLogger{},
+	}
+
+	t.context = domain.ContextWithAttachments(context.Background(), domain.NewAttachments())
+	t.request = web.CreateRequest(&http.Request{}, nil)
+}
+
+func (t *FormPrefillTestSuite) TearDownTest() {
+	t.provider.AssertExpectations(t.T())
+	t.decoder.AssertExpectations(t.T())
+	t.validator.AssertExpectations(t.T())
+	t.firstPrefill.AssertExpectations(t.T())
+	t.secondPrefill.AssertExpectations(t.T())
+	t.validatorProvider.AssertExpectations(t.T())
+}
+
+func (t *FormPrefillTestSuite) TestMergePrefillData_Struct() {
+	result, err := t.handler.mergePrefillData(formPrefillProfileTestData{
+		Name: "default",
+		Age:  18,
+		Tags: []string{"first", "second"},
+		Address: formPrefillAddressTestData{
+			City: "Berlin",
+		},
+	}, &formPrefillProfileTestData{
+		Name: "John",
+		Tags: []string{"third"},
+		Address: formPrefillAddressTestData{
+			Street: "Main Street",
+		},
+		formPrefillContactTestData: formPrefillContactTestData{
+			Phone: "0176 1234567",
+		},
+	})
+	t.NoError(err)
+	t.Equal(formPrefillProfileTestData{
+		Name: "John",
+		Age:  18,
+		Tags: []string{"third"},
+		Address: formPrefillAddressTestData{
+			Street: "Main Street",
+			City:   "Berlin",
+		},
+		formPrefillContactTestData: formPrefillContactTestData{
+			Phone: "0176 1234567",
+		},
+	}, result)
+}
+
+func (t *FormPrefillTestSuite) TestMergePrefillData_Map() {
+	formData := &formPrefillProfileTestData{
+		Name: "default",
+	}
+
+	result, err := t.handler.mergePrefillData(formData, map[string]interface{}{
+		"nickname": "johnny",
+		"age":      float64(30),
+		"tags":     []string{"first"},
+		"address": map[string]interface{}{
+			"city": "Munich",
+		},
+		"phone":   "0176 1234567",
+		"unknown": "value",
+	})
+	t.NoError(err)
+	t.Equal(&formPrefillProfileTestData{
+		Name:     "default",
+		Nickname: "johnny",
+		Age:      30,
+		Tags:     []string{"first"},
+		Address: formPrefillAddressTestData{
+			City: "Munich",
+		},
+		formPrefillContactTestData: formPrefillContactTestData{
+			Phone: "0176 1234567",
+		},
+	}, result)
+	t.Equal(&formPrefillProfileTestData{
+		Name: "default",
+	}, formData)
+
+	result, err = t.handler.mergePrefillData(map[string]string{
+		"name": "default",
+	}, map[string]interface{}{
+		"age": 30,
+	})
+	t.NoError(err)
+	t.Equal(map[string]string{
+		"name": "default",
+		"age":  "30",
+	}, result)
+}
+
+func (t *FormPrefillTestSuite) TestMergePrefillData_OptionalFields() {
+	nickname, age := "johnny", 30
+
+	// nil pointers of partial form data don't override prefilled values
+	result, err := t.handler.mergePrefillData(formPrefillOptionalTestData{
+		Nickname: &nickname,
+	}, formPrefillOptionalTestData{
+		Age: &age,
+	})
+	t.NoError(err)
+	t.Equal(formPrefillOptionalTestData{
+		Nickname: &nickname,
+		Age:      &age,
+	}, result)
+
+	birthday := time.Date(1985, 2, 1, 0, 0, 0, 0, time.UTC)
+	result, err = t.handler.mergePrefillData(formPrefillOptionalTestData{}, map[string]interface{}{
+		"nickname": "johnny",
+		"age":      float64(30),
+		"active":   false,
+		"birthday": birthday,
+	})
+	t.NoError(err)
+
+	active := false
+	t.Equal(formPrefillOptionalTestData{
+		Nickname: &nickname,
+		Age:      &age,
+		Active:   &active,
+		Birthday: &birthday,
+	}, result)
+
+	_, err = t.handler.mergePrefillData(formPrefillOptionalTestData{}, map[string]interface{}{
+		"age": []string{"30"},
+	})
+	t.Error(err)
+}
+
+func (t *FormPrefillTestSuite) TestMergeSubmittedData_OptionalFields() {
+	nickname, age, empty := "johnny", 30, ""
+
+	// fields which are not submitted keep prefilled values, while fields submitted empty clear them
+	result := t.handler.mergeSubmittedData(formPrefillOptionalTestData{
+		Nickname: &nickname,
+		Age:      &age,
+	}, formPrefillOptionalTestData{
+		Nickname: &empty,
+	}, url.Values{
+		"nickname": []string{""},
+	})
+	t.Equal(formPrefillOptionalTestData{
+		Nickname: &empty,
+		Age:      &age,
+	}, result)
+}
+
+func (t *FormPrefillTestSuite) TestMergePrefillData_Error() {
+	_, err := t.handler.mergePrefillData(formPrefillProfileTestData{}, formPrefillAddressTestData{})
+	t.Error(err)
+
+	_, err = t.handler.mergePrefillData(formPrefillProfileTestData{}, map[string]interface{}{
+		"age": "thirty",
+	})
+	t.Error(err)
+
+	_, err = t.handler.mergePrefillData(map[string]string{}, "value")
+	t.Error(err)
+}
+
+func (t *FormPrefillTestSuite) TestMergeSubmittedData() {
+	prefilled := formPrefillProfileTestData{
+		Name:     "John",
+		Nickname: "johnny",
+		Age:      30,
+		Tags:     []string{"first", "second"},
+		Address: formPrefillAddressTestData{
+			Street: "Main Street",
+			City:   "Berlin",
+		},
+		formPrefillContactTestData: formPrefillContactTestData{
+			Phone: "0176 1234567",
+		},
+	}
+
+	result := t.handler.mergeSubmittedData(prefilled, formPrefillProfileTestData{
+		Tags: []string{"third"},
+		Address: formPrefillAddressTestData{
+			City: "Munich",
+		},
+	}, url.Values{
+		"name":         []string{""},
+		"nickname":     []string{""},
+		"tags[0]":      []string{"third"},
+		"address.city": []string{"Munich"},
+	})
+	t.Equal(formPrefillProfileTestData{
+		Name:     "",
+		Nickname: "johnny",
+		Age:      30,
+		Tags:     []string{"third"},
+		Address: formPrefillAddressTestData{
+			Street: "Main Street",
+			City:   "Munich",
+		},
+		formPrefillContactTestData: formPrefillContactTestData{
+			Phone: "0176 1234567",
+		},
+	}, result)
+
+	t.Equal(map[string]string{
+		"name": "Jane",
+		"age":  "30",
+	}, t.handler.mergeSubmittedData(map[string]string{
+		"name": "John",
+		"age":  "30",
+	}, map[string]string{
+		"name": "Jane",
+	}, url.Values{
+		"name": []string{"Jane"},
+	}))
+}
+
+func (t *FormPrefillTestSuite) TestMergeSubmittedData_AbsentEmpty() {
+	result := t.handler.mergeSubmittedData(formPrefillInterestsTestData{
+		Interests: []string{"sports", "music"},
+		Sizes:     []int{36},
+		Colors:    []string{"red"},
+	}, formPrefillInterestsTestData{
+		Interests: []string{},
+		Sizes:     []int{38, 40},
+	}, url.Values{
+		"sizes": []string{"38", "40"},
+	})
+	t.Equal(formPrefillInterestsTestData{
+		Interests: []string{},
+		Sizes:     []int{38, 40},
+		Colors:    []string{"red"},
+	}, result)
+}
+
+func (t *FormPrefillTestSuite) TestMergeSubmittedData_EmbeddedPointer() {
+	prefilled := formPrefillAccountTestData{
+		Email: "mail@example.com",
+		FormPrefillBillingTestData: &FormPrefillBillingTestData{
+			Iban:  "DE02120300000000202051",
+			Owner: "John",
+		},
+	}
+
+	// submitted fields of embedded struct are merged, without modifying prefilled instance
+	result := t.handler.mergeSubmittedData(prefilled, formPrefillAccountTestData{
+		FormPrefillBillingTestData: &FormPrefillBillingTestData{
+			Owner: "Jane",
+		},
+	}, url.Values{
+		"owner": []string{"Jane"},
+	})
+	t.Equal(formPrefillAccountTestData{
+		Email: "mail@example.com",
+		FormPrefillBillingTestData: &FormPrefillBillingTestData{
+			Iban:  "DE02120300000000202051",
+			Owner: "Jane",
+		},
+	}, result)
+	t.Equal("John", prefilled.Owner)
+
+	// decoded pointer is nil, if none of embedded fields is submitted
+	result = t.handler.mergeSubmittedData(prefilled, formPrefillAccountTestData{
+		Email: "other@example.com",
+	}, url.Values{
+		"email": []string{"other@example.com"},
+	})
+	t.Equal(formPrefillAccountTestData{
+		Email:                      "other@example.com",
+		FormPrefillBillingTestData: prefilled.FormPrefillBillingTestData,
+	}, result)
+
+	// prefilled nil pointer is allocated only by submitted fields
+	result = t.handler.mergeSubmittedData(formPrefillAccountTestData{}, formPrefillAccountTestData{}, url.Values{
+		"email": []string{""},
+	})
+	t.Equal(formPrefillAccountTestData{}, result)
+
+	result = t.handler.mergeSubmittedData(formPrefillAccountTestData{}, formPrefillAccountTestData{
+		FormPrefillBillingTestData: &FormPrefillBillingTestData{
+			Iban: "DE02120300000000202051",
+		},
+	}, url.Values{
+		"iban": []string{"DE02120300000000202051"},
+	})
+	t.Equal(formPrefillAccountTestData{
+		FormPrefillBillingTestData: &FormPrefillBillingTestData{
+			Iban: "DE02120300000000202051",
+		},
+	}, result)
+}
+
+func (t *FormPrefillTestSuite) TestHandleUnsubmittedForm_Prefilled() {
+	t.provider.On("GetFormData", t.context, t.request).Return(formPrefillProfileTestData{}, nil).Once()
+	t.firstPrefill.On("Prefill", t.context, t.request, formPrefillProfileTestData{}).Return(formPrefillProfileTestData{
+		Name: "John",
+	}, nil).Once()
+	t.secondPrefill.On("Prefill", t.context, t.request, formPrefillProfileTestData{
+		Name: "John",
+	}).Return(map[string]interface{}{
+		"nickname": "johnny",
+	}, nil).Once()
+
+	form, err := t.handler.HandleUnsubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.False(form.IsSubmitted())
+	t.Equal(formPrefillProfileTestData{
+		Name:     "John",
+		Nickname: "johnny",
+	}, form.Data)
+}
+
+func (t *FormPrefillTestSuite) TestHandleSubmittedForm_PrefilledError() {
+	t.provider.On("GetFormData", t.context, t.request).Return(formPrefillProfileTestData{}, nil).Once()
+	t.firstPrefill.On("Prefill", t.context, t.request, formPrefillProfileTestData{}).Return(nil, errors.New("error")).Once()
+
+	form, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.Equal(domain.NewWrappedFormError(errors.New("error")), err)
+	t.Nil(form)
+}
+
+func (t *FormPrefillTestSuite) TestHandleUnsubmittedForm_PrefilledRecoverableError() {
+	t.provider.On("GetFormData", t.context, t.request).Return(formPrefillProfileTestData{
+		Name: "John",
+	}, nil).Once()
+	t.firstPrefill.On("Prefill", t.context, t.request, formPrefillProfileTestData{
+		Name: "John",
+	}).Return(nil, domain.NewRecoverableError("formError.profile.notFound", errors.New("profile not found"))).Once()
+
+	form, err := t.handler.HandleUnsubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.Equal(formPrefillProfileTestData{
+		Name: "John",
+	}, form.Data)
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.profile.notFound",
+			DefaultLabel: "profile not found",
+		},
+	}, form.GetGeneralErrors())
+}
+
+func (t *FormPrefillTestSuite) TestHandleSubmittedForm_Prefilled() {
+	prefilled := formPrefillProfileTestData{
+		Name:     "John",
+		Nickname: "johnny",
+		Address: formPrefillAddressTestData{
+			Street: "Main Street",
+		},
+	}
+
+	values := url.Values{
+		"nickname":     []string{""},
+		"address.city": []string{"Berlin"},
+	}
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = values
+
+	t.provider.On("GetFormData", t.context, t.request).Return(formPrefillProfileTestData{}, nil).Once()
+	t.firstPrefill.On("Prefill", t.context, t.request, formPrefillProfileTestData{}).Return(prefilled, nil).Once()
+	t.secondPrefill.On("Prefill", t.context, t.request, prefilled).Return(nil, nil).Once()
+	t.decoder.On("Decode", t.context, t.request, values, prefilled).Return(formPrefillProfileTestData{
+		Address: formPrefillAddressTestData{
+			City: "Berlin",
+		},
+	}, nil).Once()
+
+	merged := formPrefillProfileTestData{
+		Name:     "John",
+		Nickname: "johnny",
+		Address: formPrefillAddressTestData{
+			Street: "Main Street",
+			City:   "Berlin",
+		},
+	}
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, merged).Return(&domain.ValidationInfo{}, nil).Once()
+
+	form, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsSubmitted())
+	t.True(form.IsValid())
+	t.Equal(merged, form.Data)
+}
+
+func (t *FormPrefillTestSuite) TestHandleSubmittedForm_PrefilledBracketNotation() {
+	prefilled := formPrefillProfileTestData{
+		Name: "John",
+		Address: formPrefillAddressTestData{
+			Street: "Main Street",
+			City:   "Munich",
+		},
+	}
+
+	// fields posted in bracket notation are treated as submitted, same as in dot notation
+	values := url.Values{
+		"address[city]": []string{"Berlin"},
+	}
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = values
+
+	t.provider.On("GetFormData", t.context, t.request).Return(formPrefillProfileTestData{}, nil).Once()
+	t.firstPrefill.On("Prefill", t.context, t.request, formPrefillProfileTestData{}).Return(prefilled, nil).Once()
+	t.secondPrefill.On("Prefill", t.context, t.request, prefilled).Return(nil, nil).Once()
+	t.decoder.On("Decode", t.context, t.request, values, prefilled).Return(formPrefillProfileTestData{
+		Address: formPrefillAddressTestData{
+			City: "Berlin",
+		},
+	}, nil).Once()
+
+	merged := formPrefillProfileTestData{
+		Name: "John",
+		Address: formPrefillAddressTestData{
+			Street: "Main Street",
+			City:   "Berlin",
+		},
+	}
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, merged).Return(&domain.ValidationInfo{}, nil).Once()
+
+	form, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsValid())
+	t.Equal(merged, form.Data)
+}
@@ -0,0 +1,203 @@
+package application
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	// FormResponder as interface for responding with handled form depending on content negotiation, so controllers
+	// don't need to decide by themselves if form should be rendered as template or returned as JSON
+	FormResponder interface {
+		// Respond returns success response if form is submitted and valid. Otherwise, it returns JSON representation
+		// of validation info for requests which accept JSON, or template rendered with form for all other requests.
+		Respond(ctx context.Context, req *web.Request, form *domain.Form, successResponse web.Result, templateName string) web.Result
+		// WithPRG returns form responder which stores invalid submitted form into form session store under form identifier,
+		// and redirects to the same URL instead of rendering template (Post/Redirect/Get pattern)
+		WithPRG(formIdentifier string) FormResponder
+	}
+
+	// FormResponderImpl as actual implementation of FormResponder interface
+	FormResponderImpl struct {
+		responder        *web.Responder
+		formSessionStore FormSessionStore
+		logger           flamingo.Logger
+		templateDataKey  string
+		validStatus      uint
+		invalidStatus    uint
+		formIdentifier   string
+	}
+)
+
+var _ FormResponder = &FormResponderImpl{}
+
+// Inject is method used to set all dependencies as local variables
+func (r *FormResponderImpl) Inject(
+	responder *web.Responder,
+	formSessionStore FormSessionStore,
+	logger flamingo.Logger,
+	cfg *struct {
+		TemplateDataKey string  `inject:"config:form.responder.templateDataKey"`
+		ValidStatus     float64 `inject:"config:form.responder.validStatus"`
+		InvalidStatus   float64 `inject:"config:form.responder.invalidStatus"`
+	},
+) {
+	r.responder = responder
+	r.formSessionStore = formSessionStore
+	r.logger = logger
+
+	if cfg != nil {
+		r.templateDataKey = cfg.TemplateDataKey
+		r.validStatus = uint(cfg.ValidStatus)
+		r.invalidStatus = uint(cfg.InvalidStatus)
+	}
+}
+
+// WithPRG returns copy of form responder, which stores invalid submitted form into form session store under
+// form identifier, and redirects to the same URL instead of rendering template. Form handler with the same
+// form session store and form identifier restores form after redirect.
+func (r *FormResponderImpl) WithPRG(formIdentifier string) FormResponder {
+	responder := *r
+	responder.formIdentifier = formIdentifier
+
+	return &responder
+}
+
+// Respond returns success response if form is submitted and valid. Requests which accept JSON (or which are sent
+// with "X-Requested-With: XMLHttpRequest" header) get JSON representation of validation info, with configured
+// status for valid form (200 by default) and for invalid one (422 by default). All other requests get template
+// rendered with form under configured key in template data, or redirect to the same URL in case of PRG.
+func (r *FormResponderImpl) Respond(ctx context.Context, req *web.Request, form *domain.Form, successResponse web.Result, templateName string) web.Result {
+	if form == nil {
+		return r.responder.Data(nil).Status(http.StatusInternalServerError)
+	}
+
+	if r.acceptsJSON(req) {
+		return r.respondJSON(form, successResponse)
+	}
+
+	if form.IsValidAndSubmitted() {
+		return successResponse
+	}
+
+	if form.IsSubmitted() && r.formIdentifier != "" && req != nil {
+		err := r.formSessionStore.Save(ctx, req.Session(), r.formIdentifier, form)
+		if err == nil {
+			return r.responder.URLRedirect(req.Request().URL)
+		}
+
+		// form is rendered directly in case when it can't be stored, so user still sees validation errors
+		r.getLogger("prg").Warn(err.Error())
+	}
+
+	status := uint(http.StatusOK)
+	if form.IsSubmitted() && !form.IsValid() {
+		status = r.getInvalidStatus()
+	}
+
+	return r.responder.Render(templateName, map[string]interface{}{
+		r.getTemplateDataKey(): form,
+	}).Status(status)
+}
+
+// respondJSON returns JSON representation of validation info. Success response is used for valid submitted form
+// only if it's data response itself, so controllers can return their own data.
+func (r *FormResponderImpl) respondJSON(form *domain.Form, successResponse web.Result) web.Result {
+	if !form.IsValid() {
+		return r.responder.Data(form.ValidationInfo).Status(r.getInvalidStatus())
+	}
+
+	if dataResponse, ok := successResponse.(*web.DataResponse); ok && form.IsSubmitted() {
+		return dataResponse
+	}
+
+	status := uint(http.StatusOK)
+	if form.IsSubmitted() {
+		status = r.getValidStatus()
+	}
+
+	return r.responder.Data(form.ValidationInfo).Status(status)
+}
+
+// acceptsJSON checks if request is sent via XMLHttpRequest, or if JSON has higher quality than HTML in "Accept" header
+func (r *FormResponderImpl) acceptsJSON(req *web.Request) bool {
+	if req == nil || req.Request() == nil {
+		return false
+	}
+
+	header := req.Request().Header
+	if strings.EqualFold(header.Get("X-Requested-With"), "XMLHttpRequest") {
+		return true
+	}
+
+	jsonQuality, htmlQuality := 0.0, 0.0
+	for _, value := range strings.Split(header.Get("Accept"), ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(value))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+				quality = parsed
+			}
+		}
+
+		switch {
+		case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+			if quality > jsonQuality {
+				jsonQuality = quality
+			}
+		case mediaType == "text/html" || mediaType == "application/xhtml+xml":
+			if quality > htmlQuality {
+				htmlQuality = quality
+			}
+		}
+	}
+
+	return jsonQuality > htmlQuality
+}
+
+// getTemplateDataKey returns configured key of form in template data, with "form" as fallback
+func (r *FormResponderImpl) getTemplateDataKey() string {
+	if r.templateDataKey == "" {
+		return "form"
+	}
+
+	return r.templateDataKey
+}
+
+// getValidStatus returns configured status code for valid submitted form, with 200 (OK) as fallback
+func (r *FormResponderImpl) getValidStatus() uint {
+	if r.validStatus == 0 {
+		return http.StatusOK
+	}
+
+	return r.validStatus
+}
+
+// getInvalidStatus returns configured status code for invalid form, with 422 (Unprocessable Entity) as fallback
+func (r *FormResponderImpl) getInvalidStatus() uint {
+	if r.invalidStatus == 0 {
+		return http.StatusUnprocessableEntity
+	}
+
+	return r.invalidStatus
+}
+
+// getLogger returns logger with form responder area
+func (r *FormResponderImpl) getLogger(value string) flamingo.Logger {
+	if r.logger == nil {
+		return flamingo.NullLogger{}
+	}
+
+	return r.logger.WithField("FormResponder", value)
+}
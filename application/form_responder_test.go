@@ -0,0 +1,236 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	FormResponderTestSuite struct {
+		suite.Suite
+
+		formResponder    *FormResponderImpl
+		formSessionStore *FormSessionStoreImpl
+		successResponse  web.Result
+
+		context context.Context
+	}
+)
+
+func TestFormResponderTestSuite(t *testing.T) {
+	suite.Run(t, &FormResponderTestSuite{})
+}
+
+func (t *FormResponderTestSuite) SetupTest() {
+	t.formSessionStore = &FormSessionStoreImpl{}
+	t.formSessionStore.Inject(&struct {
+		TTL          string  `inject:"config:form.sessionStore.ttl"`
+		MaxSize      float64 `inject:"config:form.sessionStore.maxSize"`
+		MaxValueSize float64 `inject:"config:form.sessionStore.maxValueSize"`
+	}{
+		TTL:          "5m",
+		MaxSize:      64 << 10,
+		MaxValueSize: 4 << 10,
+	})
+
+	t.formResponder = &FormResponderImpl{}
+	t.formResponder.Inject(&web.Responder{}, t.formSessionStore, &flamingo.NullLogger{}, &struct {
+		TemplateDataKey string  `inject:"config:form.responder.templateDataKey"`
+		ValidStatus     float64 `inject:"config:form.responder.validStatus"`
+		InvalidStatus   float64 `inject:"config:form.responder.invalidStatus"`
+	}{
+		TemplateDataKey: "form",
+		ValidStatus:     http.StatusOK,
+		InvalidStatus:   http.StatusUnprocessableEntity,
+	})
+
+	t.successResponse = (&web.Responder{}).RouteRedirect("address.success", nil)
+	t.context = context.Background()
+}
+
+func (t *FormResponderTestSuite) TestRespond_Template() {
+	form := t.createForm(true, false)
+
+	result := t.formResponder.Respond(t.context, t.createRequest(map[string]string{
+		"Accept": "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8",
+	}), form, t.successResponse, "address/form")
+	t.IsType(&web.RenderResponse{}, result)
+
+	response := result.(*web.RenderResponse)
+	t.Equal("address/form", response.Template)
+	t.Equal(uint(http.StatusUnprocessableEntity), response.Response.Status)
+	t.Equal(map[string]interface{}{
+		"form": form,
+	}, response.Data)
+
+	// unsubmitted form is rendered with status 200, also for requests without "Accept" header
+	form = t.createForm(false, true)
+	result = t.formResponder.Respond(t.context, t.createRequest(nil), form, t.successResponse, "address/form")
+	t.IsType(&web.RenderResponse{}, result)
+	t.Equal(uint(http.StatusOK), result.(*web.RenderResponse).Response.Status)
+}
+
+func (t *FormResponderTestSuite) TestRespond_TemplateSuccess() {
+	result := t.formResponder.Respond(t.context, t.createRequest(nil), t.createForm(true, true), t.successResponse, "address/form")
+	t.True(result == t.successResponse)
+}
+
+func (t *FormResponderTestSuite) TestRespond_TemplateDataKey() {
+	t.formResponder.templateDataKey = "addressForm"
+	form := t.createForm(false, true)
+
+	result := t.formResponder.Respond(t.context, t.createRequest(nil), form, t.successResponse, "address/form")
+	t.Equal(map[string]interface{}{
+		"addressForm": form,
+	}, result.(*web.RenderResponse).Data)
+}
+
+func (t *FormResponderTestSuite) TestRespond_JSON() {
+	testCases := []struct {
+		Name      string
+		Headers   map[string]string
+		Submitted bool
+		Valid     bool
+		Status    uint
+	}{
+		{
+			Name: "invalid",
+			Headers: map[string]string{
+				"Accept": "application/json",
+			},
+			Submitted: true,
+			Status:    http.StatusUnprocessableEntity,
+		},
+		{
+			Name: "valid",
+			Headers: map[string]string{
+				"Accept": "application/json, text/plain, */*",
+			},
+			Submitted: true,
+			Valid:     true,
+			Status:    http.StatusOK,
+		},
+		{
+			Name: "unsubmitted",
+			Headers: map[string]string{
+				"Accept": "application/problem+json",
+			},
+			Valid:  true,
+			Status: http.StatusOK,
+		},
+		{
+			Name: "XMLHttpRequest",
+			Headers: map[string]string{
+				"X-Requested-With": "XMLHttpRequest",
+			},
+			Submitted: true,
+			Status:    http.StatusUnprocessableEntity,
+		},
+		{
+			Name: "preferred over HTML",
+			Headers: map[string]string{
+				"Accept": "text/html;q=0.5, application/json",
+			},
+			Submitted: true,
+			Status:    http.StatusUnprocessableEntity,
+		},
+	}
+
+	for _, testCase := range testCases {
+		form := t.createForm(testCase.Submitted, testCase.Valid)
+
+		result := t.formResponder.Respond(t.context, t.createRequest(testCase.Headers), form, t.successResponse, "address/form")
+		t.IsType(&web.DataResponse{}, result, testCase.Name)
+
+		response := result.(*web.DataResponse)
+		t.Equal(testCase.Status, response.Response.Status, testCase.Name)
+		t.Equal(form.ValidationInfo, response.Data, testCase.Name)
+	}
+}
+
+func (t *FormResponderTestSuite) TestRespond_JSONStatuses() {
+	t.formResponder.validStatus = http.StatusCreated
+	t.formResponder.invalidStatus = http.StatusBadRequest
+	headers := map[string]string{
+		"Accept": "application/json",
+	}
+
+	result := t.formResponder.Respond(t.context, t.createRequest(headers), t.createForm(true, true), t.successResponse, "address/form")
+	t.Equal(uint(http.StatusCreated), result.(*web.DataResponse).Response.Status)
+
+	result = t.formResponder.Respond(t.context, t.createRequest(headers), t.createForm(true, false), t.successResponse, "address/form")
+	t.Equal(uint(http.StatusBadRequest), result.(*web.DataResponse).Response.Status)
+
+	// data response of controller is used for valid submitted form
+	successResponse := (&web.Responder{}).Data(map[string]string{"id": "1"}).Status(http.StatusCreated)
+	result = t.formResponder.Respond(t.context, t.createRequest(headers), t.createForm(true, true), successResponse, "address/form")
+	t.True(result == successResponse)
+}
+
+func (t *FormResponderTestSuite) TestRespond_PRG() {
+	formResponder := t.formResponder.WithPRG("address")
+	t.Empty(t.formResponder.formIdentifier)
+
+	request := t.createRequest(nil)
+	request.Request().URL = &url.URL{Path: "/address"}
+	form := t.createForm(true, false)
+
+	result := formResponder.Respond(t.context, request, form, t.successResponse, "address/form")
+	t.IsType(&web.URLRedirectResponse{}, result)
+	t.Equal(&url.URL{Path: "/address"}, result.(*web.URLRedirectResponse).URL)
+
+	stored, ok := t.formSessionStore.Load(t.context, request.Session(), "address")
+	t.True(ok)
+	t.Equal(form.ValidationInfo, stored.ValidationInfo)
+
+	// valid form and requests which accept JSON are not redirected
+	result = formResponder.Respond(t.context, request, t.createForm(true, true), t.successResponse, "address/form")
+	t.True(result == t.successResponse)
+
+	request.Request().Header.Set("Accept", "application/json")
+	result = formResponder.Respond(t.context, request, form, t.successResponse, "address/form")
+	t.IsType(&web.DataResponse{}, result)
+}
+
+func (t *FormResponderTestSuite) TestRespond_PRGSaveError() {
+	// form state doesn't fit into session
+	t.formSessionStore.maxSize = 1
+
+	result := t.formResponder.WithPRG("address").Respond(t.context, t.createRequest(nil), t.createForm(true, false), t.successResponse, "address/form")
+	t.IsType(&web.RenderResponse{}, result)
+	t.Equal(uint(http.StatusUnprocessableEntity), result.(*web.RenderResponse).Response.Status)
+}
+
+func (t *FormResponderTestSuite) TestRespond_Nil() {
+	result := t.formResponder.Respond(t.context, t.createRequest(nil), nil, t.successResponse, "address/form")
+	t.IsType(&web.DataResponse{}, result)
+	t.Equal(uint(http.StatusInternalServerError), result.(*web.DataResponse).Response.Status)
+}
+
+// createForm creates form with field error in case when it's not valid
+func (t *FormResponderTestSuite) createForm(submitted bool, valid bool) *domain.Form {
+	form := domain.NewForm(submitted, nil)
+	if !valid {
+		form.ValidationInfo.AddFieldError("street", "formError.street.required", "street required")
+	}
+
+	return &form
+}
+
+// createRequest creates POST request with provided headers
+func (t *FormResponderTestSuite) createRequest(headers map[string]string) *web.Request {
+	httpRequest, _ := http.NewRequest(http.MethodPost, "/", nil)
+	for name, value := range headers {
+		httpRequest.Header.Set(name, value)
+	}
+
+	return web.CreateRequest(httpRequest, web.EmptySession())
+}
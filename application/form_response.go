@@ -0,0 +1,22 @@
+package application
+
+import (
+	"net/http"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+// NewFormDataResponse creates data response with JSON representation of form, so it can be used for asynchronous form submission.
+// Status code is 200 (OK) if form is valid, or 422 (Unprocessable Entity) if it's not.
+func NewFormDataResponse(responder *web.Responder, form *domain.Form) *web.DataResponse {
+	if form == nil {
+		return responder.Data(nil).Status(http.StatusInternalServerError)
+	}
+
+	if !form.IsValid() {
+		return responder.Data(form).Status(http.StatusUnprocessableEntity)
+	}
+
+	return responder.Data(form).Status(http.StatusOK)
+}
@@ -0,0 +1,50 @@
+package application
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	FormResponseTestSuite struct {
+		suite.Suite
+
+		responder *web.Responder
+	}
+)
+
+func TestFormResponseTestSuite(t *testing.T) {
+	suite.Run(t, &FormResponseTestSuite{})
+}
+
+func (t *FormResponseTestSuite) SetupTest() {
+	t.responder = &web.Responder{}
+}
+
+func (t *FormResponseTestSuite) TestNewFormDataResponse_Valid() {
+	form := domain.NewForm(true, nil)
+
+	response := NewFormDataResponse(t.responder, &form)
+	t.Equal(uint(http.StatusOK), response.Response.Status)
+	t.Equal(&form, response.Data)
+}
+
+func (t *FormResponseTestSuite) TestNewFormDataResponse_Invalid() {
+	form := domain.NewForm(true, nil)
+	form.ValidationInfo.AddFieldError("email", "formError.email.required", "email required")
+
+	response := NewFormDataResponse(t.responder, &form)
+	t.Equal(uint(http.StatusUnprocessableEntity), response.Response.Status)
+	t.Equal(&form, response.Data)
+}
+
+func (t *FormResponseTestSuite) TestNewFormDataResponse_Nil() {
+	response := NewFormDataResponse(t.responder, nil)
+	t.Equal(uint(http.StatusInternalServerError), response.Response.Status)
+	t.Nil(response.Data)
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/application/schema"
+	"flamingo.me/form/domain"
+)
+
+// Schema as method for generating JSON Schema document of form data, which describes its fields, their types and
+// validation rules, so clients (like native mobile apps) can render and validate the form on their own.
+// Form data is taken from form data provider, with request from context (if there is any), and validation tags are
+// translated with the same field validators as validation rules for templates. Options of enumerated fields
+// (from bound choice providers) are described as enums.
+func (h *formHandlerImpl) Schema(ctx context.Context) ([]byte, error) {
+	req := web.RequestFromContext(ctx)
+
+	formData, err := h.getFormData(ctx, req, h.formDataProvider)
+	if err != nil {
+		h.getLogger("schema").Error(err.Error())
+		return nil, domain.NewWrappedFormError(err)
+	}
+
+	document, err := schema.NewGenerator(h.validationRuleTranslators).Generate(formData)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fieldName := range h.getChoiceFieldNames() {
+		if property := document.Property(fieldName); property != nil {
+			property.SetEnum(domain.ChoiceValues(h.choiceProviders[fieldName].Options(ctx, req)))
+		}
+	}
+
+	return json.MarshalIndent(document, "", "  ")
+}
@@ -0,0 +1,100 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/application/schema"
+	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/mocks"
+	"flamingo.me/form/domain/validators"
+)
+
+type (
+	FormSchemaTestSuite struct {
+		suite.Suite
+
+		handler  *formHandlerImpl
+		provider *mocks.FormDataProvider
+
+		request *web.Request
+		context context.Context
+	}
+
+	formSchemaProfileTestData struct {
+		Email   string                     `form:"email" validate:"required,email"`
+		Country string                     `form:"country" validate:"required"`
+		Zip     string                     `form:"zip" validate:"regex_zip"`
+		Address formChoicesAddressTestData `form:"address"`
+	}
+)
+
+func TestFormSchemaTestSuite(t *testing.T) {
+	suite.Run(t, &FormSchemaTestSuite{})
+}
+
+func (t *FormSchemaTestSuite) SetupTest() {
+	t.provider = &mocks.FormDataProvider{}
+
+	t.handler = &formHandlerImpl{
+		formDataProvider: t.provider,
+		choiceProviders: map[string]domain.ChoiceProvider{
+			"country":         &formChoicesCountryProvider{},
+			"address.country": &formChoicesStaticProvider{choices: []domain.Choice{{Value: "DE"}, {Value: "FR", Disabled: true}}},
+			"unknown":         &formChoicesStaticProvider{choices: []domain.Choice{{Value: "any"}}},
+		},
+		validationRuleTranslators: map[string]domain.ValidationRuleTranslator{
+			"regex_zip": validators.NewRegexValidator("regex_zip", "^[0-9]{5}$"),
+		},
+		logger: &flamingo.NullLogger{},
+	}
+
+	t.request = web.CreateRequest(&http.Request{
+		Header: http.Header{
+			"Accept-Language": []string{"de"},
+		},
+	}, nil)
+	t.context = web.ContextWithRequest(context.Background(), t.request)
+}
+
+func (t *FormSchemaTestSuite) TearDownTest() {
+	t.provider.AssertExpectations(t.T())
+}
+
+func (t *FormSchemaTestSuite) TestSchema() {
+	t.provider.On("GetFormData", t.context, t.request).Return(formSchemaProfileTestData{}, nil).Once()
+
+	result, err := t.handler.Schema(t.context)
+	t.NoError(err)
+
+	document := &schema.Schema{}
+	t.NoError(json.Unmarshal(result, document))
+	t.Equal(schema.Draft, document.Schema)
+	t.Equal([]string{"email", "country"}, document.Required)
+	t.Equal("email", document.Property("email").Format)
+	t.Equal("^[0-9]{5}$", document.Property("zip").Pattern)
+
+	// options are provided for request from context, and disabled ones are not allowed
+	t.Equal([]interface{}{"DE", "AT", "CH"}, document.Property("country").Enum)
+	t.Equal([]interface{}{"DE"}, document.Property("address.country").Enum)
+}
+
+func (t *FormSchemaTestSuite) TestSchema_Errors() {
+	t.provider.On("GetFormData", t.context, t.request).Return(nil, errors.New("provider error")).Once()
+
+	_, err := t.handler.Schema(t.context)
+	t.Error(err)
+	t.IsType(&domain.WrappedFormError{}, err)
+
+	t.provider.On("GetFormData", t.context, t.request).Return("email", nil).Once()
+
+	_, err = t.handler.Schema(t.context)
+	t.Error(err)
+}
@@ -0,0 +1,162 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	// FormSessionStore as interface for persisting form state between requests, like in Post/Redirect/Get pattern,
+	// where validation errors and submitted values of failed POST request should be presented after redirect.
+	FormSessionStore interface {
+		// Save stores validation info and original values of form into session under form identifier
+		Save(ctx context.Context, session *web.Session, formIdentifier string, form *domain.Form) error
+		// Load returns form with stored validation info and original values, and removes them from session,
+		// so they are presented only once. It returns false if there is no stored form state, or if it's expired.
+		Load(ctx context.Context, session *web.Session, formIdentifier string) (*domain.Form, bool)
+	}
+
+	// FormSessionStoreImpl as actual implementation of FormSessionStore interface, which stores form state as JSON
+	FormSessionStoreImpl struct {
+		ttl          time.Duration
+		maxSize      int
+		maxValueSize int
+		now          func() time.Time
+	}
+
+	// formSessionState as struct which defines JSON representation of form state stored in session
+	formSessionState struct {
+		Submitted      bool                  `json:"submitted"`
+		ValidationInfo domain.ValidationInfo `json:"validationInfo"`
+		OriginalValues url.Values            `json:"originalValues,omitempty"`
+		ExpiresAt      time.Time             `json:"expiresAt"`
+	}
+)
+
+const (
+	// formSessionStoreKeyPrefix as prefix of session keys which contain form states
+	formSessionStoreKeyPrefix = "flamingo.form.state."
+)
+
+var _ FormSessionStore = &FormSessionStoreImpl{}
+
+// Inject is method used to set all dependencies as local variables
+func (s *FormSessionStoreImpl) Inject(cfg *struct {
+	TTL          string  `inject:"config:form.sessionStore.ttl"`
+	MaxSize      float64 `inject:"config:form.sessionStore.maxSize"`
+	MaxValueSize float64 `inject:"config:form.sessionStore.maxValueSize"`
+}) {
+	ttl, err := time.ParseDuration(cfg.TTL)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	s.ttl = ttl
+	s.maxSize = int(cfg.MaxSize)
+	s.maxValueSize = int(cfg.MaxValueSize)
+	s.now = time.Now
+}
+
+// Save stores validation info and original values of form into session under form identifier.
+// Original values bigger than maximum value size are not stored. In case when form state is still bigger than
+// maximum size, only validation info is stored, and if that's also too big, it returns error.
+func (s *FormSessionStoreImpl) Save(_ context.Context, session *web.Session, formIdentifier string, form *domain.Form) error {
+	if session == nil || form == nil {
+		return domain.NewFormError("form state can't be saved without session and form")
+	}
+
+	state := formSessionState{
+		Submitted:      form.IsSubmitted(),
+		ValidationInfo: form.ValidationInfo,
+		OriginalValues: s.limitValues(form.OriginalValues()),
+		ExpiresAt:      s.getNow().Add(s.ttl),
+	}
+
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		return domain.NewFormError(err.Error())
+	}
+
+	if s.maxSize > 0 && len(encoded) > s.maxSize {
+		state.OriginalValues = nil
+
+		encoded, err = json.Marshal(state)
+		if err != nil {
+			return domain.NewFormError(err.Error())
+		}
+
+		if len(encoded) > s.maxSize {
+			return domain.NewFormErrorf("form state of %q exceeds maximum size of %d bytes", formIdentifier, s.maxSize)
+		}
+	}
+
+	session.Store(formSessionStoreKeyPrefix+formIdentifier, string(encoded))
+
+	return nil
+}
+
+// Load returns form with stored validation info and original values, and removes them from session,
+// so they are presented only once. It returns false if there is no stored form state, or if it's expired.
+func (s *FormSessionStoreImpl) Load(_ context.Context, session *web.Session, formIdentifier string) (*domain.Form, bool) {
+	if session == nil {
+		return nil, false
+	}
+
+	key := formSessionStoreKeyPrefix + formIdentifier
+
+	stored, ok := session.Load(key)
+	if !ok {
+		return nil, false
+	}
+	session.Delete(key)
+
+	encoded, ok := stored.(string)
+	if !ok {
+		return nil, false
+	}
+
+	var state formSessionState
+	if err := json.Unmarshal([]byte(encoded), &state); err != nil {
+		return nil, false
+	}
+
+	if s.getNow().After(state.ExpiresAt) {
+		return nil, false
+	}
+
+	form := domain.NewForm(state.Submitted, nil)
+	form.ValidationInfo = state.ValidationInfo
+	form.SetOriginalValues(state.OriginalValues)
+
+	return &form, true
+}
+
+// limitValues returns copy of values without values bigger than maximum value size
+func (s *FormSessionStoreImpl) limitValues(values url.Values) url.Values {
+	limited := make(url.Values, len(values))
+
+	for name, fieldValues := range values {
+		for _, value := range fieldValues {
+			if s.maxValueSize > 0 && len(value) > s.maxValueSize {
+				continue
+			}
+			limited[name] = append(limited[name], value)
+		}
+	}
+
+	return limited
+}
+
+// getNow returns current time, by using injected clock if it's defined
+func (s *FormSessionStoreImpl) getNow() time.Time {
+	if s.now == nil {
+		return time.Now()
+	}
+
+	return s.now()
+}
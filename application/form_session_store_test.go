@@ -0,0 +1,173 @@
+package application
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	FormSessionStoreTestSuite struct {
+		suite.Suite
+
+		store *FormSessionStoreImpl
+
+		now     time.Time
+		context context.Context
+		session *web.Session
+	}
+)
+
+func TestFormSessionStoreTestSuite(t *testing.T) {
+	suite.Run(t, &FormSessionStoreTestSuite{})
+}
+
+func (t *FormSessionStoreTestSuite) SetupTest() {
+	t.store = &FormSessionStoreImpl{}
+	t.store.Inject(&struct {
+		TTL          string  `inject:"config:form.sessionStore.ttl"`
+		MaxSize      float64 `inject:"config:form.sessionStore.maxSize"`
+		MaxValueSize float64 `inject:"config:form.sessionStore.maxValueSize"`
+	}{
+		TTL:          "5m",
+		MaxSize:      512,
+		MaxValueSize: 16,
+	})
+
+	t.now = time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	t.store.now = func() time.Time {
+		return t.now
+	}
+
+	t.context = context.Background()
+	t.session = web.EmptySession()
+}
+
+func (t *FormSessionStoreTestSuite) TestInject_InvalidTTL() {
+	t.Panics(func() {
+		(&FormSessionStoreImpl{}).Inject(&struct {
+			TTL          string  `inject:"config:form.sessionStore.ttl"`
+			MaxSize      float64 `inject:"config:form.sessionStore.maxSize"`
+			MaxValueSize float64 `inject:"config:form.sessionStore.maxValueSize"`
+		}{
+			TTL: "later",
+		})
+	})
+}
+
+func (t *FormSessionStoreTestSuite) TestSaveAndLoad() {
+	form := t.createForm(url.Values{
+		"street": []string{""},
+		"city":   []string{"Berlin"},
+	})
+	form.ValidationInfo.AddGeneralError("formError.address", "address is not valid")
+	form.ValidationInfo.AddFieldErrorWithParams("street", "formError.street.required", "street required", map[string]string{
+		"tag": "required",
+	})
+
+	t.NoError(t.store.Save(t.context, t.session, "address", form))
+
+	result, ok := t.store.Load(t.context, t.session, "address")
+	t.True(ok)
+	t.True(result.IsSubmitted())
+	t.False(result.IsValid())
+	t.Equal(form.ValidationInfo, result.ValidationInfo)
+	t.Equal(url.Values{
+		"street": []string{""},
+		"city":   []string{"Berlin"},
+	}, result.OriginalValues())
+}
+
+func (t *FormSessionStoreTestSuite) TestLoad_Once() {
+	t.NoError(t.store.Save(t.context, t.session, "address", t.createForm(url.Values{})))
+
+	_, ok := t.store.Load(t.context, t.session, "contact")
+	t.False(ok)
+
+	_, ok = t.store.Load(t.context, t.session, "address")
+	t.True(ok)
+
+	result, ok := t.store.Load(t.context, t.session, "address")
+	t.False(ok)
+	t.Nil(result)
+}
+
+func (t *FormSessionStoreTestSuite) TestLoad_Expired() {
+	t.NoError(t.store.Save(t.context, t.session, "address", t.createForm(url.Values{})))
+
+	t.now = t.now.Add(5*time.Minute + time.Second)
+
+	result, ok := t.store.Load(t.context, t.session, "address")
+	t.False(ok)
+	t.Nil(result)
+
+	_, ok = t.session.Load(formSessionStoreKeyPrefix + "address")
+	t.False(ok)
+}
+
+func (t *FormSessionStoreTestSuite) TestLoad_Invalid() {
+	t.session.Store(formSessionStoreKeyPrefix+"address", 5)
+	_, ok := t.store.Load(t.context, t.session, "address")
+	t.False(ok)
+
+	t.session.Store(formSessionStoreKeyPrefix+"address", "{")
+	_, ok = t.store.Load(t.context, t.session, "address")
+	t.False(ok)
+
+	_, ok = t.store.Load(t.context, nil, "address")
+	t.False(ok)
+}
+
+func (t *FormSessionStoreTestSuite) TestSave_LargeValues() {
+	t.NoError(t.store.Save(t.context, t.session, "address", t.createForm(url.Values{
+		"street": []string{"Main Street"},
+		"file":   []string{strings.Repeat("a", 2<<20)},
+		"tags":   []string{"first", strings.Repeat("b", 17)},
+	})))
+
+	result, ok := t.store.Load(t.context, t.session, "address")
+	t.True(ok)
+	t.Equal(url.Values{
+		"street": []string{"Main Street"},
+		"tags":   []string{"first"},
+	}, result.OriginalValues())
+}
+
+func (t *FormSessionStoreTestSuite) TestSave_MaxSize() {
+	values := url.Values{}
+	for i := 0; i < 100; i++ {
+		values.Add("tags", "tag")
+	}
+
+	t.NoError(t.store.Save(t.context, t.session, "address", t.createForm(values)))
+
+	result, ok := t.store.Load(t.context, t.session, "address")
+	t.True(ok)
+	t.Equal(url.Values{}, result.OriginalValues())
+
+	form := t.createForm(url.Values{})
+	form.ValidationInfo.AddGeneralError("formError.address", strings.Repeat("c", 512))
+	t.EqualError(t.store.Save(t.context, t.session, "address", form), `FormError: form state of "address" exceeds maximum size of 512 bytes`)
+
+	_, ok = t.store.Load(t.context, t.session, "address")
+	t.False(ok)
+}
+
+func (t *FormSessionStoreTestSuite) TestSave_Error() {
+	t.Error(t.store.Save(t.context, nil, "address", t.createForm(url.Values{})))
+	t.Error(t.store.Save(t.context, t.session, "address", nil))
+}
+
+func (t *FormSessionStoreTestSuite) createForm(values url.Values) *domain.Form {
+	form := domain.NewForm(true, nil)
+	form.SetOriginalValues(values)
+
+	return &form
+}
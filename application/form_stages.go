@@ -0,0 +1,147 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type (
+	// StageTimeouts as struct which defines maximal duration of each stage of form handling.
+	// Zero duration means that stage is limited only by context of the request.
+	StageTimeouts struct {
+		// Provide limits form data provider and prefill providers
+		Provide time.Duration
+		// Decode limits decoding of submitted values
+		Decode time.Duration
+		// Validate limits validation of form data, including all field validators
+		Validate time.Duration
+		// Extensions limits each form extension separately
+		Extensions time.Duration
+	}
+
+	// stageTimeoutError as recoverable error which presents stage of form handling which didn't finish in time
+	stageTimeoutError struct {
+		stage   string
+		timeout time.Duration
+		cause   error
+	}
+)
+
+const (
+	// FormStageProvide as stage of form handling which provides and prefills form data
+	FormStageProvide = "provide"
+	// FormStageDecode as stage of form handling which decodes submitted values
+	FormStageDecode = "decode"
+	// FormStageValidate as stage of form handling which validates form data
+	FormStageValidate = "validate"
+	// FormStageExtensions as stage of form handling which processes form extensions
+	FormStageExtensions = "extensions"
+)
+
+// ParseStageTimeouts creates StageTimeouts from configured durations (like "500ms"), where empty string means no timeout
+func ParseStageTimeouts(provide string, decode string, validate string, extensions string) (StageTimeouts, error) {
+	timeouts := StageTimeouts{}
+
+	for _, entry := range []struct {
+		name   string
+		value  string
+		target *time.Duration
+	}{
+		{name: "provideTimeout", value: provide, target: &timeouts.Provide},
+		{name: "decodeTimeout", value: decode, target: &timeouts.Decode},
+		{name: "validateTimeout", value: validate, target: &timeouts.Validate},
+		{name: "extensionsTimeout", value: extensions, target: &timeouts.Extensions},
+	} {
+		if entry.value == "" {
+			continue
+		}
+
+		timeout, err := time.ParseDuration(entry.value)
+		if err != nil || timeout < 0 {
+			return StageTimeouts{}, fmt.Errorf("invalid %s %q", entry.name, entry.value)
+		}
+		*entry.target = timeout
+	}
+
+	return timeouts, nil
+}
+
+// get returns timeout of stage
+func (t StageTimeouts) get(stage string) time.Duration {
+	switch stage {
+	case FormStageProvide:
+		return t.Provide
+	case FormStageDecode:
+		return t.Decode
+	case FormStageValidate:
+		return t.Validate
+	case FormStageExtensions:
+		return t.Extensions
+	}
+
+	return 0
+}
+
+// withStageTimeout as method for getting context of form handling stage, limited by its timeout if it's defined.
+// Without timeout, stage uses context of the request as it is.
+func (h *formHandlerImpl) withStageTimeout(ctx context.Context, stage string) (context.Context, context.CancelFunc) {
+	if timeout := h.stageTimeouts.get(stage); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+
+	return ctx, func() {}
+}
+
+// runStage runs function of form handling stage with stage context. Stage runs in goroutine of form handler,
+// so it never runs concurrently with the rest of form handling (which shares http request, session and form data
+// with it), and stage timeout only cancels stage context. Stage is not started at all if context is already done.
+// Stage which returns after its context is done is reported as interrupted, but its result is returned as well,
+// so values collected before interruption (like field errors of finished validators) can be kept.
+func runStage[T any](ctx context.Context, stage string, timeout time.Duration, run func(ctx context.Context) (T, error)) (T, error) {
+	if err := ctx.Err(); err != nil {
+		var empty T
+		return empty, newStageError(stage, timeout, err)
+	}
+
+	value, err := run(ctx)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return value, newStageError(stage, timeout, ctxErr)
+	}
+
+	return value, err
+}
+
+// newStageError returns error which presents interrupted stage of form handling. Timeouts are recoverable errors,
+// so form is presented with general error, while canceled request aborts form handling.
+func newStageError(stage string, timeout time.Duration, cause error) error {
+	if errors.Is(cause, context.DeadlineExceeded) {
+		return &stageTimeoutError{
+			stage:   stage,
+			timeout: timeout,
+			cause:   cause,
+		}
+	}
+
+	return fmt.Errorf("form handling stage %q is canceled: %w", stage, cause)
+}
+
+// Error returns error message with stage which timed out
+func (e *stageTimeoutError) Error() string {
+	if e.timeout > 0 {
+		return fmt.Sprintf("form handling stage %q timed out after %s: %v", e.stage, e.timeout, e.cause)
+	}
+
+	return fmt.Sprintf("form handling stage %q timed out: %v", e.stage, e.cause)
+}
+
+// MessageKey returns message key of general error which presents timeout
+func (e *stageTimeoutError) MessageKey() string {
+	return "formError.validationTimeout"
+}
+
+// Unwrap returns original cause of stageTimeoutError
+func (e *stageTimeoutError) Unwrap() error {
+	return e.cause
+}
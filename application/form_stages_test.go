@@ -0,0 +1,277 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	FormStagesTestSuite struct {
+		suite.Suite
+
+		handler  *formHandlerImpl
+		provided bool
+
+		request *web.Request
+	}
+
+	formStagesProvider  func(ctx context.Context, req *web.Request) (interface{}, error)
+	formStagesDecoder   func(ctx context.Context, req *web.Request, values url.Values, formData interface{}) (interface{}, error)
+	formStagesValidator func(ctx context.Context, req *web.Request, validatorProvider domain.ValidatorProvider, formData interface{}) (*domain.ValidationInfo, error)
+)
+
+const (
+	formStagesTimeout = 20 * time.Millisecond
+	formStagesDelay   = 200 * time.Millisecond
+)
+
+func TestFormStagesTestSuite(t *testing.T) {
+	suite.Run(t, &FormStagesTestSuite{})
+}
+
+func (t *FormStagesTestSuite) SetupTest() {
+	t.provided = false
+
+	t.handler = &formHandlerImpl{
+		formDataProvider: formStagesProvider(func(ctx context.Context, req *web.Request) (interface{}, error) {
+			t.provided = true
+			return map[string]string{}, nil
+		}),
+		// decoding errors are collected before validation, so they have to be kept when validation times out
+		formDataDecoder: formStagesDecoder(func(ctx context.Context, req *web.Request, values url.Values, formData interface{}) (interface{}, error) {
+			validationInfo := domain.ValidationInfo{}
+			validationInfo.AddFieldError("age", "formError.invalidFormat", "age is not a number")
+
+			return map[string]string{"age": values.Get("age")}, domain.NewDecodeError(validationInfo)
+		}),
+		formDataValidator: formStagesValidator(func(ctx context.Context, req *web.Request, validatorProvider domain.ValidatorProvider, formData interface{}) (*domain.ValidationInfo, error) {
+			return &domain.ValidationInfo{}, nil
+		}),
+		stageTimeouts: StageTimeouts{
+			Validate: formStagesTimeout,
+		},
+		logger: &flamingo.NullLogger{},
+	}
+
+	httpRequest := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("age=abc"))
+	httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	t.request = web.CreateRequest(httpRequest, nil)
+}
+
+func (p formStagesProvider) GetFormData(ctx context.Context, req *web.Request) (interface{}, error) {
+	return p(ctx, req)
+}
+
+func (d formStagesDecoder) Decode(ctx context.Context, req *web.Request, values url.Values, formData interface{}) (interface{}, error) {
+	return d(ctx, req, values, formData)
+}
+
+func (v formStagesValidator) Validate(ctx context.Context, req *web.Request, validatorProvider domain.ValidatorProvider, formData interface{}) (*domain.ValidationInfo, error) {
+	return v(ctx, req, validatorProvider, formData)
+}
+
+// sleepingValidator ignores context, like validator which calls external service without deadline
+func (t *FormStagesTestSuite) sleepingValidator() domain.FormDataValidator {
+	return formStagesValidator(func(ctx context.Context, req *web.Request, validatorProvider domain.ValidatorProvider, formData interface{}) (*domain.ValidationInfo, error) {
+		time.Sleep(formStagesDelay)
+
+		validationInfo := domain.ValidationInfo{}
+		validationInfo.AddFieldError("email", "formError.email.unique", "email is already used")
+
+		return &validationInfo, nil
+	})
+}
+
+// contextValidator respects context, so it returns error of context as soon as it's done
+func (t *FormStagesTestSuite) contextValidator() domain.FormDataValidator {
+	return formStagesValidator(func(ctx context.Context, req *web.Request, validatorProvider domain.ValidatorProvider, formData interface{}) (*domain.ValidationInfo, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(formStagesDelay):
+			return &domain.ValidationInfo{}, nil
+		}
+	})
+}
+
+func (t *FormStagesTestSuite) assertValidationTimeout(form *domain.Form) {
+	t.False(form.IsValid())
+	t.Equal([]domain.Error{{
+		MessageKey:   "formError.validationTimeout",
+		DefaultLabel: `form handling stage "validate" timed out after 20ms: context deadline exceeded`,
+	}}, form.ValidationInfo.GetGeneralErrors())
+	t.Equal([]domain.Error{{
+		MessageKey:   "formError.invalidFormat",
+		DefaultLabel: "age is not a number",
+	}}, form.ValidationInfo.GetErrorsForField("age"))
+}
+
+func (t *FormStagesTestSuite) TestHandleSubmittedForm_SleepingValidator() {
+	t.handler.formDataValidator = t.sleepingValidator()
+
+	// validator which ignores context is never left running in background, so form handling waits for it
+	start := time.Now()
+	form, err := t.handler.HandleSubmittedForm(context.Background(), t.request)
+	t.NoError(err)
+	t.False(time.Since(start) < formStagesDelay)
+
+	// errors which validator collected are kept together with timeout
+	t.assertValidationTimeout(form)
+	t.Equal(map[string]string{"age": "abc"}, form.Data)
+	t.True(form.ValidationInfo.HasErrorsForField("email"))
+}
+
+func (t *FormStagesTestSuite) TestHandleSubmittedForm_ContextValidator() {
+	t.handler.formDataValidator = t.contextValidator()
+
+	start := time.Now()
+	form, err := t.handler.HandleSubmittedForm(context.Background(), t.request)
+	t.NoError(err)
+	t.Less(int64(time.Since(start)), int64(formStagesDelay))
+
+	t.assertValidationTimeout(form)
+}
+
+func (t *FormStagesTestSuite) TestHandleSubmittedForm_WithinTimeout() {
+	form, err := t.handler.HandleSubmittedForm(context.Background(), t.request)
+	t.NoError(err)
+	t.False(form.ValidationInfo.HasGeneralErrors())
+	t.True(form.ValidationInfo.HasErrorsForField("age"))
+}
+
+func (t *FormStagesTestSuite) TestHandleSubmittedForm_RequestDeadline() {
+	t.handler.stageTimeouts = StageTimeouts{}
+	t.handler.formDataValidator = t.contextValidator()
+
+	ctx, cancel := context.WithTimeout(context.Background(), formStagesTimeout)
+	defer cancel()
+
+	form, err := t.handler.HandleSubmittedForm(ctx, t.request)
+	t.NoError(err)
+	t.Equal([]domain.Error{{
+		MessageKey:   "formError.validationTimeout",
+		DefaultLabel: `form handling stage "validate" timed out: context deadline exceeded`,
+	}}, form.ValidationInfo.GetGeneralErrors())
+	t.True(form.ValidationInfo.HasErrorsForField("age"))
+}
+
+func (t *FormStagesTestSuite) TestHandleSubmittedForm_Canceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	form, err := t.handler.HandleSubmittedForm(ctx, t.request)
+	t.Nil(form)
+	t.IsType(&domain.WrappedFormError{}, err)
+	t.True(errors.Is(err, context.Canceled))
+	t.False(t.provided)
+}
+
+func (t *FormStagesTestSuite) TestHandleSubmittedForm_ProvideTimeout() {
+	t.handler.stageTimeouts = StageTimeouts{
+		Provide: formStagesTimeout,
+	}
+	t.handler.formDataProvider = formStagesProvider(func(ctx context.Context, req *web.Request) (interface{}, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+
+	form, err := t.handler.HandleSubmittedForm(context.Background(), t.request)
+	t.NoError(err)
+	t.Nil(form.Data)
+	t.Equal([]domain.Error{{
+		MessageKey:   "formError.validationTimeout",
+		DefaultLabel: `form handling stage "provide" timed out after 20ms: context deadline exceeded`,
+	}}, form.ValidationInfo.GetGeneralErrors())
+}
+
+func (t *FormStagesTestSuite) TestProcessExtensions_Timeout() {
+	t.handler.stageTimeouts = StageTimeouts{
+		Extensions: formStagesTimeout,
+	}
+	t.handler.formExtensions = map[string]domain.FormExtension{
+		"contact": t.contextValidator(),
+		"unique":  t.sleepingValidator(),
+	}
+	t.handler.defaultFormDataProvider = formStagesProvider(func(ctx context.Context, req *web.Request) (interface{}, error) {
+		return map[string]string{}, nil
+	})
+	t.handler.defaultFormDataDecoder = t.handler.formDataDecoder
+
+	form := domain.NewForm(true, nil)
+	form.ValidationInfo.AddFieldError("name", "formError.name.required", "name is required")
+
+	err := t.handler.processExtensions(context.Background(), t.request, url.Values{}, &form)
+	t.NoError(err)
+
+	t.Equal([]domain.Error{{
+		MessageKey:   "formError.validationTimeout",
+		DefaultLabel: `form handling stage "extensions" timed out after 20ms: context deadline exceeded`,
+	}}, form.ValidationInfo.GetGeneralErrors())
+	t.True(form.ValidationInfo.HasErrorsForField("name"))
+
+	// each extension has its own timeout, so extension after timed out one is processed as well,
+	// and results collected by timed out extensions are kept
+	t.True(form.ValidationInfo.HasErrorsForField("email"))
+	t.Equal(map[string]interface{}{
+		"contact": map[string]string{"age": ""},
+		"unique":  map[string]string{"age": ""},
+	}, form.FormExtensionsData)
+}
+
+func (t *FormStagesTestSuite) TestRunStage_Interrupted() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	value, err := runStage(ctx, FormStageDecode, 0, func(ctx context.Context) (string, error) {
+		cancel()
+		return "decoded", nil
+	})
+	t.Equal("decoded", value)
+	t.EqualError(err, `form handling stage "decode" is canceled: context canceled`)
+
+	// stage is not started if context is already done
+	started := false
+	_, err = runStage(ctx, FormStageValidate, 0, func(ctx context.Context) (string, error) {
+		started = true
+		return "", nil
+	})
+	t.False(started)
+	t.True(errors.Is(err, context.Canceled))
+}
+
+func (t *FormStagesTestSuite) TestRunStage_Panic() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t.Panics(func() {
+		_, _ = runStage(ctx, FormStageValidate, 0, func(ctx context.Context) (interface{}, error) {
+			panic("validator panic")
+		})
+	})
+}
+
+func (t *FormStagesTestSuite) TestParseStageTimeouts() {
+	timeouts, err := ParseStageTimeouts("1s", "", "500ms", "0s")
+	t.NoError(err)
+	t.Equal(StageTimeouts{
+		Provide:  time.Second,
+		Validate: 500 * time.Millisecond,
+	}, timeouts)
+
+	_, err = ParseStageTimeouts("1s", "soon", "", "")
+	t.EqualError(err, `invalid decodeTimeout "soon"`)
+
+	_, err = ParseStageTimeouts("", "", "-1s", "")
+	t.EqualError(err, `invalid validateTimeout "-1s"`)
+}
@@ -0,0 +1,257 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"reflect"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+const (
+	// persistTagNever as value of "persist" tag, which excludes sensitive field (like password) from persisted form state
+	persistTagNever = "never"
+)
+
+var (
+	// fileHeaderType as type of uploaded files, which can't be restored from persisted form state
+	fileHeaderType = reflect.TypeOf((*multipart.FileHeader)(nil))
+)
+
+// withStatePersistence as method for wrapping form processing, so form data of each submitted form which is not valid
+// is persisted into form state store, and persisted form state is deleted as soon as form is submitted successfully.
+// Failures of form state store are only logged, since form can be handled without persisted form state.
+func (h *formHandlerImpl) withStatePersistence(req *web.Request, process func(ctx context.Context, form *domain.Form) (*domain.Form, error)) func(ctx context.Context, form *domain.Form) (*domain.Form, error) {
+	if h.stateStore == nil {
+		return process
+	}
+
+	return func(ctx context.Context, form *domain.Form) (*domain.Form, error) {
+		form, err := process(ctx, form)
+		if err != nil || !form.IsSubmitted() || form.IsDuplicateSubmission() || form.Data == nil {
+			return form, err
+		}
+
+		formIdentifier := h.getStateIdentifier(form.Data)
+
+		if form.IsValid() {
+			if err := h.stateStore.Delete(ctx, req.Session(), formIdentifier); err != nil {
+				h.getLogger("formState").Error(err.Error())
+			}
+
+			return form, nil
+		}
+
+		state, err := json.Marshal(h.getPersistedData(form.Data))
+		if err == nil {
+			err = h.stateStore.Save(ctx, req.Session(), formIdentifier, state)
+		}
+		if err != nil {
+			h.getLogger("formState").Error(err.Error())
+		}
+
+		return form, nil
+	}
+}
+
+// restoreState as method for merging persisted form state into form data of unsubmitted form, in the same way
+// as values of prefill provider. Form state which can't be decoded into form data (like after form data is changed)
+// is deleted from form state store.
+func (h *formHandlerImpl) restoreState(ctx context.Context, req *web.Request, formData interface{}) interface{} {
+	if h.stateStore == nil || formData == nil || req == nil {
+		return formData
+	}
+
+	formIdentifier := h.getStateIdentifier(formData)
+
+	state, ok, err := h.stateStore.Load(ctx, req.Session(), formIdentifier)
+	if err != nil {
+		h.getLogger("formState").Error(err.Error())
+		return formData
+	}
+	if !ok {
+		return formData
+	}
+
+	restored := reflect.New(reflect.Indirect(reflect.ValueOf(formData)).Type())
+	if err := json.Unmarshal(state, restored.Interface()); err != nil {
+		h.deleteState(ctx, req, formIdentifier, err)
+		return formData
+	}
+
+	merged, err := h.mergePrefillData(formData, restored.Interface())
+	if err != nil {
+		h.deleteState(ctx, req, formIdentifier, err)
+		return formData
+	}
+
+	return merged
+}
+
+// deleteState as method for deleting persisted form state which can't be restored, with logging of the reason
+func (h *formHandlerImpl) deleteState(ctx context.Context, req *web.Request, formIdentifier string, reason error) {
+	h.getLogger("formState").Warn(reason.Error())
+
+	if err := h.stateStore.Delete(ctx, req.Session(), formIdentifier); err != nil {
+		h.getLogger("formState").Error(err.Error())
+	}
+}
+
+// getStateIdentifier as method for defining identifier of persisted form state, from full name of form data type
+// and namespace, so different forms (or the same form in different namespaces) don't overwrite each other's state
+func (h *formHandlerImpl) getStateIdentifier(formData interface{}) string {
+	typeOf := reflect.TypeOf(formData)
+	for typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	formIdentifier := typeOf.String()
+	if typeOf.Name() != "" && typeOf.PkgPath() != "" {
+		formIdentifier = typeOf.PkgPath() + "." + typeOf.Name()
+	}
+
+	if h.namespace != "" {
+		formIdentifier += "#" + h.namespace
+	}
+
+	return formIdentifier
+}
+
+// getPersistedData as method for returning copy of form data without fields which must not be persisted. Those are
+// fields tagged with `persist:"never"` (like passwords or card numbers) and uploaded files, in nested structs
+// and in elements of slices, arrays and maps as well.
+func (h *formHandlerImpl) getPersistedData(formData interface{}) interface{} {
+	value := reflect.ValueOf(formData)
+	if !h.hasExcludedStateFields(value.Type(), map[reflect.Type]bool{}) {
+		return formData
+	}
+
+	return h.copyWithoutStateFields(value).Interface()
+}
+
+// excludeStateFields as method for setting zero value to all struct fields which must not be persisted.
+// Nested values which contain such fields (pointers, slices, arrays and maps) are replaced with copies,
+// so form data of the form is never modified.
+func (h *formHandlerImpl) excludeStateFields(value reflect.Value) {
+	// error is never returned from callback, so it can be ignored
+	_ = h.walkFormFields(value, "", func(field reflect.StructField, fieldValue reflect.Value, _ string, _ string) error {
+		switch {
+		case field.Tag.Get("persist") == persistTagNever || h.isFileType(field.Type):
+			fieldValue.Set(reflect.Zero(field.Type))
+		case h.hasExcludedStateFields(field.Type, map[reflect.Type]bool{}):
+			fieldValue.Set(h.copyWithoutStateFields(fieldValue))
+		}
+
+		return nil
+	})
+}
+
+// copyWithoutStateFields as method for returning copy of value, where fields which must not be persisted are excluded
+// from all nested structs, including elements of slices, arrays and maps. Uploaded files are replaced with nil,
+// and nil values are returned as they are.
+func (h *formHandlerImpl) copyWithoutStateFields(value reflect.Value) reflect.Value {
+	if value.Type() == fileHeaderType {
+		return reflect.Zero(fileHeaderType)
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		if h.isNestedStruct(value.Type()) {
+			h.excludeStateFields(copied)
+		}
+
+		return copied
+	case reflect.Ptr:
+		if value.IsNil() {
+			return value
+		}
+
+		copied := reflect.New(value.Type().Elem())
+		copied.Elem().Set(h.copyWithoutStateFields(value.Elem()))
+
+		return copied
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(h.copyWithoutStateFields(value.Elem()))
+
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(h.copyWithoutStateFields(value.Index(i)))
+		}
+
+		return copied
+	case reflect.Array:
+		copied := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(h.copyWithoutStateFields(value.Index(i)))
+		}
+
+		return copied
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+
+		copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iterator := value.MapRange()
+		for iterator.Next() {
+			copied.SetMapIndex(iterator.Key(), h.copyWithoutStateFields(iterator.Value()))
+		}
+
+		return copied
+	}
+
+	return value
+}
+
+// hasExcludedStateFields as method for checking if values of type can contain fields which must not be persisted,
+// so only such values are copied. Interface types can contain anything, so they are always checked.
+func (h *formHandlerImpl) hasExcludedStateFields(typeOf reflect.Type, visited map[reflect.Type]bool) bool {
+	if typeOf == fileHeaderType {
+		return true
+	}
+
+	switch typeOf.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return h.hasExcludedStateFields(typeOf.Elem(), visited)
+	case reflect.Interface:
+		return true
+	case reflect.Struct:
+		if !h.isNestedStruct(typeOf) || visited[typeOf] {
+			return false
+		}
+		visited[typeOf] = true
+
+		for i := 0; i < typeOf.NumField(); i++ {
+			field := typeOf.Field(i)
+			if field.Tag.Get("persist") == persistTagNever || h.isFileType(field.Type) || h.hasExcludedStateFields(field.Type, visited) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isFileType as method for checking if type is uploaded file, or slice of uploaded files
+func (h *formHandlerImpl) isFileType(typeOf reflect.Type) bool {
+	if typeOf.Kind() == reflect.Slice {
+		typeOf = typeOf.Elem()
+	}
+
+	return typeOf == fileHeaderType
+}
@@ -0,0 +1,126 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	// FormStateStoreImpl as session based implementation of domain.FormStateStore interface. Since session belongs to
+	// single user, form states are stored in it only by form identifier, together with their expiration time.
+	FormStateStoreImpl struct {
+		ttl     time.Duration
+		maxSize int
+		now     func() time.Time
+	}
+
+	// formStoredState as struct which defines JSON representation of persisted form state stored in session
+	formStoredState struct {
+		State     []byte    `json:"state"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+)
+
+const (
+	// formStateStoreKeyPrefix as prefix of session keys which contain persisted form states
+	formStateStoreKeyPrefix = "flamingo.form.persisted."
+)
+
+var _ domain.FormStateStore = &FormStateStoreImpl{}
+
+// Inject is method used to set all dependencies as local variables
+func (s *FormStateStoreImpl) Inject(cfg *struct {
+	TTL     string  `inject:"config:form.stateStore.ttl"`
+	MaxSize float64 `inject:"config:form.stateStore.maxSize"`
+}) {
+	ttl, err := time.ParseDuration(cfg.TTL)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	s.ttl = ttl
+	s.maxSize = int(cfg.MaxSize)
+	s.now = time.Now
+}
+
+// Save stores encoded form state into session under form identifier, until TTL expires.
+// It returns error if form state is bigger than maximum size, so session is not overgrown by long forms.
+func (s *FormStateStoreImpl) Save(_ context.Context, session *web.Session, formIdentifier string, state []byte) error {
+	if session == nil {
+		return domain.NewFormError("form state can't be saved without session")
+	}
+
+	if s.maxSize > 0 && len(state) > s.maxSize {
+		return domain.NewFormErrorf("form state of %q exceeds maximum size of %d bytes", formIdentifier, s.maxSize)
+	}
+
+	encoded, err := json.Marshal(formStoredState{
+		State:     state,
+		ExpiresAt: s.getNow().Add(s.ttl),
+	})
+	if err != nil {
+		return domain.NewFormError(err.Error())
+	}
+
+	session.Store(formStateStoreKeyPrefix+formIdentifier, string(encoded))
+
+	return nil
+}
+
+// Load returns encoded form state stored in session under form identifier. Unlike form session store, it keeps
+// form state in session, so it's restored until it's deleted or expired. Expired form state is removed from session.
+func (s *FormStateStoreImpl) Load(_ context.Context, session *web.Session, formIdentifier string) ([]byte, bool, error) {
+	if session == nil {
+		return nil, false, nil
+	}
+
+	key := formStateStoreKeyPrefix + formIdentifier
+
+	stored, ok := session.Load(key)
+	if !ok {
+		return nil, false, nil
+	}
+
+	encoded, ok := stored.(string)
+	if !ok {
+		session.Delete(key)
+		return nil, false, domain.NewFormErrorf("form state of %q is stored as %T", formIdentifier, stored)
+	}
+
+	var state formStoredState
+	if err := json.Unmarshal([]byte(encoded), &state); err != nil {
+		session.Delete(key)
+		return nil, false, domain.NewFormError(err.Error())
+	}
+
+	if s.getNow().After(state.ExpiresAt) {
+		session.Delete(key)
+		return nil, false, nil
+	}
+
+	return state.State, true, nil
+}
+
+// Delete removes form state stored in session under form identifier
+func (s *FormStateStoreImpl) Delete(_ context.Context, session *web.Session, formIdentifier string) error {
+	if session == nil {
+		return nil
+	}
+
+	session.Delete(formStateStoreKeyPrefix + formIdentifier)
+
+	return nil
+}
+
+// getNow returns current time, by using injected clock if it's defined
+func (s *FormStateStoreImpl) getNow() time.Time {
+	if s.now == nil {
+		return time.Now()
+	}
+
+	return s.now()
+}
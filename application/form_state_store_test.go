@@ -0,0 +1,154 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	FormStateStoreTestSuite struct {
+		suite.Suite
+
+		store *FormStateStoreImpl
+
+		now     time.Time
+		context context.Context
+		session *web.Session
+	}
+)
+
+func TestFormStateStoreTestSuite(t *testing.T) {
+	suite.Run(t, &FormStateStoreTestSuite{})
+}
+
+func (t *FormStateStoreTestSuite) SetupTest() {
+	t.store = &FormStateStoreImpl{}
+	t.store.Inject(&struct {
+		TTL     string  `inject:"config:form.stateStore.ttl"`
+		MaxSize float64 `inject:"config:form.stateStore.maxSize"`
+	}{
+		TTL:     "1h",
+		MaxSize: 64,
+	})
+
+	t.now = time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	t.store.now = func() time.Time {
+		return t.now
+	}
+
+	t.context = context.Background()
+	t.session = web.EmptySession()
+}
+
+func (t *FormStateStoreTestSuite) TestInject_InvalidTTL() {
+	t.Panics(func() {
+		(&FormStateStoreImpl{}).Inject(&struct {
+			TTL     string  `inject:"config:form.stateStore.ttl"`
+			MaxSize float64 `inject:"config:form.stateStore.maxSize"`
+		}{
+			TTL: "tomorrow",
+		})
+	})
+}
+
+func (t *FormStateStoreTestSuite) TestSaveAndLoad() {
+	t.NoError(t.store.Save(t.context, t.session, "address", []byte(`{"city":"Berlin"}`)))
+	t.NoError(t.store.Save(t.context, t.session, "profile", []byte(`{"name":"Jane"}`)))
+
+	// form state is kept in session, so it's restored on each load
+	for i := 0; i < 2; i++ {
+		state, ok, err := t.store.Load(t.context, t.session, "address")
+		t.NoError(err)
+		t.True(ok)
+		t.Equal([]byte(`{"city":"Berlin"}`), state)
+	}
+
+	t.NoError(t.store.Save(t.context, t.session, "address", []byte(`{"city":"Hamburg"}`)))
+
+	state, ok, err := t.store.Load(t.context, t.session, "address")
+	t.NoError(err)
+	t.True(ok)
+	t.Equal([]byte(`{"city":"Hamburg"}`), state)
+
+	state, ok, err = t.store.Load(t.context, t.session, "profile")
+	t.NoError(err)
+	t.True(ok)
+	t.Equal([]byte(`{"name":"Jane"}`), state)
+}
+
+func (t *FormStateStoreTestSuite) TestLoad_Missing() {
+	state, ok, err := t.store.Load(t.context, t.session, "address")
+	t.NoError(err)
+	t.False(ok)
+	t.Nil(state)
+
+	state, ok, err = t.store.Load(t.context, nil, "address")
+	t.NoError(err)
+	t.False(ok)
+	t.Nil(state)
+}
+
+func (t *FormStateStoreTestSuite) TestLoad_Expired() {
+	t.NoError(t.store.Save(t.context, t.session, "address", []byte(`{"city":"Berlin"}`)))
+
+	t.now = t.now.Add(time.Hour + time.Second)
+
+	_, ok, err := t.store.Load(t.context, t.session, "address")
+	t.NoError(err)
+	t.False(ok)
+
+	_, ok = t.session.Load(formStateStoreKeyPrefix + "address")
+	t.False(ok)
+}
+
+func (t *FormStateStoreTestSuite) TestLoad_Invalid() {
+	t.session.Store(formStateStoreKeyPrefix+"address", "{")
+
+	_, ok, err := t.store.Load(t.context, t.session, "address")
+	t.Error(err)
+	t.False(ok)
+
+	_, ok = t.session.Load(formStateStoreKeyPrefix + "address")
+	t.False(ok)
+
+	t.session.Store(formStateStoreKeyPrefix+"address", 42)
+
+	_, ok, err = t.store.Load(t.context, t.session, "address")
+	t.EqualError(err, `FormError: form state of "address" is stored as int`)
+	t.False(ok)
+}
+
+func (t *FormStateStoreTestSuite) TestSave_Error() {
+	err := t.store.Save(t.context, nil, "address", []byte(`{}`))
+	t.EqualError(err, "FormError: form state can't be saved without session")
+
+	err = t.store.Save(t.context, t.session, "address", []byte(`{"city":"`+strings.Repeat("a", 64)+`"}`))
+	t.EqualError(err, `FormError: form state of "address" exceeds maximum size of 64 bytes`)
+
+	_, ok, err := t.store.Load(t.context, t.session, "address")
+	t.NoError(err)
+	t.False(ok)
+}
+
+func (t *FormStateStoreTestSuite) TestDelete() {
+	t.NoError(t.store.Save(t.context, t.session, "address", []byte(`{"city":"Berlin"}`)))
+	t.NoError(t.store.Save(t.context, t.session, "profile", []byte(`{"name":"Jane"}`)))
+
+	t.NoError(t.store.Delete(t.context, t.session, "address"))
+	t.NoError(t.store.Delete(t.context, t.session, "unknown"))
+	t.NoError(t.store.Delete(t.context, nil, "address"))
+
+	_, ok, err := t.store.Load(t.context, t.session, "address")
+	t.NoError(err)
+	t.False(ok)
+
+	_, ok, err = t.store.Load(t.context, t.session, "profile")
+	t.NoError(err)
+	t.True(ok)
+}
@@ -0,0 +1,336 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/mocks"
+)
+
+type (
+	FormStateTestSuite struct {
+		suite.Suite
+
+		handler *formHandlerImpl
+
+		provider          *mocks.FormDataProvider
+		decoder           *mocks.FormDataDecoder
+		validator         *mocks.FormDataValidator
+		validatorProvider *mocks.ValidatorProvider
+		store             *FormStateStoreImpl
+
+		context context.Context
+		request *web.Request
+	}
+
+	formStateTestData struct {
+		Name     string                `form:"name"`
+		Email    string                `form:"email"`
+		Password string                `form:"password" persist:"never"`
+		Address  formStateTestAddress  `form:"address"`
+		Card     *formStateTestCard    `form:"card"`
+		Avatar   *multipart.FileHeader `form:"avatar"`
+	}
+
+	formStateTestAddress struct {
+		Street string `form:"street"`
+		City   string `form:"city"`
+	}
+
+	formStateTestCard struct {
+		Holder string `form:"holder"`
+		Number string `form:"number" persist:"never"`
+	}
+
+	formStateTestWallet struct {
+		Cards    []formStateTestCard              `form:"cards"`
+		Backup   [1]formStateTestCard             `form:"backup"`
+		Accounts map[string]formStateTestCard     `form:"accounts"`
+		Shared   map[string]*formStateTestCard    `form:"shared"`
+		Tags     []string                         `form:"tags"`
+		Files    map[string]*multipart.FileHeader `form:"files"`
+	}
+)
+
+const (
+	formStateTestIdentifier = "flamingo.me/form/application.formStateTestData"
+)
+
+func TestFormStateTestSuite(t *testing.T) {
+	suite.Run(t, &FormStateTestSuite{})
+}
+
+func (t *FormStateTestSuite) SetupTest() {
+	t.provider = &mocks.FormDataProvider{}
+	t.decoder = &mocks.FormDataDecoder{}
+	t.validator = &mocks.FormDataValidator{}
+	t.validatorProvider = &mocks.ValidatorProvider{}
+	t.store = &FormStateStoreImpl{
+		ttl: time.Hour,
+	}
+
+	t.handler = &formHandlerImpl{
+		formDataProvider:  t.provider,
+		formDataDecoder:   t.decoder,
+		formDataValidator: t.validator,
+		validatorProvider: t.validatorProvider,
+		stateStore:        t.store,
+		logger:            &flamingo.NullLogger{},
+	}
+
+	t.context = domain.ContextWithAttachments(context.Background(), domain.NewAttachments())
+	t.request = web.CreateRequest(&http.Request{}, nil)
+}
+
+func (t *FormStateTestSuite) TearDownTest() {
+	t.provider.AssertExpectations(t.T())
+	t.decoder.AssertExpectations(t.T())
+	t.validator.AssertExpectations(t.T())
+	t.validatorProvider.AssertExpectations(t.T())
+}
+
+// submit handles submission of form data, which is decoded and validated as it's passed
+func (t *FormStateTestSuite) submit(decoded formStateTestData, validationInfo domain.ValidationInfo) *domain.Form {
+	values := url.Values{
+		"name": []string{decoded.Name},
+	}
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = values
+
+	t.provider.On("GetFormData", t.context, t.request).Return(formStateTestData{}, nil).Once()
+	t.decoder.On("Decode", t.context, t.request, values, formStateTestData{}).Return(decoded, nil).Once()
+	t.validator.On("Validate", t.context, t.request, t.validatorProvider, decoded).Return(&validationInfo, nil).Once()
+
+	form, err := t.handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+
+	return form
+}
+
+// present handles unsubmitted form, whose form data provider provides passed form data
+func (t *FormStateTestSuite) present(provided interface{}) *domain.Form {
+	t.request.Request().Method = http.MethodGet
+	t.provider.On("GetFormData", t.context, t.request).Return(provided, nil).Once()
+
+	form, err := t.handler.HandleUnsubmittedForm(t.context, t.request)
+	t.NoError(err)
+
+	return form
+}
+
+func (t *FormStateTestSuite) getInvalidInfo() domain.ValidationInfo {
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldError("email", "formError.email.required", "email required")
+
+	return validationInfo
+}
+
+func (t *FormStateTestSuite) TestHandleSubmittedForm_SaveInvalid() {
+	decoded := formStateTestData{
+		Name:     "Jane",
+		Password: "secret",
+		Address: formStateTestAddress{
+			City: "Berlin",
+		},
+		Card: &formStateTestCard{
+			Holder: "Jane Doe",
+			Number: "4111111111111111",
+		},
+		Avatar: &multipart.FileHeader{
+			Filename: "avatar.png",
+		},
+	}
+
+	form := t.submit(decoded, t.getInvalidInfo())
+	t.False(form.IsValid())
+
+	// sensitive fields are excluded only from persisted form state, not from form data of the form
+	t.Equal(decoded, form.Data)
+	t.Equal("4111111111111111", form.Data.(formStateTestData).Card.Number)
+
+	state, ok, err := t.store.Load(t.context, t.request.Session(), formStateTestIdentifier)
+	t.NoError(err)
+	t.True(ok)
+	t.JSONEq(`{
+		"Name": "Jane",
+		"Email": "",
+		"Password": "",
+		"Address": {"Street": "", "City": "Berlin"},
+		"Card": {"Holder": "Jane Doe", "Number": ""},
+		"Avatar": null
+	}`, string(state))
+}
+
+func (t *FormStateTestSuite) TestHandleUnsubmittedForm_Restore() {
+	t.submit(formStateTestData{
+		Name:     "Jane",
+		Password: "secret",
+		Address: formStateTestAddress{
+			City: "Berlin",
+		},
+		Card: &formStateTestCard{
+			Holder: "Jane Doe",
+			Number: "4111111111111111",
+		},
+	}, t.getInvalidInfo())
+
+	form := t.present(formStateTestData{
+		Email: "jane@example.com",
+		Address: formStateTestAddress{
+			Street: "Main Street",
+		},
+	})
+
+	t.False(form.IsSubmitted())
+	t.True(form.IsValid())
+	t.Equal(formStateTestData{
+		Name:  "Jane",
+		Email: "jane@example.com",
+		Address: formStateTestAddress{
+			Street: "Main Street",
+			City:   "Berlin",
+		},
+		Card: &formStateTestCard{
+			Holder: "Jane Doe",
+		},
+	}, form.Data)
+
+	// form state is restored until form is submitted successfully
+	form = t.present(formStateTestData{})
+	t.Equal("Jane", form.Data.(formStateTestData).Name)
+}
+
+func (t *FormStateTestSuite) TestHandleUnsubmittedForm_PrefillProviderWins() {
+	prefillProvider := &mocks.PrefillProvider{}
+	prefillProvider.On("Prefill", t.context, t.request, mock.Anything).Return(map[string]interface{}{
+		"email": "jane@example.com",
+		"address": map[string]interface{}{
+			"city": "Hamburg",
+		},
+	}, nil).Once()
+	t.handler.prefillProviders = []domain.PrefillProvider{prefillProvider}
+
+	t.NoError(t.store.Save(t.context, t.request.Session(), formStateTestIdentifier, []byte(`{
+		"Name": "Jane",
+		"Email": "jane.doe@example.com",
+		"Address": {"City": "Berlin"}
+	}`)))
+
+	form := t.present(formStateTestData{})
+	t.Equal(formStateTestData{
+		Name:  "Jane",
+		Email: "jane@example.com",
+		Address: formStateTestAddress{
+			City: "Hamburg",
+		},
+	}, form.Data)
+
+	prefillProvider.AssertExpectations(t.T())
+}
+
+func (t *FormStateTestSuite) TestHandleSubmittedForm_DeleteOnSuccess() {
+	t.submit(formStateTestData{Name: "Jane"}, t.getInvalidInfo())
+
+	_, ok, err := t.store.Load(t.context, t.request.Session(), formStateTestIdentifier)
+	t.NoError(err)
+	t.True(ok)
+
+	form := t.submit(formStateTestData{Name: "Jane", Email: "jane@example.com"}, domain.ValidationInfo{})
+	t.True(form.IsValidAndSubmitted())
+
+	_, ok, err = t.store.Load(t.context, t.request.Session(), formStateTestIdentifier)
+	t.NoError(err)
+	t.False(ok)
+
+	form = t.present(formStateTestData{})
+	t.Equal(formStateTestData{}, form.Data)
+}
+
+func (t *FormStateTestSuite) TestHandleUnsubmittedForm_InvalidState() {
+	t.NoError(t.store.Save(t.context, t.request.Session(), formStateTestIdentifier, []byte(`{"Name": 42}`)))
+
+	form := t.present(formStateTestData{Email: "jane@example.com"})
+	t.Equal(formStateTestData{Email: "jane@example.com"}, form.Data)
+
+	// form state which doesn't fit form data is deleted
+	_, ok, err := t.store.Load(t.context, t.request.Session(), formStateTestIdentifier)
+	t.NoError(err)
+	t.False(ok)
+}
+
+func (t *FormStateTestSuite) TestHandleSubmittedForm_StoreError() {
+	store := &mocks.FormStateStore{}
+	store.On("Save", t.context, t.request.Session(), formStateTestIdentifier, mock.Anything).Return(errors.New("store is not available")).Once()
+	t.handler.stateStore = store
+
+	form := t.submit(formStateTestData{Name: "Jane"}, t.getInvalidInfo())
+	t.NotNil(form)
+	t.False(form.IsValid())
+
+	store.AssertExpectations(t.T())
+}
+
+func (t *FormStateTestSuite) TestGetStateIdentifier() {
+	t.Equal(formStateTestIdentifier, t.handler.getStateIdentifier(formStateTestData{}))
+	t.Equal(formStateTestIdentifier, t.handler.getStateIdentifier(&formStateTestData{}))
+	t.Equal("map[string]string", t.handler.getStateIdentifier(map[string]string{}))
+
+	t.handler.namespace = "profile"
+	t.Equal(formStateTestIdentifier+"#profile", t.handler.getStateIdentifier(&formStateTestData{}))
+}
+
+func (t *FormStateTestSuite) TestGetPersistedData_Map() {
+	formData := map[string]string{"name": "Jane"}
+	t.Equal(formData, t.handler.getPersistedData(formData))
+}
+
+func (t *FormStateTestSuite) TestGetPersistedData_Collections() {
+	shared := &formStateTestCard{Holder: "John Doe", Number: "5500000000000004"}
+	formData := formStateTestWallet{
+		Cards: []formStateTestCard{
+			{Holder: "Jane Doe", Number: "4111111111111111"},
+		},
+		Backup: [1]formStateTestCard{
+			{Holder: "Jane Doe", Number: "4012888888881881"},
+		},
+		Accounts: map[string]formStateTestCard{
+			"main": {Holder: "Jane Doe", Number: "DE89370400440532013000"},
+		},
+		Shared: map[string]*formStateTestCard{
+			"john": shared,
+		},
+		Tags: []string{"private"},
+		Files: map[string]*multipart.FileHeader{
+			"avatar": {Filename: "avatar.png"},
+		},
+	}
+
+	state, err := json.Marshal(t.handler.getPersistedData(formData))
+	t.NoError(err)
+	t.JSONEq(`{
+		"Cards": [{"Holder": "Jane Doe", "Number": ""}],
+		"Backup": [{"Holder": "Jane Doe", "Number": ""}],
+		"Accounts": {"main": {"Holder": "Jane Doe", "Number": ""}},
+		"Shared": {"john": {"Holder": "John Doe", "Number": ""}},
+		"Tags": ["private"],
+		"Files": {"avatar": null}
+	}`, string(state))
+
+	// slices and maps of form data are copied, so form data of the form keeps all values
+	t.Equal("4111111111111111", formData.Cards[0].Number)
+	t.Equal("4012888888881881", formData.Backup[0].Number)
+	t.Equal("DE89370400440532013000", formData.Accounts["main"].Number)
+	t.Equal("5500000000000004", shared.Number)
+}
@@ -0,0 +1,351 @@
+package schema
+
+import (
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"flamingo.me/form/domain"
+)
+
+type (
+	// Generator represents generator of JSON Schema documents for form data types. Field names are taken from "form" tags,
+	// and validation tags are translated into JSON Schema keywords, while all tags which can't be translated (like custom
+	// validators) are listed under "x-validators" extension, so clients still know about them.
+	Generator struct {
+		ruleTranslators map[string]domain.ValidationRuleTranslator
+	}
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	fileHeaderType = reflect.TypeOf(multipart.FileHeader{})
+
+	// formats contains JSON Schema formats of validation tags which validate well known formats
+	formats = map[string]string{
+		"email":    "email",
+		"url":      "uri",
+		"uri":      "uri",
+		"uuid":     "uuid",
+		"uuid4":    "uuid",
+		"ipv4":     "ipv4",
+		"ipv6":     "ipv6",
+		"hostname": "hostname",
+	}
+
+	// runeLimits contains length tags of strings which are equivalent to tags of rune validators,
+	// since JSON Schema counts length of strings in characters as well
+	runeLimits = map[string]string{
+		"minrunes": "min",
+		"maxrunes": "max",
+		"runelen":  "len",
+	}
+
+	// paramReplacer replaces escaped characters in validation parameters, in the same way as validator does
+	paramReplacer = strings.NewReplacer("0x2C", ",", "0x7C", "|")
+)
+
+const (
+	// isoDateFormat as date format which matches "date" format of JSON Schema
+	isoDateFormat = "2006-01-02"
+)
+
+// NewGenerator creates generator with validation rule translators of field validators (like regex validators),
+// keyed by their validation tags
+func NewGenerator(ruleTranslators map[string]domain.ValidationRuleTranslator) *Generator {
+	return &Generator{
+		ruleTranslators: ruleTranslators,
+	}
+}
+
+// Generate returns JSON Schema document of form data type, which has to be struct or map. Nested structs, pointers,
+// slices and maps are described as nested schemas, and fields of anonymous embedded structs are described as fields
+// of parent struct, same as they are decoded. Recursive types are described only once per branch.
+func (g *Generator) Generate(formData interface{}) (*Schema, error) {
+	if formData == nil {
+		return nil, domain.NewFormError("form data is not defined, so its schema can't be generated")
+	}
+
+	typeOf := reflect.TypeOf(formData)
+	document := g.getTypeSchema(typeOf, map[reflect.Type]bool{})
+	if document == nil || document.Type != typeObject {
+		return nil, domain.NewFormErrorf("schema can't be generated for form data of type %s", typeOf)
+	}
+
+	document.Schema = Draft
+	document.Title = g.indirectType(typeOf).Name()
+
+	return document, nil
+}
+
+// getTypeSchema returns schema of type, without validation rules. It returns nil for types which can't be submitted (like functions).
+func (g *Generator) getTypeSchema(typeOf reflect.Type, visited map[reflect.Type]bool) *Schema {
+	typeOf = g.indirectType(typeOf)
+
+	switch typeOf {
+	case timeType:
+		return &Schema{Type: typeString, Format: "date-time"}
+	case fileHeaderType:
+		return &Schema{Type: typeString, Format: "binary"}
+	}
+
+	switch typeOf.Kind() {
+	case reflect.String:
+		return &Schema{Type: typeString}
+	case reflect.Bool:
+		return &Schema{Type: typeBoolean}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: typeInteger}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: typeNumber}
+	case reflect.Interface:
+		return &Schema{}
+	case reflect.Slice, reflect.Array:
+		if items := g.getTypeSchema(typeOf.Elem(), visited); items != nil {
+			return &Schema{Type: typeArray, Items: items}
+		}
+	case reflect.Map:
+		if values := g.getTypeSchema(typeOf.Elem(), visited); values != nil {
+			return &Schema{Type: typeObject, AdditionalProperties: values}
+		}
+	case reflect.Struct:
+		if visited[typeOf] {
+			return &Schema{Type: typeObject}
+		}
+		visited[typeOf] = true
+		defer delete(visited, typeOf)
+
+		schema := &Schema{
+			Type:       typeObject,
+			Properties: map[string]*Schema{},
+		}
+		g.addProperties(schema, typeOf, visited)
+
+		return schema
+	}
+
+	return nil
+}
+
+// addProperties adds schemas of all exported struct fields into properties of schema, together with their validation rules.
+// Fields which are defined multiple times (like in embedded structs) are described by their first definition.
+func (g *Generator) addProperties(schema *Schema, typeOf reflect.Type, visited map[reflect.Type]bool) {
+	for i := 0; i < typeOf.NumField(); i++ {
+		field := typeOf.Field(i)
+
+		name := strings.Split(field.Tag.Get("form"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		if embedded := g.indirectType(field.Type); field.Anonymous && name == "" && embedded.Kind() == reflect.Struct && embedded != timeType {
+			if !visited[embedded] {
+				visited[embedded] = true
+				g.addProperties(schema, embedded, visited)
+				delete(visited, embedded)
+			}
+			continue
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		if _, ok := schema.Properties[name]; ok {
+			continue
+		}
+
+		property := g.getTypeSchema(field.Type, visited)
+		if property == nil {
+			continue
+		}
+
+		if g.applyValidationTags(property, strings.Split(field.Tag.Get("validate"), ",")) {
+			schema.Required = append(schema.Required, name)
+		}
+		schema.Properties[name] = property
+	}
+}
+
+// applyValidationTags translates validation tags into keywords of schema, and returns true if field is required.
+// Tags defined after "dive" are applied to elements of slices and maps, where keys of maps are not described.
+func (g *Generator) applyValidationTags(schema *Schema, tags []string) bool {
+	required := false
+
+	for i, tag := range tags {
+		name, value, _ := strings.Cut(tag, "=")
+		value = paramReplacer.Replace(value)
+
+		switch {
+		case name == "" || name == "omitempty":
+		case name == "dive":
+			g.applyElementValidationTags(schema, tags[i+1:])
+			return required
+		case name == "required":
+			required = true
+		case strings.Contains(tag, "|"):
+			// alternatives (like "email|url") can't be expressed by single keyword
+			schema.addValidator(paramReplacer.Replace(tag), "")
+		default:
+			g.applyValidationTag(schema, name, value)
+		}
+	}
+
+	return required
+}
+
+// applyElementValidationTags applies validation tags to schema of elements of slice or map. Required elements
+// can't be expressed by JSON Schema, so they are listed under "x-validators" extension.
+func (g *Generator) applyElementValidationTags(schema *Schema, tags []string) {
+	element := schema.Items
+	if schema.Type == typeObject {
+		element = schema.AdditionalProperties
+	}
+	if element == nil {
+		return
+	}
+
+	// validation tags of map keys are defined between "keys" and "endkeys"
+	if len(tags) > 0 && tags[0] == "keys" {
+		for i, tag := range tags {
+			if tag == "endkeys" {
+				tags = tags[i+1:]
+				break
+			}
+		}
+	}
+
+	if g.applyValidationTags(element, tags) {
+		element.addValidator("required", "")
+	}
+}
+
+// applyValidationTag translates single validation tag into keyword of schema. Tags of field validators which define
+// translation of their validation rule (like regex validators) are translated first, so they can override built-in tags.
+func (g *Generator) applyValidationTag(schema *Schema, name string, value string) {
+	if translator, ok := g.ruleTranslators[name]; ok {
+		g.applyValidationRule(schema, translator.TranslateValidationRule(value))
+		return
+	}
+
+	switch name {
+	case "dateformat":
+		schema.Format = "date"
+		return
+	case "oneof", "eachoneof":
+		schema.SetEnum(strings.Fields(value))
+		return
+	}
+
+	if format, ok := formats[name]; ok {
+		schema.Format = format
+		return
+	}
+
+	limit, ok := runeLimits[name]
+	if !ok {
+		limit = name
+	}
+
+	if (ok && schema.Type != typeString) || !g.applyLimit(schema, limit, value) {
+		schema.addValidator(name, value)
+	}
+}
+
+// applyValidationRule applies validation rule translated by field validator. Regex patterns are exposed as pattern,
+// and date format of the application is exposed as "date" format, if it's the same one.
+func (g *Generator) applyValidationRule(schema *Schema, rule domain.ValidationRule) {
+	switch {
+	case rule.Name == "pattern" && schema.Pattern == "":
+		schema.Pattern = rule.Value
+	case rule.Name == "dateformat" && (rule.Value == isoDateFormat || rule.Value == ""):
+		schema.Format = "date"
+	default:
+		schema.addValidator(rule.Name, rule.Value)
+	}
+}
+
+// applyLimit translates "min", "max", "len", "gt", "gte", "lt" and "lte" tags into limits of numbers, or into
+// limits of length of strings, number of elements of slices, and number of entries of maps. It returns false
+// if tag can't be translated for field type, or if its parameter is not a number (like "gt" for dates).
+func (g *Generator) applyLimit(schema *Schema, name string, value string) bool {
+	switch name {
+	case "min", "max", "len", "gt", "gte", "lt", "lte":
+	default:
+		return false
+	}
+
+	if schema.Type == typeInteger || schema.Type == typeNumber {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false
+		}
+
+		switch name {
+		case "min", "gte":
+			schema.Minimum = &number
+		case "max", "lte":
+			schema.Maximum = &number
+		case "gt":
+			schema.ExclusiveMinimum = &number
+		case "lt":
+			schema.ExclusiveMaximum = &number
+		case "len":
+			schema.Minimum, schema.Maximum = &number, &number
+		}
+
+		return true
+	}
+
+	minimum, maximum, ok := g.getCountLimits(schema)
+	count, err := strconv.Atoi(value)
+	if !ok || err != nil {
+		return false
+	}
+
+	switch name {
+	case "min", "gte":
+		*minimum = &count
+	case "gt":
+		count++
+		*minimum = &count
+	case "max", "lte":
+		*maximum = &count
+	case "lt":
+		count--
+		*maximum = &count
+	case "len":
+		*minimum, *maximum = &count, &count
+	}
+
+	return true
+}
+
+// getCountLimits returns keywords which limit length of strings, number of elements of slices, or number of entries of maps.
+// Dates and files are presented as strings, but their limits are not related to length, so they don't have such keywords.
+func (g *Generator) getCountLimits(schema *Schema) (**int, **int, bool) {
+	switch {
+	case schema.Type == typeString && schema.Format != "date-time" && schema.Format != "binary":
+		return &schema.MinLength, &schema.MaxLength, true
+	case schema.Type == typeArray:
+		return &schema.MinItems, &schema.MaxItems, true
+	case schema.Type == typeObject && schema.AdditionalProperties != nil:
+		return &schema.MinProperties, &schema.MaxProperties, true
+	}
+
+	return nil, nil, false
+}
+
+// indirectType returns element type in case of pointer type
+func (g *Generator) indirectType(typeOf reflect.Type) reflect.Type {
+	for typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	return typeOf
+}
@@ -0,0 +1,198 @@
+package schema
+
+import (
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"mime/multipart"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/validators"
+)
+
+type (
+	GeneratorTestSuite struct {
+		suite.Suite
+
+		generator *Generator
+	}
+
+	generatorCheckoutTestData struct {
+		Email      string                     `form:"email" validate:"required,email"`
+		Name       string                     `form:"name" validate:"required,min=2,max=50"`
+		Age        int                        `form:"age" validate:"omitempty,gte=18,lt=130"`
+		Birthday   string                     `form:"birthday" validate:"dateformat"`
+		Phone      string                     `form:"phone" validate:"regex=^[0-9 ]+$"`
+		Zip        string                     `form:"zip" validate:"required,regex_zip"`
+		Newsletter bool                       `form:"newsletter"`
+		Shipping   string                     `form:"shipping" validate:"oneof=standard express"`
+		Contact    string                     `form:"contact" validate:"email|url"`
+		Nickname   string                     `form:"nickname" validate:"nickname,maxrunes=20"`
+		Discount   *float64                   `form:"discount" validate:"omitempty,gt=0,lte=100"`
+		Delivery   time.Time                  `form:"delivery" validate:"gt"`
+		Avatar     *multipart.FileHeader      `form:"avatar" validate:"maxfilesize=2MB"`
+		Address    generatorAddressTestData   `form:"address" validate:"required"`
+		Items      []generatorItemTestData    `form:"items" validate:"min=1,dive"`
+		Tags       []string                   `form:"tags" validate:"max=5,dive,required,min=2"`
+		Sizes      []int                      `form:"sizes" validate:"eachoneof=36 38 40"`
+		Attributes map[string]string          `form:"attributes" validate:"max=10,dive,keys,min=1,endkeys,max=20"`
+		Reference  *generatorCheckoutTestData `form:"reference"`
+		Internal   string                     `form:"-"`
+		Handler    func()                     `form:"handler"`
+		internal   string
+		generatorContactTestData
+	}
+
+	generatorAddressTestData struct {
+		Street  string `form:"street" validate:"required"`
+		City    string `validate:"len=5"`
+		Country string `form:"country"`
+	}
+
+	generatorItemTestData struct {
+		Sku      string `form:"sku" validate:"required"`
+		Quantity uint   `form:"quantity" validate:"min=1"`
+	}
+
+	generatorContactTestData struct {
+		Fax   string `form:"fax"`
+		Email string `form:"email" validate:"email"`
+	}
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGeneratorTestSuite(t *testing.T) {
+	suite.Run(t, &GeneratorTestSuite{})
+}
+
+func (t *GeneratorTestSuite) SetupTest() {
+	dateFormatValidator := &validators.DateFormatValidator{}
+	dateFormatValidator.Inject(&struct {
+		DateFormat string `inject:"config:form.validator.dateFormat"`
+	}{
+		DateFormat: "2006-01-02",
+	})
+
+	t.generator = NewGenerator(map[string]domain.ValidationRuleTranslator{
+		"dateformat": dateFormatValidator,
+		"regex":      &validators.RegexPatternValidator{},
+		"regex_zip":  validators.NewRegexValidator("regex_zip", "^[0-9]{5}$"),
+	})
+}
+
+func (t *GeneratorTestSuite) TestGenerate_Golden() {
+	document, err := t.generator.Generate(generatorCheckoutTestData{})
+	t.NoError(err)
+
+	result, err := json.MarshalIndent(document, "", "  ")
+	t.NoError(err)
+
+	golden := filepath.Join("testdata", "checkout.json")
+	if *update {
+		t.NoError(ioutil.WriteFile(golden, append(result, '\n'), 0644))
+	}
+
+	expected, err := ioutil.ReadFile(golden)
+	t.NoError(err)
+	t.JSONEq(string(expected), string(result))
+
+	// generated document is described completely by Schema, so it's not changed by decoding and encoding again
+	decoded := &Schema{}
+	t.NoError(json.Unmarshal(expected, decoded))
+
+	encoded, err := json.MarshalIndent(decoded, "", "  ")
+	t.NoError(err)
+	t.JSONEq(string(expected), string(encoded))
+}
+
+func (t *GeneratorTestSuite) TestGenerate_Pointer() {
+	document, err := t.generator.Generate(&generatorAddressTestData{})
+	t.NoError(err)
+	t.Equal("generatorAddressTestData", document.Title)
+	t.Equal([]string{"street"}, document.Required)
+	t.Equal(document.Properties["City"].MinLength, document.Properties["City"].MaxLength)
+}
+
+func (t *GeneratorTestSuite) TestGenerate_Map() {
+	document, err := NewGenerator(nil).Generate(map[string]string{})
+	t.NoError(err)
+	t.Equal(&Schema{
+		Schema: Draft,
+		Type:   "object",
+		AdditionalProperties: &Schema{
+			Type: "string",
+		},
+	}, document)
+}
+
+func (t *GeneratorTestSuite) TestGenerate_WithoutTranslators() {
+	document, err := NewGenerator(nil).Generate(generatorCheckoutTestData{})
+	t.NoError(err)
+	t.Equal("date", document.Properties["birthday"].Format)
+	t.Equal([]Validator{{Name: "regex_zip"}}, document.Properties["zip"].Validators)
+	t.Equal([]Validator{{Name: "regex", Value: "^[0-9 ]+$"}}, document.Properties["phone"].Validators)
+}
+
+func (t *GeneratorTestSuite) TestGenerate_CustomDateFormat() {
+	dateFormatValidator := &validators.DateFormatValidator{}
+	dateFormatValidator.Inject(&struct {
+		DateFormat string `inject:"config:form.validator.dateFormat"`
+	}{
+		DateFormat: "02.01.2006",
+	})
+
+	document, err := NewGenerator(map[string]domain.ValidationRuleTranslator{
+		"dateformat": dateFormatValidator,
+	}).Generate(generatorCheckoutTestData{})
+	t.NoError(err)
+
+	// "date" format of JSON Schema is always ISO 8601 date, so other date formats are exposed as they are
+	t.Empty(document.Properties["birthday"].Format)
+	t.Equal([]Validator{{Name: "dateformat", Value: "02.01.2006"}}, document.Properties["birthday"].Validators)
+}
+
+func (t *GeneratorTestSuite) TestGenerate_Errors() {
+	_, err := t.generator.Generate(nil)
+	t.Error(err)
+
+	_, err = t.generator.Generate("email")
+	t.Error(err)
+
+	_, err = t.generator.Generate([]generatorItemTestData{})
+	t.Error(err)
+}
+
+func (t *GeneratorTestSuite) TestProperty() {
+	document, err := t.generator.Generate(generatorCheckoutTestData{})
+	t.NoError(err)
+
+	t.True(document.Properties["address"].Properties["street"] == document.Property("address.street"))
+	t.True(document.Properties["items"].Items.Properties["sku"] == document.Property("items.sku"))
+	t.True(document.Properties["fax"] == document.Property("fax"))
+	t.Nil(document.Property("address.unknown"))
+	t.Nil(document.Property("email.unknown"))
+}
+
+func (t *GeneratorTestSuite) TestSetEnum() {
+	document, err := t.generator.Generate(generatorCheckoutTestData{})
+	t.NoError(err)
+
+	document.Property("address.country").SetEnum([]string{"DE", "AT"})
+	t.Equal([]interface{}{"DE", "AT"}, document.Properties["address"].Properties["country"].Enum)
+
+	// values are converted into type of elements, and invalid values are ignored
+	document.Property("sizes").SetEnum([]string{"36", "XL", "42"})
+	t.Equal([]interface{}{int64(36), int64(42)}, document.Properties["sizes"].Items.Enum)
+
+	document.Property("newsletter").SetEnum([]string{"true"})
+	t.Equal([]interface{}{true}, document.Properties["newsletter"].Enum)
+
+	document.Property("discount").SetEnum([]string{"0.5"})
+	t.Equal([]interface{}{0.5}, document.Properties["discount"].Enum)
+}
@@ -0,0 +1,109 @@
+package schema
+
+import (
+	"strconv"
+	"strings"
+)
+
+type (
+	// Schema represents JSON Schema of form data, or of single form field inside it
+	Schema struct {
+		Schema               string             `json:"$schema,omitempty"`
+		Title                string             `json:"title,omitempty"`
+		Type                 string             `json:"type,omitempty"`
+		Format               string             `json:"format,omitempty"`
+		Pattern              string             `json:"pattern,omitempty"`
+		Enum                 []interface{}      `json:"enum,omitempty"`
+		Minimum              *float64           `json:"minimum,omitempty"`
+		Maximum              *float64           `json:"maximum,omitempty"`
+		ExclusiveMinimum     *float64           `json:"exclusiveMinimum,omitempty"`
+		ExclusiveMaximum     *float64           `json:"exclusiveMaximum,omitempty"`
+		MinLength            *int               `json:"minLength,omitempty"`
+		MaxLength            *int               `json:"maxLength,omitempty"`
+		MinItems             *int               `json:"minItems,omitempty"`
+		MaxItems             *int               `json:"maxItems,omitempty"`
+		MinProperties        *int               `json:"minProperties,omitempty"`
+		MaxProperties        *int               `json:"maxProperties,omitempty"`
+		Properties           map[string]*Schema `json:"properties,omitempty"`
+		Required             []string           `json:"required,omitempty"`
+		Items                *Schema            `json:"items,omitempty"`
+		AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+		// Validators contains validation rules which can't be expressed by JSON Schema keywords (like custom validators)
+		Validators []Validator `json:"x-validators,omitempty"`
+	}
+
+	// Validator represents validation rule of "x-validators" extension, with name and parameter of its validation tag
+	Validator struct {
+		Name  string `json:"name"`
+		Value string `json:"value,omitempty"`
+	}
+)
+
+const (
+	// Draft as JSON Schema dialect used for generated documents
+	Draft = "https://json-schema.org/draft/2020-12/schema"
+
+	typeString  = "string"
+	typeBoolean = "boolean"
+	typeInteger = "integer"
+	typeNumber  = "number"
+	typeArray   = "array"
+	typeObject  = "object"
+)
+
+// Property returns schema of nested field by its form field name (like "address.street"), where elements of slices
+// are followed without index (like "items.sku"). It returns nil if there is no such field.
+func (s *Schema) Property(path string) *Schema {
+	current := s
+	for _, name := range strings.Split(path, ".") {
+		for current != nil && current.Type == typeArray {
+			current = current.Items
+		}
+		if current == nil {
+			return nil
+		}
+
+		current = current.Properties[name]
+	}
+
+	return current
+}
+
+// SetEnum sets allowed values of field, converted into type of field (like numbers for integer fields). For arrays,
+// allowed values are set for their elements. Values which can't be converted are ignored.
+func (s *Schema) SetEnum(values []string) {
+	target := s
+	for target.Type == typeArray && target.Items != nil {
+		target = target.Items
+	}
+
+	enum := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		switch target.Type {
+		case typeInteger:
+			if number, err := strconv.ParseInt(value, 10, 64); err == nil {
+				enum = append(enum, number)
+			}
+		case typeNumber:
+			if number, err := strconv.ParseFloat(value, 64); err == nil {
+				enum = append(enum, number)
+			}
+		case typeBoolean:
+			if boolean, err := strconv.ParseBool(value); err == nil {
+				enum = append(enum, boolean)
+			}
+		default:
+			enum = append(enum, value)
+		}
+	}
+
+	target.Enum = enum
+}
+
+// addValidator adds validation rule into "x-validators" extension
+func (s *Schema) addValidator(name string, value string) {
+	s.Validators = append(s.Validators, Validator{
+		Name:  name,
+		Value: value,
+	})
+}
@@ -0,0 +1,272 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	// SubmissionGuard as interface for protecting forms against double submission. Each rendered form gets one-time
+	// submission ID, which is stored in session, and which can be consumed only once.
+	SubmissionGuard interface {
+		// FieldName returns name of hidden field which contains submission ID
+		FieldName() string
+		// Generate creates new submission ID for form identifier, and stores it into session
+		Generate(ctx context.Context, session *web.Session, formIdentifier string) (string, error)
+		// Consume removes submission ID of form identifier from session, so it can't be used again
+		Consume(ctx context.Context, session *web.Session, formIdentifier string, submissionID string) SubmissionStatus
+	}
+
+	// SubmissionStatus as result of consuming submission ID
+	SubmissionStatus int
+
+	// SubmissionGuardImpl as actual implementation of SubmissionGuard interface, which stores issued submission IDs
+	// as JSON in session. Consumed IDs are kept in the same session value as markers until they expire, so duplicate
+	// submission is recognized by any instance of application, and after restart as well. Issued IDs and consumed
+	// markers are both limited to maximum number of IDs per form. Since parallel requests of the same session get
+	// their own session instances, accepted IDs are additionally remembered in memory per session ID and form
+	// identifier until they expire, and consumption is guarded with mutex, so the same ID can be accepted only once
+	// by one instance of application.
+	SubmissionGuardImpl struct {
+		ttl       time.Duration
+		maxIDs    int
+		fieldName string
+		now       func() time.Time
+		mutex     sync.Mutex
+		accepted  map[acceptedSubmissionID]time.Time
+	}
+
+	// acceptedSubmissionID as key of submission ID accepted in running instance of application
+	acceptedSubmissionID struct {
+		sessionID      string
+		formIdentifier string
+		submissionID   string
+	}
+
+	// submissionIDState as struct which defines JSON representation of submission IDs of form stored in session
+	submissionIDState struct {
+		Issued   []submissionIDEntry `json:"issued,omitempty"`
+		Consumed []submissionIDEntry `json:"consumed,omitempty"`
+	}
+
+	// submissionIDEntry as struct which defines JSON representation of single submission ID stored in session
+	submissionIDEntry struct {
+		ID        string    `json:"id"`
+		ExpiresAt time.Time `json:"expiresAt"`
+	}
+)
+
+const (
+	// SubmissionAccepted as status of submission ID which is issued and not expired, so it's consumed now
+	SubmissionAccepted SubmissionStatus = iota
+	// SubmissionDuplicate as status of submission ID which is already consumed by previous submission
+	SubmissionDuplicate
+	// SubmissionExpired as status of submission ID which is missing, unknown, or expired
+	SubmissionExpired
+)
+
+const (
+	// submissionGuardKeyPrefix as prefix of session keys which contain issued submission IDs
+	submissionGuardKeyPrefix = "flamingo.form.submission."
+	// submissionIDLength as number of random bytes used for submission ID
+	submissionIDLength = 16
+)
+
+var _ SubmissionGuard = &SubmissionGuardImpl{}
+
+// Inject is method used to set all dependencies as local variables
+func (s *SubmissionGuardImpl) Inject(cfg *struct {
+	TTL       string  `inject:"config:form.submissionGuard.ttl"`
+	MaxIDs    float64 `inject:"config:form.submissionGuard.maxIDs"`
+	FieldName string  `inject:"config:form.submissionGuard.fieldName"`
+}) {
+	ttl, err := time.ParseDuration(cfg.TTL)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	s.ttl = ttl
+	s.maxIDs = int(cfg.MaxIDs)
+	s.fieldName = cfg.FieldName
+	s.now = time.Now
+}
+
+// FieldName returns name of hidden field which contains submission ID
+func (s *SubmissionGuardImpl) FieldName() string {
+	return s.fieldName
+}
+
+// Generate creates new random submission ID for form identifier, and stores it into session. Expired IDs are removed,
+// and in case when session already contains maximum number of IDs for the form, the oldest ones are dropped.
+func (s *SubmissionGuardImpl) Generate(_ context.Context, session *web.Session, formIdentifier string) (string, error) {
+	if session == nil {
+		return "", domain.NewFormError("submission ID can't be generated without session")
+	}
+
+	random := make([]byte, submissionIDLength)
+	if _, err := rand.Read(random); err != nil {
+		return "", domain.NewWrappedFormError(err)
+	}
+	submissionID := hex.EncodeToString(random)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.getNow()
+	state := s.loadState(session, formIdentifier, now)
+	state.Issued = s.limitEntries(append(state.Issued, submissionIDEntry{
+		ID:        submissionID,
+		ExpiresAt: now.Add(s.ttl),
+	}))
+
+	if err := s.storeState(session, formIdentifier, state); err != nil {
+		return "", err
+	}
+
+	return submissionID, nil
+}
+
+// Consume removes submission ID of form identifier from issued ones in session, and stores it as consumed marker.
+// It returns SubmissionAccepted only once for each issued ID, SubmissionDuplicate for every following submission
+// with the same ID until it expires, and SubmissionExpired for IDs which are missing, unknown or expired.
+func (s *SubmissionGuardImpl) Consume(_ context.Context, session *web.Session, formIdentifier string, submissionID string) SubmissionStatus {
+	if session == nil || submissionID == "" {
+		return SubmissionExpired
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := s.getNow()
+	s.removeExpiredAccepted(now)
+
+	key := acceptedSubmissionID{
+		sessionID:      session.ID(),
+		formIdentifier: formIdentifier,
+		submissionID:   submissionID,
+	}
+	if _, ok := s.accepted[key]; ok {
+		return SubmissionDuplicate
+	}
+
+	state := s.loadState(session, formIdentifier, now)
+	for _, entry := range state.Consumed {
+		if entry.ID == submissionID {
+			return SubmissionDuplicate
+		}
+	}
+
+	issued := make([]submissionIDEntry, 0, len(state.Issued))
+	var consumed *submissionIDEntry
+	for i := range state.Issued {
+		if state.Issued[i].ID == submissionID {
+			consumed = &state.Issued[i]
+			continue
+		}
+		issued = append(issued, state.Issued[i])
+	}
+
+	if consumed == nil {
+		return SubmissionExpired
+	}
+
+	state.Issued = issued
+	state.Consumed = s.limitEntries(append(state.Consumed, *consumed))
+
+	// session is expected to contain valid entries only, since they are stored by Generate
+	_ = s.storeState(session, formIdentifier, state)
+
+	if s.accepted == nil {
+		s.accepted = map[acceptedSubmissionID]time.Time{}
+	}
+	s.accepted[key] = consumed.ExpiresAt
+
+	return SubmissionAccepted
+}
+
+// removeExpiredAccepted removes submission IDs accepted in memory, which are already expired
+func (s *SubmissionGuardImpl) removeExpiredAccepted(now time.Time) {
+	for key, expiresAt := range s.accepted {
+		if !now.Before(expiresAt) {
+			delete(s.accepted, key)
+		}
+	}
+}
+
+// loadState returns issued and consumed submission IDs of form identifier from session, which are not expired
+func (s *SubmissionGuardImpl) loadState(session *web.Session, formIdentifier string, now time.Time) submissionIDState {
+	stored, ok := session.Load(submissionGuardKeyPrefix + formIdentifier)
+	if !ok {
+		return submissionIDState{}
+	}
+
+	encoded, ok := stored.(string)
+	if !ok {
+		return submissionIDState{}
+	}
+
+	var state submissionIDState
+	if err := json.Unmarshal([]byte(encoded), &state); err != nil {
+		return submissionIDState{}
+	}
+
+	state.Issued = s.removeExpired(state.Issued, now)
+	state.Consumed = s.removeExpired(state.Consumed, now)
+
+	return state
+}
+
+// storeState stores submission IDs of form identifier into session, or removes session key if there are none
+func (s *SubmissionGuardImpl) storeState(session *web.Session, formIdentifier string, state submissionIDState) error {
+	key := submissionGuardKeyPrefix + formIdentifier
+	if len(state.Issued) == 0 && len(state.Consumed) == 0 {
+		session.Delete(key)
+		return nil
+	}
+
+	encoded, err := json.Marshal(state)
+	if err != nil {
+		return domain.NewFormError(err.Error())
+	}
+
+	session.Store(key, string(encoded))
+
+	return nil
+}
+
+// removeExpired returns submission IDs which are not expired
+func (s *SubmissionGuardImpl) removeExpired(entries []submissionIDEntry, now time.Time) []submissionIDEntry {
+	valid := entries[:0]
+	for _, entry := range entries {
+		if now.Before(entry.ExpiresAt) {
+			valid = append(valid, entry)
+		}
+	}
+
+	return valid
+}
+
+// limitEntries returns only the newest submission IDs, if there are more of them than maximum number of IDs
+func (s *SubmissionGuardImpl) limitEntries(entries []submissionIDEntry) []submissionIDEntry {
+	if s.maxIDs > 0 && len(entries) > s.maxIDs {
+		return entries[len(entries)-s.maxIDs:]
+	}
+
+	return entries
+}
+
+// getNow returns current time, by using injected clock if it's defined
+func (s *SubmissionGuardImpl) getNow() time.Time {
+	if s.now == nil {
+		return time.Now()
+	}
+
+	return s.now()
+}
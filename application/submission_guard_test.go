@@ -0,0 +1,259 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	SubmissionGuardTestSuite struct {
+		suite.Suite
+
+		guard *SubmissionGuardImpl
+
+		now     time.Time
+		context context.Context
+		session *web.Session
+	}
+)
+
+func TestSubmissionGuardTestSuite(t *testing.T) {
+	suite.Run(t, &SubmissionGuardTestSuite{})
+}
+
+func (t *SubmissionGuardTestSuite) SetupTest() {
+	t.guard = &SubmissionGuardImpl{}
+	t.guard.Inject(&struct {
+		TTL       string  `inject:"config:form.submissionGuard.ttl"`
+		MaxIDs    float64 `inject:"config:form.submissionGuard.maxIDs"`
+		FieldName string  `inject:"config:form.submissionGuard.fieldName"`
+	}{
+		TTL:       "10m",
+		MaxIDs:    3,
+		FieldName: "_submissionId",
+	})
+
+	t.now = time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	t.guard.now = func() time.Time {
+		return t.now
+	}
+
+	t.context = context.Background()
+	t.session = web.EmptySession()
+}
+
+func (t *SubmissionGuardTestSuite) TestInject_InvalidTTL() {
+	t.Panics(func() {
+		(&SubmissionGuardImpl{}).Inject(&struct {
+			TTL       string  `inject:"config:form.submissionGuard.ttl"`
+			MaxIDs    float64 `inject:"config:form.submissionGuard.maxIDs"`
+			FieldName string  `inject:"config:form.submissionGuard.fieldName"`
+		}{
+			TTL: "later",
+		})
+	})
+}
+
+func (t *SubmissionGuardTestSuite) TestFieldName() {
+	t.Equal("_submissionId", t.guard.FieldName())
+}
+
+func (t *SubmissionGuardTestSuite) TestGenerate_WithoutSession() {
+	_, err := t.guard.Generate(t.context, nil, "order")
+	t.Error(err)
+}
+
+func (t *SubmissionGuardTestSuite) TestGenerate_UniqueIDs() {
+	first, err := t.guard.Generate(t.context, t.session, "order")
+	t.NoError(err)
+	t.Len(first, 2*submissionIDLength)
+
+	second, err := t.guard.Generate(t.context, t.session, "order")
+	t.NoError(err)
+	t.NotEqual(first, second)
+}
+
+func (t *SubmissionGuardTestSuite) TestConsume_Accepted() {
+	submissionID, err := t.guard.Generate(t.context, t.session, "order")
+	t.NoError(err)
+
+	t.Equal(SubmissionAccepted, t.guard.Consume(t.context, t.session, "order", submissionID))
+
+	state := t.guard.loadState(t.session, "order", t.now)
+	t.Empty(state.Issued)
+	t.Equal([]submissionIDEntry{
+		{ID: submissionID, ExpiresAt: t.now.Add(10 * time.Minute)},
+	}, state.Consumed)
+}
+
+func (t *SubmissionGuardTestSuite) TestConsume_Duplicate() {
+	submissionID, err := t.guard.Generate(t.context, t.session, "order")
+	t.NoError(err)
+
+	t.Equal(SubmissionAccepted, t.guard.Consume(t.context, t.session, "order", submissionID))
+	t.Equal(SubmissionDuplicate, t.guard.Consume(t.context, t.session, "order", submissionID))
+
+	// consumed marker is part of session, so it's recognized by another instance (like after restart) as well
+	other := &SubmissionGuardImpl{
+		ttl:    10 * time.Minute,
+		maxIDs: 3,
+		now:    t.guard.now,
+	}
+	t.Equal(SubmissionDuplicate, other.Consume(t.context, t.session, "order", submissionID))
+}
+
+func (t *SubmissionGuardTestSuite) TestConsume_MaxConsumed() {
+	var submissionIDs []string
+	for i := 0; i < 4; i++ {
+		submissionID, err := t.guard.Generate(t.context, t.session, "order")
+		t.NoError(err)
+		t.Equal(SubmissionAccepted, t.guard.Consume(t.context, t.session, "order", submissionID))
+		submissionIDs = append(submissionIDs, submissionID)
+	}
+
+	// only configured number of consumed markers is kept, by dropping the oldest ones
+	state := t.guard.loadState(t.session, "order", t.now)
+	t.Empty(state.Issued)
+	t.Len(state.Consumed, 3)
+
+	// dropped marker is still remembered in memory by the same instance, but not by another one
+	other := &SubmissionGuardImpl{
+		ttl:    10 * time.Minute,
+		maxIDs: 3,
+		now:    t.guard.now,
+	}
+	t.Equal(SubmissionDuplicate, t.guard.Consume(t.context, t.session, "order", submissionIDs[0]))
+	t.Equal(SubmissionExpired, other.Consume(t.context, t.session, "order", submissionIDs[0]))
+	for _, submissionID := range submissionIDs[1:] {
+		t.Equal(SubmissionDuplicate, t.guard.Consume(t.context, t.session, "order", submissionID))
+	}
+}
+
+func (t *SubmissionGuardTestSuite) TestConsume_Expired() {
+	submissionID, err := t.guard.Generate(t.context, t.session, "order")
+	t.NoError(err)
+
+	t.now = t.now.Add(11 * time.Minute)
+
+	t.Equal(SubmissionExpired, t.guard.Consume(t.context, t.session, "order", submissionID))
+}
+
+func (t *SubmissionGuardTestSuite) TestConsume_DuplicateExpired() {
+	submissionID, err := t.guard.Generate(t.context, t.session, "order")
+	t.NoError(err)
+
+	t.Equal(SubmissionAccepted, t.guard.Consume(t.context, t.session, "order", submissionID))
+
+	t.now = t.now.Add(11 * time.Minute)
+
+	t.Equal(SubmissionExpired, t.guard.Consume(t.context, t.session, "order", submissionID))
+
+	// expired marker is no longer part of form state
+	state := t.guard.loadState(t.session, "order", t.now)
+	t.Empty(state.Issued)
+	t.Empty(state.Consumed)
+}
+
+func (t *SubmissionGuardTestSuite) TestConsume_Unknown() {
+	_, err := t.guard.Generate(t.context, t.session, "order")
+	t.NoError(err)
+
+	t.Equal(SubmissionExpired, t.guard.Consume(t.context, t.session, "order", ""))
+	t.Equal(SubmissionExpired, t.guard.Consume(t.context, t.session, "order", "unknown"))
+	t.Equal(SubmissionExpired, t.guard.Consume(t.context, nil, "order", "unknown"))
+}
+
+func (t *SubmissionGuardTestSuite) TestConsume_OtherFormIdentifier() {
+	submissionID, err := t.guard.Generate(t.context, t.session, "order")
+	t.NoError(err)
+
+	t.Equal(SubmissionExpired, t.guard.Consume(t.context, t.session, "address", submissionID))
+	t.Equal(SubmissionAccepted, t.guard.Consume(t.context, t.session, "order", submissionID))
+}
+
+func (t *SubmissionGuardTestSuite) TestGenerate_MaxIDs() {
+	var submissionIDs []string
+	for i := 0; i < 4; i++ {
+		submissionID, err := t.guard.Generate(t.context, t.session, "order")
+		t.NoError(err)
+		submissionIDs = append(submissionIDs, submissionID)
+	}
+
+	t.Equal(SubmissionExpired, t.guard.Consume(t.context, t.session, "order", submissionIDs[0]))
+	for _, submissionID := range submissionIDs[1:] {
+		t.Equal(SubmissionAccepted, t.guard.Consume(t.context, t.session, "order", submissionID))
+	}
+}
+
+func (t *SubmissionGuardTestSuite) TestConsume_Parallel() {
+	submissionID, err := t.guard.Generate(t.context, t.session, "order")
+	t.NoError(err)
+
+	var wg sync.WaitGroup
+	results := make(chan SubmissionStatus, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results <- t.guard.Consume(t.context, t.session, "order", submissionID)
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	accepted := 0
+	for result := range results {
+		if result == SubmissionAccepted {
+			accepted++
+			continue
+		}
+		t.Equal(SubmissionDuplicate, result)
+	}
+	t.Equal(1, accepted)
+}
+
+func (t *SubmissionGuardTestSuite) TestConsume_ParallelSessionInstances() {
+	submissionID, err := t.guard.Generate(t.context, t.session, "order")
+	t.NoError(err)
+
+	// each parallel request gets its own instance of the same session, loaded before any of them stores changes
+	stored, _ := t.session.Load(submissionGuardKeyPrefix + "order")
+	sessions := make([]*web.Session, 10)
+	for i := range sessions {
+		sessions[i] = web.EmptySession()
+		sessions[i].Store(submissionGuardKeyPrefix+"order", stored)
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan SubmissionStatus, len(sessions))
+	for _, session := range sessions {
+		wg.Add(1)
+		go func(session *web.Session) {
+			defer wg.Done()
+			results <- t.guard.Consume(t.context, session, "order", submissionID)
+		}(session)
+	}
+	wg.Wait()
+	close(results)
+
+	accepted := 0
+	for result := range results {
+		if result == SubmissionAccepted {
+			accepted++
+			continue
+		}
+		t.Equal(SubmissionDuplicate, result)
+	}
+	t.Equal(1, accepted)
+
+	// accepted ID is forgotten from memory when it expires
+	t.now = t.now.Add(11 * time.Minute)
+	t.Equal(SubmissionExpired, t.guard.Consume(t.context, sessions[0], "order", submissionID))
+	t.Empty(t.guard.accepted)
+}
@@ -0,0 +1,48 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	// DefaultSubmitDetector as default implementation of domain.SubmitDetector, which treats form as submitted
+	// in case of POST request, or in case when request contains marker parameter with true value (like "submitted=1").
+	// It allows GET forms, like search filters, which are not validated on first page load.
+	DefaultSubmitDetector struct {
+		markerName string
+	}
+)
+
+var _ domain.SubmitDetector = &DefaultSubmitDetector{}
+
+// NewDefaultSubmitDetector returns new instance of DefaultSubmitDetector, which checks also marker parameter with provided name.
+// In case when name is empty, only POST requests are treated as submissions.
+func NewDefaultSubmitDetector(markerName string) *DefaultSubmitDetector {
+	return &DefaultSubmitDetector{
+		markerName: markerName,
+	}
+}
+
+// WasSubmitted checks if request is POST request, or if its query contains marker parameter with true value
+func (d *DefaultSubmitDetector) WasSubmitted(_ context.Context, req *web.Request) bool {
+	if req == nil || req.Request() == nil {
+		return false
+	}
+
+	if req.Request().Method == http.MethodPost {
+		return true
+	}
+
+	if d.markerName == "" || req.Request().URL == nil {
+		return false
+	}
+
+	enabled, err := strconv.ParseBool(req.Request().URL.Query().Get(d.markerName))
+
+	return err == nil && enabled
+}
@@ -0,0 +1,59 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	DefaultSubmitDetectorTestSuite struct {
+		suite.Suite
+
+		detector *DefaultSubmitDetector
+
+		context context.Context
+	}
+)
+
+func TestDefaultSubmitDetectorTestSuite(t *testing.T) {
+	suite.Run(t, &DefaultSubmitDetectorTestSuite{})
+}
+
+func (t *DefaultSubmitDetectorTestSuite) SetupTest() {
+	t.detector = NewDefaultSubmitDetector("submitted")
+	t.context = context.Background()
+}
+
+func (t *DefaultSubmitDetectorTestSuite) TestWasSubmitted_Post() {
+	t.True(t.detector.WasSubmitted(t.context, t.createRequest(http.MethodPost, "")))
+	t.True(NewDefaultSubmitDetector("").WasSubmitted(t.context, t.createRequest(http.MethodPost, "")))
+}
+
+func (t *DefaultSubmitDetectorTestSuite) TestWasSubmitted_GetWithMarker() {
+	t.True(t.detector.WasSubmitted(t.context, t.createRequest(http.MethodGet, "submitted=1&query=shoes")))
+	t.True(t.detector.WasSubmitted(t.context, t.createRequest(http.MethodGet, "submitted=true")))
+}
+
+func (t *DefaultSubmitDetectorTestSuite) TestWasSubmitted_GetWithoutMarker() {
+	t.False(t.detector.WasSubmitted(t.context, t.createRequest(http.MethodGet, "")))
+	t.False(t.detector.WasSubmitted(t.context, t.createRequest(http.MethodGet, "query=shoes")))
+	t.False(t.detector.WasSubmitted(t.context, t.createRequest(http.MethodGet, "submitted=0")))
+	t.False(t.detector.WasSubmitted(t.context, t.createRequest(http.MethodGet, "submitted=yes")))
+	t.False(NewDefaultSubmitDetector("").WasSubmitted(t.context, t.createRequest(http.MethodGet, "submitted=1")))
+	t.False(t.detector.WasSubmitted(t.context, nil))
+}
+
+func (t *DefaultSubmitDetectorTestSuite) createRequest(method string, query string) *web.Request {
+	return web.CreateRequest(&http.Request{
+		Method: method,
+		URL: &url.URL{
+			RawQuery: query,
+		},
+	}, nil)
+}
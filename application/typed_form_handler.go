@@ -0,0 +1,230 @@
+package application
+
+import (
+	"context"
+	"reflect"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	// TypedForm as Form whose form data is exposed with its concrete type, so it can be used without type assertions.
+	// All other Form methods (like IsValid or GetErrorsForField) are available as well.
+	TypedForm[T any] struct {
+		domain.Form
+		// Data the form data struct with concrete type, which shadows untyped Data of embedded Form
+		Data T
+	}
+
+	// TypedFormHandler as form handler which provides form data with concrete type.
+	// It wraps domain.FormHandler, so all form handling rules stay the same.
+	TypedFormHandler[T any] interface {
+		// HandleUnsubmittedForm as method for returning TypedForm instance which is not submitted
+		HandleUnsubmittedForm(ctx context.Context, req *web.Request) (*TypedForm[T], error)
+		// HandleSubmittedForm as method for returning TypedForm instance which is submitted via POST request
+		HandleSubmittedForm(ctx context.Context, req *web.Request) (*TypedForm[T], error)
+		// HandleSubmittedGETForm as method for returning TypedForm instance which is submitted via GET request
+		HandleSubmittedGETForm(ctx context.Context, req *web.Request) (*TypedForm[T], error)
+		// HandleForm as method for returning TypedForm instance with state depending on fact if there was form submission or not, via POST request
+		HandleForm(ctx context.Context, req *web.Request) (*TypedForm[T], error)
+		// ValidateData as method for validating form data which is changed programmatically after form handling
+		ValidateData(ctx context.Context, req *web.Request, data T) (*domain.ValidationInfo, error)
+		// Schema as method for returning JSON Schema document which describes form data and its validation rules
+		Schema(ctx context.Context) ([]byte, error)
+	}
+
+	// TypedFormDataProvider as form data provider which creates form data with concrete type
+	TypedFormDataProvider[T any] interface {
+		// GetFormData as method for defining form data
+		GetFormData(ctx context.Context, req *web.Request) (T, error)
+	}
+
+	// TypedFormDataValidator as form data validator which validates form data with concrete type
+	TypedFormDataValidator[T any] interface {
+		// Validate as method for validating form data
+		Validate(ctx context.Context, req *web.Request, validatorProvider domain.ValidatorProvider, formData T) (*domain.ValidationInfo, error)
+	}
+
+	// TypedPrefillProvider as prefill provider which provides prefilled values as form data with concrete type
+	TypedPrefillProvider[T any] interface {
+		// Prefill as method for providing partial form data, whose non zero values are merged into form data
+		Prefill(ctx context.Context, req *web.Request, formData T) (T, error)
+	}
+
+	// TypedFormHandlerOption as option for configuring FormHandlerBuilder used by typed form handler.
+	// Options are bound to type of form data, so services for wrong type can't be attached.
+	TypedFormHandlerOption[T any] func(builder FormHandlerBuilder)
+
+	// typedFormHandlerImpl as implementation of TypedFormHandler which wraps untyped form handler
+	typedFormHandlerImpl[T any] struct {
+		formHandler domain.FormHandler
+	}
+
+	// typedFormDataProviderAdapter as domain.FormDataProvider which delegates to TypedFormDataProvider
+	typedFormDataProviderAdapter[T any] struct {
+		provider TypedFormDataProvider[T]
+	}
+
+	// typedFormDataValidatorAdapter as domain.FormDataValidator which delegates to TypedFormDataValidator
+	typedFormDataValidatorAdapter[T any] struct {
+		validator TypedFormDataValidator[T]
+	}
+
+	// typedPrefillProviderAdapter as domain.PrefillProvider which delegates to TypedPrefillProvider
+	typedPrefillProviderAdapter[T any] struct {
+		provider TypedPrefillProvider[T]
+	}
+
+	// typedDefaultFormDataProvider as domain.FormDataProvider which creates empty instance of form data type
+	typedDefaultFormDataProvider[T any] struct{}
+)
+
+// NewTypedFormHandler creates typed form handler by using FormHandlerBuilder from factory. Default form data provider
+// is replaced with one which creates empty instance of form data type (new instance of referenced struct in case of pointer types),
+// and all options are applied afterwards, so they can override it.
+func NewTypedFormHandler[T any](factory FormHandlerFactory, options ...TypedFormHandlerOption[T]) TypedFormHandler[T] {
+	builder := factory.GetFormHandlerBuilder()
+	builder.SetFormDataProvider(&typedDefaultFormDataProvider[T]{})
+
+	for _, option := range options {
+		option(builder)
+	}
+
+	return NewTypedFormHandlerFromFormHandler[T](builder.Build())
+}
+
+// NewTypedFormHandlerFromFormHandler creates typed form handler which wraps already built form handler
+func NewTypedFormHandlerFromFormHandler[T any](formHandler domain.FormHandler) TypedFormHandler[T] {
+	return &typedFormHandlerImpl[T]{
+		formHandler: formHandler,
+	}
+}
+
+// WithFormDataProvider option sets typed form data provider and overrides default one
+func WithFormDataProvider[T any](provider TypedFormDataProvider[T]) TypedFormHandlerOption[T] {
+	return func(builder FormHandlerBuilder) {
+		builder.SetFormDataProvider(&typedFormDataProviderAdapter[T]{provider: provider})
+	}
+}
+
+// WithFormDataValidator option sets typed form data validator and overrides default one
+func WithFormDataValidator[T any](validator TypedFormDataValidator[T]) TypedFormHandlerOption[T] {
+	return func(builder FormHandlerBuilder) {
+		builder.SetFormDataValidator(&typedFormDataValidatorAdapter[T]{validator: validator})
+	}
+}
+
+// WithPrefillProvider option adds typed prefill provider to the list of prefill providers
+func WithPrefillProvider[T any](provider TypedPrefillProvider[T]) TypedFormHandlerOption[T] {
+	return func(builder FormHandlerBuilder) {
+		builder.AddPrefillProvider(&typedPrefillProviderAdapter[T]{provider: provider})
+	}
+}
+
+// WithBuilder option configures FormHandlerBuilder directly, for all settings which don't depend on form data type
+// (like form extensions or validation mode)
+func WithBuilder[T any](configure func(builder FormHandlerBuilder)) TypedFormHandlerOption[T] {
+	return TypedFormHandlerOption[T](configure)
+}
+
+// HandleUnsubmittedForm as method for returning TypedForm instance which is not submitted
+func (h *typedFormHandlerImpl[T]) HandleUnsubmittedForm(ctx context.Context, req *web.Request) (*TypedForm[T], error) {
+	return newTypedForm[T](h.formHandler.HandleUnsubmittedForm(ctx, req))
+}
+
+// HandleSubmittedForm as method for returning TypedForm instance which is submitted via POST request
+func (h *typedFormHandlerImpl[T]) HandleSubmittedForm(ctx context.Context, req *web.Request) (*TypedForm[T], error) {
+	return newTypedForm[T](h.formHandler.HandleSubmittedForm(ctx, req))
+}
+
+// HandleSubmittedGETForm as method for returning TypedForm instance which is submitted via GET request
+func (h *typedFormHandlerImpl[T]) HandleSubmittedGETForm(ctx context.Context, req *web.Request) (*TypedForm[T], error) {
+	return newTypedForm[T](h.formHandler.HandleSubmittedGETForm(ctx, req))
+}
+
+// HandleForm as method for returning TypedForm instance with state depending on fact if there was form submission or not, via POST request
+func (h *typedFormHandlerImpl[T]) HandleForm(ctx context.Context, req *web.Request) (*TypedForm[T], error) {
+	return newTypedForm[T](h.formHandler.HandleForm(ctx, req))
+}
+
+// ValidateData as method for validating changed form data with the same configuration as wrapped form handler
+func (h *typedFormHandlerImpl[T]) ValidateData(ctx context.Context, req *web.Request, data T) (*domain.ValidationInfo, error) {
+	return h.formHandler.ValidateData(ctx, req, data)
+}
+
+// Schema as method for returning JSON Schema document of form data from wrapped form handler
+func (h *typedFormHandlerImpl[T]) Schema(ctx context.Context) ([]byte, error) {
+	return h.formHandler.Schema(ctx)
+}
+
+// UpdateData replaces typed form data, together with untyped data of embedded Form, and validation info
+// returned for it by TypedFormHandler.ValidateData
+func (f *TypedForm[T]) UpdateData(data T, validationInfo *domain.ValidationInfo) {
+	f.Form.UpdateData(data, validationInfo)
+	f.Data = data
+}
+
+// GetFormData as method for defining form data by using typed form data provider
+func (p *typedFormDataProviderAdapter[T]) GetFormData(ctx context.Context, req *web.Request) (interface{}, error) {
+	return p.provider.GetFormData(ctx, req)
+}
+
+// Validate as method for validating form data by using typed form data validator
+func (v *typedFormDataValidatorAdapter[T]) Validate(ctx context.Context, req *web.Request, validatorProvider domain.ValidatorProvider, formData interface{}) (*domain.ValidationInfo, error) {
+	typed, err := FormDataOf[T](&domain.Form{Data: formData})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.validator.Validate(ctx, req, validatorProvider, typed)
+}
+
+// Prefill as method for providing prefilled values by using typed prefill provider
+func (p *typedPrefillProviderAdapter[T]) Prefill(ctx context.Context, req *web.Request, formData interface{}) (interface{}, error) {
+	typed, err := FormDataOf[T](&domain.Form{Data: formData})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.provider.Prefill(ctx, req, typed)
+}
+
+// GetFormData as method for creating empty instance of form data type
+func (p *typedDefaultFormDataProvider[T]) GetFormData(context.Context, *web.Request) (interface{}, error) {
+	typeOf := reflect.TypeOf((*T)(nil)).Elem()
+	if typeOf.Kind() == reflect.Ptr {
+		return reflect.New(typeOf.Elem()).Interface(), nil
+	}
+
+	var formData T
+
+	return formData, nil
+}
+
+// newTypedForm creates typed form from result of untyped form handler
+func newTypedForm[T any](form *domain.Form, err error) (*TypedForm[T], error) {
+	if err != nil {
+		return nil, err
+	}
+
+	// duplicate submission doesn't contain form data, so it's presented with zero value of form data type
+	if form.IsDuplicateSubmission() && form.Data == nil {
+		var typed T
+
+		return &TypedForm[T]{
+			Form: *form,
+			Data: typed,
+		}, nil
+	}
+
+	typed, err := FormDataOf[T](form)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypedForm[T]{
+		Form: *form,
+		Data: typed,
+	}, nil
+}
@@ -0,0 +1,237 @@
+package application
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/mocks"
+)
+
+type (
+	TypedFormHandlerTestSuite struct {
+		suite.Suite
+
+		factory *FormHandlerFactoryImpl
+
+		provider          *mocks.FormDataProvider
+		defaultProvider   *mocks.DefaultFormDataProvider
+		defaultDecoder    *mocks.DefaultFormDataDecoder
+		defaultValidator  *mocks.DefaultFormDataValidator
+		validatorProvider *mocks.ValidatorProvider
+
+		context context.Context
+		request *web.Request
+	}
+
+	typedFormHandlerTestData struct {
+		Name  string `form:"name"`
+		Email string `form:"email"`
+	}
+
+	typedFormHandlerAddressTestData struct {
+		Street string `form:"street"`
+	}
+
+	typedFormHandlerTestProvider struct{}
+
+	typedFormHandlerTestValidator struct{}
+
+	typedFormHandlerTestPrefillProvider struct{}
+)
+
+func (p *typedFormHandlerTestProvider) GetFormData(context.Context, *web.Request) (typedFormHandlerTestData, error) {
+	return typedFormHandlerTestData{
+		Name: "John",
+	}, nil
+}
+
+func (v *typedFormHandlerTestValidator) Validate(_ context.Context, _ *web.Request, _ domain.ValidatorProvider, formData typedFormHandlerTestData) (*domain.ValidationInfo, error) {
+	validationInfo := &domain.ValidationInfo{}
+	if formData.Name == "" {
+		validationInfo.AddFieldError("name", "formError.name.required", "name is required")
+	}
+
+	return validationInfo, nil
+}
+
+func (p *typedFormHandlerTestPrefillProvider) Prefill(context.Context, *web.Request, typedFormHandlerTestData) (typedFormHandlerTestData, error) {
+	return typedFormHandlerTestData{
+		Email: "john@example.com",
+	}, nil
+}
+
+// options are bound to type of form data, so services of other form data types can't be attached
+var (
+	_ TypedFormHandlerOption[typedFormHandlerTestData] = WithFormDataProvider[typedFormHandlerTestData](&typedFormHandlerTestProvider{})
+	_ TypedFormHandlerOption[typedFormHandlerTestData] = WithFormDataValidator[typedFormHandlerTestData](&typedFormHandlerTestValidator{})
+	_ TypedFormHandlerOption[typedFormHandlerTestData] = WithPrefillProvider[typedFormHandlerTestData](&typedFormHandlerTestPrefillProvider{})
+)
+
+func TestTypedFormHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &TypedFormHandlerTestSuite{})
+}
+
+func (t *TypedFormHandlerTestSuite) SetupTest() {
+	t.provider = &mocks.FormDataProvider{}
+	t.defaultProvider = &mocks.DefaultFormDataProvider{}
+	t.defaultDecoder = &mocks.DefaultFormDataDecoder{}
+	t.defaultValidator = &mocks.DefaultFormDataValidator{}
+	t.validatorProvider = &mocks.ValidatorProvider{}
+
+	t.factory = &FormHandlerFactoryImpl{}
+	t.factory.Inject(nil, nil, nil, nil, nil, t.defaultProvider, t.defaultDecoder, t.defaultValidator, t.validatorProvider, nil, &flamingo.NullLogger{}, nil)
+
+	t.context = context.Background()
+	t.request = web.CreateRequest(&http.Request{}, nil)
+}
+
+func (t *TypedFormHandlerTestSuite) TearDownTest() {
+	t.provider.AssertExpectations(t.T())
+	t.defaultProvider.AssertExpectations(t.T())
+	t.defaultDecoder.AssertExpectations(t.T())
+	t.defaultValidator.AssertExpectations(t.T())
+	t.validatorProvider.AssertExpectations(t.T())
+}
+
+func (t *TypedFormHandlerTestSuite) TestHandleUnsubmittedForm_DefaultProvider() {
+	handler := NewTypedFormHandler[typedFormHandlerTestData](t.factory)
+
+	form, err := handler.HandleUnsubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.False(form.IsSubmitted())
+	t.Equal(typedFormHandlerTestData{}, form.Data)
+	t.Equal("", form.Data.Name)
+}
+
+func (t *TypedFormHandlerTestSuite) TestHandleUnsubmittedForm_PointerType() {
+	handler := NewTypedFormHandler[*typedFormHandlerTestData](t.factory)
+
+	form, err := handler.HandleUnsubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.Equal(&typedFormHandlerTestData{}, form.Data)
+}
+
+func (t *TypedFormHandlerTestSuite) TestHandleUnsubmittedForm_TypedProvider() {
+	handler := NewTypedFormHandler(t.factory, WithFormDataProvider[typedFormHandlerTestData](&typedFormHandlerTestProvider{}))
+
+	form, err := handler.HandleUnsubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.Equal("John", form.Data.Name)
+}
+
+func (t *TypedFormHandlerTestSuite) TestHandleSubmittedForm_TypedServices() {
+	values := url.Values{
+		"name": []string{""},
+	}
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = values
+
+	prefilled := typedFormHandlerTestData{
+		Email: "john@example.com",
+	}
+	t.defaultDecoder.On("Decode", mock.Anything, t.request, values, prefilled).Return(typedFormHandlerTestData{}, nil).Once()
+
+	handler := NewTypedFormHandler(t.factory,
+		WithPrefillProvider[typedFormHandlerTestData](&typedFormHandlerTestPrefillProvider{}),
+		WithFormDataValidator[typedFormHandlerTestData](&typedFormHandlerTestValidator{}),
+	)
+
+	form, err := handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsSubmitted())
+	t.False(form.IsValid())
+	t.Equal(prefilled, form.Data)
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.name.required",
+			DefaultLabel: "name is required",
+		},
+	}, form.GetErrorsForField("name"))
+}
+
+func (t *TypedFormHandlerTestSuite) TestValidateData() {
+	t.request.Request().Method = http.MethodPost
+	t.request.Request().PostForm = url.Values{
+		"name": []string{"John"},
+	}
+	t.defaultDecoder.On("Decode", mock.Anything, t.request, t.request.Request().PostForm, typedFormHandlerTestData{}).Return(typedFormHandlerTestData{
+		Name: "John",
+	}, nil).Once()
+
+	handler := NewTypedFormHandler(t.factory, WithFormDataValidator[typedFormHandlerTestData](&typedFormHandlerTestValidator{}))
+
+	form, err := handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.True(form.IsValidAndSubmitted())
+
+	changed := form.Data
+	changed.Name = ""
+	validationInfo, err := handler.ValidateData(t.context, t.request, changed)
+	t.NoError(err)
+	t.True(validationInfo.HasErrorsForField("name"))
+
+	form.UpdateData(changed, validationInfo)
+	t.False(form.IsValidAndSubmitted())
+	t.Equal(changed, form.Data)
+	t.Equal(changed, form.Form.Data)
+}
+
+func (t *TypedFormHandlerTestSuite) TestSchema() {
+	handler := NewTypedFormHandler[typedFormHandlerTestData](t.factory)
+
+	result, err := handler.Schema(t.context)
+	t.NoError(err)
+	t.Contains(string(result), `"title": "typedFormHandlerTestData"`)
+}
+
+func (t *TypedFormHandlerTestSuite) TestHandleForm_WrongType() {
+	t.provider.On("GetFormData", mock.Anything, t.request).Return(typedFormHandlerAddressTestData{}, nil).Once()
+
+	handler := NewTypedFormHandler(t.factory, WithBuilder[typedFormHandlerTestData](func(builder FormHandlerBuilder) {
+		builder.SetFormDataProvider(t.provider)
+	}))
+
+	form, err := handler.HandleForm(t.context, t.request)
+	t.Nil(form)
+	t.Equal(domain.NewFormError("form data of type application.typedFormHandlerAddressTestData can't be used as application.typedFormHandlerTestData"), err)
+}
+
+func (t *TypedFormHandlerTestSuite) TestHandleForm_Error() {
+	t.provider.On("GetFormData", mock.Anything, t.request).Return(nil, domain.NewFormError("error")).Once()
+
+	handler := NewTypedFormHandlerFromFormHandler[typedFormHandlerTestData](t.factory.GetFormHandlerBuilder().SetFormDataProvider(t.provider).Build())
+
+	form, err := handler.HandleUnsubmittedForm(t.context, t.request)
+	t.Nil(form)
+	t.Equal(domain.NewWrappedFormError(domain.NewFormError("error")), err)
+}
+
+func (t *TypedFormHandlerTestSuite) TestValidatorAdapter_FormData() {
+	adapter := &typedFormDataValidatorAdapter[typedFormHandlerTestData]{
+		validator: &typedFormHandlerTestValidator{},
+	}
+
+	// form data is converted in the same way as by FormDataOf, so pointer to form data is accepted as well
+	for _, formData := range []interface{}{typedFormHandlerTestData{Name: "John"}, &typedFormHandlerTestData{Name: "Jane"}} {
+		validationInfo, err := adapter.Validate(t.context, t.request, t.validatorProvider, formData)
+		t.NoError(err)
+		t.True(validationInfo.IsValid())
+	}
+
+	_, err := adapter.Validate(t.context, t.request, t.validatorProvider, nil)
+	t.EqualError(err, "FormError: form data is nil, expected application.typedFormHandlerTestData")
+
+	_, err = adapter.Validate(t.context, t.request, t.validatorProvider, (*typedFormHandlerTestData)(nil))
+	t.EqualError(err, "FormError: form data is nil, expected application.typedFormHandlerTestData")
+
+	_, err = adapter.Validate(t.context, t.request, t.validatorProvider, typedFormHandlerAddressTestData{})
+	t.EqualError(err, "FormError: form data of type application.typedFormHandlerAddressTestData can't be used as application.typedFormHandlerTestData")
+}
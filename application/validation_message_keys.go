@@ -0,0 +1,120 @@
+package application
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"flamingo.me/form/domain"
+)
+
+type (
+	// ValidationMessageKeys as struct which defines how message keys and default labels of validation errors are built.
+	// By default, message keys are built as "formError.<field>.<tag>" and default labels as "<label> <tag>".
+	ValidationMessageKeys struct {
+		prefix string
+		keys   map[string]string
+		labels map[string]string
+	}
+)
+
+var (
+	// fixedMessageKeys contains message keys of validation tags which don't depend on field name, relative to the prefix
+	fixedMessageKeys = map[string]string{
+		"passwordconfirm": "passwordMismatch",
+	}
+)
+
+const (
+	// DefaultMessageKeyPrefix as prefix of all message keys of validation errors, if there is no other prefix configured
+	DefaultMessageKeyPrefix = "formError"
+)
+
+// NewValidationMessageKeys creates instance of ValidationMessageKeys with custom prefix of message keys, and with
+// message keys and default labels which override generated ones for specific validation tags.
+// It returns error which lists all invalid configuration values.
+func NewValidationMessageKeys(prefix string, keyMapping map[string]interface{}, labelMapping map[string]interface{}) (*ValidationMessageKeys, error) {
+	var invalid []string
+
+	if prefix == "" || strings.TrimSpace(prefix) != prefix || strings.HasPrefix(prefix, ".") || strings.HasSuffix(prefix, ".") {
+		invalid = append(invalid, fmt.Sprintf("messageKeyPrefix (%q is not valid prefix)", prefix))
+	}
+
+	keys, invalidKeys := parseValidationMessageMapping("messageKeyMapping", keyMapping)
+	labels, invalidLabels := parseValidationMessageMapping("defaultLabelMapping", labelMapping)
+
+	invalid = append(invalid, invalidKeys...)
+	invalid = append(invalid, invalidLabels...)
+
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return nil, domain.NewFormErrorf("invalid validation message configuration: %s", strings.Join(invalid, ", "))
+	}
+
+	return &ValidationMessageKeys{
+		prefix: prefix,
+		keys:   keys,
+		labels: labels,
+	}, nil
+}
+
+// parseValidationMessageMapping converts configured mapping into map of strings, and returns names of all invalid entries
+func parseValidationMessageMapping(name string, mapping map[string]interface{}) (map[string]string, []string) {
+	var invalid []string
+	result := make(map[string]string, len(mapping))
+
+	for tag, value := range mapping {
+		text, ok := value.(string)
+		if !ok || strings.TrimSpace(text) == "" {
+			invalid = append(invalid, fmt.Sprintf("%s.%s (value is not a non empty string)", name, tag))
+			continue
+		}
+
+		result[tag] = text
+	}
+
+	return result, invalid
+}
+
+// GetMessageKey returns message key for validation error of field with specific tag.
+// Configured message key is used as it is, otherwise it's built from prefix, field name and tag. Tags which don't
+// depend on field (like "passwordconfirm", with message key "formError.passwordMismatch") are built without field name.
+func (k *ValidationMessageKeys) GetMessageKey(fieldName string, tag string) string {
+	if k != nil {
+		if key, ok := k.keys[tag]; ok {
+			return key
+		}
+	}
+
+	if key, ok := fixedMessageKeys[tag]; ok {
+		return k.GetPrefix() + "." + key
+	}
+
+	return k.GetPrefix() + "." + fieldName + "." + tag
+}
+
+// GetDefaultLabel returns default label for validation error with specific tag. Configured default label can contain
+// error parameters as placeholders (like "{field} is mandatory"), otherwise it's built from field label and tag.
+func (k *ValidationMessageKeys) GetDefaultLabel(label string, tag string, params map[string]string) string {
+	if k != nil {
+		if defaultLabel, ok := k.labels[tag]; ok {
+			replacements := make([]string, 0, 2*len(params))
+			for name, value := range params {
+				replacements = append(replacements, "{"+name+"}", value)
+			}
+
+			return strings.NewReplacer(replacements...).Replace(defaultLabel)
+		}
+	}
+
+	return label + " " + tag
+}
+
+// GetPrefix returns prefix of message keys
+func (k *ValidationMessageKeys) GetPrefix() string {
+	if k == nil || k.prefix == "" {
+		return DefaultMessageKeyPrefix
+	}
+
+	return k.prefix
+}
@@ -0,0 +1,81 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	ValidationMessageKeysTestSuite struct {
+		suite.Suite
+	}
+)
+
+func TestValidationMessageKeysTestSuite(t *testing.T) {
+	suite.Run(t, &ValidationMessageKeysTestSuite{})
+}
+
+func (t *ValidationMessageKeysTestSuite) TestNewValidationMessageKeys() {
+	messageKeys, err := NewValidationMessageKeys("errors", map[string]interface{}{
+		"required": "errors.mandatory",
+		"email":    "errors.emailInvalid",
+	}, map[string]interface{}{
+		"required": "{field} is mandatory",
+	})
+	t.NoError(err)
+
+	t.Equal("errors", messageKeys.GetPrefix())
+	t.Equal("errors.mandatory", messageKeys.GetMessageKey("address.street", "required"))
+	t.Equal("errors.emailInvalid", messageKeys.GetMessageKey("email", "email"))
+	t.Equal("errors.password.min", messageKeys.GetMessageKey("password", "min"))
+
+	t.Equal("Street is mandatory", messageKeys.GetDefaultLabel("Street", "required", map[string]string{
+		"tag":   "required",
+		"field": "Street",
+	}))
+	t.Equal("E-Mail email", messageKeys.GetDefaultLabel("E-Mail", "email", map[string]string{
+		"tag":   "email",
+		"field": "E-Mail",
+	}))
+}
+
+func (t *ValidationMessageKeysTestSuite) TestNewValidationMessageKeys_Invalid() {
+	messageKeys, err := NewValidationMessageKeys("errors.", map[string]interface{}{
+		"required": 5,
+		"email":    "errors.emailInvalid",
+	}, map[string]interface{}{
+		"min": " ",
+	})
+	t.Nil(messageKeys)
+	t.EqualError(err, `FormError: invalid validation message configuration: defaultLabelMapping.min (value is not a non empty string), messageKeyMapping.required (value is not a non empty string), messageKeyPrefix ("errors." is not valid prefix)`)
+
+	_, err = NewValidationMessageKeys("", nil, nil)
+	t.Error(err)
+}
+
+func (t *ValidationMessageKeysTestSuite) TestDefaults() {
+	var messageKeys *ValidationMessageKeys
+
+	t.Equal(DefaultMessageKeyPrefix, messageKeys.GetPrefix())
+	t.Equal("formError.email.required", messageKeys.GetMessageKey("email", "required"))
+	t.Equal("E-Mail required", messageKeys.GetDefaultLabel("E-Mail", "required", nil))
+
+	t.Equal("formError.email.required", (&ValidationMessageKeys{}).GetMessageKey("email", "required"))
+}
+
+func (t *ValidationMessageKeysTestSuite) TestFixedMessageKeys() {
+	var messageKeys *ValidationMessageKeys
+	t.Equal("formError.passwordMismatch", messageKeys.GetMessageKey("passwordConfirm", "passwordconfirm"))
+
+	// fixed message keys follow configured prefix, and they can be overridden as well
+	messageKeys, err := NewValidationMessageKeys("errors", nil, nil)
+	t.NoError(err)
+	t.Equal("errors.passwordMismatch", messageKeys.GetMessageKey("passwordConfirm", "passwordconfirm"))
+
+	messageKeys, err = NewValidationMessageKeys("errors", map[string]interface{}{
+		"passwordconfirm": "errors.passwordRepeat",
+	}, nil)
+	t.NoError(err)
+	t.Equal("errors.passwordRepeat", messageKeys.GetMessageKey("passwordConfirm", "passwordconfirm"))
+}
@@ -0,0 +1,153 @@
+package application
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"flamingo.me/form/domain"
+)
+
+type (
+	// fieldValidationTags as struct for storing parsed validation tags of single form field
+	fieldValidationTags struct {
+		path string
+		kind reflect.Kind
+		tags []domain.ValidationRule
+	}
+)
+
+var (
+	// validationTagsCache contains parsed validation tags for each form data type, since they depend only on type
+	validationTagsCache sync.Map
+
+	// validatorParamReplacer replaces escaped characters in validation parameters, in the same way as validator does
+	validatorParamReplacer = strings.NewReplacer("0x2C", ",", "0x7C", "|")
+)
+
+// extractValidationRules as method for extracting form fields validation rules, keyed by field paths as they are submitted
+func (h *formHandlerImpl) extractValidationRules(formData interface{}) map[string][]domain.ValidationRule {
+	validationRules := map[string][]domain.ValidationRule{}
+
+	if formData == nil {
+		return validationRules
+	}
+
+	for _, field := range h.getValidationTags(reflect.TypeOf(formData)) {
+		for _, tag := range field.tags {
+			validationRules[field.path] = append(validationRules[field.path], h.translateValidationRule(tag, field.kind)...)
+		}
+	}
+
+	return validationRules
+}
+
+// getValidationTags as method for fetching parsed validation tags of all form data fields, which are parsed only once per type
+func (h *formHandlerImpl) getValidationTags(typeOf reflect.Type) []fieldValidationTags {
+	if cached, ok := validationTagsCache.Load(typeOf); ok {
+		return cached.([]fieldValidationTags)
+	}
+
+	var fields []fieldValidationTags
+	if elem := h.indirectType(typeOf); elem.Kind() == reflect.Struct {
+		fields = h.parseValidationTags(elem, "")
+	}
+
+	validationTagsCache.Store(typeOf, fields)
+
+	return fields
+}
+
+// parseValidationTags as method for parsing validation tags of struct fields. Nested structs, pointers to structs
+// and slices of structs are followed with dotted paths, where elements of slices are exposed without index.
+func (h *formHandlerImpl) parseValidationTags(typeOf reflect.Type, prefix string) []fieldValidationTags {
+	var fields []fieldValidationTags
+
+	// error is never returned from callback, so it can be ignored
+	_ = h.walkFormFields(reflect.New(typeOf).Elem(), prefix, func(field reflect.StructField, _ reflect.Value, _ string, path string) error {
+		fieldType := h.indirectType(field.Type)
+
+		if h.isNestedStruct(fieldType) {
+			fields = append(fields, h.parseValidationTags(fieldType, path+".")...)
+			return nil
+		}
+
+		if tags := h.parseValidationTag(field.Tag.Get("validate")); len(tags) > 0 {
+			fields = append(fields, fieldValidationTags{
+				path: path,
+				kind: fieldType.Kind(),
+				tags: tags,
+			})
+		}
+
+		if fieldType.Kind() == reflect.Slice || fieldType.Kind() == reflect.Array {
+			if elem := h.indirectType(fieldType.Elem()); h.isNestedStruct(elem) {
+				fields = append(fields, h.parseValidationTags(elem, path+".")...)
+			}
+		}
+
+		return nil
+	})
+
+	return fields
+}
+
+// parseValidationTag as method for parsing validation tag into list of validation rules. Rules defined after "dive"
+// belong to elements of slices and maps, so they are not exposed for field itself.
+func (h *formHandlerImpl) parseValidationTag(validationTag string) []domain.ValidationRule {
+	var rules []domain.ValidationRule
+
+	for _, tag := range strings.Split(validationTag, ",") {
+		values := strings.SplitN(tag, "=", 2)
+		if values[0] == "dive" {
+			break
+		}
+		if values[0] == "omitempty" || values[0] == "" {
+			continue
+		}
+
+		rule := domain.ValidationRule{
+			Name: values[0],
+		}
+		if len(values) > 1 {
+			rule.Value = validatorParamReplacer.Replace(values[1])
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules
+}
+
+// translateValidationRule as method for translating validation tag into validation rules usable in templates.
+// Length validations of strings are translated into "minlength" and "maxlength" rules, field validators can
+// define own translation by implementing domain.ValidationRuleTranslator, and all other tags are passed as they are.
+func (h *formHandlerImpl) translateValidationRule(tag domain.ValidationRule, kind reflect.Kind) []domain.ValidationRule {
+	if translator, ok := h.validationRuleTranslators[tag.Name]; ok {
+		return []domain.ValidationRule{translator.TranslateValidationRule(tag.Value)}
+	}
+
+	if kind != reflect.String {
+		return []domain.ValidationRule{tag}
+	}
+
+	switch tag.Name {
+	case "min":
+		return []domain.ValidationRule{{Name: "minlength", Value: tag.Value}}
+	case "max":
+		return []domain.ValidationRule{{Name: "maxlength", Value: tag.Value}}
+	case "len":
+		return []domain.ValidationRule{{Name: "minlength", Value: tag.Value}, {Name: "maxlength", Value: tag.Value}}
+	}
+
+	return []domain.ValidationRule{tag}
+}
+
+// indirectType as method for fetching type which pointer type points to
+func (h *formHandlerImpl) indirectType(typeOf reflect.Type) reflect.Type {
+	for typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	return typeOf
+}
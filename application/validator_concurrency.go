@@ -0,0 +1,327 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+
+	validator "gopkg.in/go-playground/validator.v9"
+
+	"flamingo.me/form/domain"
+)
+
+type (
+	// contextValidationBatch as set of context field validations of single struct validation. In first pass over
+	// the struct, each context field validation is started in its own goroutine (with limited number of them running
+	// at once), and in second pass, which reports validation errors, their results are used instead of validating again.
+	contextValidationBatch struct {
+		collecting     bool
+		slots          chan struct{}
+		wg             sync.WaitGroup
+		jobs           []*contextValidationJob
+		jobsByLocation map[contextValidationLocation]*contextValidationJob
+	}
+
+	// contextValidationKey as key of context field validation, by validation tag and by names of validated field
+	contextValidationKey struct {
+		tag             string
+		param           string
+		fieldName       string
+		structFieldName string
+	}
+
+	// contextValidationLocation as location of context field validation inside validated struct, by its key
+	// and by address and type of parent struct, which are the same in both passes
+	contextValidationLocation struct {
+		key        contextValidationKey
+		parentType reflect.Type
+		parent     uintptr
+	}
+
+	// contextValidationJob as single context field validation started in first pass
+	contextValidationJob struct {
+		key       contextValidationKey
+		valid     bool
+		panicked  bool
+		recovered interface{}
+	}
+
+	// contextValidationBatchKey as type of context key which contains context validation batch of current validation
+	contextValidationBatchKey struct{}
+
+	// fieldLevelSnapshot as copy of validator.FieldLevel state, since validator.Validate reuses its instance
+	// of validator.FieldLevel for all fields, while validated field is still used by another goroutine
+	fieldLevelSnapshot struct {
+		delegate        validator.FieldLevel
+		top             reflect.Value
+		parent          reflect.Value
+		field           reflect.Value
+		fieldName       string
+		structFieldName string
+		param           string
+
+		structField      reflect.Value
+		structFieldKind  reflect.Kind
+		structFieldFound bool
+		structFieldPanic interface{}
+	}
+)
+
+var _ validator.FieldLevel = &fieldLevelSnapshot{}
+
+// newContextValidationBatch creates context validation batch which runs at most concurrency validations at once
+func newContextValidationBatch(concurrency int) *contextValidationBatch {
+	return &contextValidationBatch{
+		collecting:     true,
+		slots:          make(chan struct{}, concurrency),
+		jobsByLocation: map[contextValidationLocation]*contextValidationJob{},
+	}
+}
+
+// prefetchContextValidations method which validates struct for the first time, only to start all its context field
+// validations concurrently. Field and struct validators are skipped and errors of this pass are discarded, since struct
+// is validated again, where results of finished context field validations are used.
+func (p *ValidatorProviderImpl) prefetchContextValidations(ctx context.Context, value interface{}) *contextValidationBatch {
+	batch := newContextValidationBatch(p.concurrency)
+
+	_ = p.validateStruct(batch.withContext(ctx), value)
+	batch.wait()
+
+	return batch
+}
+
+// addressableValue returns pointer to copy of value, in case when it's not already a pointer, so fields of
+// validated struct have the same addresses in both passes, and context field validations can be located by them
+func addressableValue(value interface{}) interface{} {
+	valueOf := reflect.ValueOf(value)
+	if !valueOf.IsValid() || valueOf.Kind() == reflect.Ptr {
+		return value
+	}
+
+	pointer := reflect.New(valueOf.Type())
+	pointer.Elem().Set(valueOf)
+
+	return pointer.Interface()
+}
+
+// skipWhileCollecting wraps validation function of field validator, so it's not called while context field
+// validations are started concurrently. Its result is not needed in first pass, so it's called only once,
+// when struct is validated again.
+func skipWhileCollecting(validate validator.FuncCtx) validator.FuncCtx {
+	return func(ctx context.Context, fl validator.FieldLevel) bool {
+		if isCollectingContextValidations(ctx) {
+			return true
+		}
+
+		return validate(ctx, fl)
+	}
+}
+
+// concurrentContextValidation method which wraps validation function of context field validator, so it's started
+// concurrently in first pass of the struct, and its result is used in second pass. Outside of concurrent validation
+// (like when validator.Validate is used directly), and for fields which can't be started concurrently,
+// validation function is called as it is.
+func (p *ValidatorProviderImpl) concurrentContextValidation(tag string, validate validator.FuncCtx) validator.FuncCtx {
+	return func(ctx context.Context, fl validator.FieldLevel) bool {
+		batch, ok := ctx.Value(contextValidationBatchKey{}).(*contextValidationBatch)
+		if !ok {
+			return validate(ctx, fl)
+		}
+
+		if batch.collecting {
+			// all validations of field are performed in first pass, so any of them can be reported in second pass
+			batch.start(ctx, tag, validate, fl)
+			return true
+		}
+
+		if valid, ok := batch.result(tag, fl); ok {
+			return valid
+		}
+
+		return validate(ctx, fl)
+	}
+}
+
+// withContext method which returns context with context validation batch, which is used by wrapped validation functions
+func (b *contextValidationBatch) withContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextValidationBatchKey{}, b)
+}
+
+// isCollectingContextValidations returns true if context contains context validation batch of first pass,
+// where struct validators are skipped
+func isCollectingContextValidations(ctx context.Context) bool {
+	batch, ok := ctx.Value(contextValidationBatchKey{}).(*contextValidationBatch)
+
+	return ok && batch.collecting
+}
+
+// start method which starts validation of field in new goroutine, as soon as there is free slot for it.
+// Field is not started if the same validation is already started, or if its parent struct can't be located.
+func (b *contextValidationBatch) start(ctx context.Context, tag string, validate validator.FuncCtx, fl validator.FieldLevel) {
+	location, ok := newContextValidationLocation(tag, fl)
+	if !ok || b.jobsByLocation[location] != nil {
+		return
+	}
+
+	job := &contextValidationJob{
+		key: location.key,
+	}
+	b.jobs = append(b.jobs, job)
+	b.jobsByLocation[location] = job
+
+	snapshot := newFieldLevelSnapshot(fl)
+
+	b.slots <- struct{}{}
+	b.wg.Add(1)
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				job.valid = true
+				job.panicked = true
+				job.recovered = recovered
+			}
+
+			<-b.slots
+			b.wg.Done()
+		}()
+
+		job.valid = validate(ctx, snapshot)
+	}()
+}
+
+// wait method which waits until all started validations are finished, and switches batch to second pass
+func (b *contextValidationBatch) wait() {
+	b.wg.Wait()
+	b.collecting = false
+}
+
+// result method which returns result of finished validation of field, and false if validation isn't started in first pass
+func (b *contextValidationBatch) result(tag string, fl validator.FieldLevel) (bool, bool) {
+	location, ok := newContextValidationLocation(tag, fl)
+	if !ok {
+		return false, false
+	}
+
+	job := b.jobsByLocation[location]
+	if job == nil {
+		return false, false
+	}
+
+	return job.valid, true
+}
+
+// addPanicErrors method which adds general error for each validator which panicked, in order in which validations
+// are started. Field validated by such validator is treated as valid, so results of other validators are kept.
+func (b *contextValidationBatch) addPanicErrors(validationInfo *domain.ValidationInfo, prefix string) {
+	for _, job := range b.jobs {
+		if !job.panicked {
+			continue
+		}
+
+		validationInfo.AddGeneralErrorWithParams(
+			prefix+".validatorPanic",
+			fmt.Sprintf("validator %q of field %q panicked: %v", job.key.tag, job.key.fieldName, job.recovered),
+			map[string]string{
+				"field":     job.key.fieldName,
+				"validator": job.key.tag,
+			},
+		)
+	}
+}
+
+// newContextValidationKey creates key of context field validation of field
+func newContextValidationKey(tag string, fl validator.FieldLevel) contextValidationKey {
+	return contextValidationKey{
+		tag:             tag,
+		param:           fl.Param(),
+		fieldName:       fl.FieldName(),
+		structFieldName: fl.StructFieldName(),
+	}
+}
+
+// newContextValidationLocation creates location of context field validation of field. Parent struct can't be
+// located if it's not addressable (like struct stored as value of map), and such fields are validated only in second pass.
+func newContextValidationLocation(tag string, fl validator.FieldLevel) (contextValidationLocation, bool) {
+	parent := fl.Parent()
+	for parent.Kind() == reflect.Ptr && !parent.IsNil() {
+		parent = parent.Elem()
+	}
+
+	if !parent.CanAddr() {
+		return contextValidationLocation{}, false
+	}
+
+	return contextValidationLocation{
+		key:        newContextValidationKey(tag, fl),
+		parentType: parent.Type(),
+		parent:     parent.UnsafeAddr(),
+	}, true
+}
+
+// newFieldLevelSnapshot creates copy of current state of validator.FieldLevel. Field referenced by validation
+// parameter is resolved immediately, since it depends on state of validator.FieldLevel, and panic during its
+// resolving is raised again only when it's requested by validator.
+func newFieldLevelSnapshot(fl validator.FieldLevel) (snapshot *fieldLevelSnapshot) {
+	snapshot = &fieldLevelSnapshot{
+		delegate:        fl,
+		top:             fl.Top(),
+		parent:          fl.Parent(),
+		field:           fl.Field(),
+		fieldName:       fl.FieldName(),
+		structFieldName: fl.StructFieldName(),
+		param:           fl.Param(),
+	}
+
+	defer func() {
+		snapshot.structFieldPanic = recover()
+	}()
+	snapshot.structField, snapshot.structFieldKind, snapshot.structFieldFound = fl.GetStructFieldOK()
+
+	return snapshot
+}
+
+// Top returns top level struct of validation
+func (s *fieldLevelSnapshot) Top() reflect.Value {
+	return s.top
+}
+
+// Parent returns struct which contains validated field
+func (s *fieldLevelSnapshot) Parent() reflect.Value {
+	return s.parent
+}
+
+// Field returns validated field
+func (s *fieldLevelSnapshot) Field() reflect.Value {
+	return s.field
+}
+
+// FieldName returns name of validated field, as it's defined by tag name function
+func (s *fieldLevelSnapshot) FieldName() string {
+	return s.fieldName
+}
+
+// StructFieldName returns struct name of validated field
+func (s *fieldLevelSnapshot) StructFieldName() string {
+	return s.structFieldName
+}
+
+// Param returns parameter of validation tag
+func (s *fieldLevelSnapshot) Param() string {
+	return s.param
+}
+
+// ExtractType returns underlying value and kind of field. It depends only on custom types of validator.Validate
+// instance, and not on state of currently validated field, so it's delegated to original validator.FieldLevel.
+func (s *fieldLevelSnapshot) ExtractType(field reflect.Value) (reflect.Value, reflect.Kind, bool) {
+	return s.delegate.ExtractType(field)
+}
+
+// GetStructFieldOK returns field of parent struct referenced by validation parameter
+func (s *fieldLevelSnapshot) GetStructFieldOK() (reflect.Value, reflect.Kind, bool) {
+	if s.structFieldPanic != nil {
+		panic(s.structFieldPanic)
+	}
+
+	return s.structField, s.structFieldKind, s.structFieldFound
+}
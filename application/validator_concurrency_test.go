@@ -0,0 +1,447 @@
+package application
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	validator "gopkg.in/go-playground/validator.v9"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/mocks"
+)
+
+type (
+	ValidatorConcurrencyTestSuite struct {
+		suite.Suite
+
+		provider *ValidatorProviderImpl
+		context  context.Context
+	}
+
+	// validatorConcurrencyRemoteValidator simulates context field validator which calls remote service,
+	// by sleeping for defined delay. Values "invalid" are not valid, and value "panic" panics.
+	validatorConcurrencyRemoteValidator struct {
+		name    string
+		delay   time.Duration
+		calls   int32
+		running *int32
+		maximum *int32
+	}
+
+	validatorConcurrencyCheckoutTestData struct {
+		TaxID   string `form:"taxId" validate:"required,taxid"`
+		Street  string `form:"street" validate:"required,address"`
+		Email   string `form:"email" validate:"required,email,mxrecord"`
+		Company string `form:"company" validate:"blacklist"`
+	}
+)
+
+func TestValidatorConcurrencyTestSuite(t *testing.T) {
+	suite.Run(t, &ValidatorConcurrencyTestSuite{})
+}
+
+func (t *ValidatorConcurrencyTestSuite) SetupTest() {
+	t.provider = &ValidatorProviderImpl{
+		concurrency: 4,
+	}
+	t.context = context.Background()
+}
+
+func (v *validatorConcurrencyRemoteValidator) ValidatorName() string {
+	return v.name
+}
+
+func (v *validatorConcurrencyRemoteValidator) ValidateWithContext(_ context.Context, fl validator.FieldLevel) bool {
+	atomic.AddInt32(&v.calls, 1)
+
+	if v.running != nil {
+		running := atomic.AddInt32(v.running, 1)
+		defer atomic.AddInt32(v.running, -1)
+
+		for {
+			maximum := atomic.LoadInt32(v.maximum)
+			if running <= maximum || atomic.CompareAndSwapInt32(v.maximum, maximum, running) {
+				break
+			}
+		}
+	}
+
+	time.Sleep(v.delay)
+
+	switch fl.Field().String() {
+	case "panic":
+		panic("remote service is not available")
+	case "invalid":
+		return false
+	}
+
+	return true
+}
+
+// fieldLevel creates field level of struct field with defined name, as it's passed by validator.Validate,
+// where parent is pointer to validated struct, so its fields are addressable
+func (t *ValidatorConcurrencyTestSuite) fieldLevel(parent interface{}, structFieldName string, param string) *mocks.FieldLevel {
+	parentValue := reflect.Indirect(reflect.ValueOf(parent))
+	field, _ := parentValue.Type().FieldByName(structFieldName)
+
+	fl := &mocks.FieldLevel{}
+	fl.On("Top").Return(parentValue)
+	fl.On("Parent").Return(parentValue)
+	fl.On("Field").Return(parentValue.FieldByName(structFieldName))
+	fl.On("FieldName").Return(field.Tag.Get("form"))
+	fl.On("StructFieldName").Return(structFieldName)
+	fl.On("Param").Return(param)
+	fl.On("GetStructFieldOK").Return(reflect.Value{}, reflect.Invalid, false)
+
+	return fl
+}
+
+// validateTwice simulates both passes of validator.Validate over the struct, with the same field levels,
+// and returns results of second pass
+func (t *ValidatorConcurrencyTestSuite) validateTwice(batch *contextValidationBatch, validators map[string]validator.FuncCtx, fieldLevels map[string]validator.FieldLevel, order []string) []bool {
+	ctx := batch.withContext(t.context)
+
+	for _, name := range order {
+		t.True(validators[name](ctx, fieldLevels[name]), "first pass always succeeds")
+	}
+	batch.wait()
+
+	results := make([]bool, 0, len(order))
+	for _, name := range order {
+		results = append(results, validators[name](ctx, fieldLevels[name]))
+	}
+
+	return results
+}
+
+func (t *ValidatorConcurrencyTestSuite) TestConcurrentContextValidation() {
+	data := validatorConcurrencyCheckoutTestData{
+		TaxID:   "DE123456789",
+		Street:  "invalid",
+		Email:   "jane@example.com",
+		Company: "invalid",
+	}
+
+	// validators sleep different durations, so they finish in different order than they are started
+	remoteValidators := map[string]*validatorConcurrencyRemoteValidator{
+		"taxid":     {name: "taxid", delay: 40 * time.Millisecond},
+		"address":   {name: "address", delay: 10 * time.Millisecond},
+		"mxrecord":  {name: "mxrecord", delay: 30 * time.Millisecond},
+		"blacklist": {name: "blacklist", delay: 20 * time.Millisecond},
+	}
+	validators := map[string]validator.FuncCtx{}
+	for name, remoteValidator := range remoteValidators {
+		validators[name] = t.provider.concurrentContextValidation(name, remoteValidator.ValidateWithContext)
+	}
+
+	fieldLevels := map[string]validator.FieldLevel{
+		"taxid":     t.fieldLevel(&data, "TaxID", ""),
+		"address":   t.fieldLevel(&data, "Street", ""),
+		"mxrecord":  t.fieldLevel(&data, "Email", ""),
+		"blacklist": t.fieldLevel(&data, "Company", ""),
+	}
+
+	start := time.Now()
+	results := t.validateTwice(newContextValidationBatch(4), validators, fieldLevels, []string{"taxid", "address", "mxrecord", "blacklist"})
+	t.Less(int64(time.Since(start)), int64(100*time.Millisecond))
+
+	t.Equal([]bool{true, false, true, false}, results)
+	for name, remoteValidator := range remoteValidators {
+		t.Equal(int32(1), remoteValidator.calls, name)
+	}
+}
+
+func (t *ValidatorConcurrencyTestSuite) TestConcurrentContextValidation_Limit() {
+	var running, maximum int32
+	remoteValidator := &validatorConcurrencyRemoteValidator{
+		name:    "blacklist",
+		delay:   5 * time.Millisecond,
+		running: &running,
+		maximum: &maximum,
+	}
+	validate := t.provider.concurrentContextValidation("blacklist", remoteValidator.ValidateWithContext)
+
+	validators := map[string]validator.FuncCtx{}
+	fieldLevels := map[string]validator.FieldLevel{}
+	var order []string
+	for _, company := range []string{"Acme", "Globex", "Initech", "Umbrella", "Hooli", "invalid"} {
+		validators[company] = validate
+		fieldLevels[company] = t.fieldLevel(&validatorConcurrencyCheckoutTestData{Company: company}, "Company", "")
+		order = append(order, company)
+	}
+
+	results := t.validateTwice(newContextValidationBatch(2), validators, fieldLevels, order)
+	t.Equal([]bool{true, true, true, true, true, false}, results)
+	t.Equal(int32(6), remoteValidator.calls)
+	t.Equal(int32(2), maximum)
+}
+
+func (t *ValidatorConcurrencyTestSuite) TestConcurrentContextValidation_Panic() {
+	data := validatorConcurrencyCheckoutTestData{
+		TaxID:   "panic",
+		Street:  "invalid",
+		Company: "panic",
+	}
+
+	validators := map[string]validator.FuncCtx{
+		"taxid":     t.provider.concurrentContextValidation("taxid", (&validatorConcurrencyRemoteValidator{name: "taxid"}).ValidateWithContext),
+		"address":   t.provider.concurrentContextValidation("address", (&validatorConcurrencyRemoteValidator{name: "address", delay: 10 * time.Millisecond}).ValidateWithContext),
+		"blacklist": t.provider.concurrentContextValidation("blacklist", (&validatorConcurrencyRemoteValidator{name: "blacklist"}).ValidateWithContext),
+	}
+	fieldLevels := map[string]validator.FieldLevel{
+		"taxid":     t.fieldLevel(&data, "TaxID", ""),
+		"address":   t.fieldLevel(&data, "Street", ""),
+		"blacklist": t.fieldLevel(&data, "Company", ""),
+	}
+
+	batch := newContextValidationBatch(4)
+	results := t.validateTwice(batch, validators, fieldLevels, []string{"taxid", "address", "blacklist"})
+
+	// fields of panicking validators are treated as valid, while result of another validator is kept
+	t.Equal([]bool{true, false, true}, results)
+
+	validationInfo := domain.ValidationInfo{}
+	batch.addPanicErrors(&validationInfo, DefaultMessageKeyPrefix)
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.validatorPanic",
+			DefaultLabel: `validator "taxid" of field "taxId" panicked: remote service is not available`,
+			Parameters: map[string]string{
+				"field":     "taxId",
+				"validator": "taxid",
+			},
+		},
+		{
+			MessageKey:   "formError.validatorPanic",
+			DefaultLabel: `validator "blacklist" of field "company" panicked: remote service is not available`,
+			Parameters: map[string]string{
+				"field":     "company",
+				"validator": "blacklist",
+			},
+		},
+	}, validationInfo.GetGeneralErrors())
+}
+
+func (t *ValidatorConcurrencyTestSuite) TestConcurrentContextValidation_SameValues() {
+	remoteValidator := &validatorConcurrencyRemoteValidator{name: "blacklist"}
+	validate := t.provider.concurrentContextValidation("blacklist", remoteValidator.ValidateWithContext)
+
+	// the same validation of the same field is started only once, while rows with the same values are validated separately
+	data := validatorConcurrencyCheckoutTestData{Company: "Acme"}
+	validators := map[string]validator.FuncCtx{
+		"first":  validate,
+		"again":  validate,
+		"strict": validate,
+		"row":    validate,
+	}
+	fieldLevels := map[string]validator.FieldLevel{
+		"first":  t.fieldLevel(&data, "Company", ""),
+		"again":  t.fieldLevel(&data, "Company", ""),
+		"strict": t.fieldLevel(&data, "Company", "strict"),
+		"row":    t.fieldLevel(&validatorConcurrencyCheckoutTestData{Company: "Acme"}, "Company", ""),
+	}
+
+	results := t.validateTwice(newContextValidationBatch(4), validators, fieldLevels, []string{"first", "again", "strict", "row"})
+	t.Equal([]bool{true, true, true, true}, results)
+	t.Equal(int32(3), remoteValidator.calls)
+}
+
+func (t *ValidatorConcurrencyTestSuite) TestConcurrentContextValidation_NotAddressable() {
+	remoteValidator := &validatorConcurrencyRemoteValidator{name: "address"}
+	validate := t.provider.concurrentContextValidation("address", remoteValidator.ValidateWithContext)
+
+	// field of struct which can't be located, like struct stored as value of map, is validated only in second pass
+	fl := t.fieldLevel(validatorConcurrencyCheckoutTestData{Street: "invalid"}, "Street", "")
+	results := t.validateTwice(newContextValidationBatch(4), map[string]validator.FuncCtx{"address": validate}, map[string]validator.FieldLevel{"address": fl}, []string{"address"})
+	t.Equal([]bool{false}, results)
+	t.Equal(int32(1), remoteValidator.calls)
+}
+
+func (t *ValidatorConcurrencyTestSuite) TestSkipWhileCollecting() {
+	var calls int32
+	validate := skipWhileCollecting(func(context.Context, validator.FieldLevel) bool {
+		atomic.AddInt32(&calls, 1)
+		return false
+	})
+	fl := t.fieldLevel(&validatorConcurrencyCheckoutTestData{}, "Street", "")
+
+	// field validators are called only in second pass, or when there is no batch at all
+	batch := newContextValidationBatch(4)
+	ctx := batch.withContext(t.context)
+	t.True(validate(ctx, fl))
+	batch.wait()
+	t.False(validate(ctx, fl))
+	t.False(validate(t.context, fl))
+	t.Equal(int32(2), calls)
+}
+
+func (t *ValidatorConcurrencyTestSuite) TestAddressableValue() {
+	data := validatorConcurrencyCheckoutTestData{Company: "Acme"}
+
+	copied := addressableValue(data)
+	t.Equal(&data, copied)
+	t.True(reflect.ValueOf(copied).Elem().Field(0).CanAddr())
+
+	t.Equal(&data, addressableValue(&data))
+	t.Nil(addressableValue(nil))
+}
+
+func (t *ValidatorConcurrencyTestSuite) TestConcurrentContextValidation_Sequential() {
+	remoteValidator := &validatorConcurrencyRemoteValidator{name: "address"}
+	validate := t.provider.concurrentContextValidation("address", remoteValidator.ValidateWithContext)
+
+	// without batch in context, like when validator.Validate is used directly, field is validated immediately
+	t.False(validate(t.context, t.fieldLevel(&validatorConcurrencyCheckoutTestData{Street: "invalid"}, "Street", "")))
+	t.Equal(int32(1), remoteValidator.calls)
+
+	// field which isn't validated in first pass is validated in second pass
+	batch := newContextValidationBatch(4)
+	batch.wait()
+	t.False(validate(batch.withContext(t.context), t.fieldLevel(&validatorConcurrencyCheckoutTestData{Street: "invalid"}, "Street", "")))
+	t.Equal(int32(2), remoteValidator.calls)
+}
+
+func (t *ValidatorConcurrencyTestSuite) TestCombineStructValidators_Collecting() {
+	structValidator := &mocks.StructValidator{}
+	structLevel := &mocks.StructLevel{}
+	validateStruct := t.provider.combineStructValidators([]domain.StructValidator{structValidator})
+
+	batch := newContextValidationBatch(4)
+	ctx := batch.withContext(t.context)
+
+	// struct validators run only after all context field validations are finished
+	validateStruct(ctx, structLevel)
+	batch.wait()
+
+	structValidator.On("ValidateStruct", ctx, structLevel).Return().Once()
+	validateStruct(ctx, structLevel)
+
+	structValidator.AssertExpectations(t.T())
+}
+
+func (t *ValidatorConcurrencyTestSuite) TestFieldLevelSnapshot() {
+	data := validatorConcurrencyCheckoutTestData{Street: "Main Street"}
+
+	fl := t.fieldLevel(&data, "Street", "")
+	fl.On("ExtractType", mock.Anything).Return(reflect.ValueOf("Main Street"), reflect.String, false).Once()
+
+	snapshot := newFieldLevelSnapshot(fl)
+	t.Equal(data, snapshot.Top().Interface())
+	t.Equal(data, snapshot.Parent().Interface())
+	t.Equal("Main Street", snapshot.Field().String())
+	t.Equal("street", snapshot.FieldName())
+	t.Equal("Street", snapshot.StructFieldName())
+	t.Equal("", snapshot.Param())
+
+	value, kind, nullable := snapshot.ExtractType(snapshot.Field())
+	t.Equal("Main Street", value.String())
+	t.Equal(reflect.String, kind)
+	t.False(nullable)
+
+	_, _, found := snapshot.GetStructFieldOK()
+	t.False(found)
+
+	fl.AssertExpectations(t.T())
+}
+
+func (t *ValidatorConcurrencyTestSuite) TestFieldLevelSnapshot_StructFieldPanic() {
+	fl := &mocks.FieldLevel{}
+	fl.On("Top").Return(reflect.Value{})
+	fl.On("Parent").Return(reflect.Value{})
+	fl.On("Field").Return(reflect.Value{})
+	fl.On("FieldName").Return("items")
+	fl.On("StructFieldName").Return("Items")
+	fl.On("Param").Return("Items[")
+	fl.On("GetStructFieldOK").Run(func(args mock.Arguments) {
+		panic("invalid namespace")
+	})
+
+	// panic is raised only if field referenced by parameter is requested by validator
+	snapshot := newFieldLevelSnapshot(fl)
+	t.Equal("items", snapshot.FieldName())
+	t.Panics(func() {
+		snapshot.GetStructFieldOK()
+	})
+}
+
+func (t *ValidatorConcurrencyTestSuite) TestConcurrentContextValidation_Race() {
+	remoteValidator := &validatorConcurrencyRemoteValidator{name: "blacklist"}
+	validate := t.provider.concurrentContextValidation("blacklist", remoteValidator.ValidateWithContext)
+
+	// parallel validations have their own batches, while sharing validators and validated data
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			company := "Acme"
+			if i%2 == 0 {
+				company = "invalid"
+			}
+			data := reflect.ValueOf(&validatorConcurrencyCheckoutTestData{Company: company}).Elem()
+
+			batch := newContextValidationBatch(2)
+			ctx := batch.withContext(t.context)
+			fl := &fieldLevelSnapshot{
+				top:             data,
+				parent:          data,
+				field:           data.FieldByName("Company"),
+				fieldName:       "company",
+				structFieldName: "Company",
+			}
+
+			validate(ctx, fl)
+			batch.wait()
+			t.Equal(company != "invalid", validate(ctx, fl))
+		}(i)
+	}
+	wg.Wait()
+
+	t.Equal(int32(10), remoteValidator.calls)
+}
+
+func BenchmarkValidatorProvider_ContextValidators(b *testing.B) {
+	contextFieldValidators := []domain.ContextFieldValidator{
+		&validatorConcurrencyRemoteValidator{name: "taxid", delay: 4 * time.Millisecond},
+		&validatorConcurrencyRemoteValidator{name: "address", delay: 3 * time.Millisecond},
+		&validatorConcurrencyRemoteValidator{name: "mxrecord", delay: 2 * time.Millisecond},
+		&validatorConcurrencyRemoteValidator{name: "blacklist", delay: 1 * time.Millisecond},
+	}
+	data := validatorConcurrencyCheckoutTestData{
+		TaxID:   "DE123456789",
+		Street:  "Main Street",
+		Email:   "jane@example.com",
+		Company: "Acme",
+	}
+
+	for _, benchmark := range []struct {
+		name        string
+		concurrency float64
+	}{
+		{name: "sequential", concurrency: 0},
+		{name: "concurrent", concurrency: 4},
+	} {
+		provider := &ValidatorProviderImpl{}
+		provider.Inject(nil, contextFieldValidators, nil, nil, nil, &struct {
+			FirstErrorOnly bool    `inject:"config:form.validator.firstErrorOnly"`
+			Concurrency    float64 `inject:"config:form.validator.concurrency"`
+		}{
+			Concurrency: benchmark.concurrency,
+		})
+		request := web.CreateRequest(nil, web.EmptySession())
+
+		b.Run(benchmark.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				provider.Validate(context.Background(), request, data)
+			}
+		})
+	}
+}
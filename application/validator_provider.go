@@ -2,8 +2,14 @@ package application
 
 import (
 	"context"
+	"fmt"
+	"mime/multipart"
+	"reflect"
 	"strings"
+	"sync"
+	"unicode"
 
+	ut "github.com/go-playground/universal-translator"
 	validator "gopkg.in/go-playground/validator.v9"
 
 	"flamingo.me/flamingo/v3/framework/web"
@@ -13,27 +19,164 @@ import (
 type (
 	// ValidatorProviderImpl as struct which implements interface ValidatorProvider
 	ValidatorProviderImpl struct {
-		validate *validator.Validate
+		validate    *validator.Validate
+		labelFunc   domain.LabelFunc
+		messageKeys *ValidationMessageKeys
+		warningTags map[string]bool
+		// criteriaValidators contains field validators which report their failed criteria as separate errors, by their tags
+		criteriaValidators map[string]domain.CriteriaFieldValidator
+		// firstErrorOnly flag if only first error is kept for each field, unless field is tagged with `validateMode:"all"`
+		firstErrorOnly bool
+		// concurrency as maximal number of context field validations which run at once, where zero means sequential validation
+		concurrency int
+		// structFields contains *structFieldMetadata for each structFieldKey, since validation errors
+		// of the same form data type are resolved to the same struct fields on every request
+		structFields sync.Map
+	}
+
+	// structFieldKey as key of cached struct field, where indices and map keys are removed from struct namespace
+	// (like "FormData.Items[].Sku"), so all rows of slice or map share the same entry
+	structFieldKey struct {
+		typeOf    reflect.Type
+		namespace string
+	}
+
+	// fieldErrorEntry as field error which is prepared to be added into validation info
+	fieldErrorEntry struct {
+		err        validator.FieldError
+		fieldName  string
+		messageKey string
+		label      string
+		params     map[string]string
+	}
+
+	// requiredFieldError as validation error of nil pointer field, which is reported as "required" error,
+	// since validator reports only first validation rule of nil pointer, even if "required" is defined later
+	requiredFieldError struct {
+		validator.FieldError
+	}
+
+	// nilEmbedFieldError as validation error of nil pointer to unexported embedded struct, which is reported by
+	// ValidatorProviderImpl itself, since validator.Validate panics while it reports error of such field
+	nilEmbedFieldError struct {
+		tag             string
+		param           string
+		namespace       string
+		structNamespace string
+		field           string
+		structField     string
+		typeOf          reflect.Type
+	}
+
+	// structFieldMetadata as cached result of struct field lookup, together with its resolved label
+	structFieldMetadata struct {
+		field    reflect.StructField
+		found    bool
+		label    string
+		hasLabel bool
 	}
 )
 
-var _ domain.ValidatorProvider = &ValidatorProviderImpl{}
+const (
+	// errorsTagCollapse as value of "errors" tag, which reports errors of slice, array or map elements for the field itself
+	errorsTagCollapse = "collapse"
+	// formStepTag as name of tag which binds field, together with its nested fields, to steps of multi-step form
+	formStepTag = "formstep"
+	// validateModeTag as name of tag which overrides configured error collapsing for single field
+	validateModeTag = "validateMode"
+	// validateModeAll as value of "validateMode" tag, which keeps all errors of the field
+	validateModeAll = "all"
+	// validateModeFirst as value of "validateMode" tag, which keeps only first error of the field
+	validateModeFirst = "first"
+)
+
+var (
+	_ domain.ValidatorProvider = &ValidatorProviderImpl{}
+	_ validator.FieldError     = &nilEmbedFieldError{}
+
+	// crossFieldTags contains validation tags which reference other fields as their parameter
+	crossFieldTags = map[string]bool{
+		"eqfield":              true,
+		"nefield":              true,
+		"gtfield":              true,
+		"gtefield":             true,
+		"ltfield":              true,
+		"ltefield":             true,
+		"eqcsfield":            true,
+		"necsfield":            true,
+		"gtcsfield":            true,
+		"gtecsfield":           true,
+		"ltcsfield":            true,
+		"ltecsfield":           true,
+		"required_with":        true,
+		"required_with_all":    true,
+		"required_without":     true,
+		"required_without_all": true,
+		"required_if":          true,
+		"required_unless":      true,
+		"passwordconfirm":      true,
+	}
+)
+
+// Inject initialize instance of validator.Validate struct.
+// Instance is created only once and reused for all validations, since context of each validation
+// is passed by validator.Validate to all context field validators. Message keys and default labels of validation
+// errors are built by using injected ValidationMessageKeys.
+func (p *ValidatorProviderImpl) Inject(fieldValidators []domain.FieldValidator, contextFieldValidators []domain.ContextFieldValidator, warningFieldValidators []domain.WarningFieldValidator, structValidators []domain.StructValidator, messageKeys *ValidationMessageKeys, cfg *struct {
+	FirstErrorOnly bool    `inject:"config:form.validator.firstErrorOnly"`
+	Concurrency    float64 `inject:"config:form.validator.concurrency"`
+}) {
+	if cfg != nil {
+		p.firstErrorOnly = cfg.FirstErrorOnly
+		if cfg.Concurrency > 0 {
+			p.concurrency = int(cfg.Concurrency)
+		}
+	}
 
-// Inject initialize instance of validator.Validate struct
-func (p *ValidatorProviderImpl) Inject(fieldValidators []domain.FieldValidator, structValidators []domain.StructValidator) {
 	validate := validator.New()
+	validate.RegisterTagNameFunc(p.getFormFieldName)
+	p.attachCustomTypes(validate)
 	p.attachFieldValidators(validate, fieldValidators)
+	p.attachContextFieldValidators(validate, contextFieldValidators)
+	p.attachWarningFieldValidators(validate, warningFieldValidators)
 	p.attachStructValidators(validate, structValidators)
 	p.validate = validate
+	p.labelFunc = p.getLabelFromTag
+	p.messageKeys = messageKeys
 }
 
-// Validate method which validates any struct and returns domain.ValidationInfo as a result of validation
+// Validate method which validates any struct and returns domain.ValidationInfo as a result of validation.
+// In case when context contains active form steps, errors of fields which belong to other steps are discarded.
+// In case when concurrency is configured, context field validators of all fields run concurrently before
+// struct validators, and validators which panic are reported as general errors, instead of failing the validation.
+// Struct is then validated through pointer to its copy, so its fields can be located in both passes.
 func (p *ValidatorProviderImpl) Validate(ctx context.Context, req *web.Request, value interface{}) domain.ValidationInfo {
 	reqCtx := web.ContextWithRequest(ctx, req)
-	validate := p.GetValidator()
-	err := validate.StructCtx(reqCtx, value)
 
-	return p.ErrorsToValidationInfo(err)
+	validated := value
+	var batch *contextValidationBatch
+	if p.concurrency > 0 {
+		validated = addressableValue(value)
+		batch = p.prefetchContextValidations(reqCtx, validated)
+		reqCtx = batch.withContext(reqCtx)
+	}
+
+	err := p.validateStruct(reqCtx, validated)
+
+	var activeSteps map[string]bool
+	if steps, ok := domain.ActiveStepsFromContext(ctx); ok {
+		activeSteps = make(map[string]bool, len(steps))
+		for _, step := range steps {
+			activeSteps[step] = true
+		}
+	}
+
+	validationInfo := p.errorsToValidationInfo(err, reflect.TypeOf(value), activeSteps)
+	if batch != nil {
+		batch.addPanicErrors(&validationInfo, p.messageKeys.GetPrefix())
+	}
+
+	return validationInfo
 }
 
 // GetValidator method which returns instance of validator.Validate struct with all injected field and struct validations
@@ -43,6 +186,32 @@ func (p *ValidatorProviderImpl) GetValidator() *validator.Validate {
 
 // ErrorsToValidationInfo method which transforms errors into domain.ValidationInfo
 func (p *ValidatorProviderImpl) ErrorsToValidationInfo(err error) domain.ValidationInfo {
+	return p.errorsToValidationInfo(err, nil, nil)
+}
+
+// RegisterLabelFunc method which registers function for resolving field labels, used in errors' default labels.
+// It should be called on application startup, since it's not thread safe.
+func (p *ValidatorProviderImpl) RegisterLabelFunc(fn domain.LabelFunc) {
+	// cached labels are resolved by previous function
+	p.ResetMetadataCache()
+	p.labelFunc = fn
+}
+
+// ResetMetadataCache method which removes cached struct fields and labels of all validated types.
+// It's meant to be used by tests, which validate different types with the same provider instance.
+func (p *ValidatorProviderImpl) ResetMetadataCache() {
+	p.structFields.Range(func(key interface{}, _ interface{}) bool {
+		p.structFields.Delete(key)
+		return true
+	})
+}
+
+// errorsToValidationInfo method which transforms errors into domain.ValidationInfo.
+// If type of validated struct is known, field labels are resolved from its fields.
+// If active steps are defined (not nil), only errors of fields from active steps and fields without step are kept.
+// Errors with tags of warning field validators, also when they are reported by struct validators, are added as field warnings.
+// In case when only first error should be kept, other errors of the same field are discarded.
+func (p *ValidatorProviderImpl) errorsToValidationInfo(err error, typeOf reflect.Type, activeSteps map[string]bool) domain.ValidationInfo {
 	validationInfo := domain.ValidationInfo{}
 
 	if err == nil {
@@ -50,45 +219,854 @@ func (p *ValidatorProviderImpl) ErrorsToValidationInfo(err error) domain.Validat
 	}
 
 	if validationErrors, ok := err.(validator.ValidationErrors); ok {
+		entries := make([]fieldErrorEntry, 0, len(validationErrors))
 		for _, err := range validationErrors {
-			fieldName := p.getRelativeFieldNameFromValidationError(err)
-			validationInfo.AddFieldError(fieldName, "formError."+fieldName+"."+err.Tag(), err.Field()+" "+err.Tag())
+			if activeSteps != nil && !p.isInActiveSteps(typeOf, err, activeSteps) {
+				continue
+			}
+
+			err, ok := p.getNilPointerError(typeOf, err)
+			if !ok {
+				continue
+			}
+
+			fieldName := p.getRelativeFieldNameFromValidationError(typeOf, err)
+			label := p.getFieldLabel(typeOf, err)
+			tag := err.Tag()
+			params := p.getParamsFromValidationError(err, label)
+			if p.warningTags[tag] {
+				validationInfo.AddFieldWarningWithParams(fieldName, p.messageKeys.GetMessageKey(fieldName, tag), p.messageKeys.GetDefaultLabel(label, tag, params), params)
+				continue
+			}
+			if criteriaEntries := p.getCriteriaEntries(err, tag, fieldName, label); len(criteriaEntries) > 0 {
+				entries = append(entries, criteriaEntries...)
+				continue
+			}
+			entries = append(entries, fieldErrorEntry{
+				err:        err,
+				fieldName:  fieldName,
+				messageKey: p.messageKeys.GetMessageKey(fieldName, tag),
+				label:      p.messageKeys.GetDefaultLabel(label, tag, params),
+				params:     params,
+			})
+		}
+
+		for _, entry := range p.collapseFieldErrors(typeOf, entries) {
+			validationInfo.AddFieldErrorWithParams(entry.fieldName, entry.messageKey, entry.label, entry.params)
 		}
 	} else {
-		validationInfo.AddGeneralError("formError.invalidValidation", err.Error())
+		validationInfo.AddGeneralError(p.messageKeys.GetPrefix()+".invalidValidation", err.Error())
 	}
 
 	return validationInfo
 }
 
-// attachFieldValidators method which attach all injected instances of FieldValidator interface into validator.Validate instance
+// getCriteriaEntries method which prepares one field error for each failed criterion of criteria field validator.
+// Criteria are used as tags of errors, so each of them gets its own message key and default label (like
+// "formError.password.passwordlength"), while ranking of errors still uses tag of the validator.
+func (p *ValidatorProviderImpl) getCriteriaEntries(err validator.FieldError, tag string, fieldName string, label string) []fieldErrorEntry {
+	criteriaValidator, ok := p.criteriaValidators[tag]
+	if !ok {
+		return nil
+	}
+
+	criteria := criteriaValidator.FailedCriteria(err.Value(), err.Param())
+	entries := make([]fieldErrorEntry, 0, len(criteria))
+	for _, criterion := range criteria {
+		params := map[string]string{
+			"tag":   criterion.Name,
+			"field": label,
+		}
+		if criterion.Value != "" {
+			params["param"] = criterion.Value
+			params[criterion.Name] = criterion.Value
+		}
+
+		entries = append(entries, fieldErrorEntry{
+			err:        err,
+			fieldName:  fieldName,
+			messageKey: p.messageKeys.GetMessageKey(fieldName, criterion.Name),
+			label:      p.messageKeys.GetDefaultLabel(label, criterion.Name, params),
+			params:     params,
+		})
+	}
+
+	return entries
+}
+
+// collapseFieldErrors method which keeps only first error of each field, if first error only mode is configured
+// or if field is tagged with `validateMode:"first"`. Fields tagged with `validateMode:"all"` keep all their errors.
+// First error is chosen by order of tags in field's "validate" tag, while "required" errors always win over
+// all others. Errors with tags which are not defined in field's tag (like ones reported by struct validators)
+// come after them, in order they are reported. Order of remaining errors is preserved.
+func (p *ValidatorProviderImpl) collapseFieldErrors(typeOf reflect.Type, entries []fieldErrorEntry) []fieldErrorEntry {
+	if len(entries) < 2 {
+		return entries
+	}
+
+	chosen := make(map[string]int, len(entries))
+	ranks := make(map[string]int, len(entries))
+	for i, entry := range entries {
+		var field reflect.StructField
+		found := false
+		if typeOf != nil {
+			field, found = p.getStructField(typeOf, entry.err.StructNamespace())
+		}
+
+		if !p.isFirstErrorOnly(field, found) {
+			continue
+		}
+
+		rank := p.getErrorRank(field, found, entry.err.Tag())
+		if current, ok := ranks[entry.fieldName]; !ok || rank < current {
+			chosen[entry.fieldName] = i
+			ranks[entry.fieldName] = rank
+		}
+	}
+
+	if len(chosen) == 0 {
+		return entries
+	}
+
+	collapsed := make([]fieldErrorEntry, 0, len(entries))
+	for i, entry := range entries {
+		if index, ok := chosen[entry.fieldName]; ok && index != i {
+			continue
+		}
+		collapsed = append(collapsed, entry)
+	}
+
+	return collapsed
+}
+
+// isFirstErrorOnly method which checks if only first error should be kept for the field, by using its "validateMode" tag,
+// or configured mode if tag is not defined
+func (p *ValidatorProviderImpl) isFirstErrorOnly(field reflect.StructField, found bool) bool {
+	if found {
+		switch field.Tag.Get(validateModeTag) {
+		case validateModeAll:
+			return false
+		case validateModeFirst:
+			return true
+		}
+	}
+
+	return p.firstErrorOnly
+}
+
+// getErrorRank method which defines position of error's tag within field's "validate" tag, where "required" tags
+// are always first, and tags which are not defined for the field are last
+func (p *ValidatorProviderImpl) getErrorRank(field reflect.StructField, found bool, tag string) int {
+	if p.isRequiredTag(tag) {
+		return 0
+	}
+
+	if !found {
+		return 1
+	}
+
+	tags := strings.Split(field.Tag.Get("validate"), ",")
+	for i, fieldTag := range tags {
+		if strings.SplitN(fieldTag, "=", 2)[0] == tag {
+			return i + 1
+		}
+	}
+
+	return len(tags) + 1
+}
+
+// getNilPointerError method which decides how error of nil pointer field (like *string, which is not submitted) is reported.
+// Nil pointer means that value is not defined, so rules like "min", "max" or custom field validators are skipped,
+// and error is reported only if field is tagged with "required" (or conditional "required_..." rules).
+// Errors of other fields, and errors reported by struct validators, are returned without any change.
+// It returns false if error should be skipped.
+func (p *ValidatorProviderImpl) getNilPointerError(typeOf reflect.Type, err validator.FieldError) (validator.FieldError, bool) {
+	if typeOf == nil {
+		return err, true
+	}
+
+	if valueOf := reflect.ValueOf(err.Value()); valueOf.Kind() != reflect.Ptr || !valueOf.IsNil() {
+		return err, true
+	}
+
+	field, ok := p.getStructField(typeOf, err.StructNamespace())
+	if !ok || p.isRequiredTag(err.Tag()) {
+		return err, true
+	}
+
+	// rules after "dive" are applied to elements, so they are not rules of the field itself
+	tags := strings.Split(strings.SplitN(field.Tag.Get("validate"), ",dive", 2)[0], ",")
+	isFieldRule := false
+	isRequired := false
+	for _, tag := range tags {
+		name := strings.SplitN(tag, "=", 2)[0]
+		isFieldRule = isFieldRule || name == err.Tag()
+		isRequired = isRequired || name == "required"
+	}
+
+	switch {
+	case !isFieldRule:
+		return err, true
+	case isRequired:
+		return &requiredFieldError{FieldError: err}, true
+	}
+
+	return nil, false
+}
+
+// isRequiredTag method which checks if validation tag is "required" or any of conditional "required_..." tags
+func (p *ValidatorProviderImpl) isRequiredTag(tag string) bool {
+	return tag == "required" || strings.HasPrefix(tag, "required_")
+}
+
+// Tag returns "required" as tag of error
+func (e *requiredFieldError) Tag() string {
+	return "required"
+}
+
+// ActualTag returns "required" as actual tag of error
+func (e *requiredFieldError) ActualTag() string {
+	return "required"
+}
+
+// Param returns empty parameter, since "required" validation rule doesn't have any
+func (e *requiredFieldError) Param() string {
+	return ""
+}
+
+// getFormFieldName method which is used as validator.TagNameFunc, so errors' namespaces are built from form field names.
+// Form field name is taken from "form" tag, and if it's not defined, struct field name is used with lower first character.
+func (p *ValidatorProviderImpl) getFormFieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("form"), ",")[0]
+	if name != "" && name != "-" {
+		return name
+	}
+
+	runes := []rune(field.Name)
+	runes[0] = unicode.ToLower(runes[0])
+
+	return string(runes)
+}
+
+// getLabelFromTag method which is used as default domain.LabelFunc, and it reads field label from "label" tag
+func (p *ValidatorProviderImpl) getLabelFromTag(field reflect.StructField) string {
+	return field.Tag.Get("label")
+}
+
+// getFieldLabel method which resolves field label by using registered domain.LabelFunc.
+// Field is found by following error's struct namespace from validated struct type, so labels of nested fields can be resolved.
+// If label can't be resolved, struct field name is used.
+func (p *ValidatorProviderImpl) getFieldLabel(typeOf reflect.Type, err validator.FieldError) string {
+	if typeOf != nil && p.labelFunc != nil {
+		namespace := err.StructNamespace()
+		if metadata := p.getStructFieldMetadata(typeOf, namespace); metadata.found {
+			if !metadata.hasLabel {
+				// concurrent requests can resolve the same label, which is fine, since label function is expected to be pure
+				metadata = &structFieldMetadata{
+					field:    metadata.field,
+					found:    true,
+					label:    p.labelFunc(metadata.field),
+					hasLabel: true,
+				}
+				p.storeStructFieldMetadata(typeOf, namespace, metadata)
+			}
+
+			if metadata.label != "" {
+				return metadata.label
+			}
+		}
+	}
+
+	return err.StructField()
+}
+
+// getStructField method which finds struct field by its struct namespace (like "FormData.Items[1].Sku").
+// First part of namespace is name of validated struct, so it's skipped.
+func (p *ValidatorProviderImpl) getStructField(typeOf reflect.Type, namespace string) (reflect.StructField, bool) {
+	metadata := p.getStructFieldMetadata(typeOf, namespace)
+
+	return metadata.field, metadata.found
+}
+
+// getStructFieldMetadata method which returns cached result of struct field lookup, or finds struct field
+// and caches it, if namespace can be cached
+func (p *ValidatorProviderImpl) getStructFieldMetadata(typeOf reflect.Type, namespace string) *structFieldMetadata {
+	key, cacheable := p.getStructFieldKey(typeOf, namespace)
+	if cacheable {
+		if cached, ok := p.structFields.Load(key); ok {
+			return cached.(*structFieldMetadata)
+		}
+	}
+
+	field, found := p.findStructField(typeOf, namespace)
+	metadata := &structFieldMetadata{
+		field: field,
+		found: found,
+	}
+	if cacheable {
+		p.structFields.Store(key, metadata)
+	}
+
+	return metadata
+}
+
+// storeStructFieldMetadata method which replaces cached result of struct field lookup, if namespace can be cached
+func (p *ValidatorProviderImpl) storeStructFieldMetadata(typeOf reflect.Type, namespace string, metadata *structFieldMetadata) {
+	if key, cacheable := p.getStructFieldKey(typeOf, namespace); cacheable {
+		p.structFields.Store(key, metadata)
+	}
+}
+
+// getStructFieldKey method which creates cache key of struct field, by removing indices and map keys from namespace.
+// Namespaces with nested brackets (like map keys which contain brackets) are not cached, so submitted map keys
+// can't grow the cache.
+func (p *ValidatorProviderImpl) getStructFieldKey(typeOf reflect.Type, namespace string) (structFieldKey, bool) {
+	var result strings.Builder
+	depth := 0
+
+	for _, r := range namespace {
+		switch {
+		case r == '[':
+			depth++
+			if depth > 1 {
+				return structFieldKey{}, false
+			}
+			result.WriteRune(r)
+		case r == ']' && depth > 0:
+			depth--
+			result.WriteRune(r)
+		case depth == 0:
+			result.WriteRune(r)
+		}
+	}
+
+	return structFieldKey{
+		typeOf:    typeOf,
+		namespace: result.String(),
+	}, true
+}
+
+// findStructField method which finds struct field by following parts of its struct namespace
+func (p *ValidatorProviderImpl) findStructField(typeOf reflect.Type, namespace string) (reflect.StructField, bool) {
+	var field reflect.StructField
+	parts := p.splitNamespace(namespace)
+	if len(parts) < 2 {
+		return field, false
+	}
+
+	for _, part := range parts[1:] {
+		for typeOf.Kind() == reflect.Ptr {
+			typeOf = typeOf.Elem()
+		}
+		if typeOf.Kind() != reflect.Struct {
+			return field, false
+		}
+
+		name := part
+		if index := strings.Index(part, "["); index != -1 {
+			name = part[:index]
+		}
+
+		var ok bool
+		field, ok = typeOf.FieldByName(name)
+		if !ok {
+			return field, false
+		}
+
+		typeOf = field.Type
+		// each index or map key steps into element type of slice, array or map
+		for i := strings.Count(part, "["); i > 0; i-- {
+			for typeOf.Kind() == reflect.Ptr {
+				typeOf = typeOf.Elem()
+			}
+			switch typeOf.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map:
+				typeOf = typeOf.Elem()
+			default:
+				return field, false
+			}
+		}
+	}
+
+	return field, true
+}
+
+// splitNamespace method which splits namespace into parts by dots, which are not placed inside indices or map keys
+func (p *ValidatorProviderImpl) splitNamespace(namespace string) []string {
+	var parts []string
+	depth := 0
+	start := 0
+
+	for i, r := range namespace {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+		case r == '.' && depth == 0:
+			parts = append(parts, namespace[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, namespace[start:])
+}
+
+// attachCustomTypes method which registers custom types into validator.Validate instance, so they are validated as single values.
+// Uploaded files are presented as list of files, instead of being traversed as structs, so field validators can be applied on them.
+func (p *ValidatorProviderImpl) attachCustomTypes(validate *validator.Validate) {
+	validate.RegisterCustomTypeFunc(func(field reflect.Value) interface{} {
+		if header, ok := field.Interface().(multipart.FileHeader); ok {
+			return []*multipart.FileHeader{&header}
+		}
+
+		return nil
+	}, multipart.FileHeader{})
+}
+
+// attachFieldValidators method which attach all injected instances of FieldValidator interface into validator.Validate instance,
+// and remembers ones which implement CriteriaFieldValidator interface, so their failed criteria are reported as separate errors.
+// In case when concurrency is configured, they are skipped while context field validations are started.
 func (p *ValidatorProviderImpl) attachFieldValidators(validate *validator.Validate, fieldValidators []domain.FieldValidator) {
+	p.criteriaValidators = map[string]domain.CriteriaFieldValidator{}
 	for _, fieldValidator := range fieldValidators {
-		validate.RegisterValidationCtx(fieldValidator.ValidatorName(), fieldValidator.ValidateField)
+		validateField := fieldValidator.ValidateField
+		if p.concurrency > 0 {
+			validateField = skipWhileCollecting(validateField)
+		}
+
+		validate.RegisterValidationCtx(fieldValidator.ValidatorName(), validateField)
+		if criteriaValidator, ok := fieldValidator.(domain.CriteriaFieldValidator); ok {
+			p.criteriaValidators[fieldValidator.ValidatorName()] = criteriaValidator
+		}
+	}
+}
+
+// attachContextFieldValidators method which attach all injected instances of ContextFieldValidator interface into validator.Validate instance.
+// In case when concurrency is configured, they are wrapped, so they can be started concurrently.
+func (p *ValidatorProviderImpl) attachContextFieldValidators(validate *validator.Validate, contextFieldValidators []domain.ContextFieldValidator) {
+	for _, contextFieldValidator := range contextFieldValidators {
+		validateField := contextFieldValidator.ValidateWithContext
+		if p.concurrency > 0 {
+			validateField = p.concurrentContextValidation(contextFieldValidator.ValidatorName(), validateField)
+		}
+
+		validate.RegisterValidationCtx(contextFieldValidator.ValidatorName(), validateField)
 	}
 }
 
-// attachFieldValidators method which attach all injected instances of StructValidator interface into validator.Validate instance
+// attachWarningFieldValidators method which attach all injected instances of WarningFieldValidator interface into
+// validator.Validate instance, and remembers their tags, so their failures are reported as warnings.
+// Like field validators, they are skipped while context field validations are started.
+func (p *ValidatorProviderImpl) attachWarningFieldValidators(validate *validator.Validate, warningFieldValidators []domain.WarningFieldValidator) {
+	p.warningTags = make(map[string]bool, len(warningFieldValidators))
+	for _, warningFieldValidator := range warningFieldValidators {
+		validateField := warningFieldValidator.ValidateFieldWarning
+		if p.concurrency > 0 {
+			validateField = skipWhileCollecting(validateField)
+		}
+
+		validate.RegisterValidationCtx(warningFieldValidator.ValidatorName(), validateField)
+		p.warningTags[warningFieldValidator.ValidatorName()] = true
+	}
+}
+
+// attachStructValidators method which attach all injected instances of StructValidator interface into validator.Validate instance.
+// Since validator.Validate allows only one struct validation per type, all struct validators for same type are combined and called in order.
 func (p *ValidatorProviderImpl) attachStructValidators(validate *validator.Validate, structValidators []domain.StructValidator) {
+	var structTypes []interface{}
+	grouped := map[reflect.Type][]domain.StructValidator{}
+
 	for _, structValidator := range structValidators {
-		validate.RegisterStructValidationCtx(structValidator.ValidateStruct, structValidator.StructType())
+		structType := structValidator.StructType()
+		typeOf := reflect.TypeOf(structType)
+
+		if _, ok := grouped[typeOf]; !ok {
+			structTypes = append(structTypes, structType)
+		}
+		grouped[typeOf] = append(grouped[typeOf], structValidator)
+	}
+
+	for _, structType := range structTypes {
+		validate.RegisterStructValidationCtx(p.combineStructValidators(grouped[reflect.TypeOf(structType)]), structType)
 	}
 }
 
-// getRelativeFieldNameFromValidationError method which extracts relative field name depending on it's full namespace
-func (p *ValidatorProviderImpl) getRelativeFieldNameFromValidationError(err validator.FieldError) string {
-	namespace := err.Namespace()
+// combineStructValidators method which creates single struct validation function from list of struct validators.
+// Struct validators are skipped while context field validations are started concurrently, so they run only
+// after all field validations are finished.
+func (p *ValidatorProviderImpl) combineStructValidators(structValidators []domain.StructValidator) validator.StructLevelFuncCtx {
+	return func(ctx context.Context, sl validator.StructLevel) {
+		if isCollectingContextValidations(ctx) {
+			return
+		}
+
+		for _, structValidator := range structValidators {
+			structValidator.ValidateStruct(ctx, sl)
+		}
+	}
+}
+
+// getRelativeFieldNameFromValidationError method which extracts relative field name depending on it's full namespace.
+// If type of validated struct is known, errors of fields tagged with `errors:"collapse"` are collapsed onto the field itself,
+// and names of anonymous embedded structs are removed, so field names match names of decoded fields.
+func (p *ValidatorProviderImpl) getRelativeFieldNameFromValidationError(typeOf reflect.Type, err validator.FieldError) string {
+	namespace := p.getEmbeddedFreeNamespace(typeOf, err, p.getCollapsedNamespace(typeOf, err))
 
 	//first part of namespace is not required to have the relative path:
 	fieldName := namespace[(strings.Index(namespace, ".") + 1):]
 
-	// initialize array of namespace parts
-	parts := strings.Split(fieldName, ".")
-	result := make([]string, len(parts))
+	return p.getRelativeFieldName(fieldName)
+}
+
+// getCollapsedNamespace method which cuts error's namespace after first field tagged with `errors:"collapse"`,
+// together with its index or map key. This way errors of any element (like "interests[2]" or "items[1].sku") are reported
+// for the field itself ("interests" or "items"), so they can be presented next to single form widget.
+func (p *ValidatorProviderImpl) getCollapsedNamespace(typeOf reflect.Type, err validator.FieldError) string {
+	namespace := err.Namespace()
+	if typeOf == nil {
+		return namespace
+	}
+
+	parts := p.splitNamespace(namespace)
+	structParts := p.splitNamespace(err.StructNamespace())
+	if len(parts) != len(structParts) {
+		return namespace
+	}
+
+	for i := 2; i <= len(structParts); i++ {
+		field, ok := p.getStructField(typeOf, strings.Join(structParts[:i], "."))
+		if !ok {
+			break
+		}
+
+		if field.Tag.Get("errors") == errorsTagCollapse {
+			name := parts[i-1]
+			if index := strings.Index(name, "["); index != -1 {
+				name = name[:index]
+			}
+
+			return strings.Join(append(parts[:i-1], name), ".")
+		}
+	}
+
+	return namespace
+}
+
+// getEmbeddedFreeNamespace method which removes names of anonymous embedded structs, and pointers to them, from namespace
+// (like "checkoutForm.street" instead of "checkoutForm.addressFragment.street"), the same way as form decoder flattens
+// their fields. Named nested structs are kept, as well as embedded structs with name defined in "form" tag.
+// Last part of namespace is never removed, so errors of embedded field itself (like `validate:"required"` on pointer)
+// are still reported for the embedded field.
+func (p *ValidatorProviderImpl) getEmbeddedFreeNamespace(typeOf reflect.Type, err validator.FieldError, namespace string) string {
+	if typeOf == nil {
+		return namespace
+	}
+
+	parts := p.splitNamespace(namespace)
+	structParts := p.splitNamespace(err.StructNamespace())
+	// collapsed namespace can be shorter than struct namespace, but its parts still match the first struct parts
+	if len(parts) < 3 || len(parts) > len(structParts) {
+		return namespace
+	}
+
+	result := parts[:1:1]
+	for i := 1; i < len(parts)-1; i++ {
+		field, ok := p.getStructField(typeOf, strings.Join(structParts[:i+1], "."))
+		if !ok {
+			return strings.Join(append(result, parts[i:]...), ".")
+		}
+
+		if !p.isFlattenedEmbed(field) {
+			result = append(result, parts[i])
+		}
+	}
+
+	return strings.Join(append(result, parts[len(parts)-1]), ".")
+}
+
+// isFlattenedEmbed method which checks if struct field is anonymous embedded struct, or pointer to it,
+// without name in "form" tag, so its fields are decoded as fields of parent struct
+func (p *ValidatorProviderImpl) isFlattenedEmbed(field reflect.StructField) bool {
+	if !field.Anonymous || strings.Split(field.Tag.Get("form"), ",")[0] != "" {
+		return false
+	}
+
+	typeOf := field.Type
+	for typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	return typeOf.Kind() == reflect.Struct
+}
+
+// validateStruct method which validates struct with validator.Validate. Nil pointers to unexported embedded structs
+// with validation rules are excluded from validation, and their errors are reported directly, since validator.Validate
+// panics while it reports them, as it can't read values of unexported fields.
+func (p *ValidatorProviderImpl) validateStruct(ctx context.Context, value interface{}) error {
+	valueOf := reflect.Indirect(reflect.ValueOf(value))
+	if valueOf.Kind() != reflect.Struct {
+		return p.GetValidator().StructCtx(ctx, value)
+	}
+
+	name := valueOf.Type().Name()
+	embedErrors := p.findNilEmbedErrors(valueOf, name, name)
+	if len(embedErrors) == 0 {
+		return p.GetValidator().StructCtx(ctx, value)
+	}
+
+	excluded := make([]string, 0, len(embedErrors))
+	for _, embedError := range embedErrors {
+		excluded = append(excluded, strings.TrimPrefix(embedError.StructNamespace(), name+"."))
+	}
+
+	err := p.GetValidator().StructExceptCtx(ctx, value, excluded...)
+	if err == nil {
+		return embedErrors
+	}
+
+	if validationErrors, ok := err.(validator.ValidationErrors); ok {
+		return append(validationErrors, embedErrors...)
+	}
+
+	return err
+}
+
+// findNilEmbedErrors method which returns validation errors of all nil pointers to unexported embedded structs
+// inside struct and its nested structs, which have validation rule that validator.Validate would report.
+// Elements of slices and arrays are checked only if their field's rules are applied to elements with "dive".
+func (p *ValidatorProviderImpl) findNilEmbedErrors(current reflect.Value, namespace string, structNamespace string) validator.ValidationErrors {
+	var errs validator.ValidationErrors
+
+	for current.Kind() == reflect.Ptr || current.Kind() == reflect.Interface {
+		if current.IsNil() {
+			return nil
+		}
+		current = current.Elem()
+	}
+
+	if current.Kind() != reflect.Struct {
+		return nil
+	}
+
+	typeOf := current.Type()
+	for i := 0; i < typeOf.NumField(); i++ {
+		field := typeOf.Field(i)
+		tag := field.Tag.Get("validate")
+		// validator.Validate skips unexported fields which are not embedded, and fields which are not validated
+		if tag == "-" || (!field.Anonymous && field.PkgPath != "") {
+			continue
+		}
+
+		fieldNamespace := p.joinNamespace(namespace, p.getFormFieldName(field))
+		fieldStructNamespace := p.joinNamespace(structNamespace, field.Name)
+		value := current.Field(i)
+
+		if field.Anonymous && field.PkgPath != "" && value.Kind() == reflect.Ptr && value.IsNil() {
+			rule := strings.SplitN(strings.Split(tag, ",")[0], "=", 2)
+			if rule[0] == "" || rule[0] == "omitempty" || rule[0] == "isdefault" {
+				continue
+			}
+
+			embedError := &nilEmbedFieldError{
+				tag:             rule[0],
+				namespace:       fieldNamespace,
+				structNamespace: fieldStructNamespace,
+				field:           p.getFormFieldName(field),
+				structField:     field.Name,
+				typeOf:          field.Type,
+			}
+			if len(rule) > 1 {
+				embedError.param = rule[1]
+			}
+			errs = append(errs, embedError)
+			continue
+		}
+
+		errs = append(errs, p.findNilEmbedErrors(value, fieldNamespace, fieldStructNamespace)...)
+
+		elements := reflect.Indirect(value)
+		if (elements.Kind() == reflect.Slice || elements.Kind() == reflect.Array) && strings.Contains(","+tag+",", ",dive,") {
+			for j := 0; j < elements.Len(); j++ {
+				index := fmt.Sprintf("[%d]", j)
+				errs = append(errs, p.findNilEmbedErrors(elements.Index(j), fieldNamespace+index, fieldStructNamespace+index)...)
+			}
+		}
+	}
+
+	return errs
+}
+
+// joinNamespace method which appends name of field to namespace of its parent struct
+func (p *ValidatorProviderImpl) joinNamespace(namespace string, name string) string {
+	if namespace == "" {
+		return name
+	}
+
+	return namespace + "." + name
+}
+
+// Tag returns validation tag of embedded field
+func (e *nilEmbedFieldError) Tag() string {
+	return e.tag
+}
+
+// ActualTag returns validation tag of embedded field
+func (e *nilEmbedFieldError) ActualTag() string {
+	return e.tag
+}
+
+// Namespace returns namespace of embedded field, built from form field names
+func (e *nilEmbedFieldError) Namespace() string {
+	return e.namespace
+}
+
+// StructNamespace returns namespace of embedded field, built from struct field names
+func (e *nilEmbedFieldError) StructNamespace() string {
+	return e.structNamespace
+}
+
+// Field returns form field name of embedded field
+func (e *nilEmbedFieldError) Field() string {
+	return e.field
+}
+
+// StructField returns struct field name of embedded field
+func (e *nilEmbedFieldError) StructField() string {
+	return e.structField
+}
+
+// Value returns nil pointer of embedded field type
+func (e *nilEmbedFieldError) Value() interface{} {
+	return reflect.Zero(e.typeOf).Interface()
+}
+
+// Param returns parameter of validation tag
+func (e *nilEmbedFieldError) Param() string {
+	return e.param
+}
+
+// Kind returns pointer kind of embedded field
+func (e *nilEmbedFieldError) Kind() reflect.Kind {
+	return reflect.Ptr
+}
+
+// Type returns type of embedded field
+func (e *nilEmbedFieldError) Type() reflect.Type {
+	return e.typeOf
+}
+
+// Translate returns error message, since errors of embedded fields are not translated by validator
+func (e *nilEmbedFieldError) Translate(_ ut.Translator) string {
+	return e.Error()
+}
+
+// Error returns error message in the same format as validator.Validate uses
+func (e *nilEmbedFieldError) Error() string {
+	return fmt.Sprintf("Key: '%s' Error:Field validation for '%s' failed on the '%s' tag", e.namespace, e.field, e.tag)
+}
+
+// isInActiveSteps method which checks if field of validation error belongs to one of active steps. Step of field is
+// defined by the closest "formstep" tag in its struct namespace, so nested fields inherit step of their parent field.
+// Fields without step, and fields of unknown type, are always validated.
+func (p *ValidatorProviderImpl) isInActiveSteps(typeOf reflect.Type, err validator.FieldError, activeSteps map[string]bool) bool {
+	if typeOf == nil {
+		return true
+	}
+
+	var steps []string
+	structParts := p.splitNamespace(err.StructNamespace())
+	for i := 2; i <= len(structParts); i++ {
+		field, ok := p.getStructField(typeOf, strings.Join(structParts[:i], "."))
+		if !ok {
+			break
+		}
+
+		if tag, ok := field.Tag.Lookup(formStepTag); ok {
+			steps = p.splitFormSteps(tag)
+		}
+	}
+
+	if len(steps) == 0 {
+		return true
+	}
+
+	for _, step := range steps {
+		if activeSteps[step] {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitFormSteps method which splits "formstep" tag into steps, since field can belong to multiple steps
+// (like `formstep:"shipping,billing"`)
+func (p *ValidatorProviderImpl) splitFormSteps(tag string) []string {
+	var steps []string
+	for _, step := range strings.Split(tag, ",") {
+		if step = strings.TrimSpace(step); step != "" {
+			steps = append(steps, step)
+		}
+	}
+
+	return steps
+}
+
+// getRelativeFieldName method which converts struct field path into relative field name, by lowering first character of each part.
+// Slice indices and map keys are preserved as they are (like "items[1].sku" or "addresses[Home].street"),
+// so field errors can be matched with exact row which failed.
+func (p *ValidatorProviderImpl) getRelativeFieldName(fieldName string) string {
+	result := make([]rune, 0, len(fieldName))
+	depth := 0
+	partStart := true
+
+	for _, r := range fieldName {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+		case depth == 0 && r == '.':
+			partStart = true
+			result = append(result, r)
+			continue
+		case depth == 0 && partStart:
+			r = unicode.ToLower(r)
+		}
+
+		partStart = false
+		result = append(result, r)
+	}
+
+	return string(result)
+}
+
+// getParamsFromValidationError method which extracts parameters for message interpolation from validation error.
+// Besides "tag", "field" (field label) and "param", validation parameter is also available under tag name (like "min" for "min=8" rule).
+// Actual value is available as "value" only for numbers and booleans, so submitted texts (like passwords) are not exposed.
+func (p *ValidatorProviderImpl) getParamsFromValidationError(err validator.FieldError, label string) map[string]string {
+	tag := err.Tag()
+	params := map[string]string{
+		"tag":   tag,
+		"field": label,
+	}
+
+	if param := err.Param(); param != "" {
+		params["param"] = param
+		params[tag] = param
+
+		// cross field validation rules reference other field by its struct field name, which is converted to relative field name
+		if crossFieldTags[tag] {
+			params["referencedField"] = p.getRelativeFieldName(strings.Fields(param)[0])
+		}
+	}
 
-	for i, part := range parts {
-		result[i] = strings.ToLower(part[0:1]) + part[1:]
+	switch err.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Float32, reflect.Float64:
+		params["value"] = fmt.Sprint(err.Value())
 	}
 
-	return strings.Join(result, ".")
+	return params
 }
@@ -2,7 +2,12 @@ package application
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/mock"
@@ -13,6 +18,7 @@ import (
 	"flamingo.me/flamingo/v3/framework/web"
 	"flamingo.me/form/domain"
 	"flamingo.me/form/domain/mocks"
+	"flamingo.me/form/domain/validators"
 )
 
 type (
@@ -24,6 +30,8 @@ type (
 		firstFieldValidator  *mocks.FieldValidator
 		secondFieldValidator *mocks.FieldValidator
 
+		contextFieldValidator *mocks.ContextFieldValidator
+
 		structValidator *mocks.StructValidator
 	}
 
@@ -31,8 +39,265 @@ type (
 		First  string `validate:"firstfield"`
 		Second string `validate:"secondfield"`
 	}
+
+	validatorProviderCheckoutTestData struct {
+		SameAsShipping bool
+		BillingAddress validatorProviderAddressTestData
+	}
+
+	validatorProviderAddressTestData struct {
+		Street string
+		City   string
+	}
+
+	validatorProviderCheckoutStructValidator struct{}
+
+	validatorProviderOrderTestData struct {
+		Items     []validatorProviderItemTestData                  `validate:"dive"`
+		Addresses map[string]validatorProviderOrderAddressTestData `validate:"dive"`
+	}
+
+	validatorProviderItemTestData struct {
+		Sku string `validate:"required"`
+	}
+
+	validatorProviderOrderAddressTestData struct {
+		Street string `validate:"required"`
+	}
+
+	validatorProviderMessageKeyTestData struct {
+		Email string `form:"email" validate:"required" label:"E-Mail"`
+		Name  string `form:"name" validate:"min=3"`
+	}
+
+	validatorProviderUserRepository struct {
+		usernames map[string]string
+	}
+
+	validatorProviderUniqueUsernameValidator struct {
+		repository *validatorProviderUserRepository
+	}
+
+	validatorProviderRegistrationTestData struct {
+		Username string `form:"username" validate:"uniqueusername"`
+	}
+
+	validatorProviderEmailTypoValidator struct{}
+
+	validatorProviderNewsletterTestData struct {
+		Email string `form:"email" validate:"required,email,emailtypo"`
+	}
+
+	validatorProviderSignupTestData struct {
+		Password          string `form:"password" validate:"required,passwordstrength"`
+		PasswordConfirm   string `form:"passwordConfirm" validate:"passwordconfirm"`
+		NewPassword       string `form:"newPassword"`
+		NewPasswordRepeat string `form:"newPasswordRepeat" validate:"passwordconfirm=NewPassword"`
+	}
+
+	validatorProviderLabelTestData struct {
+		Email           string                                `form:"email" validate:"required" label:"E-Mail"`
+		ShippingAddress validatorProviderLabelAddressTestData `form:"shippingAddress"`
+		Items           []validatorProviderLabelItemTestData  `form:"positions" validate:"dive"`
+		validatorProviderLabelContactTestData
+	}
+
+	validatorProviderLabelAddressTestData struct {
+		FirstName string `form:"firstName" validate:"required" label:"Vorname"`
+		LastName  string `validate:"required"`
+	}
+
+	validatorProviderLabelItemTestData struct {
+		Sku string `form:"articleNumber" validate:"required" label:"Artikelnummer"`
+	}
+
+	validatorProviderLabelContactTestData struct {
+		Phone string `form:"phone" validate:"required" label:"Telefon"`
+	}
+
+	validatorProviderRunesTestData struct {
+		Name     string   `form:"name" validate:"required,minrunes=6"`
+		Nickname string   `form:"nickname" validate:"minrunes=6"`
+		Tags     []string `form:"tags" validate:"maxrunes=3"`
+	}
+
+	validatorProviderInterestsTestData struct {
+		Interests []string                        `form:"interests" validate:"eachoneof=sports music books"`
+		Sizes     []int                           `form:"sizes" validate:"dive,oneof=36 38 40" errors:"collapse" label:"Sizes"`
+		Colors    []string                        `form:"colors" validate:"dive,oneof=red blue" label:"Colors"`
+		Items     []validatorProviderItemTestData `form:"positions" validate:"dive" errors:"collapse"`
+	}
+
+	validatorProviderWizardTestData struct {
+		Email    string                            `form:"email" validate:"required"`
+		Shipping validatorProviderShippingTestData `form:"shipping" formstep:"shipping"`
+		Payment  *validatorProviderPaymentTestData `form:"payment" formstep:"payment"`
+		Terms    bool                              `form:"terms" formstep:"payment, summary" validate:"required"`
+	}
+
+	validatorProviderShippingTestData struct {
+		Street string `form:"street" validate:"required"`
+		Phone  string `form:"phone" formstep:"contact" validate:"required"`
+		Notes  string `form:"notes" formstep:"" validate:"max=5"`
+	}
+
+	validatorProviderPaymentTestData struct {
+		Iban string `form:"iban" validate:"required"`
+	}
+
+	validatorProviderEmbedCheckoutTestData struct {
+		Email string `form:"email" validate:"required"`
+		validatorProviderEmbedAddressTestData
+		*validatorProviderEmbedContactTestData
+		Billing validatorProviderEmbedBillingTestData `form:"billing"`
+	}
+
+	validatorProviderEmbedAddressTestData struct {
+		Street string `form:"street" validate:"required"`
+		City   string `form:"city" validate:"required"`
+	}
+
+	validatorProviderEmbedContactTestData struct {
+		Phone string `form:"phone" validate:"required"`
+	}
+
+	validatorProviderEmbedBillingTestData struct {
+		validatorProviderEmbedAddressTestData
+		Company validatorProviderEmbedCompanyTestData `form:"company"`
+	}
+
+	validatorProviderEmbedCompanyTestData struct {
+		Name string `form:"name" validate:"required"`
+	}
+
+	// validatorProviderBenchmarkTestData as nested form with 30 fields, which is used for benchmarks
+	validatorProviderBenchmarkTestData struct {
+		FirstName  string                                     `form:"firstName" validate:"required" label:"First name"`
+		LastName   string                                     `form:"lastName" validate:"required" label:"Last name"`
+		Email      string                                     `form:"email" validate:"required,email"`
+		Phone      string                                     `form:"phone" validate:"required"`
+		Mobile     string                                     `form:"mobile" validate:"omitempty,min=6"`
+		Title      string                                     `form:"title" validate:"max=10"`
+		Gender     string                                     `form:"gender" validate:"omitempty,oneof=male female diverse"`
+		Website    string                                     `form:"website" validate:"omitempty,url"`
+		Age        int                                        `form:"age" validate:"gte=18"`
+		Company    string                                     `form:"company"`
+		Newsletter bool                                       `form:"newsletter"`
+		Terms      bool                                       `form:"terms" validate:"required"`
+		Interests  []string                                   `form:"interests" validate:"max=3"`
+		Shipping   validatorProviderBenchmarkAddressTestData  `form:"shipping"`
+		Billing    *validatorProviderBenchmarkAddressTestData `form:"billing"`
+		Items      []validatorProviderBenchmarkItemTestData   `form:"items" validate:"dive"`
+		Comment    string                                     `form:"comment" validate:"max=200"`
+		validatorProviderBenchmarkMetaTestData
+	}
+
+	validatorProviderBenchmarkAddressTestData struct {
+		Street     string `form:"street" validate:"required" label:"Street"`
+		Number     string `form:"number" validate:"required"`
+		PostalCode string `form:"postalCode" validate:"required,len=5"`
+		City       string `form:"city" validate:"required"`
+		Country    string `form:"country" validate:"required,len=2"`
+	}
+
+	validatorProviderBenchmarkItemTestData struct {
+		Sku      string  `form:"sku" validate:"required"`
+		Quantity int     `form:"quantity" validate:"gte=1"`
+		Price    float64 `form:"price" validate:"gt=0"`
+	}
+
+	validatorProviderBenchmarkMetaTestData struct {
+		Source  string `form:"source" validate:"required"`
+		Channel string `form:"channel"`
+	}
+
+	validatorProviderNamedEmbedTestData struct {
+		validatorProviderEmbedAddressTestData  `form:"address"`
+		*validatorProviderEmbedContactTestData `validate:"required"`
+	}
+
+	validatorProviderOptionalTestData struct {
+		Nickname *string   `form:"nickname" validate:"min=3"`
+		Age      *int      `form:"age" validate:"min=18,max=99"`
+		Email    *string   `form:"email" validate:"email,required"`
+		Active   *bool     `form:"active" validate:"required"`
+		Tags     *[]string `form:"tags" validate:"min=1,dive,required"`
+	}
+
+	validatorProviderSubscriptionTestData struct {
+		Email       string `form:"email" validate:"required,email"`
+		BackupEmail string `form:"backupEmail" validate:"required,email" validateMode:"all"`
+		Nickname    string `form:"nickname" validate:"min=3,alphanum" validateMode:"first"`
+	}
+
+	validatorProviderSubscriptionStructValidator struct{}
 )
 
+func (v *validatorProviderSubscriptionStructValidator) StructType() interface{} {
+	return validatorProviderSubscriptionTestData{}
+}
+
+func (v *validatorProviderSubscriptionStructValidator) ValidateStruct(_ context.Context, sl validator.StructLevel) {
+	subscription := sl.Current().Interface().(validatorProviderSubscriptionTestData)
+	if !strings.HasSuffix(subscription.Email, "@example.com") {
+		sl.ReportError(subscription.Email, "Email", "Email", "companyemail", "")
+	}
+	if !strings.HasSuffix(subscription.BackupEmail, "@example.com") {
+		sl.ReportError(subscription.BackupEmail, "BackupEmail", "BackupEmail", "companyemail", "")
+	}
+}
+
+func (v *validatorProviderCheckoutStructValidator) StructType() interface{} {
+	return validatorProviderCheckoutTestData{}
+}
+
+func (v *validatorProviderCheckoutStructValidator) ValidateStruct(_ context.Context, sl validator.StructLevel) {
+	checkout := sl.Current().Interface().(validatorProviderCheckoutTestData)
+	if checkout.SameAsShipping {
+		return
+	}
+
+	if checkout.BillingAddress.Street == "" {
+		sl.ReportError(checkout.BillingAddress.Street, "BillingAddress.Street", "BillingAddress.Street", "required", "")
+	}
+	if checkout.BillingAddress.City == "" {
+		sl.ReportError(checkout.BillingAddress.City, "BillingAddress.City", "BillingAddress.City", "required", "")
+	}
+}
+
+func (r *validatorProviderUserRepository) FindUserIDByUsername(username string) (string, bool) {
+	userID, ok := r.usernames[username]
+	return userID, ok
+}
+
+func (v *validatorProviderEmailTypoValidator) ValidatorName() string {
+	return "emailtypo"
+}
+
+func (v *validatorProviderEmailTypoValidator) ValidateFieldWarning(_ context.Context, fl validator.FieldLevel) bool {
+	return !strings.HasSuffix(fl.Field().String(), "@gmial.com")
+}
+
+func (v *validatorProviderUniqueUsernameValidator) ValidatorName() string {
+	return "uniqueusername"
+}
+
+func (v *validatorProviderUniqueUsernameValidator) ValidateWithContext(ctx context.Context, fl validator.FieldLevel) bool {
+	userID, ok := v.repository.FindUserIDByUsername(fl.Field().String())
+	if !ok {
+		return true
+	}
+
+	// current user is allowed to keep its own username
+	req := web.RequestFromContext(ctx)
+	if req == nil {
+		return false
+	}
+	currentUserID, _ := req.Session().Load("userID")
+
+	return currentUserID == userID
+}
+
 func TestValidatorProviderTestSuite(t *testing.T) {
 	suite.Run(t, &ValidatorProviderTestSuite{})
 }
@@ -40,19 +305,23 @@ func TestValidatorProviderTestSuite(t *testing.T) {
 func (t *ValidatorProviderTestSuite) SetupTest() {
 	t.firstFieldValidator = &mocks.FieldValidator{}
 	t.secondFieldValidator = &mocks.FieldValidator{}
+	t.contextFieldValidator = &mocks.ContextFieldValidator{}
 	t.structValidator = &mocks.StructValidator{}
 	t.provider = &ValidatorProviderImpl{}
 
 	t.firstFieldValidator.On("ValidatorName").Return("firstfield").Once()
 	t.secondFieldValidator.On("ValidatorName").Return("secondfield").Once()
+	t.contextFieldValidator.On("ValidatorName").Return("contextfield").Once()
 	t.structValidator.On("StructType").Return(validatorProviderTestData{}).Once()
 
 	t.provider.Inject([]domain.FieldValidator{
 		t.firstFieldValidator,
 		t.secondFieldValidator,
-	}, []domain.StructValidator{
+	}, []domain.ContextFieldValidator{
+		t.contextFieldValidator,
+	}, nil, []domain.StructValidator{
 		t.structValidator,
-	})
+	}, nil, nil)
 }
 
 func (t *ValidatorProviderTestSuite) TearDownTest() {
@@ -60,6 +329,8 @@ func (t *ValidatorProviderTestSuite) TearDownTest() {
 	t.firstFieldValidator = nil
 	t.secondFieldValidator.AssertExpectations(t.T())
 	t.secondFieldValidator = nil
+	t.contextFieldValidator.AssertExpectations(t.T())
+	t.contextFieldValidator = nil
 	t.structValidator.AssertExpectations(t.T())
 	t.structValidator = nil
 	t.provider = nil
@@ -78,7 +349,9 @@ func (t *ValidatorProviderTestSuite) TestErrorsToValidationInfo_FieldError() {
 	err := &mocks.FieldError{}
 	err.On("Namespace").Return("formData.fieldName1").Once()
 	err.On("Tag").Return("firstfield").Twice()
-	err.On("Field").Return("FieldName1").Once()
+	err.On("StructField").Return("FieldName1").Once()
+	err.On("Param").Return("").Once()
+	err.On("Kind").Return(reflect.String).Once()
 
 	validationInfo := t.provider.ErrorsToValidationInfo(validator.ValidationErrors{
 		err,
@@ -89,6 +362,10 @@ func (t *ValidatorProviderTestSuite) TestErrorsToValidationInfo_FieldError() {
 			{
 				MessageKey:   "formError.fieldName1.firstfield",
 				DefaultLabel: "FieldName1 firstfield",
+				Parameters: map[string]string{
+					"tag":   "firstfield",
+					"field": "FieldName1",
+				},
 			},
 		},
 	}, validationInfo.GetErrorsForAllFields())
@@ -96,6 +373,161 @@ func (t *ValidatorProviderTestSuite) TestErrorsToValidationInfo_FieldError() {
 	err.AssertExpectations(t.T())
 }
 
+func (t *ValidatorProviderTestSuite) TestErrorsToValidationInfo_Parameters() {
+	testCases := []struct {
+		Tag    string
+		Param  string
+		Kind   reflect.Kind
+		Value  interface{}
+		Result map[string]string
+	}{
+		{
+			Tag:   "min",
+			Param: "8",
+			Kind:  reflect.String,
+			Value: "secret",
+			Result: map[string]string{
+				"tag":   "min",
+				"field": "FieldName1",
+				"param": "8",
+				"min":   "8",
+			},
+		},
+		{
+			Tag:   "max",
+			Param: "10",
+			Kind:  reflect.Int,
+			Value: 12,
+			Result: map[string]string{
+				"tag":   "max",
+				"field": "FieldName1",
+				"param": "10",
+				"max":   "10",
+				"value": "12",
+			},
+		},
+		{
+			Tag:   "len",
+			Param: "5",
+			Kind:  reflect.Slice,
+			Value: []string{"first"},
+			Result: map[string]string{
+				"tag":   "len",
+				"field": "FieldName1",
+				"param": "5",
+				"len":   "5",
+			},
+		},
+		{
+			Tag:   "gte",
+			Param: "1.5",
+			Kind:  reflect.Float64,
+			Value: 0.5,
+			Result: map[string]string{
+				"tag":   "gte",
+				"field": "FieldName1",
+				"param": "1.5",
+				"gte":   "1.5",
+				"value": "0.5",
+			},
+		},
+		{
+			Tag:   "eqfield",
+			Param: "Password",
+			Kind:  reflect.String,
+			Value: "secret",
+			Result: map[string]string{
+				"tag":             "eqfield",
+				"field":           "FieldName1",
+				"param":           "Password",
+				"eqfield":         "Password",
+				"referencedField": "password",
+			},
+		},
+		{
+			Tag:   "dateformat",
+			Param: "",
+			Kind:  reflect.String,
+			Value: "wrong",
+			Result: map[string]string{
+				"tag":   "dateformat",
+				"field": "FieldName1",
+			},
+		},
+		{
+			Tag:   "minimumage",
+			Param: "18",
+			Kind:  reflect.String,
+			Value: "2020-01-01",
+			Result: map[string]string{
+				"tag":        "minimumage",
+				"field":      "FieldName1",
+				"param":      "18",
+				"minimumage": "18",
+			},
+		},
+		{
+			Tag:   "maximumage",
+			Param: "150",
+			Kind:  reflect.String,
+			Value: "1800-01-01",
+			Result: map[string]string{
+				"tag":        "maximumage",
+				"field":      "FieldName1",
+				"param":      "150",
+				"maximumage": "150",
+			},
+		},
+		{
+			Tag:   "maxfilesize",
+			Param: "2MB",
+			Kind:  reflect.Slice,
+			Value: nil,
+			Result: map[string]string{
+				"tag":         "maxfilesize",
+				"field":       "FieldName1",
+				"param":       "2MB",
+				"maxfilesize": "2MB",
+			},
+		},
+		{
+			Tag:   "mimetype",
+			Param: "image/png image/jpeg",
+			Kind:  reflect.Slice,
+			Value: nil,
+			Result: map[string]string{
+				"tag":      "mimetype",
+				"field":    "FieldName1",
+				"param":    "image/png image/jpeg",
+				"mimetype": "image/png image/jpeg",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := &mocks.FieldError{}
+		err.On("Namespace").Return("formData.fieldName1").Once()
+		err.On("Tag").Return(testCase.Tag).Twice()
+		err.On("StructField").Return("FieldName1").Once()
+		err.On("Param").Return(testCase.Param).Once()
+		err.On("Kind").Return(testCase.Kind).Once()
+		err.On("Value").Return(testCase.Value).Maybe()
+
+		validationInfo := t.provider.ErrorsToValidationInfo(validator.ValidationErrors{
+			err,
+		})
+		t.Equal([]domain.Error{
+			{
+				MessageKey:   "formError.fieldName1." + testCase.Tag,
+				DefaultLabel: "FieldName1 " + testCase.Tag,
+				Parameters:   testCase.Result,
+			},
+		}, validationInfo.GetErrorsForField("fieldName1"))
+
+		err.AssertExpectations(t.T())
+	}
+}
+
 func (t *ValidatorProviderTestSuite) TestErrorsToValidationInfo_GeneralError() {
 	err := errors.New("error")
 	validationInfo := t.provider.ErrorsToValidationInfo(err)
@@ -125,37 +557,1525 @@ func (t *ValidatorProviderTestSuite) TestGetRelativeFieldNameFromValidationError
 			Namespace: "formData.subData.fieldName1",
 			Result:    "subData.fieldName1",
 		},
+		{
+			Namespace: "FormData.SubData.FieldName1",
+			Result:    "subData.fieldName1",
+		},
+		{
+			Namespace: "formData.subData.",
+			Result:    "subData.",
+		},
+		{
+			Namespace: "FormData.Items[1].Sku",
+			Result:    "items[1].sku",
+		},
+		{
+			Namespace: "FormData.Items[0].Tags[2]",
+			Result:    "items[0].tags[2]",
+		},
+		{
+			Namespace: "FormData.Addresses[Home.Office].Street",
+			Result:    "addresses[Home.Office].street",
+		},
+		{
+			Namespace: "FormData.Rows[2][Key].Value",
+			Result:    "rows[2][Key].value",
+		},
 	}
 
 	for _, testCase := range testCases {
 		err := &mocks.FieldError{}
 		err.On("Namespace").Return(testCase.Namespace).Once()
-		t.Equal(testCase.Result, t.provider.getRelativeFieldNameFromValidationError(err))
+		t.Equal(testCase.Result, t.provider.getRelativeFieldNameFromValidationError(nil, err))
 		err.AssertExpectations(t.T())
 	}
 }
 
-func (t *ValidatorProviderTestSuite) TestValidate() {
-	ctx := context.Background()
-	request := &web.Request{}
-	reqCtx := web.ContextWithRequest(ctx, request)
+func (t *ValidatorProviderTestSuite) TestGetCollapsedNamespace() {
+	typeOf := reflect.TypeOf(&validatorProviderInterestsTestData{})
 
-	t.firstFieldValidator.On("ValidateField", reqCtx, mock.Anything).Return(false).Once()
-	t.secondFieldValidator.On("ValidateField", reqCtx, mock.Anything).Return(true).Once()
+	testCases := []struct {
+		Namespace       string
+		StructNamespace string
+		Result          string
+	}{
+		{
+			Namespace:       "validatorProviderInterestsTestData.interests",
+			StructNamespace: "validatorProviderInterestsTestData.Interests",
+			Result:          "validatorProviderInterestsTestData.interests",
+		},
+		{
+			Namespace:       "validatorProviderInterestsTestData.sizes[2]",
+			StructNamespace: "validatorProviderInterestsTestData.Sizes[2]",
+			Result:          "validatorProviderInterestsTestData.sizes",
+		},
+		{
+			Namespace:       "validatorProviderInterestsTestData.colors[1]",
+			StructNamespace: "validatorProviderInterestsTestData.Colors[1]",
+			Result:          "validatorProviderInterestsTestData.colors[1]",
+		},
+		{
+			Namespace:       "validatorProviderInterestsTestData.positions[1].sku",
+			StructNamespace: "validatorProviderInterestsTestData.Items[1].Sku",
+			Result:          "validatorProviderInterestsTestData.positions",
+		},
+		{
+			Namespace:       "validatorProviderInterestsTestData.unknown[1]",
+			StructNamespace: "validatorProviderInterestsTestData.Unknown[1]",
+			Result:          "validatorProviderInterestsTestData.unknown[1]",
+		},
+	}
 
-	t.structValidator.On("ValidateStruct", reqCtx, mock.Anything).Return().Once()
+	for _, testCase := range testCases {
+		err := &mocks.FieldError{}
+		err.On("Namespace").Return(testCase.Namespace).Once()
+		err.On("StructNamespace").Return(testCase.StructNamespace).Once()
+		t.Equal(testCase.Result, t.provider.getCollapsedNamespace(typeOf, err), testCase.Namespace)
+		err.AssertExpectations(t.T())
+	}
 
-	validationInfo := t.provider.Validate(ctx, request, validatorProviderTestData{
-		First:  "first",
-		Second: "second",
-	})
-	t.False(validationInfo.IsValid())
-	t.Equal(map[string][]domain.Error{
-		"first": {
-			{
-				MessageKey:   "formError.first.firstfield",
-				DefaultLabel: "First firstfield",
-			},
+	err := &mocks.FieldError{}
+	err.On("Namespace").Return("validatorProviderInterestsTestData.sizes[2]").Once()
+	t.Equal("validatorProviderInterestsTestData.sizes[2]", t.provider.getCollapsedNamespace(nil, err))
+	err.AssertExpectations(t.T())
+}
+
+func (t *ValidatorProviderTestSuite) TestGetEmbeddedFreeNamespace() {
+	testCases := []struct {
+		TypeOf          reflect.Type
+		Namespace       string
+		StructNamespace string
+		Result          string
+	}{
+		{
+			TypeOf:          reflect.TypeOf(validatorProviderEmbedCheckoutTestData{}),
+			Namespace:       "validatorProviderEmbedCheckoutTestData.email",
+			StructNamespace: "validatorProviderEmbedCheckoutTestData.Email",
+			Result:          "validatorProviderEmbedCheckoutTestData.email",
 		},
-	}, validationInfo.GetErrorsForAllFields())
+		{
+			TypeOf:          reflect.TypeOf(validatorProviderEmbedCheckoutTestData{}),
+			Namespace:       "validatorProviderEmbedCheckoutTestData.validatorProviderEmbedAddressTestData.street",
+			StructNamespace: "validatorProviderEmbedCheckoutTestData.validatorProviderEmbedAddressTestData.Street",
+			Result:          "validatorProviderEmbedCheckoutTestData.street",
+		},
+		{
+			TypeOf:          reflect.TypeOf(&validatorProviderEmbedCheckoutTestData{}),
+			Namespace:       "validatorProviderEmbedCheckoutTestData.validatorProviderEmbedContactTestData.phone",
+			StructNamespace: "validatorProviderEmbedCheckoutTestData.validatorProviderEmbedContactTestData.Phone",
+			Result:          "validatorProviderEmbedCheckoutTestData.phone",
+		},
+		{
+			TypeOf:          reflect.TypeOf(validatorProviderEmbedCheckoutTestData{}),
+			Namespace:       "validatorProviderEmbedCheckoutTestData.billing.validatorProviderEmbedAddressTestData.city",
+			StructNamespace: "validatorProviderEmbedCheckoutTestData.Billing.validatorProviderEmbedAddressTestData.City",
+			Result:          "validatorProviderEmbedCheckoutTestData.billing.city",
+		},
+		{
+			TypeOf:          reflect.TypeOf(validatorProviderEmbedCheckoutTestData{}),
+			Namespace:       "validatorProviderEmbedCheckoutTestData.billing.company.name",
+			StructNamespace: "validatorProviderEmbedCheckoutTestData.Billing.Company.Name",
+			Result:          "validatorProviderEmbedCheckoutTestData.billing.company.name",
+		},
+		{
+			TypeOf:          reflect.TypeOf(validatorProviderNamedEmbedTestData{}),
+			Namespace:       "validatorProviderNamedEmbedTestData.address.street",
+			StructNamespace: "validatorProviderNamedEmbedTestData.validatorProviderEmbedAddressTestData.Street",
+			Result:          "validatorProviderNamedEmbedTestData.address.street",
+		},
+		{
+			TypeOf:          reflect.TypeOf(validatorProviderNamedEmbedTestData{}),
+			Namespace:       "validatorProviderNamedEmbedTestData.validatorProviderEmbedContactTestData",
+			StructNamespace: "validatorProviderNamedEmbedTestData.validatorProviderEmbedContactTestData",
+			Result:          "validatorProviderNamedEmbedTestData.validatorProviderEmbedContactTestData",
+		},
+		{
+			TypeOf:          reflect.TypeOf(validatorProviderEmbedCheckoutTestData{}),
+			Namespace:       "validatorProviderEmbedCheckoutTestData.unknown.street",
+			StructNamespace: "validatorProviderEmbedCheckoutTestData.Unknown.Street",
+			Result:          "validatorProviderEmbedCheckoutTestData.unknown.street",
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := &mocks.FieldError{}
+		err.On("StructNamespace").Return(testCase.StructNamespace)
+		t.Equal(testCase.Result, t.provider.getEmbeddedFreeNamespace(testCase.TypeOf, err, testCase.Namespace), testCase.Namespace)
+	}
+
+	t.Equal("formData.validatorProviderEmbedAddressTestData.street", t.provider.getEmbeddedFreeNamespace(nil, &mocks.FieldError{}, "formData.validatorProviderEmbedAddressTestData.street"))
+}
+
+func (t *ValidatorProviderTestSuite) TestGetFormFieldName() {
+	typeOf := reflect.TypeOf(validatorProviderLabelTestData{})
+
+	email, _ := typeOf.FieldByName("Email")
+	t.Equal("email", t.provider.getFormFieldName(email))
+
+	items, _ := typeOf.FieldByName("Items")
+	t.Equal("positions", t.provider.getFormFieldName(items))
+
+	contact, _ := typeOf.FieldByName("validatorProviderLabelContactTestData")
+	t.Equal("validatorProviderLabelContactTestData", t.provider.getFormFieldName(contact))
+
+	lastName, _ := reflect.TypeOf(validatorProviderLabelAddressTestData{}).FieldByName("LastName")
+	t.Equal("lastName", t.provider.getFormFieldName(lastName))
+}
+
+func (t *ValidatorProviderTestSuite) TestGetFieldLabel() {
+	typeOf := reflect.TypeOf(&validatorProviderLabelTestData{})
+
+	testCases := []struct {
+		StructNamespace string
+		StructField     string
+		Result          string
+	}{
+		{
+			StructNamespace: "validatorProviderLabelTestData.Email",
+			StructField:     "Email",
+			Result:          "E-Mail",
+		},
+		{
+			StructNamespace: "validatorProviderLabelTestData.ShippingAddress.FirstName",
+			StructField:     "FirstName",
+			Result:          "Vorname",
+		},
+		{
+			StructNamespace: "validatorProviderLabelTestData.ShippingAddress.LastName",
+			StructField:     "LastName",
+			Result:          "LastName",
+		},
+		{
+			StructNamespace: "validatorProviderLabelTestData.Items[2].Sku",
+			StructField:     "Sku",
+			Result:          "Artikelnummer",
+		},
+		{
+			StructNamespace: "validatorProviderLabelTestData.validatorProviderLabelContactTestData.Phone",
+			StructField:     "Phone",
+			Result:          "Telefon",
+		},
+		{
+			StructNamespace: "validatorProviderLabelTestData.Phone",
+			StructField:     "Phone",
+			Result:          "Telefon",
+		},
+		{
+			StructNamespace: "validatorProviderLabelTestData.Unknown.FirstName",
+			StructField:     "FirstName",
+			Result:          "FirstName",
+		},
+		{
+			StructNamespace: "validatorProviderLabelTestData.Email[0].Value",
+			StructField:     "Value",
+			Result:          "Value",
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := &mocks.FieldError{}
+		err.On("StructNamespace").Return(testCase.StructNamespace).Once()
+		err.On("StructField").Return(testCase.StructField).Maybe()
+		t.Equal(testCase.Result, t.provider.getFieldLabel(typeOf, err), testCase.StructNamespace)
+		err.AssertExpectations(t.T())
+	}
+
+	err := &mocks.FieldError{}
+	err.On("StructField").Return("Email").Once()
+	t.Equal("Email", t.provider.getFieldLabel(nil, err))
+	err.AssertExpectations(t.T())
+}
+
+func (t *ValidatorProviderTestSuite) TestRegisterLabelFunc() {
+	t.provider.RegisterLabelFunc(func(field reflect.StructField) string {
+		if key := field.Tag.Get("form"); key != "" {
+			return "label." + key
+		}
+
+		return ""
+	})
+
+	err := &mocks.FieldError{}
+	err.On("StructNamespace").Return("validatorProviderLabelTestData.ShippingAddress.FirstName").Once()
+	t.Equal("label.firstName", t.provider.getFieldLabel(reflect.TypeOf(validatorProviderLabelTestData{}), err))
+	err.AssertExpectations(t.T())
+
+	err = &mocks.FieldError{}
+	err.On("StructNamespace").Return("validatorProviderLabelTestData.ShippingAddress.LastName").Once()
+	err.On("StructField").Return("LastName").Once()
+	t.Equal("LastName", t.provider.getFieldLabel(reflect.TypeOf(validatorProviderLabelTestData{}), err))
+	err.AssertExpectations(t.T())
+}
+
+func (t *ValidatorProviderTestSuite) TestRegisterLabelFunc_ResetsCachedLabels() {
+	typeOf := reflect.TypeOf(validatorProviderLabelTestData{})
+
+	err := &mocks.FieldError{}
+	err.On("StructNamespace").Return("validatorProviderLabelTestData.ShippingAddress.FirstName")
+	t.Equal("Vorname", t.provider.getFieldLabel(typeOf, err))
+
+	t.provider.RegisterLabelFunc(func(field reflect.StructField) string {
+		return "label." + field.Name
+	})
+	t.Equal("label.FirstName", t.provider.getFieldLabel(typeOf, err))
+}
+
+func (t *ValidatorProviderTestSuite) TestGetStructFieldKey() {
+	typeOf := reflect.TypeOf(validatorProviderOrderTestData{})
+
+	testCases := []struct {
+		Namespace string
+		Result    string
+		Cacheable bool
+	}{
+		{
+			Namespace: "validatorProviderOrderTestData.Items",
+			Result:    "validatorProviderOrderTestData.Items",
+			Cacheable: true,
+		},
+		{
+			Namespace: "validatorProviderOrderTestData.Items[12].Sku",
+			Result:    "validatorProviderOrderTestData.Items[].Sku",
+			Cacheable: true,
+		},
+		{
+			Namespace: "validatorProviderOrderTestData.Addresses[Home.Office].Street",
+			Result:    "validatorProviderOrderTestData.Addresses[].Street",
+			Cacheable: true,
+		},
+		{
+			Namespace: "validatorProviderOrderTestData.Rows[2][Key].Value",
+			Result:    "validatorProviderOrderTestData.Rows[][].Value",
+			Cacheable: true,
+		},
+		{
+			Namespace: "validatorProviderOrderTestData.Addresses[Home[1]].Street",
+			Cacheable: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		key, cacheable := t.provider.getStructFieldKey(typeOf, testCase.Namespace)
+		t.Equal(testCase.Cacheable, cacheable, testCase.Namespace)
+		if cacheable {
+			t.Equal(structFieldKey{typeOf: typeOf, namespace: testCase.Result}, key, testCase.Namespace)
+		}
+	}
+}
+
+func (t *ValidatorProviderTestSuite) TestGetStructField_Cached() {
+	typeOf := reflect.TypeOf(validatorProviderOrderTestData{})
+
+	first, ok := t.provider.getStructField(typeOf, "validatorProviderOrderTestData.Items[1].Sku")
+	t.True(ok)
+	second, ok := t.provider.getStructField(typeOf, "validatorProviderOrderTestData.Items[2].Sku")
+	t.True(ok)
+	t.Equal(first, second)
+
+	_, ok = t.provider.getStructField(typeOf, "validatorProviderOrderTestData.Items[1].Unknown")
+	t.False(ok)
+	_, _ = t.provider.getStructField(typeOf, "validatorProviderOrderTestData.Addresses[Home[1]].Street")
+
+	// all rows share one entry, unknown fields are cached as well, and namespaces with nested brackets are not cached
+	t.Equal(2, t.countCachedStructFields())
+
+	t.provider.ResetMetadataCache()
+	t.Equal(0, t.countCachedStructFields())
+
+	field, ok := t.provider.getStructField(typeOf, "validatorProviderOrderTestData.Items[3].Sku")
+	t.True(ok)
+	t.Equal(first, field)
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_Concurrent() {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject(nil, nil, nil, nil, nil, nil)
+
+	var wg sync.WaitGroup
+	results := make(chan []string, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			validationInfo := provider.Validate(context.Background(), &web.Request{}, validatorProviderLabelTestData{
+				Items: []validatorProviderLabelItemTestData{
+					{},
+				},
+			})
+
+			labels := make([]string, 0, len(validationInfo.FieldErrorsSorted()))
+			for _, entry := range validationInfo.FieldErrorsSorted() {
+				labels = append(labels, entry.Errors[0].Parameters["field"])
+			}
+			results <- labels
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	for labels := range results {
+		t.Equal([]string{"E-Mail", "Vorname", "LastName", "Artikelnummer", "Telefon"}, labels)
+	}
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate() {
+	ctx := context.Background()
+	request := &web.Request{}
+	reqCtx := web.ContextWithRequest(ctx, request)
+
+	t.firstFieldValidator.On("ValidateField", reqCtx, mock.Anything).Return(false).Once()
+	t.secondFieldValidator.On("ValidateField", reqCtx, mock.Anything).Return(true).Once()
+
+	t.structValidator.On("ValidateStruct", reqCtx, mock.Anything).Return().Once()
+
+	validationInfo := t.provider.Validate(ctx, request, validatorProviderTestData{
+		First:  "first",
+		Second: "second",
+	})
+	t.False(validationInfo.IsValid())
+	t.Equal(map[string][]domain.Error{
+		"first": {
+			{
+				MessageKey:   "formError.first.firstfield",
+				DefaultLabel: "First firstfield",
+				Parameters: map[string]string{
+					"tag":   "firstfield",
+					"field": "First",
+				},
+			},
+		},
+	}, validationInfo.GetErrorsForAllFields())
+}
+
+func (t *ValidatorProviderTestSuite) TestCombineStructValidators() {
+	ctx := context.Background()
+	structLevel := &mocks.StructLevel{}
+
+	firstStructValidator := &mocks.StructValidator{}
+	firstStructValidator.On("ValidateStruct", ctx, structLevel).Return().Once()
+	secondStructValidator := &mocks.StructValidator{}
+	secondStructValidator.On("ValidateStruct", ctx, structLevel).Return().Once()
+
+	validateStruct := t.provider.combineStructValidators([]domain.StructValidator{
+		firstStructValidator,
+		secondStructValidator,
+	})
+	validateStruct(ctx, structLevel)
+
+	firstStructValidator.AssertExpectations(t.T())
+	secondStructValidator.AssertExpectations(t.T())
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_StructLevelErrors() {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject(nil, nil, nil, []domain.StructValidator{
+		&validatorProviderCheckoutStructValidator{},
+	}, nil, nil)
+
+	validationInfo := provider.Validate(context.Background(), &web.Request{}, validatorProviderCheckoutTestData{
+		SameAsShipping: false,
+	})
+	t.False(validationInfo.IsValid())
+	t.Equal(map[string][]domain.Error{
+		"billingAddress.street": {
+			{
+				MessageKey:   "formError.billingAddress.street.required",
+				DefaultLabel: "BillingAddress.Street required",
+				Parameters: map[string]string{
+					"tag":   "required",
+					"field": "BillingAddress.Street",
+				},
+			},
+		},
+		"billingAddress.city": {
+			{
+				MessageKey:   "formError.billingAddress.city.required",
+				DefaultLabel: "BillingAddress.City required",
+				Parameters: map[string]string{
+					"tag":   "required",
+					"field": "BillingAddress.City",
+				},
+			},
+		},
+	}, validationInfo.GetErrorsForAllFields())
+
+	validationInfo = provider.Validate(context.Background(), &web.Request{}, validatorProviderCheckoutTestData{
+		SameAsShipping: true,
+	})
+	t.True(validationInfo.IsValid())
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_IndexedErrors() {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject(nil, nil, nil, nil, nil, nil)
+
+	validationInfo := provider.Validate(context.Background(), &web.Request{}, validatorProviderOrderTestData{
+		Items: []validatorProviderItemTestData{
+			{
+				Sku: "A1",
+			},
+			{
+				Sku: "",
+			},
+			{
+				Sku: "C3",
+			},
+		},
+		Addresses: map[string]validatorProviderOrderAddressTestData{
+			"Home": {
+				Street: "Main Street",
+			},
+			"Office": {
+				Street: "",
+			},
+		},
+	})
+	t.False(validationInfo.IsValid())
+	t.Equal(map[string][]domain.Error{
+		"items[1].sku": {
+			{
+				MessageKey:   "formError.items[1].sku.required",
+				DefaultLabel: "Sku required",
+				Parameters: map[string]string{
+					"tag":   "required",
+					"field": "Sku",
+				},
+			},
+		},
+		"addresses[Office].street": {
+			{
+				MessageKey:   "formError.addresses[Office].street.required",
+				DefaultLabel: "Street required",
+				Parameters: map[string]string{
+					"tag":   "required",
+					"field": "Street",
+				},
+			},
+		},
+	}, validationInfo.GetErrorsForAllFields())
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_MessageKeys() {
+	messageKeys, err := NewValidationMessageKeys("errors", map[string]interface{}{
+		"required": "errors.mandatory",
+	}, map[string]interface{}{
+		"required": "{field} is mandatory",
+	})
+	t.NoError(err)
+
+	provider := &ValidatorProviderImpl{}
+	provider.Inject(nil, nil, nil, nil, messageKeys, nil)
+
+	validationInfo := provider.Validate(context.Background(), &web.Request{}, validatorProviderMessageKeyTestData{
+		Name: "ab",
+	})
+	t.False(validationInfo.IsValid())
+	t.Equal(map[string][]domain.Error{
+		"email": {
+			{
+				MessageKey:   "errors.mandatory",
+				DefaultLabel: "E-Mail is mandatory",
+				Parameters: map[string]string{
+					"tag":   "required",
+					"field": "E-Mail",
+				},
+			},
+		},
+		"name": {
+			{
+				MessageKey:   "errors.name.min",
+				DefaultLabel: "Name min",
+				Parameters: map[string]string{
+					"tag":   "min",
+					"field": "Name",
+					"param": "3",
+					"min":   "3",
+				},
+			},
+		},
+	}, validationInfo.GetErrorsForAllFields())
+
+	validationInfo = provider.ErrorsToValidationInfo(errors.New("invalid"))
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "errors.invalidValidation",
+			DefaultLabel: "invalid",
+		},
+	}, validationInfo.GetGeneralErrors())
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_Labels() {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject(nil, nil, nil, nil, nil, nil)
+
+	validationInfo := provider.Validate(context.Background(), &web.Request{}, validatorProviderLabelTestData{
+		Items: []validatorProviderLabelItemTestData{
+			{
+				Sku: "",
+			},
+		},
+	})
+	t.False(validationInfo.IsValid())
+	t.Equal(map[string][]domain.Error{
+		"email": {
+			{
+				MessageKey:   "formError.email.required",
+				DefaultLabel: "E-Mail required",
+				Parameters: map[string]string{
+					"tag":   "required",
+					"field": "E-Mail",
+				},
+			},
+		},
+		"shippingAddress.firstName": {
+			{
+				MessageKey:   "formError.shippingAddress.firstName.required",
+				DefaultLabel: "Vorname required",
+				Parameters: map[string]string{
+					"tag":   "required",
+					"field": "Vorname",
+				},
+			},
+		},
+		"shippingAddress.lastName": {
+			{
+				MessageKey:   "formError.shippingAddress.lastName.required",
+				DefaultLabel: "LastName required",
+				Parameters: map[string]string{
+					"tag":   "required",
+					"field": "LastName",
+				},
+			},
+		},
+		"positions[0].articleNumber": {
+			{
+				MessageKey:   "formError.positions[0].articleNumber.required",
+				DefaultLabel: "Artikelnummer required",
+				Parameters: map[string]string{
+					"tag":   "required",
+					"field": "Artikelnummer",
+				},
+			},
+		},
+		"phone": {
+			{
+				MessageKey:   "formError.phone.required",
+				DefaultLabel: "Telefon required",
+				Parameters: map[string]string{
+					"tag":   "required",
+					"field": "Telefon",
+				},
+			},
+		},
+	}, validationInfo.GetErrorsForAllFields())
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_ContextFieldValidator() {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject(nil, []domain.ContextFieldValidator{
+		&validatorProviderUniqueUsernameValidator{
+			repository: &validatorProviderUserRepository{
+				usernames: map[string]string{
+					"taken": "user1",
+				},
+			},
+		},
+	}, nil, nil, nil, nil)
+
+	request := web.CreateRequest(nil, web.EmptySession())
+
+	validationInfo := provider.Validate(context.Background(), request, validatorProviderRegistrationTestData{
+		Username: "free",
+	})
+	t.True(validationInfo.IsValid())
+
+	validationInfo = provider.Validate(context.Background(), request, validatorProviderRegistrationTestData{
+		Username: "taken",
+	})
+	t.False(validationInfo.IsValid())
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.username.uniqueusername",
+			DefaultLabel: "Username uniqueusername",
+			Parameters: map[string]string{
+				"tag":   "uniqueusername",
+				"field": "Username",
+			},
+		},
+	}, validationInfo.GetErrorsForField("username"))
+
+	request.Session().Store("userID", "user1")
+	validationInfo = provider.Validate(context.Background(), request, validatorProviderRegistrationTestData{
+		Username: "taken",
+	})
+	t.True(validationInfo.IsValid())
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_ConcurrentContextFieldValidator() {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject(nil, []domain.ContextFieldValidator{
+		&validatorProviderUniqueUsernameValidator{
+			repository: &validatorProviderUserRepository{
+				usernames: map[string]string{
+					"taken": "user1",
+				},
+			},
+		},
+	}, nil, nil, nil, &struct {
+		FirstErrorOnly bool    `inject:"config:form.validator.firstErrorOnly"`
+		Concurrency    float64 `inject:"config:form.validator.concurrency"`
+	}{
+		Concurrency: 2,
+	})
+
+	request := web.CreateRequest(nil, web.EmptySession())
+
+	validationInfo := provider.Validate(context.Background(), request, validatorProviderRegistrationTestData{
+		Username: "free",
+	})
+	t.True(validationInfo.IsValid())
+
+	// results of concurrent validation are the same as results of sequential one
+	validationInfo = provider.Validate(context.Background(), request, validatorProviderRegistrationTestData{
+		Username: "taken",
+	})
+	t.False(validationInfo.IsValid())
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.username.uniqueusername",
+			DefaultLabel: "Username uniqueusername",
+			Parameters: map[string]string{
+				"tag":   "uniqueusername",
+				"field": "Username",
+			},
+		},
+	}, validationInfo.GetErrorsForField("username"))
+	t.Empty(validationInfo.GetGeneralErrors())
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_WarningFieldValidator() {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject(nil, nil, []domain.WarningFieldValidator{
+		&validatorProviderEmailTypoValidator{},
+	}, nil, nil, nil)
+
+	validationInfo := provider.Validate(context.Background(), &web.Request{}, validatorProviderNewsletterTestData{
+		Email: "john@gmail.com",
+	})
+	t.True(validationInfo.IsValid())
+	t.False(validationInfo.HasWarnings())
+
+	validationInfo = provider.Validate(context.Background(), &web.Request{}, validatorProviderNewsletterTestData{
+		Email: "john@gmial.com",
+	})
+	t.True(validationInfo.IsValid())
+	t.True(validationInfo.HasWarnings())
+	t.Empty(validationInfo.GetErrorsForField("email"))
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.email.emailtypo",
+			DefaultLabel: "Email emailtypo",
+			Parameters: map[string]string{
+				"tag":   "emailtypo",
+				"field": "Email",
+			},
+			Severity: domain.SeverityWarning,
+		},
+	}, validationInfo.GetWarningsForField("email"))
+
+	// warnings are not reported for fields with errors
+	validationInfo = provider.Validate(context.Background(), &web.Request{}, validatorProviderNewsletterTestData{
+		Email: "gmial.com",
+	})
+	t.False(validationInfo.IsValid())
+	t.False(validationInfo.HasWarnings())
+}
+
+func (t *ValidatorProviderTestSuite) TestErrorsToValidationInfo_CriteriaFieldValidator() {
+	criteriaValidator := &mocks.CriteriaFieldValidator{}
+	criteriaValidator.On("FailedCriteria", "secret", "").Return([]domain.ValidationRule{
+		{Name: "passwordlength", Value: "8"},
+		{Name: "passworddigit"},
+	}).Once()
+	t.provider.criteriaValidators = map[string]domain.CriteriaFieldValidator{
+		"passwordstrength": criteriaValidator,
+	}
+
+	err := &mocks.FieldError{}
+	err.On("Namespace").Return("formData.password").Once()
+	err.On("Tag").Return("passwordstrength").Twice()
+	err.On("StructField").Return("Password").Once()
+	err.On("Param").Return("")
+	err.On("Kind").Return(reflect.String).Once()
+	err.On("Value").Return("secret").Once()
+
+	validationInfo := t.provider.ErrorsToValidationInfo(validator.ValidationErrors{
+		err,
+	})
+	t.Equal(map[string][]domain.Error{
+		"password": {
+			{
+				MessageKey:   "formError.password.passwordlength",
+				DefaultLabel: "Password passwordlength",
+				Parameters: map[string]string{
+					"tag":            "passwordlength",
+					"field":          "Password",
+					"param":          "8",
+					"passwordlength": "8",
+				},
+			},
+			{
+				MessageKey:   "formError.password.passworddigit",
+				DefaultLabel: "Password passworddigit",
+				Parameters: map[string]string{
+					"tag":   "passworddigit",
+					"field": "Password",
+				},
+			},
+		},
+	}, validationInfo.GetErrorsForAllFields())
+
+	err.AssertExpectations(t.T())
+	criteriaValidator.AssertExpectations(t.T())
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_PasswordValidators() {
+	confirmValidator := &validators.PasswordConfirmValidator{}
+	confirmValidator.Inject(&struct {
+		PasswordField string `inject:"config:form.validator.passwordField"`
+	}{
+		PasswordField: "Password",
+	})
+
+	provider := &ValidatorProviderImpl{}
+	provider.Inject([]domain.FieldValidator{
+		validators.NewPasswordStrengthValidator(validators.PasswordPolicy{
+			MinLength:    10,
+			RequireDigit: true,
+		}),
+		confirmValidator,
+	}, nil, nil, nil, nil, nil)
+
+	validationInfo := provider.Validate(context.Background(), &web.Request{}, validatorProviderSignupTestData{
+		Password:          "secret1234",
+		PasswordConfirm:   "secret1234",
+		NewPassword:       "other",
+		NewPasswordRepeat: "other",
+	})
+	t.True(validationInfo.IsValid())
+
+	validationInfo = provider.Validate(context.Background(), &web.Request{}, validatorProviderSignupTestData{
+		Password:          "secret",
+		PasswordConfirm:   "secret1234",
+		NewPassword:       "other",
+		NewPasswordRepeat: "another",
+	})
+	t.Equal(map[string][]domain.Error{
+		// each failed criterion of password policy is reported on its own
+		"password": {
+			{
+				MessageKey:   "formError.password.passwordlength",
+				DefaultLabel: "Password passwordlength",
+				Parameters: map[string]string{
+					"tag":            "passwordlength",
+					"field":          "Password",
+					"param":          "10",
+					"passwordlength": "10",
+				},
+			},
+			{
+				MessageKey:   "formError.password.passworddigit",
+				DefaultLabel: "Password passworddigit",
+				Parameters: map[string]string{
+					"tag":   "passworddigit",
+					"field": "Password",
+				},
+			},
+		},
+		// mismatch is reported on confirmation field, not on password field
+		"passwordConfirm": {
+			{
+				MessageKey:   "formError.passwordMismatch",
+				DefaultLabel: "PasswordConfirm passwordconfirm",
+				Parameters: map[string]string{
+					"tag":   "passwordconfirm",
+					"field": "PasswordConfirm",
+				},
+			},
+		},
+		"newPasswordRepeat": {
+			{
+				MessageKey:   "formError.passwordMismatch",
+				DefaultLabel: "NewPasswordRepeat passwordconfirm",
+				Parameters: map[string]string{
+					"tag":             "passwordconfirm",
+					"field":           "NewPasswordRepeat",
+					"param":           "NewPassword",
+					"passwordconfirm": "NewPassword",
+					"referencedField": "newPassword",
+				},
+			},
+		},
+	}, validationInfo.GetErrorsForAllFields())
+}
+
+func BenchmarkValidatorProviderImpl_Validate(b *testing.B) {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject(nil, []domain.ContextFieldValidator{
+		&validatorProviderUniqueUsernameValidator{
+			repository: &validatorProviderUserRepository{
+				usernames: map[string]string{
+					"taken": "user1",
+				},
+			},
+		},
+	}, nil, nil, nil, nil)
+
+	request := web.CreateRequest(nil, web.EmptySession())
+	data := validatorProviderRegistrationTestData{
+		Username: "free",
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		provider.Validate(context.Background(), request, data)
+	}
+}
+
+func BenchmarkValidatorProviderImpl_ValidateNested(b *testing.B) {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject(nil, nil, nil, nil, nil, nil)
+
+	request := web.CreateRequest(nil, web.EmptySession())
+	data := validatorProviderBenchmarkTestData{
+		Email:     "invalid",
+		Age:       16,
+		Interests: []string{"sports", "music", "books", "movies"},
+		Billing:   &validatorProviderBenchmarkAddressTestData{},
+		Items: []validatorProviderBenchmarkItemTestData{
+			{Sku: "A1"},
+			{Quantity: 1},
+			{Price: 12.5},
+		},
+	}
+
+	// uncached benchmark removes all struct fields and labels before each validation, like they were resolved on every request before
+	for _, cached := range []bool{false, true} {
+		b.Run("cached="+strconv.FormatBool(cached), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if !cached {
+					provider.ResetMetadataCache()
+				}
+				provider.Validate(context.Background(), request, data)
+			}
+		})
+	}
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_RuneValidators() {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject([]domain.FieldValidator{
+		&validators.MinimumRunesValidator{},
+		&validators.MaximumRunesValidator{},
+	}, nil, nil, nil, nil, nil)
+
+	validationInfo := provider.Validate(context.Background(), &web.Request{}, validatorProviderRunesTestData{
+		Name: "Müller",
+		Tags: []string{"Bär", "Öl"},
+	})
+	t.True(validationInfo.IsValid())
+
+	validationInfo = provider.Validate(context.Background(), &web.Request{}, validatorProviderRunesTestData{
+		Name:     "",
+		Nickname: "Bär",
+		Tags:     []string{"Bären"},
+	})
+	t.Equal(map[string][]domain.Error{
+		"name": {
+			{
+				MessageKey:   "formError.name.required",
+				DefaultLabel: "Name required",
+				Parameters: map[string]string{
+					"tag":   "required",
+					"field": "Name",
+				},
+			},
+		},
+		"nickname": {
+			{
+				MessageKey:   "formError.nickname.minrunes",
+				DefaultLabel: "Nickname minrunes",
+				Parameters: map[string]string{
+					"tag":      "minrunes",
+					"field":    "Nickname",
+					"param":    "6",
+					"minrunes": "6",
+				},
+			},
+		},
+		"tags": {
+			{
+				MessageKey:   "formError.tags.maxrunes",
+				DefaultLabel: "Tags maxrunes",
+				Parameters: map[string]string{
+					"tag":      "maxrunes",
+					"field":    "Tags",
+					"param":    "3",
+					"maxrunes": "3",
+				},
+			},
+		},
+	}, validationInfo.GetErrorsForAllFields())
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_CollapsedErrors() {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject([]domain.FieldValidator{
+		&validators.EachOneOfValidator{},
+	}, nil, nil, nil, nil, nil)
+
+	validationInfo := provider.Validate(context.Background(), &web.Request{}, validatorProviderInterestsTestData{
+		Interests: []string{"sports", "books"},
+		Sizes:     []int{36, 40},
+		Colors:    []string{"red"},
+	})
+	t.True(validationInfo.IsValid())
+
+	validationInfo = provider.Validate(context.Background(), &web.Request{}, validatorProviderInterestsTestData{
+		Interests: []string{"sports", "movies"},
+		Sizes:     []int{36, 42, 44},
+		Colors:    []string{"red", "green"},
+		Items: []validatorProviderItemTestData{
+			{
+				Sku: "A1",
+			},
+			{
+				Sku: "",
+			},
+		},
+	})
+	t.Equal(map[string][]domain.Error{
+		"interests": {
+			{
+				MessageKey:   "formError.interests.eachoneof",
+				DefaultLabel: "Interests eachoneof",
+				Parameters: map[string]string{
+					"tag":       "eachoneof",
+					"field":     "Interests",
+					"param":     "sports music books",
+					"eachoneof": "sports music books",
+				},
+			},
+		},
+		"sizes": {
+			{
+				MessageKey:   "formError.sizes.oneof",
+				DefaultLabel: "Sizes oneof",
+				Parameters: map[string]string{
+					"tag":   "oneof",
+					"field": "Sizes",
+					"param": "36 38 40",
+					"oneof": "36 38 40",
+					"value": "42",
+				},
+			},
+		},
+		"colors[1]": {
+			{
+				MessageKey:   "formError.colors[1].oneof",
+				DefaultLabel: "Colors oneof",
+				Parameters: map[string]string{
+					"tag":   "oneof",
+					"field": "Colors",
+					"param": "red blue",
+					"oneof": "red blue",
+				},
+			},
+		},
+		"positions": {
+			{
+				MessageKey:   "formError.positions.required",
+				DefaultLabel: "Sku required",
+				Parameters: map[string]string{
+					"tag":   "required",
+					"field": "Sku",
+				},
+			},
+		},
+	}, validationInfo.GetErrorsForAllFields())
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_StableOrder() {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject(nil, nil, nil, nil, nil, nil)
+
+	var first string
+	for i := 0; i < 50; i++ {
+		validationInfo := provider.Validate(context.Background(), &web.Request{}, validatorProviderLabelTestData{
+			Items: []validatorProviderLabelItemTestData{{}, {}},
+		})
+
+		jsonString, err := json.Marshal(validationInfo)
+		t.NoError(err)
+
+		if i == 0 {
+			first = string(jsonString)
+
+			var fieldNames []string
+			for _, entry := range validationInfo.FieldErrorsSorted() {
+				fieldNames = append(fieldNames, entry.FieldName)
+			}
+			t.Equal([]string{
+				"email",
+				"shippingAddress.firstName",
+				"shippingAddress.lastName",
+				"positions[0].articleNumber",
+				"positions[1].articleNumber",
+				"phone",
+			}, fieldNames)
+		}
+		t.Equal(first, string(jsonString))
+	}
+}
+
+func (t *ValidatorProviderTestSuite) TestIsInActiveSteps() {
+	typeOf := reflect.TypeOf(validatorProviderWizardTestData{})
+	activeSteps := map[string]bool{
+		"shipping": true,
+		"summary":  true,
+	}
+
+	testCases := []struct {
+		StructNamespace string
+		Result          bool
+	}{
+		{
+			StructNamespace: "validatorProviderWizardTestData.Email",
+			Result:          true,
+		},
+		{
+			StructNamespace: "validatorProviderWizardTestData.Shipping.Street",
+			Result:          true,
+		},
+		{
+			StructNamespace: "validatorProviderWizardTestData.Shipping.Phone",
+			Result:          false,
+		},
+		{
+			StructNamespace: "validatorProviderWizardTestData.Shipping.Notes",
+			Result:          true,
+		},
+		{
+			StructNamespace: "validatorProviderWizardTestData.Payment.Iban",
+			Result:          false,
+		},
+		{
+			StructNamespace: "validatorProviderWizardTestData.Terms",
+			Result:          true,
+		},
+		{
+			StructNamespace: "validatorProviderWizardTestData.Unknown",
+			Result:          true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := &mocks.FieldError{}
+		err.On("StructNamespace").Return(testCase.StructNamespace).Once()
+		t.Equal(testCase.Result, t.provider.isInActiveSteps(typeOf, err, activeSteps), testCase.StructNamespace)
+		err.AssertExpectations(t.T())
+	}
+
+	t.True(t.provider.isInActiveSteps(nil, &mocks.FieldError{}, activeSteps))
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_ActiveSteps() {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject(nil, nil, nil, nil, nil, nil)
+
+	formData := validatorProviderWizardTestData{
+		Email: "mail@example.com",
+		Shipping: validatorProviderShippingTestData{
+			Street: "Main Street",
+			Phone:  "123",
+		},
+		Payment: &validatorProviderPaymentTestData{},
+	}
+
+	// first step is valid, while fields of second step are still empty
+	validationInfo := provider.Validate(domain.ContextWithActiveSteps(context.Background(), "shipping"), &web.Request{}, formData)
+	t.True(validationInfo.IsValid())
+
+	validationInfo = provider.Validate(domain.ContextWithActiveSteps(context.Background(), "shipping", "contact", "payment"), &web.Request{}, formData)
+	t.False(validationInfo.IsValid())
+	t.Equal([]string{"payment.iban", "terms"}, t.getFieldNames(validationInfo))
+
+	// without active steps, all fields are validated
+	validationInfo = provider.Validate(context.Background(), &web.Request{}, validatorProviderWizardTestData{
+		Payment: &validatorProviderPaymentTestData{},
+	})
+	t.Equal([]string{"email", "shipping.street", "shipping.phone", "payment.iban", "terms"}, t.getFieldNames(validationInfo))
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_EmbeddedStructs() {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject(nil, nil, nil, nil, nil, nil)
+
+	// nil pointer to embedded struct is not validated, same as any other nil pointer without rules
+	validationInfo := provider.Validate(context.Background(), &web.Request{}, validatorProviderEmbedCheckoutTestData{})
+	t.Equal([]string{"email", "street", "city", "billing.street", "billing.city", "billing.company.name"}, t.getFieldNames(validationInfo))
+
+	validationInfo = provider.Validate(context.Background(), &web.Request{}, &validatorProviderEmbedCheckoutTestData{
+		Email: "mail@example.com",
+		validatorProviderEmbedAddressTestData: validatorProviderEmbedAddressTestData{
+			Street: "Main Street",
+		},
+		validatorProviderEmbedContactTestData: &validatorProviderEmbedContactTestData{},
+		Billing: validatorProviderEmbedBillingTestData{
+			validatorProviderEmbedAddressTestData: validatorProviderEmbedAddressTestData{
+				Street: "Second Street",
+				City:   "Berlin",
+			},
+			Company: validatorProviderEmbedCompanyTestData{
+				Name: "ACME",
+			},
+		},
+	})
+	t.Equal(map[string][]domain.Error{
+		"city": {
+			{
+				MessageKey:   "formError.city.required",
+				DefaultLabel: "City required",
+				Parameters: map[string]string{
+					"tag":   "required",
+					"field": "City",
+				},
+			},
+		},
+		"phone": {
+			{
+				MessageKey:   "formError.phone.required",
+				DefaultLabel: "Phone required",
+				Parameters: map[string]string{
+					"tag":   "required",
+					"field": "Phone",
+				},
+			},
+		},
+	}, validationInfo.GetErrorsForAllFields())
+
+	// embedded structs with form name keep it, and so do errors of embedded field itself
+	validationInfo = provider.Validate(context.Background(), &web.Request{}, validatorProviderNamedEmbedTestData{})
+	t.Equal([]string{"address.street", "address.city", "validatorProviderEmbedContactTestData"}, t.getFieldNames(validationInfo))
+}
+
+func (t *ValidatorProviderTestSuite) countCachedStructFields() int {
+	count := 0
+	t.provider.structFields.Range(func(_ interface{}, _ interface{}) bool {
+		count++
+		return true
+	})
+
+	return count
+}
+
+func (t *ValidatorProviderTestSuite) TestGetNilPointerError() {
+	typeOf := reflect.TypeOf(validatorProviderOptionalTestData{})
+
+	testCases := []struct {
+		StructNamespace string
+		Tag             string
+		Value           interface{}
+		Skipped         bool
+		ResultTag       string
+	}{
+		{
+			StructNamespace: "validatorProviderOptionalTestData.Nickname",
+			Tag:             "min",
+			Value:           "ab",
+			ResultTag:       "min",
+		},
+		{
+			StructNamespace: "validatorProviderOptionalTestData.Nickname",
+			Tag:             "min",
+			Value:           (*string)(nil),
+			Skipped:         true,
+		},
+		{
+			StructNamespace: "validatorProviderOptionalTestData.Age",
+			Tag:             "max",
+			Value:           (*int)(nil),
+			Skipped:         true,
+		},
+		{
+			StructNamespace: "validatorProviderOptionalTestData.Email",
+			Tag:             "email",
+			Value:           (*string)(nil),
+			ResultTag:       "required",
+		},
+		{
+			StructNamespace: "validatorProviderOptionalTestData.Active",
+			Tag:             "required",
+			Value:           (*bool)(nil),
+			ResultTag:       "required",
+		},
+		// rules of struct validators are not defined in field's tag, so they are kept
+		{
+			StructNamespace: "validatorProviderOptionalTestData.Nickname",
+			Tag:             "unique",
+			Value:           (*string)(nil),
+			ResultTag:       "unique",
+		},
+		// rules after "dive" are rules of elements
+		{
+			StructNamespace: "validatorProviderOptionalTestData.Tags",
+			Tag:             "min",
+			Value:           (*[]string)(nil),
+			Skipped:         true,
+		},
+		{
+			StructNamespace: "validatorProviderOptionalTestData.Unknown",
+			Tag:             "min",
+			Value:           (*string)(nil),
+			ResultTag:       "min",
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := &mocks.FieldError{}
+		err.On("StructNamespace").Return(testCase.StructNamespace)
+		err.On("Tag").Return(testCase.Tag)
+		err.On("Value").Return(testCase.Value)
+
+		result, ok := t.provider.getNilPointerError(typeOf, err)
+		t.Equal(!testCase.Skipped, ok, testCase.StructNamespace)
+		if ok {
+			t.Equal(testCase.ResultTag, result.Tag(), testCase.StructNamespace)
+		}
+	}
+
+	err := &mocks.FieldError{}
+	result, ok := t.provider.getNilPointerError(nil, err)
+	t.True(ok)
+	t.True(result == err)
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_OptionalFields() {
+	provider := &ValidatorProviderImpl{}
+	provider.Inject(nil, nil, nil, nil, nil, nil)
+
+	empty := ""
+	zero := 0
+	inactive := false
+	nickname := "john"
+	age := 42
+	email := "mail@example.com"
+	active := true
+
+	testCases := []struct {
+		Name       string
+		FormData   validatorProviderOptionalTestData
+		FieldNames []string
+	}{
+		{
+			Name:       "absent",
+			FormData:   validatorProviderOptionalTestData{},
+			FieldNames: []string{"email", "active"},
+		},
+		{
+			Name: "empty",
+			FormData: validatorProviderOptionalTestData{
+				Nickname: &empty,
+				Age:      &zero,
+				Email:    &empty,
+				Active:   &inactive,
+			},
+			FieldNames: []string{"nickname", "age", "email"},
+		},
+		{
+			Name: "filled",
+			FormData: validatorProviderOptionalTestData{
+				Nickname: &nickname,
+				Age:      &age,
+				Email:    &email,
+				Active:   &active,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		validationInfo := provider.Validate(context.Background(), &web.Request{}, testCase.FormData)
+		t.Equal(testCase.FieldNames, t.getFieldNames(validationInfo), testCase.Name)
+	}
+
+	// missing required field is reported as "required", even if other rule is defined first
+	validationInfo := provider.Validate(context.Background(), &web.Request{}, validatorProviderOptionalTestData{})
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.email.required",
+			DefaultLabel: "Email required",
+			Parameters: map[string]string{
+				"tag":   "required",
+				"field": "Email",
+			},
+		},
+	}, validationInfo.GetErrorsForAllFields()["email"])
+}
+
+func (t *ValidatorProviderTestSuite) TestErrorsToValidationInfo_FirstErrorOnly() {
+	typeOf := reflect.TypeOf(validatorProviderSubscriptionTestData{})
+	createErrors := func() validator.ValidationErrors {
+		return validator.ValidationErrors{
+			t.createFieldError("validatorProviderSubscriptionTestData.email", "validatorProviderSubscriptionTestData.Email", "companyemail"),
+			t.createFieldError("validatorProviderSubscriptionTestData.email", "validatorProviderSubscriptionTestData.Email", "email"),
+			t.createFieldError("validatorProviderSubscriptionTestData.email", "validatorProviderSubscriptionTestData.Email", "required"),
+			t.createFieldError("validatorProviderSubscriptionTestData.backupEmail", "validatorProviderSubscriptionTestData.BackupEmail", "email"),
+			t.createFieldError("validatorProviderSubscriptionTestData.backupEmail", "validatorProviderSubscriptionTestData.BackupEmail", "required"),
+			t.createFieldError("validatorProviderSubscriptionTestData.nickname", "validatorProviderSubscriptionTestData.Nickname", "alphanum"),
+			t.createFieldError("validatorProviderSubscriptionTestData.nickname", "validatorProviderSubscriptionTestData.Nickname", "min"),
+		}
+	}
+
+	testCases := []struct {
+		Name           string
+		FirstErrorOnly bool
+		Result         map[string][]string
+	}{
+		{
+			Name:           "all errors",
+			FirstErrorOnly: false,
+			Result: map[string][]string{
+				"email":       {"companyemail", "email", "required"},
+				"backupEmail": {"email", "required"},
+				"nickname":    {"min"},
+			},
+		},
+		{
+			Name:           "first error only",
+			FirstErrorOnly: true,
+			Result: map[string][]string{
+				"email":       {"required"},
+				"backupEmail": {"email", "required"},
+				"nickname":    {"min"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.provider.firstErrorOnly = testCase.FirstErrorOnly
+
+		// result is the same, no matter how many times errors are collapsed
+		for i := 0; i < 3; i++ {
+			validationInfo := t.provider.errorsToValidationInfo(createErrors(), typeOf, nil)
+			t.Equal(testCase.Result, t.getErrorTags(validationInfo), testCase.Name)
+		}
+	}
+}
+
+func (t *ValidatorProviderTestSuite) TestCollapseFieldErrors_TagOrder() {
+	t.provider.firstErrorOnly = true
+	typeOf := reflect.TypeOf(validatorProviderSubscriptionTestData{})
+
+	validationInfo := t.provider.errorsToValidationInfo(validator.ValidationErrors{
+		t.createFieldError("validatorProviderSubscriptionTestData.email", "validatorProviderSubscriptionTestData.Email", "companyemail"),
+		t.createFieldError("validatorProviderSubscriptionTestData.email", "validatorProviderSubscriptionTestData.Email", "email"),
+	}, typeOf, nil)
+	t.Equal("email", validationInfo.GetErrorsForField("email")[0].Parameters["tag"])
+
+	// errors with tags which are not defined for the field keep order in which they are reported
+	validationInfo = t.provider.errorsToValidationInfo(validator.ValidationErrors{
+		t.createFieldError("validatorProviderSubscriptionTestData.email", "validatorProviderSubscriptionTestData.Email", "companyemail"),
+		t.createFieldError("validatorProviderSubscriptionTestData.email", "validatorProviderSubscriptionTestData.Email", "blockedemail"),
+	}, typeOf, nil)
+	t.Len(validationInfo.GetErrorsForField("email"), 1)
+	t.Equal("companyemail", validationInfo.GetErrorsForField("email")[0].Parameters["tag"])
+
+	// without known type, errors are collapsed only by their field names
+	validationInfo = t.provider.errorsToValidationInfo(validator.ValidationErrors{
+		t.createFieldError("formData.email", "formData.Email", "email"),
+		t.createFieldError("formData.email", "formData.Email", "required"),
+	}, nil, nil)
+	t.Len(validationInfo.GetErrorsForField("email"), 1)
+	t.Equal("required", validationInfo.GetErrorsForField("email")[0].Parameters["tag"])
+}
+
+func (t *ValidatorProviderTestSuite) TestValidate_FirstErrorOnly() {
+	testCases := []struct {
+		Name           string
+		FirstErrorOnly bool
+		Result         map[string][]string
+	}{
+		{
+			Name:           "all errors",
+			FirstErrorOnly: false,
+			Result: map[string][]string{
+				"email":       {"required", "companyemail"},
+				"backupEmail": {"required", "companyemail"},
+			},
+		},
+		{
+			Name:           "first error only",
+			FirstErrorOnly: true,
+			Result: map[string][]string{
+				"email":       {"required"},
+				"backupEmail": {"required", "companyemail"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		provider := &ValidatorProviderImpl{}
+		provider.Inject(nil, nil, nil, []domain.StructValidator{
+			&validatorProviderSubscriptionStructValidator{},
+		}, nil, &struct {
+			FirstErrorOnly bool    `inject:"config:form.validator.firstErrorOnly"`
+			Concurrency    float64 `inject:"config:form.validator.concurrency"`
+		}{
+			FirstErrorOnly: testCase.FirstErrorOnly,
+		})
+
+		validationInfo := provider.Validate(context.Background(), &web.Request{}, validatorProviderSubscriptionTestData{
+			Nickname: "john",
+		})
+		t.Equal(testCase.Result, t.getErrorTags(validationInfo), testCase.Name)
+	}
+}
+
+// createFieldError creates field error mock with provided namespaces and tag
+func (t *ValidatorProviderTestSuite) createFieldError(namespace string, structNamespace string, tag string) *mocks.FieldError {
+	err := &mocks.FieldError{}
+	err.On("Namespace").Return(namespace).Maybe()
+	err.On("StructNamespace").Return(structNamespace).Maybe()
+	err.On("StructField").Return(structNamespace[strings.LastIndex(structNamespace, ".")+1:]).Maybe()
+	err.On("Tag").Return(tag).Maybe()
+	err.On("Param").Return("").Maybe()
+	err.On("Kind").Return(reflect.String).Maybe()
+	err.On("Value").Return("").Maybe()
+
+	return err
+}
+
+func (t *ValidatorProviderTestSuite) getFieldNames(validationInfo domain.ValidationInfo) []string {
+	var fieldNames []string
+	for _, entry := range validationInfo.FieldErrorsSorted() {
+		fieldNames = append(fieldNames, entry.FieldName)
+	}
+
+	return fieldNames
+}
+
+// getErrorTags returns tags of all field errors, grouped by field names
+func (t *ValidatorProviderTestSuite) getErrorTags(validationInfo domain.ValidationInfo) map[string][]string {
+	result := map[string][]string{}
+	for fieldName, errs := range validationInfo.GetErrorsForAllFields() {
+		for _, err := range errs {
+			result[fieldName] = append(result[fieldName], err.Parameters["tag"])
+		}
+	}
+
+	return result
 }
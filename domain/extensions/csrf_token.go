@@ -0,0 +1,211 @@
+package extensions
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+const (
+	// CsrfTokenFormExtensionName defines name of CSRF token form extension provided via dingo injector
+	CsrfTokenFormExtensionName = "formExtension.csrfToken"
+	// CsrfTokenFormExtensionDataName defines name under which CSRF token data is stored in form extensions data
+	CsrfTokenFormExtensionDataName = "csrf"
+	// CsrfTokenFieldName defines name of hidden field which contains CSRF token
+	CsrfTokenFieldName = "csrf_token"
+	// CsrfTokenHeaderName defines name of http header which can contain CSRF token, in case when it's not sent as field
+	CsrfTokenHeaderName = "X-CSRF-Token"
+
+	csrfTokenSessionKey = "form.csrf.token"
+)
+
+type (
+	// CsrfTokenFormExtension as form extension which provides signed CSRF token, stores it into session
+	// and validates submitted token against the one from session
+	CsrfTokenFormExtension struct {
+		secret []byte
+		ttl    time.Duration
+	}
+
+	// CsrfTokenData as form extension data which contains CSRF token, which should be rendered as hidden field "csrf_token"
+	CsrfTokenData struct {
+		// Token CSRF token which should be submitted with form
+		Token string
+		// submittedToken CSRF token submitted with form
+		submittedToken string
+	}
+)
+
+var (
+	_ domain.FormDataProvider       = &CsrfTokenFormExtension{}
+	_ domain.FormDataDecoder        = &CsrfTokenFormExtension{}
+	_ domain.FormDataValidator      = &CsrfTokenFormExtension{}
+	_ domain.FormExtensionFinalizer = &CsrfTokenFormExtension{}
+)
+
+// Inject is method used to set all dependencies as local variables
+func (e *CsrfTokenFormExtension) Inject(cfg *struct {
+	Secret string `inject:"config:form.csrf.secret"`
+	TTL    string `inject:"config:form.csrf.ttl"`
+}) {
+	ttl, err := time.ParseDuration(cfg.TTL)
+	if err != nil {
+		panic(err.Error())
+	}
+	e.ttl = ttl
+
+	e.secret = []byte(cfg.Secret)
+	if len(e.secret) == 0 {
+		// without configured secret, tokens are valid only for current instance of application
+		e.secret = make([]byte, 32)
+		if _, err := rand.Read(e.secret); err != nil {
+			panic(err.Error())
+		}
+	}
+}
+
+// GetFormData provides CSRF token from session, or creates new one if there is no valid token in session
+func (e *CsrfTokenFormExtension) GetFormData(_ context.Context, req *web.Request) (interface{}, error) {
+	session := req.Session()
+	if session == nil {
+		return nil, domain.NewFormError("session is required for CSRF token")
+	}
+
+	token, ok := session.Load(csrfTokenSessionKey)
+	if converted, isString := token.(string); ok && isString && e.isValid(converted) {
+		return &CsrfTokenData{
+			Token: converted,
+		}, nil
+	}
+
+	newToken, err := e.rotate(session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CsrfTokenData{
+		Token: newToken,
+	}, nil
+}
+
+// Decode extracts submitted CSRF token from hidden field, or from http header if it's not sent as field
+func (e *CsrfTokenFormExtension) Decode(_ context.Context, req *web.Request, values url.Values, formData interface{}) (interface{}, error) {
+	data, ok := formData.(*CsrfTokenData)
+	if !ok {
+		return nil, domain.NewFormErrorf("wrong CSRF token data passed: %#v", formData)
+	}
+
+	data.submittedToken = values.Get(CsrfTokenFieldName)
+	if data.submittedToken == "" {
+		data.submittedToken = req.Request().Header.Get(CsrfTokenHeaderName)
+	}
+
+	return data, nil
+}
+
+// Validate checks submitted CSRF token against the one from session. In case when it's missing, expired
+// or mismatched, general error "formError.csrf" is added. Token is not rotated here, since submitted form
+// can still be invalid, and the same token is kept for other forms rendered with current session.
+func (e *CsrfTokenFormExtension) Validate(_ context.Context, req *web.Request, _ domain.ValidatorProvider, formData interface{}) (*domain.ValidationInfo, error) {
+	validationInfo := domain.ValidationInfo{}
+
+	data, ok := formData.(*CsrfTokenData)
+	if !ok {
+		return nil, domain.NewFormErrorf("wrong CSRF token data passed: %#v", formData)
+	}
+
+	session := req.Session()
+	if session == nil {
+		validationInfo.AddGeneralError("formError.csrf", "invalid csrf token")
+		return &validationInfo, nil
+	}
+
+	token, _ := session.Load(csrfTokenSessionKey)
+	sessionToken, _ := token.(string)
+
+	if data.submittedToken == "" || subtle.ConstantTimeCompare([]byte(data.submittedToken), []byte(sessionToken)) != 1 || !e.isValid(data.submittedToken) {
+		validationInfo.AddGeneralError("formError.csrf", "invalid csrf token")
+	}
+
+	return &validationInfo, nil
+}
+
+// Finalize rotates CSRF token after form is successfully submitted, so the same token can't be submitted again
+func (e *CsrfTokenFormExtension) Finalize(_ context.Context, req *web.Request, formExtensionData interface{}) (interface{}, error) {
+	data, ok := formExtensionData.(*CsrfTokenData)
+	if !ok {
+		return nil, domain.NewFormErrorf("wrong CSRF token data passed: %#v", formExtensionData)
+	}
+
+	session := req.Session()
+	if session == nil {
+		return data, nil
+	}
+
+	newToken, err := e.rotate(session)
+	if err != nil {
+		return nil, err
+	}
+	data.Token = newToken
+
+	return data, nil
+}
+
+// rotate creates new CSRF token and stores it into session, so previous token is no longer valid
+func (e *CsrfTokenFormExtension) rotate(session *web.Session) (string, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	payload := hex.EncodeToString(nonce) + "." + strconv.FormatInt(time.Now().Add(e.ttl).Unix(), 10)
+	token := payload + "." + e.sign(payload)
+
+	session.Store(csrfTokenSessionKey, token)
+
+	return token, nil
+}
+
+// isValid checks if CSRF token is signed with configured secret and it's not expired
+func (e *CsrfTokenFormExtension) isValid(token string) bool {
+	index := strings.LastIndex(token, ".")
+	if index < 0 {
+		return false
+	}
+
+	payload, signature := token[:index], token[index+1:]
+	if !hmac.Equal([]byte(signature), []byte(e.sign(payload))) {
+		return false
+	}
+
+	parts := strings.Split(payload, ".")
+	if len(parts) != 2 {
+		return false
+	}
+
+	expiry, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return false
+	}
+
+	return time.Now().Unix() < expiry
+}
+
+// sign creates HMAC signature of CSRF token payload
+func (e *CsrfTokenFormExtension) sign(payload string) string {
+	mac := hmac.New(sha256.New, e.secret)
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
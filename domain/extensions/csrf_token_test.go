@@ -0,0 +1,234 @@
+package extensions
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	CsrfTokenFormExtensionTestSuite struct {
+		suite.Suite
+
+		extension *CsrfTokenFormExtension
+		request   *web.Request
+	}
+)
+
+func TestCsrfTokenFormExtensionTestSuite(t *testing.T) {
+	suite.Run(t, &CsrfTokenFormExtensionTestSuite{})
+}
+
+func (t *CsrfTokenFormExtensionTestSuite) SetupTest() {
+	t.extension = &CsrfTokenFormExtension{}
+	t.extension.Inject(&struct {
+		Secret string `inject:"config:form.csrf.secret"`
+		TTL    string `inject:"config:form.csrf.ttl"`
+	}{
+		Secret: "secret",
+		TTL:    "30m",
+	})
+
+	t.request = web.CreateRequest(&http.Request{
+		Header: http.Header{},
+	}, web.EmptySession())
+}
+
+func (t *CsrfTokenFormExtensionTestSuite) TestInject_WrongTTL() {
+	t.Panics(func() {
+		t.extension.Inject(&struct {
+			Secret string `inject:"config:form.csrf.secret"`
+			TTL    string `inject:"config:form.csrf.ttl"`
+		}{
+			TTL: "wrong",
+		})
+	})
+}
+
+func (t *CsrfTokenFormExtensionTestSuite) TestGetFormData() {
+	first, err := t.extension.GetFormData(nil, t.request)
+	t.NoError(err)
+	t.IsType(&CsrfTokenData{}, first)
+	t.NotEmpty(first.(*CsrfTokenData).Token)
+
+	stored, ok := t.request.Session().Load(csrfTokenSessionKey)
+	t.True(ok)
+	t.Equal(first.(*CsrfTokenData).Token, stored)
+
+	second, err := t.extension.GetFormData(nil, t.request)
+	t.NoError(err)
+	t.Equal(first, second)
+}
+
+func (t *CsrfTokenFormExtensionTestSuite) TestGetFormData_ExpiredToken() {
+	t.extension.ttl = -time.Minute
+	first, err := t.extension.GetFormData(nil, t.request)
+	t.NoError(err)
+
+	t.extension.ttl = 30 * time.Minute
+	second, err := t.extension.GetFormData(nil, t.request)
+	t.NoError(err)
+	t.NotEqual(first.(*CsrfTokenData).Token, second.(*CsrfTokenData).Token)
+}
+
+func (t *CsrfTokenFormExtensionTestSuite) TestDecode() {
+	result, err := t.extension.Decode(nil, t.request, url.Values{
+		CsrfTokenFieldName: []string{"token"},
+	}, &CsrfTokenData{})
+	t.NoError(err)
+	t.Equal(&CsrfTokenData{
+		submittedToken: "token",
+	}, result)
+
+	t.request.Request().Header.Set(CsrfTokenHeaderName, "header")
+	result, err = t.extension.Decode(nil, t.request, url.Values{}, &CsrfTokenData{})
+	t.NoError(err)
+	t.Equal(&CsrfTokenData{
+		submittedToken: "header",
+	}, result)
+
+	result, err = t.extension.Decode(nil, t.request, url.Values{}, map[string]string{})
+	t.Error(err)
+	t.Nil(result)
+}
+
+func (t *CsrfTokenFormExtensionTestSuite) TestValidate_Success() {
+	data := t.submit(func(token string) string {
+		return token
+	})
+
+	validationInfo, err := t.extension.Validate(nil, t.request, nil, data)
+	t.NoError(err)
+	t.True(validationInfo.IsValid())
+
+	// token is kept until form is successfully submitted, so other forms of the same session stay valid
+	stored, _ := t.request.Session().Load(csrfTokenSessionKey)
+	t.Equal(data.submittedToken, stored)
+	t.Equal(stored, data.Token)
+}
+
+func (t *CsrfTokenFormExtensionTestSuite) TestFinalize() {
+	data := t.submit(func(token string) string {
+		return token
+	})
+
+	result, err := t.extension.Finalize(nil, t.request, data)
+	t.NoError(err)
+	t.Equal(data, result)
+
+	stored, _ := t.request.Session().Load(csrfTokenSessionKey)
+	t.NotEqual(data.submittedToken, stored)
+	t.Equal(stored, data.Token)
+}
+
+func (t *CsrfTokenFormExtensionTestSuite) TestFinalize_WrongData() {
+	result, err := t.extension.Finalize(nil, t.request, map[string]string{})
+	t.Error(err)
+	t.Nil(result)
+}
+
+func (t *CsrfTokenFormExtensionTestSuite) TestValidate_ReplayedToken() {
+	data := t.submit(func(token string) string {
+		return token
+	})
+
+	validationInfo, err := t.extension.Validate(nil, t.request, nil, data)
+	t.NoError(err)
+	t.True(validationInfo.IsValid())
+
+	_, err = t.extension.Finalize(nil, t.request, data)
+	t.NoError(err)
+
+	replayed := &CsrfTokenData{
+		submittedToken: data.submittedToken,
+	}
+	validationInfo, err = t.extension.Validate(nil, t.request, nil, replayed)
+	t.NoError(err)
+	t.False(validationInfo.IsValid())
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.csrf",
+			DefaultLabel: "invalid csrf token",
+		},
+	}, validationInfo.GetGeneralErrors())
+}
+
+func (t *CsrfTokenFormExtensionTestSuite) TestValidate_InvalidToken() {
+	testCases := []struct {
+		Name   string
+		TTL    time.Duration
+		Submit func(token string) string
+	}{
+		{
+			Name: "missing",
+			TTL:  30 * time.Minute,
+			Submit: func(token string) string {
+				return ""
+			},
+		},
+		{
+			Name: "mismatched",
+			TTL:  30 * time.Minute,
+			Submit: func(token string) string {
+				return "wrong"
+			},
+		},
+		{
+			Name: "tampered",
+			TTL:  30 * time.Minute,
+			Submit: func(token string) string {
+				parts := strings.Split(token, ".")
+				parts[1] = "99999999999"
+				return strings.Join(parts, ".")
+			},
+		},
+		{
+			Name: "expired",
+			TTL:  -time.Minute,
+			Submit: func(token string) string {
+				return token
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.SetupTest()
+		t.extension.ttl = testCase.TTL
+		data := t.submit(testCase.Submit)
+
+		validationInfo, err := t.extension.Validate(nil, t.request, nil, data)
+		t.NoError(err)
+		t.Equal([]domain.Error{
+			{
+				MessageKey:   "formError.csrf",
+				DefaultLabel: "invalid csrf token",
+			},
+		}, validationInfo.GetGeneralErrors(), testCase.Name)
+	}
+}
+
+func (t *CsrfTokenFormExtensionTestSuite) TestValidate_WrongData() {
+	validationInfo, err := t.extension.Validate(nil, t.request, nil, map[string]string{})
+	t.Error(err)
+	t.Nil(validationInfo)
+}
+
+// submit provides CSRF token and decodes submitted token, which is created from provided one
+func (t *CsrfTokenFormExtensionTestSuite) submit(submit func(token string) string) *CsrfTokenData {
+	formData, err := t.extension.GetFormData(nil, t.request)
+	t.NoError(err)
+
+	result, err := t.extension.Decode(nil, t.request, url.Values{
+		CsrfTokenFieldName: []string{submit(formData.(*CsrfTokenData).Token)},
+	}, formData)
+	t.NoError(err)
+
+	return result.(*CsrfTokenData)
+}
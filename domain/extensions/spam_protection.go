@@ -0,0 +1,239 @@
+package extensions
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+const (
+	// SpamProtectionFormExtensionName defines name of spam protection form extension provided via dingo injector
+	SpamProtectionFormExtensionName = "formExtension.spamProtection"
+	// SpamProtectionFormExtensionDataName defines name under which spam protection data is stored in form extensions data
+	SpamProtectionFormExtensionDataName = "spamProtection"
+	// SpamProtectionTimestampFieldName defines name of hidden field which contains signed timestamp of form rendering
+	SpamProtectionTimestampFieldName = "_formTimestamp"
+
+	spamProtectionSessionKey = "form.spamProtection.honeypot"
+)
+
+type (
+	// SpamProtectionFormExtension as form extension which provides honeypot field with randomized name and signed
+	// timestamp of form rendering. Submission is rejected if honeypot field is filled, or if form is submitted
+	// faster than minimum fill time, which is the case with most of spam bots. Timestamps older than maximum age
+	// are rejected too, so once rendered form can't be submitted by bots forever.
+	SpamProtectionFormExtension struct {
+		secret      []byte
+		minFillTime time.Duration
+		maxAge      time.Duration
+		clockSkew   time.Duration
+		now         func() time.Time
+	}
+
+	// SpamProtectionOptions as options of spam protection for single form
+	SpamProtectionOptions struct {
+		// MinFillTime minimum time between rendering and submitting of form. If it's not defined, configured one is used.
+		MinFillTime time.Duration
+	}
+
+	// SpamProtectionData as form extension data which contains names and values of hidden fields, which should be rendered
+	// with form. Honeypot field should be rendered empty and invisible for users (like with CSS), so only bots fill it.
+	SpamProtectionData struct {
+		// HoneypotFieldName randomized name of honeypot field, which is stored in session
+		HoneypotFieldName string
+		// TimestampFieldName name of hidden field which contains signed timestamp
+		TimestampFieldName string
+		// Timestamp signed timestamp of form rendering, which should be submitted with form
+		Timestamp string
+		// submittedHoneypot value of honeypot field submitted with form
+		submittedHoneypot string
+		// submittedTimestamp signed timestamp submitted with form
+		submittedTimestamp string
+	}
+)
+
+var (
+	_ domain.FormDataProvider  = &SpamProtectionFormExtension{}
+	_ domain.FormDataDecoder   = &SpamProtectionFormExtension{}
+	_ domain.FormDataValidator = &SpamProtectionFormExtension{}
+)
+
+// Inject is method used to set all dependencies as local variables
+func (e *SpamProtectionFormExtension) Inject(cfg *struct {
+	Secret      string `inject:"config:form.spamProtection.secret"`
+	MinFillTime string `inject:"config:form.spamProtection.minFillTime"`
+	MaxAge      string `inject:"config:form.spamProtection.maxAge"`
+	ClockSkew   string `inject:"config:form.spamProtection.clockSkew"`
+}, logger flamingo.Logger) {
+	minFillTime, err := time.ParseDuration(cfg.MinFillTime)
+	if err != nil {
+		panic(err.Error())
+	}
+	e.minFillTime = minFillTime
+
+	maxAge, err := time.ParseDuration(cfg.MaxAge)
+	if err != nil {
+		panic(err.Error())
+	}
+	e.maxAge = maxAge
+
+	clockSkew, err := time.ParseDuration(cfg.ClockSkew)
+	if err != nil {
+		panic(err.Error())
+	}
+	e.clockSkew = clockSkew
+
+	e.secret = []byte(cfg.Secret)
+	if len(e.secret) == 0 {
+		// without configured secret, timestamps are valid only for current instance of application
+		e.secret = make([]byte, 32)
+		if _, err := rand.Read(e.secret); err != nil {
+			panic(err.Error())
+		}
+
+		if logger != nil {
+			logger.WithField("SpamProtectionFormExtension", "secret").Warn("secret of spam protection is not configured, " +
+				"so random one is used and forms rendered by other instances of application are rejected as spam")
+		}
+	}
+
+	e.now = time.Now
+}
+
+// WithOptions returns copy of spam protection form extension, which uses provided options for single form.
+// Options with zero values keep configured ones.
+func (e *SpamProtectionFormExtension) WithOptions(options SpamProtectionOptions) *SpamProtectionFormExtension {
+	extension := *e
+	if options.MinFillTime > 0 {
+		extension.minFillTime = options.MinFillTime
+	}
+
+	return &extension
+}
+
+// GetFormData provides honeypot field name from session (or creates new one if there is none in session),
+// together with signed timestamp of current time
+func (e *SpamProtectionFormExtension) GetFormData(_ context.Context, req *web.Request) (interface{}, error) {
+	session := req.Session()
+	if session == nil {
+		return nil, domain.NewFormError("session is required for spam protection")
+	}
+
+	honeypotFieldName, err := e.getHoneypotFieldName(session)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SpamProtectionData{
+		HoneypotFieldName:  honeypotFieldName,
+		TimestampFieldName: SpamProtectionTimestampFieldName,
+		Timestamp:          e.createTimestamp(honeypotFieldName, e.now()),
+	}, nil
+}
+
+// Decode extracts submitted values of honeypot field and timestamp field
+func (e *SpamProtectionFormExtension) Decode(_ context.Context, _ *web.Request, values url.Values, formData interface{}) (interface{}, error) {
+	data, ok := formData.(*SpamProtectionData)
+	if !ok {
+		return nil, domain.NewFormErrorf("wrong spam protection data passed: %#v", formData)
+	}
+
+	data.submittedHoneypot = values.Get(data.HoneypotFieldName)
+	data.submittedTimestamp = values.Get(data.TimestampFieldName)
+
+	return data, nil
+}
+
+// Validate adds general error "formError.spamDetected" if honeypot field is filled, or if submitted timestamp
+// is missing, tampered, newer than minimum fill time or older than maximum age. Clock skew between instances
+// of application is tolerated by reducing minimum fill time and extending maximum age for configured duration.
+func (e *SpamProtectionFormExtension) Validate(_ context.Context, _ *web.Request, _ domain.ValidatorProvider, formData interface{}) (*domain.ValidationInfo, error) {
+	validationInfo := domain.ValidationInfo{}
+
+	data, ok := formData.(*SpamProtectionData)
+	if !ok {
+		return nil, domain.NewFormErrorf("wrong spam protection data passed: %#v", formData)
+	}
+
+	if data.submittedHoneypot != "" {
+		validationInfo.AddGeneralError("formError.spamDetected", "spam detected")
+		return &validationInfo, nil
+	}
+
+	renderedAt, ok := e.parseTimestamp(data.HoneypotFieldName, data.submittedTimestamp)
+	if !ok {
+		validationInfo.AddGeneralError("formError.spamDetected", "spam detected")
+		return &validationInfo, nil
+	}
+
+	elapsed := e.now().Sub(renderedAt)
+	if elapsed+e.clockSkew < e.minFillTime || elapsed-e.clockSkew > e.maxAge {
+		validationInfo.AddGeneralError("formError.spamDetected", "spam detected")
+	}
+
+	return &validationInfo, nil
+}
+
+// getHoneypotFieldName returns randomized name of honeypot field stored in session, or creates new one
+func (e *SpamProtectionFormExtension) getHoneypotFieldName(session *web.Session) (string, error) {
+	stored, ok := session.Load(spamProtectionSessionKey)
+	if name, isString := stored.(string); ok && isString && name != "" {
+		return name, nil
+	}
+
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", err
+	}
+
+	name := "field_" + hex.EncodeToString(suffix)
+	session.Store(spamProtectionSessionKey, name)
+
+	return name, nil
+}
+
+// createTimestamp creates timestamp signed together with honeypot field name, so it can't be reused with other sessions
+func (e *SpamProtectionFormExtension) createTimestamp(honeypotFieldName string, renderedAt time.Time) string {
+	payload := strconv.FormatInt(renderedAt.UnixNano(), 10)
+
+	return payload + "." + e.sign(honeypotFieldName+"."+payload)
+}
+
+// parseTimestamp returns time of form rendering from signed timestamp, or false if timestamp is tampered
+func (e *SpamProtectionFormExtension) parseTimestamp(honeypotFieldName string, timestamp string) (time.Time, bool) {
+	index := strings.LastIndex(timestamp, ".")
+	if index < 0 {
+		return time.Time{}, false
+	}
+
+	payload, signature := timestamp[:index], timestamp[index+1:]
+	if !hmac.Equal([]byte(signature), []byte(e.sign(honeypotFieldName+"."+payload))) {
+		return time.Time{}, false
+	}
+
+	nanoseconds, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return time.Unix(0, nanoseconds), true
+}
+
+// sign creates HMAC signature of timestamp payload
+func (e *SpamProtectionFormExtension) sign(payload string) string {
+	mac := hmac.New(sha256.New, e.secret)
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,284 @@
+package extensions
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	SpamProtectionFormExtensionTestSuite struct {
+		suite.Suite
+
+		extension *SpamProtectionFormExtension
+		request   *web.Request
+		now       time.Time
+	}
+
+	spamProtectionTestConfig = struct {
+		Secret      string `inject:"config:form.spamProtection.secret"`
+		MinFillTime string `inject:"config:form.spamProtection.minFillTime"`
+		MaxAge      string `inject:"config:form.spamProtection.maxAge"`
+		ClockSkew   string `inject:"config:form.spamProtection.clockSkew"`
+	}
+)
+
+func TestSpamProtectionFormExtensionTestSuite(t *testing.T) {
+	suite.Run(t, &SpamProtectionFormExtensionTestSuite{})
+}
+
+func (t *SpamProtectionFormExtensionTestSuite) SetupTest() {
+	t.extension = &SpamProtectionFormExtension{}
+	t.extension.Inject(&spamProtectionTestConfig{
+		Secret:      "secret",
+		MinFillTime: "2s",
+		MaxAge:      "1h",
+		ClockSkew:   "500ms",
+	}, &flamingo.NullLogger{})
+
+	t.now = time.Date(2019, 10, 1, 12, 0, 0, 0, time.UTC)
+	t.extension.now = func() time.Time {
+		return t.now
+	}
+
+	t.request = web.CreateRequest(&http.Request{
+		Header: http.Header{},
+	}, web.EmptySession())
+}
+
+func (t *SpamProtectionFormExtensionTestSuite) TestInject_WrongDurations() {
+	t.Panics(func() {
+		t.extension.Inject(&spamProtectionTestConfig{
+			MinFillTime: "wrong",
+			MaxAge:      "1h",
+			ClockSkew:   "1s",
+		}, nil)
+	})
+
+	t.Panics(func() {
+		t.extension.Inject(&spamProtectionTestConfig{
+			MinFillTime: "2s",
+			MaxAge:      "wrong",
+			ClockSkew:   "1s",
+		}, nil)
+	})
+
+	t.Panics(func() {
+		t.extension.Inject(&spamProtectionTestConfig{
+			MinFillTime: "2s",
+			MaxAge:      "1h",
+			ClockSkew:   "wrong",
+		}, nil)
+	})
+}
+
+func (t *SpamProtectionFormExtensionTestSuite) TestGetFormData() {
+	first, err := t.extension.GetFormData(nil, t.request)
+	t.NoError(err)
+	t.IsType(&SpamProtectionData{}, first)
+
+	data := first.(*SpamProtectionData)
+	t.True(strings.HasPrefix(data.HoneypotFieldName, "field_"))
+	t.Equal(SpamProtectionTimestampFieldName, data.TimestampFieldName)
+	t.NotEmpty(data.Timestamp)
+
+	stored, ok := t.request.Session().Load(spamProtectionSessionKey)
+	t.True(ok)
+	t.Equal(data.HoneypotFieldName, stored)
+
+	// honeypot field name is kept within session
+	second, err := t.extension.GetFormData(nil, t.request)
+	t.NoError(err)
+	t.Equal(data.HoneypotFieldName, second.(*SpamProtectionData).HoneypotFieldName)
+
+	other, err := t.extension.GetFormData(nil, web.CreateRequest(&http.Request{}, web.EmptySession()))
+	t.NoError(err)
+	t.NotEqual(data.HoneypotFieldName, other.(*SpamProtectionData).HoneypotFieldName)
+}
+
+func (t *SpamProtectionFormExtensionTestSuite) TestGetFormData_WithoutSession() {
+	result, err := t.extension.GetFormData(nil, &web.Request{})
+	t.Error(err)
+	t.Nil(result)
+}
+
+func (t *SpamProtectionFormExtensionTestSuite) TestDecode() {
+	result, err := t.extension.Decode(nil, t.request, url.Values{
+		"field_abc":                      []string{"spam"},
+		SpamProtectionTimestampFieldName: []string{"timestamp"},
+	}, &SpamProtectionData{
+		HoneypotFieldName:  "field_abc",
+		TimestampFieldName: SpamProtectionTimestampFieldName,
+	})
+	t.NoError(err)
+	t.Equal(&SpamProtectionData{
+		HoneypotFieldName:  "field_abc",
+		TimestampFieldName: SpamProtectionTimestampFieldName,
+		submittedHoneypot:  "spam",
+		submittedTimestamp: "timestamp",
+	}, result)
+
+	result, err = t.extension.Decode(nil, t.request, url.Values{}, map[string]string{})
+	t.Error(err)
+	t.Nil(result)
+}
+
+func (t *SpamProtectionFormExtensionTestSuite) TestValidate_CleanSubmit() {
+	data := t.submit(5*time.Second, "", nil)
+
+	validationInfo, err := t.extension.Validate(nil, t.request, nil, data)
+	t.NoError(err)
+	t.True(validationInfo.IsValid())
+}
+
+func (t *SpamProtectionFormExtensionTestSuite) TestValidate_HoneypotFilled() {
+	data := t.submit(5*time.Second, "http://spam.example.com", nil)
+
+	validationInfo, err := t.extension.Validate(nil, t.request, nil, data)
+	t.NoError(err)
+	t.False(validationInfo.IsValid())
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.spamDetected",
+			DefaultLabel: "spam detected",
+		},
+	}, validationInfo.GetGeneralErrors())
+}
+
+func (t *SpamProtectionFormExtensionTestSuite) TestValidate_FillTime() {
+	testCases := []struct {
+		Name    string
+		Elapsed time.Duration
+		Valid   bool
+	}{
+		{
+			Name:    "immediately",
+			Elapsed: 0,
+		},
+		{
+			Name:    "before clock skew tolerance",
+			Elapsed: 1499 * time.Millisecond,
+		},
+		{
+			Name:    "within clock skew tolerance",
+			Elapsed: 1500 * time.Millisecond,
+			Valid:   true,
+		},
+		{
+			Name:    "rendered in future",
+			Elapsed: -time.Minute,
+		},
+		{
+			Name:    "within clock skew tolerance of maximum age",
+			Elapsed: time.Hour + 500*time.Millisecond,
+			Valid:   true,
+		},
+		{
+			Name:    "older than maximum age",
+			Elapsed: time.Hour + 501*time.Millisecond,
+		},
+	}
+
+	for _, testCase := range testCases {
+		data := t.submit(testCase.Elapsed, "", nil)
+
+		validationInfo, err := t.extension.Validate(nil, t.request, nil, data)
+		t.NoError(err, testCase.Name)
+		t.Equal(testCase.Valid, validationInfo.IsValid(), testCase.Name)
+	}
+}
+
+func (t *SpamProtectionFormExtensionTestSuite) TestValidate_TamperedTimestamp() {
+	testCases := []struct {
+		Name   string
+		Submit func(timestamp string, data *SpamProtectionData) string
+	}{
+		{
+			Name: "missing",
+			Submit: func(string, *SpamProtectionData) string {
+				return ""
+			},
+		},
+		{
+			Name: "without signature",
+			Submit: func(timestamp string, _ *SpamProtectionData) string {
+				return timestamp[:strings.LastIndex(timestamp, ".")]
+			},
+		},
+		{
+			Name: "changed time",
+			Submit: func(timestamp string, _ *SpamProtectionData) string {
+				return strings.Replace(timestamp, timestamp[:4], "1000", 1)
+			},
+		},
+		{
+			Name: "other honeypot field",
+			Submit: func(timestamp string, data *SpamProtectionData) string {
+				data.HoneypotFieldName = "field_other"
+				return timestamp
+			},
+		},
+		{
+			Name: "invalid time",
+			Submit: func(_ string, data *SpamProtectionData) string {
+				return "wrong." + t.extension.sign(data.HoneypotFieldName+".wrong")
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		data := t.submit(time.Minute, "", testCase.Submit)
+
+		validationInfo, err := t.extension.Validate(nil, t.request, nil, data)
+		t.NoError(err, testCase.Name)
+		t.False(validationInfo.IsValid(), testCase.Name)
+	}
+}
+
+func (t *SpamProtectionFormExtensionTestSuite) TestValidate_WrongData() {
+	validationInfo, err := t.extension.Validate(nil, t.request, nil, map[string]string{})
+	t.Error(err)
+	t.Nil(validationInfo)
+}
+
+func (t *SpamProtectionFormExtensionTestSuite) TestWithOptions() {
+	extension := t.extension.WithOptions(SpamProtectionOptions{
+		MinFillTime: 10 * time.Second,
+	})
+	t.Equal(10*time.Second, extension.minFillTime)
+	t.Equal(2*time.Second, t.extension.minFillTime)
+
+	extension = t.extension.WithOptions(SpamProtectionOptions{})
+	t.Equal(2*time.Second, extension.minFillTime)
+	t.True(extension != t.extension)
+}
+
+// submit renders form data, and submits it after elapsed time with provided honeypot value and timestamp
+func (t *SpamProtectionFormExtensionTestSuite) submit(elapsed time.Duration, honeypot string, timestamp func(timestamp string, data *SpamProtectionData) string) *SpamProtectionData {
+	formData, err := t.extension.GetFormData(nil, t.request)
+	t.NoError(err)
+
+	data := formData.(*SpamProtectionData)
+	submittedTimestamp := data.Timestamp
+	if timestamp != nil {
+		submittedTimestamp = timestamp(submittedTimestamp, data)
+	}
+
+	t.now = t.now.Add(elapsed)
+
+	result, err := t.extension.Decode(nil, t.request, url.Values{
+		data.HoneypotFieldName:  []string{honeypot},
+		data.TimestampFieldName: []string{submittedTimestamp},
+	}, data)
+	t.NoError(err)
+
+	return result.(*SpamProtectionData)
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"strings"
+)
+
+type (
+	// FieldNotation as style of field names used in field errors of validation info
+	FieldNotation string
+)
+
+const (
+	// FieldNotationDot as notation where nested struct fields are separated by dots, while slice indices and map keys
+	// are placed inside brackets (like "address.street" or "items[0].sku"). It's used by default.
+	FieldNotationDot FieldNotation = "dot"
+	// FieldNotationBracket as notation where all nested fields, slice indices and map keys are placed inside brackets
+	// (like "address[street]" or "items[0][sku]")
+	FieldNotationBracket FieldNotation = "bracket"
+)
+
+// FormatFieldName as method for converting field name from dot notation into notation of its own.
+// Content of brackets is kept as it is, so map keys with dots (like "attributes[color.dark]") are not changed.
+func (n FieldNotation) FormatFieldName(fieldName string) string {
+	if n != FieldNotationBracket {
+		return fieldName
+	}
+
+	var result strings.Builder
+	depth := 0
+	open := false
+
+	for _, r := range fieldName {
+		switch {
+		case r == '[':
+			if open {
+				result.WriteRune(']')
+				open = false
+			}
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+		case r == '.' && depth == 0:
+			if open {
+				result.WriteRune(']')
+			}
+			result.WriteRune('[')
+			open = true
+			continue
+		}
+
+		result.WriteRune(r)
+	}
+
+	if open {
+		result.WriteRune(']')
+	}
+
+	return result.String()
+}
+
+// FormatValidationInfo as method for converting names of all field errors and field warnings from dot notation into
+// notation of its own. Order of field errors and warnings is preserved.
+func (n FieldNotation) FormatValidationInfo(validationInfo ValidationInfo) ValidationInfo {
+	if n != FieldNotationBracket || (!validationInfo.HasAnyFieldErrors() && !validationInfo.HasWarnings()) {
+		return validationInfo
+	}
+
+	formatted := ValidationInfo{}
+	formatted.AppendGeneralErrors(validationInfo.GetGeneralErrors())
+	formatted.AppendGeneralErrors(validationInfo.GetGeneralWarnings())
+	for _, entry := range validationInfo.FieldErrorsSorted() {
+		for _, err := range entry.Errors {
+			formatted.AddFieldErrorWithParams(n.FormatFieldName(entry.FieldName), err.MessageKey, err.DefaultLabel, err.Parameters)
+		}
+	}
+	for _, entry := range validationInfo.FieldWarningsSorted() {
+		for _, warning := range entry.Errors {
+			formatted.AddFieldWarningWithParams(n.FormatFieldName(entry.FieldName), warning.MessageKey, warning.DefaultLabel, warning.Parameters)
+		}
+	}
+
+	return formatted
+}
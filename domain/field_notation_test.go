@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	FieldNotationTestSuite struct {
+		suite.Suite
+	}
+)
+
+func TestFieldNotationTestSuite(t *testing.T) {
+	suite.Run(t, &FieldNotationTestSuite{})
+}
+
+func (t *FieldNotationTestSuite) TestFormatFieldName() {
+	testCases := []struct {
+		FieldName string
+		Dot       string
+		Bracket   string
+	}{
+		{
+			FieldName: "email",
+			Dot:       "email",
+			Bracket:   "email",
+		},
+		{
+			FieldName: "address.street",
+			Dot:       "address.street",
+			Bracket:   "address[street]",
+		},
+		{
+			FieldName: "items[0].sku",
+			Dot:       "items[0].sku",
+			Bracket:   "items[0][sku]",
+		},
+		{
+			FieldName: "login.addresses[home].street",
+			Dot:       "login.addresses[home].street",
+			Bracket:   "login[addresses][home][street]",
+		},
+		{
+			FieldName: "attributes[color.dark]",
+			Dot:       "attributes[color.dark]",
+			Bracket:   "attributes[color.dark]",
+		},
+		{
+			FieldName: "matrix[1][2]",
+			Dot:       "matrix[1][2]",
+			Bracket:   "matrix[1][2]",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Equal(testCase.Dot, FieldNotationDot.FormatFieldName(testCase.FieldName), testCase.FieldName)
+		t.Equal(testCase.Bracket, FieldNotationBracket.FormatFieldName(testCase.FieldName), testCase.FieldName)
+		t.Equal(testCase.Dot, FieldNotation("").FormatFieldName(testCase.FieldName), testCase.FieldName)
+	}
+}
+
+func (t *FieldNotationTestSuite) TestFormatValidationInfo() {
+	validationInfo := ValidationInfo{}
+	validationInfo.AddGeneralError("formError.general", "general error")
+	validationInfo.AddFieldError("items[0].sku", "formError.items.sku.required", "Sku required")
+	validationInfo.AddFieldError("address.street", "formError.address.street.required", "Street required")
+	validationInfo.AddFieldError("email", "formError.email.required", "Email required")
+
+	t.Equal(validationInfo, FieldNotationDot.FormatValidationInfo(validationInfo))
+
+	formatted := FieldNotationBracket.FormatValidationInfo(validationInfo)
+	t.Equal(validationInfo.GetGeneralErrors(), formatted.GetGeneralErrors())
+	t.Equal([]FieldErrorsEntry{
+		{
+			FieldName: "items[0][sku]",
+			Errors: []Error{
+				{
+					MessageKey:   "formError.items.sku.required",
+					DefaultLabel: "Sku required",
+				},
+			},
+		},
+		{
+			FieldName: "address[street]",
+			Errors: []Error{
+				{
+					MessageKey:   "formError.address.street.required",
+					DefaultLabel: "Street required",
+				},
+			},
+		},
+		{
+			FieldName: "email",
+			Errors: []Error{
+				{
+					MessageKey:   "formError.email.required",
+					DefaultLabel: "Email required",
+				},
+			},
+		},
+	}, formatted.FieldErrorsSorted())
+}
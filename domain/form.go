@@ -1,6 +1,14 @@
 package domain
 
-import "fmt"
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+)
 
 type (
 	// Form as struct for storing form processing results
@@ -15,10 +23,63 @@ type (
 		submitted bool
 		// validationRules contains map with validation rules for all validatable fields
 		validationRules map[string][]ValidationRule
+		// originalValues contains raw submitted values, exactly as they are sent before decoding
+		originalValues url.Values
+		// validatedSteps contains form steps which are validated during submission of multi-step form
+		validatedSteps []string
+		// submissionFieldName contains name of hidden field which should contain submission ID
+		submissionFieldName string
+		// submissionID contains one-time submission ID which protects form against double submission
+		submissionID string
+		// duplicateSubmission flag if form is submitted with already consumed submission ID
+		duplicateSubmission bool
+		// attachments contains custom values attached by form services or controllers, which are not part of JSON representation
+		attachments *Attachments
+		// choices contains options of enumerated fields, provided by choice providers bound to form handler
+		choices map[string][]Choice
+	}
+
+	// formEncodeAble defines stable JSON representation of Form
+	formEncodeAble struct {
+		Submitted     bool               `json:"submitted"`
+		Valid         bool               `json:"valid"`
+		Data          interface{}        `json:"data"`
+		GeneralErrors []Error            `json:"generalErrors"`
+		FieldErrors   FieldErrorsEntries `json:"fieldErrors"`
+		// warnings are omitted if there are none
+		GeneralWarnings []Error            `json:"generalWarnings,omitempty"`
+		FieldWarnings   FieldErrorsEntries `json:"fieldWarnings,omitempty"`
 	}
 
 	// FormError is used as wrapper for storing form error messages
 	FormError string
+
+	// DecodeError is used by form data decoders to report submitted content which can't be decoded.
+	// Instead of failing, form handler attaches its validation info to the form.
+	DecodeError struct {
+		ValidationInfo ValidationInfo
+	}
+
+	// WrappedFormError is FormError which keeps its original cause, so it can be checked by using errors.Is and errors.As
+	WrappedFormError struct {
+		FormError
+		cause error
+	}
+
+	// RecoverableError is interface for errors returned by form data providers, prefill providers, decoders and validators,
+	// which shouldn't abort form handling (like "not found" errors). Instead of failing, form handler presents them
+	// as general errors in validation info of the form, with message key defined by error and error message as default label.
+	RecoverableError interface {
+		error
+		// MessageKey as method for defining message key of general error which presents failure
+		MessageKey() string
+	}
+
+	// recoverableError as simple implementation of RecoverableError which wraps its cause
+	recoverableError struct {
+		messageKey string
+		cause      error
+	}
 )
 
 // NewForm returns new instance of Form struct
@@ -69,6 +130,26 @@ func (f Form) GetErrorsForField(name string) []Error {
 	return f.ValidationInfo.GetErrorsForField(name)
 }
 
+// HasWarnings method which defines if there is any general or field warning, which doesn't make form invalid
+func (f Form) HasWarnings() bool {
+	return f.ValidationInfo.HasWarnings()
+}
+
+// HasWarningForField method which defines if there is any warning for specific field
+func (f Form) HasWarningForField(name string) bool {
+	return f.ValidationInfo.HasWarningsForField(name)
+}
+
+// GetGeneralWarnings method which returns list of all general warnings
+func (f Form) GetGeneralWarnings() []Error {
+	return f.ValidationInfo.GetGeneralWarnings()
+}
+
+// GetWarningsForField method which returns list of all warnings for specific field
+func (f Form) GetWarningsForField(name string) []Error {
+	return f.ValidationInfo.GetWarningsForField(name)
+}
+
 // GetValidationRulesForField adds option to extract validation rules for desired field in templates
 func (f Form) GetValidationRulesForField(name string) []ValidationRule {
 	return f.validationRules[name]
@@ -79,6 +160,178 @@ func (f Form) GetValidationRules() map[string][]ValidationRule {
 	return f.validationRules
 }
 
+// OriginalValues returns raw submitted values, so invalid input can be presented exactly as it's sent.
+// It returns empty values for unsubmitted form.
+func (f Form) OriginalValues() url.Values {
+	if f.originalValues == nil {
+		return url.Values{}
+	}
+
+	return f.originalValues
+}
+
+// GetOriginalValue returns first raw submitted value for specific field. It returns empty string if there is no such value.
+func (f Form) GetOriginalValue(name string) string {
+	return f.originalValues.Get(name)
+}
+
+// SetOriginalValues sets raw submitted values, before they are decoded into form data
+func (f *Form) SetOriginalValues(values url.Values) {
+	f.originalValues = values
+}
+
+// ValidatedSteps returns form steps which are validated during submission of multi-step form, so templates can
+// present progress of the wizard. It returns nil if form is not submitted, or if it's validated without active steps.
+func (f Form) ValidatedSteps() []string {
+	return f.validatedSteps
+}
+
+// IsStepValidated defines if form step is validated during submission of multi-step form
+func (f Form) IsStepValidated(step string) bool {
+	for _, validatedStep := range f.validatedSteps {
+		if validatedStep == step {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SetValidatedSteps sets form steps which are validated during submission of multi-step form
+func (f *Form) SetValidatedSteps(steps []string) {
+	f.validatedSteps = steps
+}
+
+// SubmissionID returns one-time submission ID, which should be rendered as hidden field, so form handler can detect
+// double submission. It's empty if form handler doesn't use submission guard.
+func (f Form) SubmissionID() string {
+	return f.submissionID
+}
+
+// SubmissionFieldName returns name of hidden field which should contain submission ID
+func (f Form) SubmissionFieldName() string {
+	return f.submissionFieldName
+}
+
+// SetSubmissionID sets one-time submission ID and name of hidden field which should contain it
+func (f *Form) SetSubmissionID(fieldName string, submissionID string) {
+	f.submissionFieldName = fieldName
+	f.submissionID = submissionID
+}
+
+// IsDuplicateSubmission defines if form is submitted with submission ID which is already consumed. Such form
+// is neither decoded nor validated, so it doesn't contain form data.
+func (f Form) IsDuplicateSubmission() bool {
+	return f.duplicateSubmission
+}
+
+// SetDuplicateSubmission sets flag if form is submitted with submission ID which is already consumed
+func (f *Form) SetDuplicateSubmission(duplicate bool) {
+	f.duplicateSubmission = duplicate
+}
+
+// SetAttachment attaches custom value to the form under key, by replacing value which is already attached under same key.
+// Attached values are not part of form's JSON representation.
+func (f *Form) SetAttachment(key string, value interface{}) {
+	if f.attachments == nil {
+		f.attachments = NewAttachments()
+	}
+
+	f.attachments.Set(key, value)
+}
+
+// Attachment returns custom value attached to the form under key, and flag if there is such value at all
+func (f Form) Attachment(key string) (interface{}, bool) {
+	return f.attachments.Get(key)
+}
+
+// Attachments returns all custom values attached to the form. It returns nil if there are no attachments.
+func (f Form) Attachments() *Attachments {
+	return f.attachments
+}
+
+// SetAttachments sets custom values attached to the form, like ones collected by form handler from form services
+func (f *Form) SetAttachments(attachments *Attachments) {
+	f.attachments = attachments
+}
+
+// ChoicesFor returns options of enumerated field, which are provided by choice provider bound to the field.
+// It returns nil if there is no choice provider for the field.
+func (f Form) ChoicesFor(name string) []Choice {
+	return f.choices[name]
+}
+
+// SetChoices sets options of enumerated field, like ones provided by choice provider bound to form handler
+func (f *Form) SetChoices(name string, choices []Choice) {
+	if f.choices == nil {
+		f.choices = map[string][]Choice{}
+	}
+
+	f.choices[name] = choices
+}
+
+// UpdateData replaces form data with data changed programmatically after form handling, together with validation info
+// returned for it by FormHandler.ValidateData. Existing validation info is replaced, so errors which are not related
+// to form data (like decoding errors or errors of form extensions) are kept only if they are merged into provided one,
+// by using ValidationInfo.Merge. Nil validation info is used as empty one.
+func (f *Form) UpdateData(data interface{}, validationInfo *ValidationInfo) {
+	f.Data = data
+	f.ValidationInfo = ValidationInfo{}
+	if validationInfo != nil {
+		f.ValidationInfo = *validationInfo
+	}
+}
+
+// DataAs copies form data into target, which must be non nil pointer. Form data can be stored either as value
+// or as pointer to value of target's type, and target can also be pointer to pointer. It returns error if form data
+// is nil or if its type doesn't match target's type, instead of panicking like type assertion does.
+func (f Form) DataAs(target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Ptr || targetValue.IsNil() {
+		return NewFormErrorf("target must be non nil pointer, %T given", target)
+	}
+
+	targetValue = targetValue.Elem()
+	targetType := targetValue.Type()
+
+	dataValue := reflect.ValueOf(f.Data)
+	if !dataValue.IsValid() || (dataValue.Kind() == reflect.Ptr && dataValue.IsNil()) {
+		return NewFormErrorf("form data is nil, expected %s", targetType)
+	}
+
+	switch {
+	case dataValue.Type().AssignableTo(targetType):
+		targetValue.Set(dataValue)
+	case dataValue.Kind() == reflect.Ptr && dataValue.Elem().Type().AssignableTo(targetType):
+		targetValue.Set(dataValue.Elem())
+	case targetType.Kind() == reflect.Ptr && dataValue.Type().AssignableTo(targetType.Elem()):
+		copied := reflect.New(targetType.Elem())
+		copied.Elem().Set(dataValue)
+		targetValue.Set(copied)
+	default:
+		return NewFormErrorf("form data of type %s can't be used as %s", dataValue.Type(), targetType)
+	}
+
+	return nil
+}
+
+// MarshalJSON - implements MarshalJson interface - so that form state can be used as response.
+// JSON representation contains "submitted", "valid", "data", "generalErrors" and "fieldErrors", together with
+// "generalWarnings" and "fieldWarnings" if there are any warnings. Form data struct fields are named by their "form" tags, the same way as they are named in field errors.
+func (f Form) MarshalJSON() ([]byte, error) {
+	validationInfo := f.ValidationInfo.toEncodeAble()
+
+	return json.Marshal(formEncodeAble{
+		Submitted:       f.submitted,
+		Valid:           validationInfo.Valid,
+		Data:            encodeFormData(reflect.ValueOf(f.Data)),
+		GeneralErrors:   validationInfo.GeneralErrors,
+		FieldErrors:     validationInfo.FieldErrors,
+		GeneralWarnings: validationInfo.GeneralWarnings,
+		FieldWarnings:   validationInfo.FieldWarnings,
+	})
+}
+
 // NewFormError returns new instance of error interface by defining string content of error
 func NewFormError(details string) FormError {
 	return FormError(details)
@@ -93,3 +346,171 @@ func NewFormErrorf(details string, args ...interface{}) FormError {
 func (e FormError) Error() string {
 	return fmt.Sprintf("FormError: %s", string(e))
 }
+
+// NewWrappedFormError returns new instance of WrappedFormError with same content as cause
+func NewWrappedFormError(cause error) *WrappedFormError {
+	return &WrappedFormError{
+		FormError: FormError(cause.Error()),
+		cause:     cause,
+	}
+}
+
+// Unwrap returns original cause of WrappedFormError
+func (e *WrappedFormError) Unwrap() error {
+	return e.cause
+}
+
+// Is reports WrappedFormError as equal to FormError with same content
+func (e *WrappedFormError) Is(target error) bool {
+	formError, ok := target.(FormError)
+	return ok && formError == e.FormError
+}
+
+// As stores content of WrappedFormError into target, if it's pointer to FormError
+func (e *WrappedFormError) As(target interface{}) bool {
+	formError, ok := target.(*FormError)
+	if ok {
+		*formError = e.FormError
+	}
+
+	return ok
+}
+
+// NewRecoverableError returns new instance of RecoverableError, which wraps cause and presents it with message key
+func NewRecoverableError(messageKey string, cause error) RecoverableError {
+	return &recoverableError{
+		messageKey: messageKey,
+		cause:      cause,
+	}
+}
+
+// Error returns error message of wrapped cause
+func (e *recoverableError) Error() string {
+	return e.cause.Error()
+}
+
+// MessageKey returns message key of general error which presents failure
+func (e *recoverableError) MessageKey() string {
+	return e.messageKey
+}
+
+// Unwrap returns original cause of recoverableError
+func (e *recoverableError) Unwrap() error {
+	return e.cause
+}
+
+// NewDecodeError returns new instance of DecodeError with validation info which describes decoding failures
+func NewDecodeError(validationInfo ValidationInfo) *DecodeError {
+	return &DecodeError{
+		ValidationInfo: validationInfo,
+	}
+}
+
+// Error represents implementation for required method so DecodeError can fulfil error interface
+func (e *DecodeError) Error() string {
+	messages := make([]string, 0, len(e.ValidationInfo.GetGeneralErrors()))
+	for _, err := range e.ValidationInfo.GetGeneralErrors() {
+		messages = append(messages, err.DefaultLabel)
+	}
+
+	fieldErrors := e.ValidationInfo.GetErrorsForAllFields()
+	fieldNames := make([]string, 0, len(fieldErrors))
+	for fieldName := range fieldErrors {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	for _, fieldName := range fieldNames {
+		for _, err := range fieldErrors[fieldName] {
+			messages = append(messages, fieldName+": "+err.DefaultLabel)
+		}
+	}
+
+	return fmt.Sprintf("DecodeError: %s", strings.Join(messages, ", "))
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// encodeFormData transforms form data into value for JSON representation, where struct fields are named by their "form" tags
+func encodeFormData(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+
+	if value.Type().Implements(jsonMarshalerType) || value.Type().Implements(textMarshalerType) {
+		return value.Interface()
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return encodeFormData(value.Elem())
+	case reflect.Struct:
+		result := map[string]interface{}{}
+		encodeFormDataStruct(result, value)
+		return result
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return []interface{}{}
+		}
+		result := make([]interface{}, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			result[i] = encodeFormData(value.Index(i))
+		}
+		return result
+	case reflect.Map:
+		result := make(map[string]interface{}, value.Len())
+		for _, key := range value.MapKeys() {
+			result[fmt.Sprint(key.Interface())] = encodeFormData(value.MapIndex(key))
+		}
+		return result
+	}
+
+	return value.Interface()
+}
+
+// encodeFormDataStruct stores all exported struct fields into map, by using their "form" tags as keys. Embedded structs are flattened.
+func encodeFormDataStruct(result map[string]interface{}, value reflect.Value) {
+	typeOf := value.Type()
+
+	for i := 0; i < typeOf.NumField(); i++ {
+		field := typeOf.Field(i)
+		if field.PkgPath != "" && !(field.Anonymous && field.Type.Kind() == reflect.Struct) {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("form"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if field.Anonymous && name == "" {
+			if fieldValue.Kind() == reflect.Ptr {
+				if fieldValue.IsNil() {
+					continue
+				}
+				fieldValue = fieldValue.Elem()
+			}
+			if fieldValue.Kind() == reflect.Struct {
+				encodeFormDataStruct(result, fieldValue)
+				continue
+			}
+		}
+
+		if field.PkgPath != "" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		result[name] = encodeFormData(fieldValue)
+	}
+}
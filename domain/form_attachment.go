@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+type (
+	// Attachments as collection of custom values attached to form by form services (like shipping cost preview,
+	// or suggested address correction computed during validation), so controllers can use them without computing
+	// them again. It's safe to use it from multiple goroutines.
+	Attachments struct {
+		mutex  sync.RWMutex
+		values map[string]interface{}
+	}
+
+	// attachmentsContextKey as type of context key which contains attachments of form being handled
+	attachmentsContextKey struct{}
+)
+
+// NewAttachments returns new empty instance of Attachments
+func NewAttachments() *Attachments {
+	return &Attachments{
+		values: map[string]interface{}{},
+	}
+}
+
+// Set stores value under key, by replacing value which is already stored under same key
+func (a *Attachments) Set(key string, value interface{}) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if a.values == nil {
+		a.values = map[string]interface{}{}
+	}
+	a.values[key] = value
+}
+
+// Get returns value stored under key, and flag if there is such value at all
+func (a *Attachments) Get(key string) (interface{}, bool) {
+	if a == nil {
+		return nil, false
+	}
+
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	value, ok := a.values[key]
+
+	return value, ok
+}
+
+// Keys returns sorted keys of all stored values
+func (a *Attachments) Keys() []string {
+	if a == nil {
+		return nil
+	}
+
+	a.mutex.RLock()
+	defer a.mutex.RUnlock()
+
+	keys := make([]string, 0, len(a.values))
+	for key := range a.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// ContextWithAttachments returns context with attachments of form being handled. Form handler passes such context
+// to form data providers, prefill providers, decoders, validators, form extensions and post processors,
+// so they can attach values to the form by using SetAttachment.
+func ContextWithAttachments(ctx context.Context, attachments *Attachments) context.Context {
+	return context.WithValue(ctx, attachmentsContextKey{}, attachments)
+}
+
+// AttachmentsFromContext returns attachments of form being handled from context, and flag if they are defined at all
+func AttachmentsFromContext(ctx context.Context) (*Attachments, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+
+	attachments, ok := ctx.Value(attachmentsContextKey{}).(*Attachments)
+
+	return attachments, ok && attachments != nil
+}
+
+// SetAttachment stores value under key into attachments of form being handled. It returns false if context
+// doesn't contain attachments, like when form service is used outside of form handler.
+func SetAttachment(ctx context.Context, key string, value interface{}) bool {
+	attachments, ok := AttachmentsFromContext(ctx)
+	if !ok {
+		return false
+	}
+
+	attachments.Set(key, value)
+
+	return true
+}
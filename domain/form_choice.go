@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// Choice as single option of enumerated form field (like select field or group of radio buttons)
+	Choice struct {
+		// Value submitted value of the option
+		Value string
+		// Label presented label of the option
+		Label string
+		// Disabled flag if option is presented, but it can't be chosen
+		Disabled bool
+	}
+
+	// ChoiceProvider as interface for providing options of enumerated form field. Same options are used for rendering
+	// the field and for validating submitted values, so they can't drift apart. Options can depend on request
+	// (like country list translated for locale of current request).
+	ChoiceProvider interface {
+		// Options as method for providing options of the field
+		Options(ctx context.Context, req *web.Request) []Choice
+	}
+)
+
+// ChoiceValues returns values of all options which can be chosen, without disabled ones
+func ChoiceValues(choices []Choice) []string {
+	values := make([]string, 0, len(choices))
+	for _, choice := range choices {
+		if !choice.Disabled {
+			values = append(values, choice.Value)
+		}
+	}
+
+	return values
+}
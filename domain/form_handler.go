@@ -8,7 +8,11 @@ import (
 )
 
 type (
-	// FormHandler is interface for defining main form processor which provider instance of Form domain
+	// FormHandler is interface for defining main form processor which provider instance of Form domain.
+	// Submitted content which can't be decoded (DecodeError) and errors of form data providers, prefill providers,
+	// decoders and validators which implement RecoverableError don't abort form handling. Instead, form is returned
+	// with best-effort form data, and failures are presented in its validation info. All other errors are returned
+	// without form, wrapped into WrappedFormError, so original cause can be checked with errors.Is and errors.As.
 	FormHandler interface {
 		// HandleUnsubmittedForm as method for returning Form instance which is not submitted
 		HandleUnsubmittedForm(ctx context.Context, req *web.Request) (*Form, error)
@@ -18,6 +22,10 @@ type (
 		HandleSubmittedGETForm(ctx context.Context, req *web.Request) (*Form, error)
 		// HandleForm as method for returning Form instance with state depending on fact if there was form submission or not, via POST request
 		HandleForm(ctx context.Context, req *web.Request) (*Form, error)
+		// ValidateData as method for validating form data which is changed programmatically after form handling
+		ValidateData(ctx context.Context, req *web.Request, data interface{}) (*ValidationInfo, error)
+		// Schema as method for returning JSON Schema document which describes form data and its validation rules
+		Schema(ctx context.Context) ([]byte, error)
 	}
 
 	// FormExtension is helper interface for form extensions used for binding with dingo injector
@@ -44,6 +52,26 @@ type (
 		Decode(ctx context.Context, req *web.Request, values url.Values, formData interface{}) (interface{}, error)
 	}
 
+	// CustomTypeDecoder is interface for defining decoding of custom types (like money or identifiers) from submitted values.
+	// All instances bound via dingo injector are used by default form data decoder.
+	CustomTypeDecoder interface {
+		// Type as method for defining instance of type which is decoded by custom type decoder
+		Type() interface{}
+		// Decode as method for transforming submitted values into instance of custom type
+		Decode(values []string) (interface{}, error)
+	}
+
+	// FieldModifier is interface for defining sanitization of submitted string values (like stripping HTML).
+	// Default form data decoder applies modifiers to string fields and slices of strings, which contain modifier name
+	// in "mod" tag (like `mod:"striphtml,nfc"`), before form data is validated.
+	// All instances bound via dingo injector are used by default form data decoder.
+	FieldModifier interface {
+		// Name as method for defining modifier name used in fields' "mod" tags
+		Name() string
+		// Modify as method for transforming submitted string value
+		Modify(value string) string
+	}
+
 	// DefaultFormDataDecoder is interface for defining default form data decoder
 	// used in case when there is no custom form data decoder defined
 	DefaultFormDataDecoder interface {
@@ -69,6 +97,34 @@ type (
 		FormDataValidator
 	}
 
+	// PrefillProvider is interface for defining all form services which prefill form data before submitted values are decoded over it
+	PrefillProvider interface {
+		// Prefill as method for providing prefilled values for form data. It returns partial form data of same type,
+		// or map[string]interface{} with values keyed by form field names (with nested maps for nested structs).
+		Prefill(ctx context.Context, req *web.Request, formData interface{}) (interface{}, error)
+	}
+
+	// PostProcessor is interface for defining all form services which process form data after it's decoded and validated
+	PostProcessor interface {
+		// Process as method for modifying form data and validation info, before form is returned.
+		// Form data is always passed as pointer, so it can be modified. Returned error aborts form handling.
+		Process(ctx context.Context, req *web.Request, formData interface{}, validationInfo *ValidationInfo) error
+	}
+
+	// FormExtensionFinalizer is interface for defining form extensions which update their form extension data only
+	// after form is successfully submitted, which means that form data is valid, and it's validated with all validation rules
+	FormExtensionFinalizer interface {
+		// Finalize as method for updating form extension data of successfully submitted form
+		Finalize(ctx context.Context, req *web.Request, formExtensionData interface{}) (interface{}, error)
+	}
+
+	// SubmitDetector is interface for defining if form is submitted in current request, so form handler can decide
+	// if submitted values should be decoded and validated, or if only form data with prefilled values is presented
+	SubmitDetector interface {
+		// WasSubmitted as method for checking if form is submitted in current request
+		WasSubmitted(ctx context.Context, req *web.Request) bool
+	}
+
 	// FormDataEncoder is interface for defining all form services which encode (previously decoded) formdata into urlValues
 	FormDataEncoder interface {
 		// Encode as method for transforming http request body into form data
@@ -80,4 +136,18 @@ type (
 	DefaultFormDataEncoder interface {
 		FormDataEncoder
 	}
+
+	// FormStateStore is interface for persisting form data of submitted forms which are not valid, so long forms can be
+	// resumed in later request. Form state is stored per form identifier and session, so stores which don't keep it in
+	// session itself (like shared caches) should key it by form identifier and session ID. Stored state expires after TTL
+	// defined by the store.
+	FormStateStore interface {
+		// Save as method for storing encoded form state under form identifier, replacing previously stored one
+		Save(ctx context.Context, session *web.Session, formIdentifier string, state []byte) error
+		// Load as method for returning encoded form state stored under form identifier.
+		// It returns false if there is no stored form state, or if it's expired.
+		Load(ctx context.Context, session *web.Session, formIdentifier string) ([]byte, bool, error)
+		// Delete as method for removing form state stored under form identifier
+		Delete(ctx context.Context, session *web.Session, formIdentifier string) error
+	}
 )
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"context"
+	"mime/multipart"
+	"net/url"
+	"strings"
+)
+
+type (
+	// namespaceContextKey as type of context key which contains namespace of form handler
+	namespaceContextKey struct{}
+)
+
+// ContextWithNamespace returns context with namespace of form handler (like "login"), so form data decoder
+// decodes only values, files and JSON object which belong to that namespace.
+func ContextWithNamespace(ctx context.Context, namespace string) context.Context {
+	return context.WithValue(ctx, namespaceContextKey{}, namespace)
+}
+
+// NamespaceFromContext returns namespace of form handler from context, or empty string if it's not defined
+func NamespaceFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	namespace, _ := ctx.Value(namespaceContextKey{}).(string)
+
+	return namespace
+}
+
+// StripNamespace removes namespace prefix from field name, so "login.email" and "login[email]" are both
+// transformed into "email", and "login[items][0]" into "items[0]". It returns false if field doesn't belong to namespace.
+func StripNamespace(namespace string, name string) (string, bool) {
+	if strings.HasPrefix(name, namespace+".") {
+		return name[len(namespace)+1:], len(name) > len(namespace)+1
+	}
+
+	if strings.HasPrefix(name, namespace+"[") {
+		rest := name[len(namespace)+1:]
+		end := strings.Index(rest, "]")
+		if end <= 0 {
+			return "", false
+		}
+
+		return rest[:end] + rest[end+1:], true
+	}
+
+	return "", false
+}
+
+// StripNamespacedValues returns only values which belong to namespace, without namespace prefix.
+// All values are returned if namespace is empty.
+func StripNamespacedValues(namespace string, values url.Values) url.Values {
+	if namespace == "" {
+		return values
+	}
+
+	stripped := url.Values{}
+	for k, v := range values {
+		if name, ok := StripNamespace(namespace, k); ok {
+			stripped[name] = v
+		}
+	}
+
+	return stripped
+}
+
+// StripNamespacedFiles returns only uploaded files which belong to namespace, without namespace prefix.
+// All files are returned if namespace is empty.
+func StripNamespacedFiles(namespace string, files map[string][]*multipart.FileHeader) map[string][]*multipart.FileHeader {
+	if namespace == "" {
+		return files
+	}
+
+	stripped := map[string][]*multipart.FileHeader{}
+	for k, v := range files {
+		if name, ok := StripNamespace(namespace, k); ok {
+			stripped[name] = v
+		}
+	}
+
+	return stripped
+}
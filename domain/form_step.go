@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"context"
+)
+
+type (
+	// activeStepsContextKey as type of context key which contains active form steps
+	activeStepsContextKey struct{}
+)
+
+// ContextWithActiveSteps returns context with active form steps of multi-step form (like "shipping" or "payment").
+// When active steps are defined, ValidatorProvider keeps only errors of fields tagged with `formstep:"..."` of one of
+// active steps, and errors of fields without step, which are validated always. Nested structs inherit step of parent field,
+// unless they define their own. Context without active steps validates all fields.
+func ContextWithActiveSteps(ctx context.Context, steps ...string) context.Context {
+	return context.WithValue(ctx, activeStepsContextKey{}, append([]string{}, steps...))
+}
+
+// ActiveStepsFromContext returns active form steps from context, and flag if they are defined at all
+func ActiveStepsFromContext(ctx context.Context) ([]string, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+
+	steps, ok := ctx.Value(activeStepsContextKey{}).([]string)
+
+	return steps, ok
+}
@@ -1,7 +1,14 @@
 package domain
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -10,6 +17,40 @@ type (
 	FormTestSuite struct {
 		suite.Suite
 	}
+
+	formTestData struct {
+		Email     string                         `form:"email"`
+		Birthday  time.Time                      `form:"birthday"`
+		Address   formTestAddressData            `form:"address"`
+		Items     []formTestItemData             `form:"items"`
+		Addresses map[string]formTestAddressData `form:"addresses"`
+		Comment   *string                        `form:"comment"`
+		Ignored   string                         `form:"-"`
+		formTestEmbeddedData
+		unexported string
+	}
+
+	formTestAddressData struct {
+		Street string `form:"street"`
+		City   string
+	}
+
+	formTestItemData struct {
+		Sku      string `form:"sku"`
+		Quantity int    `form:"quantity"`
+	}
+
+	formTestEmbeddedData struct {
+		Newsletter bool `form:"newsletter"`
+	}
+
+	formTestContract struct {
+		Submitted     bool                   `json:"submitted"`
+		Valid         bool                   `json:"valid"`
+		Data          map[string]interface{} `json:"data"`
+		GeneralErrors []Error                `json:"generalErrors"`
+		FieldErrors   map[string][]Error     `json:"fieldErrors"`
+	}
 )
 
 func TestFormTestSuite(t *testing.T) {
@@ -103,3 +144,316 @@ func (t *FormTestSuite) TestErrors() {
 		},
 	}, form.GetErrorsForField("fieldName1"))
 }
+
+func (t *FormTestSuite) TestOriginalValues() {
+	form := NewForm(false, map[string][]ValidationRule{})
+	t.Equal(url.Values{}, form.OriginalValues())
+	t.Equal("", form.OriginalValues().Get("age"))
+	t.Equal("", form.GetOriginalValue("age"))
+
+	form.SetOriginalValues(url.Values{
+		"age": []string{"abc", "def"},
+	})
+	t.Equal(url.Values{
+		"age": []string{"abc", "def"},
+	}, form.OriginalValues())
+	t.Equal("abc", form.OriginalValues().Get("age"))
+	t.Equal("abc", form.GetOriginalValue("age"))
+	t.Equal("", form.GetOriginalValue("name"))
+}
+
+func (t *FormTestSuite) TestDataAs() {
+	form := NewForm(true, nil)
+	form.Data = formTestAddressData{
+		Street: "Main Street",
+	}
+
+	var value formTestAddressData
+	t.NoError(form.DataAs(&value))
+	t.Equal(formTestAddressData{
+		Street: "Main Street",
+	}, value)
+
+	var pointer *formTestAddressData
+	t.NoError(form.DataAs(&pointer))
+	t.Equal(&formTestAddressData{
+		Street: "Main Street",
+	}, pointer)
+
+	form.Data = &formTestAddressData{
+		City: "Berlin",
+	}
+
+	value = formTestAddressData{}
+	t.NoError(form.DataAs(&value))
+	t.Equal(formTestAddressData{
+		City: "Berlin",
+	}, value)
+
+	pointer = nil
+	t.NoError(form.DataAs(&pointer))
+	t.True(form.Data == pointer)
+}
+
+func (t *FormTestSuite) TestDataAs_Error() {
+	form := NewForm(true, nil)
+
+	var value formTestAddressData
+	t.EqualError(form.DataAs(&value), "FormError: form data is nil, expected domain.formTestAddressData")
+
+	form.Data = (*formTestAddressData)(nil)
+	t.EqualError(form.DataAs(&value), "FormError: form data is nil, expected domain.formTestAddressData")
+
+	form.Data = formTestItemData{}
+	t.EqualError(form.DataAs(&value), "FormError: form data of type domain.formTestItemData can't be used as domain.formTestAddressData")
+	t.EqualError(form.DataAs(value), "FormError: target must be non nil pointer, domain.formTestAddressData given")
+	t.EqualError(form.DataAs((*formTestAddressData)(nil)), "FormError: target must be non nil pointer, *domain.formTestAddressData given")
+	t.Equal(formTestAddressData{}, value)
+}
+
+func (t *FormTestSuite) TestChoices() {
+	form := NewForm(false, nil)
+	t.Nil(form.ChoicesFor("country"))
+
+	choices := []Choice{
+		{Value: "DE", Label: "Germany"},
+		{Value: "AT", Label: "Austria", Disabled: true},
+		{Value: "US", Label: "United States"},
+	}
+	form.SetChoices("country", choices)
+	t.Equal(choices, form.ChoicesFor("country"))
+	t.Nil(form.ChoicesFor("address.country"))
+
+	t.Equal([]string{"DE", "US"}, ChoiceValues(choices))
+	t.Equal([]string{}, ChoiceValues(nil))
+}
+
+func (t *FormTestSuite) TestUpdateData() {
+	form := NewForm(true, nil)
+	form.Data = formTestAddressData{
+		Street: "Main Street",
+	}
+	form.ValidationInfo.AddGeneralError("formError.csrf", "csrf token invalid")
+	t.False(form.IsValidAndSubmitted())
+
+	validationInfo := ValidationInfo{}
+	validationInfo.AddFieldError("city", "formError.city.required", "City required")
+	form.UpdateData(formTestAddressData{
+		Street: "Main Street 1",
+	}, &validationInfo)
+	t.Equal(formTestAddressData{
+		Street: "Main Street 1",
+	}, form.Data)
+	t.True(form.HasErrorForField("city"))
+	t.False(form.HasGeneralErrors())
+
+	form.UpdateData(formTestAddressData{
+		Street: "Main Street 1",
+		City:   "Berlin",
+	}, nil)
+	t.True(form.IsValidAndSubmitted())
+}
+
+func (t *FormTestSuite) TestMarshalJSON() {
+	form := NewForm(true, nil)
+	form.Data = formTestData{
+		Email:    "email@example.com",
+		Birthday: time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC),
+		Address: formTestAddressData{
+			Street: "Main Street",
+			City:   "Berlin",
+		},
+		Items: []formTestItemData{
+			{
+				Sku:      "A1",
+				Quantity: 2,
+			},
+		},
+		Addresses: map[string]formTestAddressData{
+			"home": {
+				Street: "Second Street",
+			},
+		},
+		Ignored: "ignored",
+		formTestEmbeddedData: formTestEmbeddedData{
+			Newsletter: true,
+		},
+		unexported: "unexported",
+	}
+	form.ValidationInfo.AddGeneralError("formError.general", "general error")
+	form.ValidationInfo.AddFieldErrorWithParams("items[0].quantity", "formError.items[0].quantity.max", "Quantity max", map[string]string{
+		"max": "1",
+	})
+
+	encoded, err := json.Marshal(form)
+	t.NoError(err)
+
+	contract := formTestContract{}
+	t.NoError(json.Unmarshal(encoded, &contract))
+	t.Equal(formTestContract{
+		Submitted: true,
+		Valid:     false,
+		Data: map[string]interface{}{
+			"email":    "email@example.com",
+			"birthday": "2000-01-02T00:00:00Z",
+			"address": map[string]interface{}{
+				"street": "Main Street",
+				"City":   "Berlin",
+			},
+			"items": []interface{}{
+				map[string]interface{}{
+					"sku":      "A1",
+					"quantity": float64(2),
+				},
+			},
+			"addresses": map[string]interface{}{
+				"home": map[string]interface{}{
+					"street": "Second Street",
+					"City":   "",
+				},
+			},
+			"comment":    nil,
+			"newsletter": true,
+		},
+		GeneralErrors: []Error{
+			{
+				MessageKey:   "formError.general",
+				DefaultLabel: "general error",
+			},
+		},
+		FieldErrors: map[string][]Error{
+			"items[0].quantity": {
+				{
+					MessageKey:   "formError.items[0].quantity.max",
+					DefaultLabel: "Quantity max",
+					Parameters: map[string]string{
+						"max": "1",
+					},
+				},
+			},
+		},
+	}, contract)
+}
+
+func (t *FormTestSuite) TestMarshalJSON_Empty() {
+	form := NewForm(false, nil)
+
+	encoded, err := json.Marshal(&form)
+	t.NoError(err)
+	t.JSONEq(`{"submitted": false, "valid": true, "data": null, "generalErrors": [], "fieldErrors": {}}`, string(encoded))
+}
+
+func (t *FormTestSuite) TestMarshalJSON_Warnings() {
+	form := NewForm(true, nil)
+	form.ValidationInfo.AddFieldWarning("email", "formError.email.domainTypo", "email domain looks like a typo")
+
+	t.True(form.IsValid())
+	t.True(form.HasWarnings())
+	t.True(form.HasWarningForField("email"))
+	t.Empty(form.GetGeneralWarnings())
+	t.Len(form.GetWarningsForField("email"), 1)
+
+	encoded, err := json.Marshal(&form)
+	t.NoError(err)
+	t.JSONEq(`{
+		"submitted": true,
+		"valid": true,
+		"data": null,
+		"generalErrors": [],
+		"fieldErrors": {},
+		"fieldWarnings": {"email": [{"messageKey": "formError.email.domainTypo", "defaultLabel": "email domain looks like a typo", "severity": "warning"}]}
+	}`, string(encoded))
+}
+
+func (t *FormTestSuite) TestAttachments() {
+	form := NewForm(true, nil)
+	t.Nil(form.Attachments())
+
+	_, ok := form.Attachment("suggestion")
+	t.False(ok)
+
+	form.SetAttachment("suggestion", "Main Street")
+	form.SetAttachment("shippingCost", 4.99)
+	form.SetAttachment("suggestion", "Second Street")
+
+	value, ok := form.Attachment("suggestion")
+	t.True(ok)
+	t.Equal("Second Street", value)
+	t.Equal([]string{"shippingCost", "suggestion"}, form.Attachments().Keys())
+
+	// copies of form share the same attachments
+	copied := form
+	copied.SetAttachment("copied", true)
+	_, ok = form.Attachment("copied")
+	t.True(ok)
+
+	encoded, err := json.Marshal(form)
+	t.NoError(err)
+	t.NotContains(string(encoded), "Second Street")
+}
+
+func (t *FormTestSuite) TestAttachmentsFromContext() {
+	t.False(SetAttachment(context.Background(), "suggestion", "Main Street"))
+
+	_, ok := AttachmentsFromContext(nil)
+	t.False(ok)
+
+	_, ok = AttachmentsFromContext(ContextWithAttachments(context.Background(), nil))
+	t.False(ok)
+
+	attachments := NewAttachments()
+	ctx := ContextWithAttachments(context.Background(), attachments)
+
+	result, ok := AttachmentsFromContext(ctx)
+	t.True(ok)
+	t.True(result == attachments)
+
+	t.True(SetAttachment(ctx, "suggestion", "Main Street"))
+	value, ok := attachments.Get("suggestion")
+	t.True(ok)
+	t.Equal("Main Street", value)
+}
+
+func (t *FormTestSuite) TestAttachments_Concurrent() {
+	attachments := &Attachments{}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			attachments.Set(fmt.Sprintf("key%02d", i), i)
+			attachments.Get("key00")
+		}(i)
+	}
+	wg.Wait()
+
+	t.Len(attachments.Keys(), 20)
+}
+
+func (t *FormTestSuite) TestWrappedFormError() {
+	cause := errors.New("error")
+	err := fmt.Errorf("handling: %w", NewWrappedFormError(cause))
+
+	t.EqualError(NewWrappedFormError(cause), "FormError: error")
+	t.True(errors.Is(err, cause))
+	t.True(errors.Is(err, FormError("error")))
+	t.False(errors.Is(err, FormError("other")))
+
+	var formError FormError
+	t.True(errors.As(err, &formError))
+	t.Equal(FormError("error"), formError)
+}
+
+func (t *FormTestSuite) TestRecoverableError() {
+	cause := errors.New("address not found")
+	err := NewRecoverableError("formError.address.notFound", cause)
+
+	t.EqualError(err, "address not found")
+	t.Equal("formError.address.notFound", err.MessageKey())
+	t.True(errors.Is(err, cause))
+
+	var recoverable RecoverableError
+	t.True(errors.As(fmt.Errorf("provider: %w", err), &recoverable))
+	t.Equal("formError.address.notFound", recoverable.MessageKey())
+}
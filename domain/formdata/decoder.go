@@ -1,33 +1,372 @@
 package formdata
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
 	"net/url"
 	"reflect"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unsafe"
 
 	"github.com/leebenson/conform"
 
 	"github.com/go-playground/form"
 
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
 	"flamingo.me/flamingo/v3/framework/web"
 	"flamingo.me/form/domain"
 )
 
 type (
 	// DefaultFormDataDecoderImpl represents implementation of default domain.FormDataDecoder.
-	DefaultFormDataDecoderImpl struct{}
+	DefaultFormDataDecoderImpl struct {
+		maxMemory       int64
+		maxFileSize     int64
+		maxBodySize     int64
+		maxFormKeys     int
+		maxArraySize    int
+		dateFormat      string
+		decimalComma    bool
+		lenientBooleans bool
+		trimNumbers     bool
+		localeSource    string
+		localeKey       string
+		localeFormats   map[string]localeFormat
+		decoder         *form.Decoder
+		modifiers       map[string]domain.FieldModifier
+		logger          flamingo.Logger
+		// checkedModifiers contains error (or nil) of checking names of field modifiers for each form data type
+		checkedModifiers sync.Map
+	}
+
+	// namePart as part of field name, with flags if it's placed inside brackets, or if it can't be parsed at all
+	namePart struct {
+		name    string
+		bracket bool
+		raw     bool
+	}
+
+	// sourceField as struct field whose value is taken from route params or headers of http request, instead of submitted values
+	sourceField struct {
+		// name of field in notation of go-playground form decoder (like "profile.id")
+		name string
+		// source of value, like "path" or "header"
+		source string
+		// key as name of route param or header which contains value
+		key string
+	}
+
+	// customTypeError wraps errors returned from custom type decoding functions,
+	// so they can be distinguished from other decoding errors and reported as field errors
+	customTypeError struct {
+		err error
+	}
+)
+
+const (
+	// absentTagEmpty as value of "absent" tag, which decodes field that is not submitted as empty slice or map, instead of nil
+	absentTagEmpty = "empty"
+	// formOptionOmitEmptyRows as option of "form" tag, which removes slice elements that contain only zero values
+	formOptionOmitEmptyRows = "omitempty-rows"
+	// sourceTag as name of tag which defines that field value is taken from http request, instead of submitted values
+	sourceTag = "source"
+	// sourcePath as value of "source" tag, which takes field value from route params of http request
+	sourcePath = "path"
+	// sourceHeader as value of "source" tag, which takes field value from headers of http request
+	sourceHeader = "header"
+	// DefaultMaxBodySize as maximum size of JSON http request body read outside of decoding (like for original values),
+	// which is the same as limit used by net/http for url encoded http request body
+	DefaultMaxBodySize = 10 << 20
+)
+
+var (
+	_ domain.DefaultFormDataDecoder = &DefaultFormDataDecoderImpl{}
+
+	fileHeaderType  = reflect.TypeOf((*multipart.FileHeader)(nil))
+	fileHeadersType = reflect.TypeOf([]*multipart.FileHeader{})
+
+	// ErrRequestTooLarge is returned when http request body is bigger than maximum body size
+	ErrRequestTooLarge = errors.New("request body is too large")
+	// ErrTooManyFields is returned when http request contains more submitted fields than maximum number of form keys
+	ErrTooManyFields = errors.New("too many fields are submitted")
+
+	// numberTypes contains all numeric types which are decoded by lenient parsing, when it's enabled
+	numberTypes = []interface{}{
+		int(0), int8(0), int16(0), int32(0), int64(0),
+		uint(0), uint8(0), uint16(0), uint32(0), uint64(0),
+		float32(0), float64(0),
+	}
 )
 
-var _ domain.DefaultFormDataDecoder = &DefaultFormDataDecoderImpl{}
+// Inject is method used to set all dependencies as local variables.
+// Decoder with all custom type decoders is created only once, since it caches structure of decoded types.
+func (p *DefaultFormDataDecoderImpl) Inject(cfg *struct {
+	MaxMemory       float64    `inject:"config:form.decoder.maxMemory"`
+	MaxFileSize     float64    `inject:"config:form.decoder.maxFileSize"`
+	MaxBodySize     float64    `inject:"config:form.decoder.maxBodySize"`
+	MaxFormKeys     float64    `inject:"config:form.decoder.maxFormKeys"`
+	MaxArraySize    float64    `inject:"config:form.decoder.maxArraySize"`
+	DateFormat      string     `inject:"config:form.validator.dateFormat"`
+	DecimalComma    bool       `inject:"config:form.decoder.lenient.decimalComma"`
+	LenientBooleans bool       `inject:"config:form.decoder.lenient.booleans"`
+	TrimNumbers     bool       `inject:"config:form.decoder.lenient.trimNumbers"`
+	LocaleSource    string     `inject:"config:form.decoder.locale.source"`
+	LocaleKey       string     `inject:"config:form.decoder.locale.key"`
+	LocaleFormats   config.Map `inject:"config:form.decoder.locale.formats"`
+}, customTypeDecoders []domain.CustomTypeDecoder, fieldModifiers []domain.FieldModifier, logger flamingo.Logger) {
+	p.maxMemory = int64(cfg.MaxMemory)
+	p.maxFileSize = int64(cfg.MaxFileSize)
+	p.maxBodySize = int64(cfg.MaxBodySize)
+	p.maxFormKeys = int(cfg.MaxFormKeys)
+	p.maxArraySize = int(cfg.MaxArraySize)
+	p.logger = logger
+	p.dateFormat = cfg.DateFormat
+	p.decimalComma = cfg.DecimalComma
+	p.lenientBooleans = cfg.LenientBooleans
+	p.trimNumbers = cfg.TrimNumbers
+	p.localeSource = cfg.LocaleSource
+	p.localeKey = cfg.LocaleKey
+
+	var invalidLocales []string
+	p.localeFormats, invalidLocales = parseLocaleFormats(cfg.LocaleFormats)
+	if len(invalidLocales) > 0 && logger != nil {
+		logger.WithField("FormDataDecoder", "locale").Warn(fmt.Sprintf("invalid formats of locales are ignored: %s", strings.Join(invalidLocales, ", ")))
+	}
+
+	p.decoder = p.newDecoder(customTypeDecoders)
+
+	p.modifiers = make(map[string]domain.FieldModifier, len(fieldModifiers))
+	for _, fieldModifier := range fieldModifiers {
+		p.modifiers[fieldModifier.Name()] = fieldModifier
+	}
+}
+
+// Error returns error message of wrapped custom type decoding error
+func (e *customTypeError) Error() string {
+	return e.err.Error()
+}
 
 // Decode performs default form data decoding, depending if passed form data is instance of map[string]string or any other interface.
-func (p *DefaultFormDataDecoderImpl) Decode(_ context.Context, _ *web.Request, values url.Values, formData interface{}) (interface{}, error) {
+// In case when http request body is sent as JSON, its content is used instead of passed url values.
+// In case when http request body is sent as multipart form, uploaded files are stored into form data fields
+// of type *multipart.FileHeader or []*multipart.FileHeader.
+// Requests which exceed configured limits (size of http request body, number of submitted keys, or slice index)
+// are not decoded, but presented as general error in domain.ValidationInfo.
+func (p *DefaultFormDataDecoderImpl) Decode(ctx context.Context, req *web.Request, values url.Values, formData interface{}) (interface{}, error) {
+	var files map[string][]*multipart.FileHeader
+
+	// in case of namespace, only JSON object, multipart values and files from namespace are decoded, without namespace prefix
+	namespace := domain.NamespaceFromContext(ctx)
+
+	mediaType := p.getMediaType(req)
+	// multipart http request body is limited by maximum memory and maximum file size instead
+	if mediaType != "multipart/form-data" && p.isBodyTooLarge(req) {
+		return nil, p.newLimitError("formError.requestTooLarge", ErrRequestTooLarge.Error(), nil)
+	}
+
+	switch mediaType {
+	case "application/json":
+		jsonValues, err := p.getJSONValues(req, formData, namespace)
+		if errors.Is(err, ErrRequestTooLarge) {
+			return nil, p.newLimitError("formError.requestTooLarge", err.Error(), nil)
+		}
+		if err != nil {
+			validationInfo := domain.ValidationInfo{}
+			validationInfo.AddGeneralError("formError.invalidJSON", err.Error())
+			return nil, domain.NewDecodeError(validationInfo)
+		}
+
+		// empty JSON body doesn't contain any submitted value, but fields taken from http request are still decoded
+		values = domain.StripNamespacedValues(namespace, jsonValues)
+		if values == nil {
+			values = url.Values{}
+		}
+	case "multipart/form-data":
+		multipartForm, err := p.getMultipartForm(req)
+		if err != nil {
+			validationInfo := domain.ValidationInfo{}
+			validationInfo.AddGeneralError("formError.invalidMultipart", err.Error())
+			return nil, domain.NewDecodeError(validationInfo)
+		}
+
+		values = p.mergeMultipartValues(values, domain.StripNamespacedValues(namespace, multipartForm.Value))
+		files = domain.StripNamespacedFiles(namespace, multipartForm.File)
+	}
+
+	if p.maxFormKeys > 0 && len(values)+len(files) > p.maxFormKeys {
+		return nil, p.newLimitError("formError.tooManyFields", ErrTooManyFields.Error(), map[string]string{
+			"max": strconv.Itoa(p.maxFormKeys),
+		})
+	}
+
 	if _, ok := formData.(map[string]string); ok {
 		return p.decodeStringMap(values), nil
 	}
 
-	return p.decodeUnknownInterface(values, formData)
+	values, err := p.applySourceValues(req, values, formData)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON values are machine generated, so they are never localized
+	localizedValidationInfo := domain.ValidationInfo{}
+	if mediaType != "application/json" {
+		values, localizedValidationInfo = p.applyLocalizedValues(req, values, formData)
+	}
+
+	result, err := p.decodeUnknownInterface(values, formData)
+	if err == nil && !localizedValidationInfo.IsValid() {
+		err = domain.NewDecodeError(localizedValidationInfo)
+	} else if decodeError, ok := err.(*domain.DecodeError); ok {
+		// localized values which can't be parsed are reported together with all other field errors of decoding
+		decodeError.ValidationInfo.Merge(localizedValidationInfo)
+	}
+
+	decodeError, isDecodeError := err.(*domain.DecodeError)
+	if (err != nil && !isDecodeError) || len(files) == 0 {
+		return result, err
+	}
+
+	result, err = p.decodeFiles(files, result)
+	if !isDecodeError {
+		return result, err
+	}
+
+	// field errors of invalid custom types are merged with field errors of too large files
+	if filesDecodeError, ok := err.(*domain.DecodeError); ok {
+		decodeError.ValidationInfo.Merge(filesDecodeError.ValidationInfo)
+	}
+
+	return result, decodeError
+}
+
+// applySourceValues replaces submitted values of fields tagged with `source:"path"` or `source:"header"` with values
+// of route params or headers of http request, so they can't be tampered with by posted content. Name of route param
+// or header is the same as form field name, unless it's defined in tag (like `source:"header:Accept-Language"`).
+// Submitted values of those fields are always ignored, also when http request doesn't provide any value for them.
+// Values are decoded like any other values, so values which can't be converted (like invalid integers) are reported as field errors.
+// Form data type with field tagged with unknown source is rejected, so misspelled tag never leaves field silently empty.
+func (p *DefaultFormDataDecoderImpl) applySourceValues(req *web.Request, values url.Values, formData interface{}) (url.Values, error) {
+	typeOf := reflect.TypeOf(formData)
+
+	metadata := p.getFormMetadata(typeOf)
+	if metadata.sourceError != nil {
+		return nil, metadata.sourceError
+	}
+
+	sourceFields := metadata.sourceFields
+	if len(sourceFields) == 0 {
+		return values, nil
+	}
+
+	// names are normalized first, so submitted values are ignored regardless of notation in which they are posted
+	values = p.normalizeValues(values, typeOf)
+	for _, field := range sourceFields {
+		delete(values, field.name)
+
+		if value, ok := p.getSourceValue(req, field); ok {
+			values[field.name] = []string{value}
+		}
+	}
+
+	return values, nil
+}
+
+// getSourceFields returns all fields of struct, including fields of nested and embedded structs, which are tagged with "source" tag
+func (p *DefaultFormDataDecoderImpl) getSourceFields(typeOf reflect.Type, namespace string, visited map[reflect.Type]bool) []sourceField {
+	typeOf = p.indirectType(typeOf)
+	if typeOf == nil || typeOf.Kind() != reflect.Struct || typeOf == reflect.TypeOf(time.Time{}) || visited[typeOf] {
+		return nil
+	}
+
+	// recursive types are visited only once per branch
+	visited[typeOf] = true
+	defer delete(visited, typeOf)
+
+	var fields []sourceField
+	for i := 0; i < typeOf.NumField(); i++ {
+		field := typeOf.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+
+		name := strings.Split(field.Tag.Get("form"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldNamespace := name
+		if field.Anonymous {
+			fieldNamespace = namespace
+		} else if namespace != "" {
+			fieldNamespace = namespace + "." + name
+		}
+
+		tag, ok := field.Tag.Lookup(sourceTag)
+		if !ok {
+			fields = append(fields, p.getSourceFields(field.Type, fieldNamespace, visited)...)
+			continue
+		}
+
+		source, key := tag, name
+		if index := strings.Index(tag, ":"); index >= 0 {
+			source, key = tag[:index], tag[index+1:]
+		}
+
+		fields = append(fields, sourceField{
+			name:   fieldNamespace,
+			source: strings.TrimSpace(source),
+			key:    strings.TrimSpace(key),
+		})
+	}
+
+	return fields
+}
+
+// validateSourceFields returns error for first source field with unknown source (like `source:"cookie"`)
+func (p *DefaultFormDataDecoderImpl) validateSourceFields(fields []sourceField) error {
+	for _, field := range fields {
+		if field.source != sourcePath && field.source != sourceHeader {
+			return domain.NewFormErrorf("field %s has unknown source %q, only %q and %q are supported", field.name, field.source, sourcePath, sourceHeader)
+		}
+	}
+
+	return nil
+}
+
+// getSourceValue returns value of route param or header of http request for source field
+func (p *DefaultFormDataDecoderImpl) getSourceValue(req *web.Request, field sourceField) (string, bool) {
+	if req == nil {
+		return "", false
+	}
+
+	switch field.source {
+	case sourcePath:
+		value, ok := req.Params[field.key]
+		return value, ok
+	case sourceHeader:
+		if values := req.Request().Header.Values(field.key); len(values) > 0 {
+			return values[0], true
+		}
+	}
+
+	return "", false
 }
 
 // decodeStringMap performs form data decoding by storing all POST values into simple instance of map[string]string.
@@ -46,34 +385,1263 @@ func (p *DefaultFormDataDecoderImpl) decodeStringMap(values url.Values) map[stri
 }
 
 // decodeUnknownInterface performs form data decoding by using decoder from go-playground form package.
-// It also performs string values' optimization byt using conform package.
+// It also performs string values' optimization byt using conform package, and applies field modifiers defined in "mod" tags.
+// Values which can't be decoded (like invalid numbers, or values rejected by custom type decoders) are reported
+// as field errors, while all other fields are still decoded.
 func (p *DefaultFormDataDecoderImpl) decodeUnknownInterface(values url.Values, formData interface{}) (interface{}, error) {
 	typeOf := reflect.TypeOf(formData)
 	if typeOf.Kind() == reflect.Ptr {
 		typeOf = typeOf.Elem()
 	}
 
+	// pointers to embedded structs are allocated during decoding, which is not possible for unexported types
+	if metadata := p.getFormMetadata(typeOf); metadata.hasUnexportedEmbed {
+		return nil, domain.NewFormErrorf("embedded field %s is pointer to unexported type, so it can't be allocated during decoding", metadata.unexportedEmbedPointer)
+	}
+
+	if err := p.checkModifiers(typeOf); err != nil {
+		return nil, err
+	}
+
 	zeroFormData := reflect.New(typeOf).Interface()
 
 	if values == nil {
 		values = url.Values{}
 	}
 
-	decoder := form.NewDecoder()
-	err := decoder.Decode(&zeroFormData, values)
+	// both dot and bracket notation are accepted, so all fields are decoded regardless of posted style
+	values = p.normalizeValues(values, typeOf)
+
+	// slices are allocated up to the highest submitted index, so absurd indices are rejected before decoding
+	if namespace, ok := p.findTooLargeIndex(values, typeOf); ok {
+		return nil, p.newLimitError("formError.indexTooLarge", fmt.Sprintf("index of field %s is too large", namespace), map[string]string{
+			"field": namespace,
+			"max":   strconv.Itoa(p.maxArraySize - 1),
+		})
+	}
+
+	decoder := p.decoder
+	if decoder == nil {
+		decoder = p.newDecoder(nil)
+	}
+
+	validationInfo, err := p.getDecodeValidationInfo(decoder.Decode(&zeroFormData, values), values)
 	if err != nil {
 		return nil, err
 	}
 
+	p.allocateSubmittedPointers(reflect.ValueOf(zeroFormData), "", values)
+
 	err = conform.Strings(zeroFormData)
 	if err != nil {
 		return nil, err
 	}
 
-	finalFormData := reflect.ValueOf(zeroFormData)
-	if finalFormData.Kind() == reflect.Ptr {
-		return finalFormData.Elem().Interface(), nil
+	err = p.conformUnexportedEmbeds(reflect.ValueOf(zeroFormData))
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.modifyValue(reflect.ValueOf(zeroFormData), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	validationInfo = p.removeEmptyRows(reflect.ValueOf(zeroFormData), "", validationInfo)
+	p.emptyAbsentValues(reflect.ValueOf(zeroFormData))
+
+	var result interface{} = zeroFormData
+	if finalFormData := reflect.ValueOf(zeroFormData); finalFormData.Kind() == reflect.Ptr {
+		result = finalFormData.Elem().Interface()
+	}
+
+	if !validationInfo.IsValid() {
+		return result, domain.NewDecodeError(validationInfo)
+	}
+
+	return result, nil
+}
+
+// findUnexportedEmbedPointer returns name of anonymous embedded field (like "CheckoutForm.addressFragment"), which is pointer
+// to unexported type, in form data type or any of its nested types. Such field can't be set via reflection, so decoding
+// any of its fields would panic. Embedded values of unexported types are not affected, since their fields are set directly.
+func (p *DefaultFormDataDecoderImpl) findUnexportedEmbedPointer(typeOf reflect.Type, visited map[reflect.Type]bool) (string, bool) {
+	typeOf = p.indirectType(typeOf)
+	switch typeOf.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return p.findUnexportedEmbedPointer(typeOf.Elem(), visited)
+	case reflect.Struct:
+	default:
+		return "", false
+	}
+
+	if visited[typeOf] {
+		return "", false
+	}
+	visited[typeOf] = true
+
+	for i := 0; i < typeOf.NumField(); i++ {
+		field := typeOf.Field(i)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		if strings.Split(field.Tag.Get("form"), ",")[0] == "-" {
+			continue
+		}
+
+		if field.Anonymous && field.PkgPath != "" && field.Type.Kind() == reflect.Ptr {
+			return typeOf.Name() + "." + field.Name, true
+		}
+
+		if name, ok := p.findUnexportedEmbedPointer(field.Type, visited); ok {
+			return name, true
+		}
+	}
+
+	return "", false
+}
+
+// modifyValue applies field modifiers to string value, or to all string values inside slices, arrays and maps.
+// Modifiers of struct fields are defined in their "mod" tags, and they are applied for nested structs as well.
+// Values of all other types are not changed.
+func (p *DefaultFormDataDecoderImpl) modifyValue(valueOf reflect.Value, modifierNames []string) error {
+	switch valueOf.Kind() {
+	case reflect.Ptr:
+		if !valueOf.IsNil() {
+			return p.modifyValue(valueOf.Elem(), modifierNames)
+		}
+	case reflect.String:
+		if len(modifierNames) == 0 || !valueOf.CanSet() {
+			return nil
+		}
+
+		value, err := p.modifyString(valueOf.String(), modifierNames)
+		if err != nil {
+			return err
+		}
+		valueOf.SetString(value)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < valueOf.Len(); i++ {
+			if err := p.modifyValue(valueOf.Index(i), modifierNames); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		if len(modifierNames) == 0 || valueOf.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+
+		for _, key := range valueOf.MapKeys() {
+			value, err := p.modifyString(valueOf.MapIndex(key).String(), modifierNames)
+			if err != nil {
+				return err
+			}
+			valueOf.SetMapIndex(key, reflect.ValueOf(value).Convert(valueOf.Type().Elem()))
+		}
+	case reflect.Struct:
+		for _, field := range p.getStructMetadata(valueOf.Type()).fields {
+			if field.field.PkgPath != "" && !field.field.Anonymous {
+				continue
+			}
+
+			if err := p.modifyValue(valueOf.Field(field.index), field.modifiers); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// conformUnexportedEmbeds applies conform modifiers to fields of unexported embedded structs (like addressFragment),
+// in form data and all its nested structs. conform.Strings skips such structs, since their values can't be used
+// as interface, while their fields are still promoted to parent struct and decoded as its own.
+func (p *DefaultFormDataDecoderImpl) conformUnexportedEmbeds(valueOf reflect.Value) error {
+	valueOf = reflect.Indirect(valueOf)
+
+	switch valueOf.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < valueOf.Len(); i++ {
+			if err := p.conformUnexportedEmbeds(valueOf.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		typeOf := valueOf.Type()
+		for i := 0; i < typeOf.NumField(); i++ {
+			field := typeOf.Field(i)
+			// other unexported fields are not decoded at all
+			if field.PkgPath != "" && !field.Anonymous {
+				continue
+			}
+
+			embedded := reflect.Indirect(valueOf.Field(i))
+			if field.PkgPath != "" && embedded.Kind() == reflect.Struct && embedded.CanAddr() {
+				// embedded struct is addressable, so pointer to it can be created without unexported field
+				pointer := reflect.NewAt(embedded.Type(), unsafe.Pointer(embedded.UnsafeAddr()))
+				if err := conform.Strings(pointer.Interface()); err != nil {
+					return err
+				}
+			}
+
+			if err := p.conformUnexportedEmbeds(valueOf.Field(i)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// allocateSubmittedPointers sets pointers to zero values into all nil pointer fields (like *string, *int, *bool or *time.Time),
+// which are submitted with empty value. This way nil pointer always means that field is not submitted at all,
+// while pointer to zero value means that field is submitted empty, regardless of decoding rules of its type.
+// Pointers to nested structs are allocated by decoder itself, once any of their fields is submitted.
+func (p *DefaultFormDataDecoderImpl) allocateSubmittedPointers(valueOf reflect.Value, namespace string, values url.Values) {
+	switch valueOf.Kind() {
+	case reflect.Ptr:
+		if !valueOf.IsNil() {
+			p.allocateSubmittedPointers(valueOf.Elem(), namespace, values)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < valueOf.Len(); i++ {
+			p.allocateSubmittedPointers(valueOf.Index(i), fmt.Sprintf("%s[%d]", namespace, i), values)
+		}
+	case reflect.Struct:
+		if valueOf.Type() == reflect.TypeOf(time.Time{}) {
+			return
+		}
+
+		for _, field := range p.getStructMetadata(valueOf.Type()).fields {
+			fieldValue := valueOf.Field(field.index)
+			if !fieldValue.CanSet() || field.ignored {
+				continue
+			}
+
+			fieldNamespace := p.getFieldNamespace(namespace, field)
+			if fieldValue.Kind() != reflect.Ptr || !fieldValue.IsNil() || !p.isSingleValueType(field.field.Type.Elem()) {
+				p.allocateSubmittedPointers(fieldValue, fieldNamespace, values)
+				continue
+			}
+
+			// non empty values which are not decoded are invalid, so they are already reported as field errors
+			if submitted, ok := values[fieldNamespace]; ok && len(submitted) > 0 && submitted[0] == "" {
+				fieldValue.Set(reflect.New(field.field.Type.Elem()))
+			}
+		}
+	}
+}
+
+// isSingleValueType checks if type is decoded from single submitted value, like strings, numbers, booleans and dates
+func (p *DefaultFormDataDecoderImpl) isSingleValueType(typeOf reflect.Type) bool {
+	switch typeOf.Kind() {
+	case reflect.Struct:
+		return typeOf == reflect.TypeOf(time.Time{})
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map, reflect.Interface, reflect.Chan, reflect.Func:
+		return false
+	}
+
+	return true
+}
+
+// removeEmptyRows removes elements of slices tagged with `form:"items,omitempty-rows"`, which contain only zero values,
+// like trailing rows posted by dynamic "add row" forms which are never filled. Remaining elements keep their order
+// and they are re-indexed, so field errors of decoding are renamed to match their new indices. Elements with
+// field errors are never removed. Slices of nested structs (like "items[0].options") are handled in the same way.
+func (p *DefaultFormDataDecoderImpl) removeEmptyRows(valueOf reflect.Value, namespace string, validationInfo domain.ValidationInfo) domain.ValidationInfo {
+	switch valueOf.Kind() {
+	case reflect.Ptr:
+		if !valueOf.IsNil() {
+			return p.removeEmptyRows(valueOf.Elem(), namespace, validationInfo)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < valueOf.Len(); i++ {
+			validationInfo = p.removeEmptyRows(valueOf.Index(i), fmt.Sprintf("%s[%d]", namespace, i), validationInfo)
+		}
+	case reflect.Struct:
+		if valueOf.Type() == reflect.TypeOf(time.Time{}) {
+			return validationInfo
+		}
+
+		for _, field := range p.getStructMetadata(valueOf.Type()).fields {
+			fieldValue := valueOf.Field(field.index)
+			if !fieldValue.CanSet() || field.ignored {
+				continue
+			}
+
+			fieldNamespace := p.getFieldNamespace(namespace, field)
+
+			// nested slices are cleaned first, so rows which contain only empty nested rows are removed as well
+			validationInfo = p.removeEmptyRows(fieldValue, fieldNamespace, validationInfo)
+
+			if fieldValue.Kind() == reflect.Slice && p.hasOption(field.options, formOptionOmitEmptyRows) {
+				validationInfo = p.removeEmptySliceRows(fieldValue, fieldNamespace, validationInfo)
+			}
+		}
+	}
+
+	return validationInfo
+}
+
+// removeEmptySliceRows removes elements of slice which contain only zero values and don't have any field errors.
+// Slice without remaining elements is set to nil, same as it was not submitted.
+func (p *DefaultFormDataDecoderImpl) removeEmptySliceRows(valueOf reflect.Value, namespace string, validationInfo domain.ValidationInfo) domain.ValidationInfo {
+	kept := reflect.MakeSlice(valueOf.Type(), 0, valueOf.Len())
+	renamed := map[string]string{}
+
+	for i := 0; i < valueOf.Len(); i++ {
+		row := valueOf.Index(i)
+		rowNamespace := fmt.Sprintf("%s[%d]", namespace, i)
+		if p.isEmptyRow(row) && !p.hasFieldErrors(validationInfo, rowNamespace) {
+			continue
+		}
+
+		if keptNamespace := fmt.Sprintf("%s[%d]", namespace, kept.Len()); keptNamespace != rowNamespace {
+			renamed[rowNamespace] = keptNamespace
+		}
+		kept = reflect.Append(kept, row)
+	}
+
+	if kept.Len() == valueOf.Len() {
+		return validationInfo
+	}
+
+	if kept.Len() == 0 {
+		valueOf.Set(reflect.Zero(valueOf.Type()))
+	} else {
+		valueOf.Set(kept)
+	}
+
+	if len(renamed) == 0 {
+		return validationInfo
+	}
+
+	result := domain.ValidationInfo{}
+	result.AppendGeneralErrors(validationInfo.GetGeneralErrors())
+	for _, entry := range validationInfo.FieldErrorsSorted() {
+		fieldName := entry.FieldName
+		for rowNamespace, keptNamespace := range renamed {
+			if p.isRowField(fieldName, rowNamespace) {
+				fieldName = keptNamespace + strings.TrimPrefix(fieldName, rowNamespace)
+				break
+			}
+		}
+
+		for _, err := range entry.Errors {
+			result.AddFieldErrorWithParams(fieldName, err.MessageKey, err.DefaultLabel, err.Parameters)
+		}
+	}
+
+	return result
+}
+
+// isEmptyRow checks if element of slice contains only zero values, where empty slices and maps are also handled as zero values
+func (p *DefaultFormDataDecoderImpl) isEmptyRow(valueOf reflect.Value) bool {
+	switch valueOf.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return valueOf.IsNil() || p.isEmptyRow(valueOf.Elem())
+	case reflect.Slice, reflect.Map:
+		return valueOf.Len() == 0
+	case reflect.Struct:
+		if valueOf.Type() == reflect.TypeOf(time.Time{}) {
+			return valueOf.IsZero()
+		}
+
+		for i := 0; i < valueOf.NumField(); i++ {
+			if !p.isEmptyRow(valueOf.Field(i)) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return valueOf.IsZero()
+}
+
+// hasFieldErrors checks if validation info contains field errors for element of slice, or any of its nested fields
+func (p *DefaultFormDataDecoderImpl) hasFieldErrors(validationInfo domain.ValidationInfo, rowNamespace string) bool {
+	for fieldName := range validationInfo.GetErrorsForAllFields() {
+		if p.isRowField(fieldName, rowNamespace) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRowField checks if field name belongs to element of slice (like "items[1].sku" for "items[1]")
+func (p *DefaultFormDataDecoderImpl) isRowField(fieldName string, rowNamespace string) bool {
+	return fieldName == rowNamespace || strings.HasPrefix(fieldName, rowNamespace+".") || strings.HasPrefix(fieldName, rowNamespace+"[")
+}
+
+// hasOption checks if options of struct field tag contain desired option
+func (p *DefaultFormDataDecoderImpl) hasOption(options []string, option string) bool {
+	for _, value := range options {
+		if strings.TrimSpace(value) == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// emptyAbsentValues sets empty slices and maps into all nil struct fields tagged with `absent:"empty"`, including nested structs.
+// Nil value means that field is not submitted at all (like checkbox group without any checked option),
+// so it's presented as empty, which can't be mistaken with unknown or previous value of the field.
+func (p *DefaultFormDataDecoderImpl) emptyAbsentValues(valueOf reflect.Value) {
+	switch valueOf.Kind() {
+	case reflect.Ptr:
+		if !valueOf.IsNil() {
+			p.emptyAbsentValues(valueOf.Elem())
+		}
+	case reflect.Struct:
+		for _, field := range p.getStructMetadata(valueOf.Type()).fields {
+			fieldValue := valueOf.Field(field.index)
+			if !fieldValue.CanSet() {
+				continue
+			}
+
+			switch {
+			case !field.absentEmpty:
+				p.emptyAbsentValues(fieldValue)
+			case fieldValue.Kind() == reflect.Slice && fieldValue.IsNil():
+				fieldValue.Set(reflect.MakeSlice(field.field.Type, 0, 0))
+			case fieldValue.Kind() == reflect.Map && fieldValue.IsNil():
+				fieldValue.Set(reflect.MakeMap(field.field.Type))
+			}
+		}
+	}
+}
+
+// modifyString applies field modifiers to string value in defined order.
+// It returns error if there is no field modifier with defined name.
+func (p *DefaultFormDataDecoderImpl) modifyString(value string, modifierNames []string) (string, error) {
+	for _, name := range modifierNames {
+		fieldModifier, ok := p.modifiers[name]
+		if !ok {
+			return "", domain.NewFormErrorf("there is no FieldModifier with name %q", name)
+		}
+
+		value = fieldModifier.Modify(value)
+	}
+
+	return value, nil
+}
+
+// checkModifiers checks if there is field modifier for every name used in "mod" tags of form data type and its nested types.
+// Names are checked only once per type, and unknown name is logged at first use, so misspelled tag is reported
+// for every decoding of that type, regardless if its field is submitted or not.
+func (p *DefaultFormDataDecoderImpl) checkModifiers(typeOf reflect.Type) error {
+	if checked, ok := p.checkedModifiers.Load(typeOf); ok {
+		err, _ := checked.(error)
+		return err
+	}
+
+	modifierFields := p.getFormMetadata(typeOf).modifierFields
+	fieldNames := make([]string, 0, len(modifierFields))
+	for fieldName := range modifierFields {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	var err error
+	for _, fieldName := range fieldNames {
+		for _, name := range modifierFields[fieldName] {
+			if _, ok := p.modifiers[name]; !ok && err == nil {
+				err = domain.NewFormErrorf("field %s uses unknown FieldModifier %q", fieldName, name)
+			}
+		}
+	}
+
+	if _, loaded := p.checkedModifiers.LoadOrStore(typeOf, err); !loaded && err != nil && p.logger != nil {
+		p.logger.WithField("FormDataDecoder", "modifiers").Error(err.Error())
+	}
+
+	return err
+}
+
+// getModifierFields collects names of field modifiers of all fields with "mod" tag, including fields of nested structs
+// and structs inside slices and arrays, keyed by form names of fields without indices (like "comments.text")
+func (p *DefaultFormDataDecoderImpl) getModifierFields(typeOf reflect.Type, namespace string, visited map[reflect.Type]bool, fields map[string][]string) {
+	typeOf = p.indirectType(typeOf)
+	if typeOf != nil && (typeOf.Kind() == reflect.Slice || typeOf.Kind() == reflect.Array) {
+		typeOf = p.indirectType(typeOf.Elem())
+	}
+	if typeOf == nil || typeOf.Kind() != reflect.Struct || typeOf == timeType || visited[typeOf] {
+		return
+	}
+
+	// recursive types are visited only once per branch
+	visited[typeOf] = true
+	defer delete(visited, typeOf)
+
+	for _, field := range p.getStructMetadata(typeOf).fields {
+		if field.field.PkgPath != "" && !field.field.Anonymous {
+			continue
+		}
+
+		fieldNamespace := p.getFieldNamespace(namespace, field)
+		if len(field.modifiers) > 0 {
+			fields[fieldNamespace] = field.modifiers
+		}
+
+		p.getModifierFields(field.field.Type, fieldNamespace, visited, fields)
+	}
+}
+
+// getModifierNames returns names of field modifiers, defined as comma separated list in "mod" tag of struct field
+func (p *DefaultFormDataDecoderImpl) getModifierNames(field reflect.StructField) []string {
+	var names []string
+	for _, name := range strings.Split(field.Tag.Get("mod"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// newDecoder creates decoder from go-playground form package, with registered time decoding, lenient decoding of numbers
+// and booleans if it's enabled, and all custom type decoders. Custom type decoders are registered last, so they can override others.
+func (p *DefaultFormDataDecoderImpl) newDecoder(customTypeDecoders []domain.CustomTypeDecoder) *form.Decoder {
+	decoder := form.NewDecoder()
+	decoder.RegisterCustomTypeFunc(p.wrapCustomTypeFunc(p.decodeTime), time.Time{})
+
+	if p.maxArraySize > 0 {
+		decoder.SetMaxArraySize(uint(p.maxArraySize))
+	}
+
+	for _, numberType := range numberTypes {
+		kind := reflect.TypeOf(numberType).Kind()
+		if p.trimNumbers || (p.decimalComma && (kind == reflect.Float32 || kind == reflect.Float64)) {
+			decoder.RegisterCustomTypeFunc(p.lenientNumberFunc(reflect.TypeOf(numberType)), numberType)
+		}
+	}
+
+	if p.lenientBooleans {
+		decoder.RegisterCustomTypeFunc(p.decodeLenientBool, false)
+	}
+
+	for _, customTypeDecoder := range customTypeDecoders {
+		decoder.RegisterCustomTypeFunc(p.wrapCustomTypeFunc(customTypeDecoder.Decode), customTypeDecoder.Type())
+	}
+
+	return decoder
+}
+
+// wrapCustomTypeFunc wraps errors returned from custom type decoding function into customTypeError
+func (p *DefaultFormDataDecoderImpl) wrapCustomTypeFunc(fn form.DecodeCustomTypeFunc) form.DecodeCustomTypeFunc {
+	return func(values []string) (interface{}, error) {
+		result, err := fn(values)
+		if err != nil {
+			return nil, &customTypeError{err: err}
+		}
+
+		return result, nil
+	}
+}
+
+// getDecodeValidationInfo transforms errors of custom type decoding into field errors with key "formError.invalidFormat",
+// and all other field decoding errors (like invalid numbers) into field errors with key "formError.invalidValue",
+// which contain submitted raw value as "value" parameter. It returns original error if it's not related to fields.
+func (p *DefaultFormDataDecoderImpl) getDecodeValidationInfo(err error, values url.Values) (domain.ValidationInfo, error) {
+	validationInfo := domain.ValidationInfo{}
+	if err == nil {
+		return validationInfo, nil
+	}
+
+	decodeErrors, ok := err.(form.DecodeErrors)
+	if !ok {
+		return validationInfo, err
+	}
+
+	// namespaces are sorted, so field errors are always added in the same order
+	namespaces := make([]string, 0, len(decodeErrors))
+	for namespace := range decodeErrors {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		fieldErr := decodeErrors[namespace]
+		if customErr, ok := fieldErr.(*customTypeError); ok {
+			validationInfo.AddFieldError(namespace, "formError.invalidFormat", customErr.Error())
+			continue
+		}
+
+		validationInfo.AddFieldErrorWithParams(namespace, "formError.invalidValue", "invalid value", map[string]string{
+			"value": p.getRawValue(values, namespace),
+		})
+	}
+
+	return validationInfo, nil
+}
+
+// getRawValue returns submitted raw value for field namespace. Elements of slices (like "numbers[1]") can be
+// submitted either with index or as repeated values without index.
+func (p *DefaultFormDataDecoderImpl) getRawValue(values url.Values, namespace string) string {
+	if fieldValues, ok := values[namespace]; ok && len(fieldValues) > 0 {
+		return fieldValues[0]
+	}
+
+	start := strings.LastIndex(namespace, "[")
+	if start == -1 || !strings.HasSuffix(namespace, "]") {
+		return ""
+	}
+
+	index, err := strconv.Atoi(namespace[start+1 : len(namespace)-1])
+	if err != nil || index < 0 {
+		return ""
+	}
+
+	if fieldValues := values[namespace[:start]]; index < len(fieldValues) {
+		return fieldValues[index]
+	}
+
+	return ""
+}
+
+// lenientNumberFunc creates decoding function for numeric type, which trims whitespaces around submitted number,
+// and, in case of floats, accepts comma as decimal separator (like "1,5"), depending on configuration.
+// Empty value is decoded as zero.
+func (p *DefaultFormDataDecoderImpl) lenientNumberFunc(typeOf reflect.Type) form.DecodeCustomTypeFunc {
+	return func(values []string) (interface{}, error) {
+		value := values[0]
+		if p.trimNumbers {
+			value = strings.TrimSpace(value)
+		}
+
+		result := reflect.New(typeOf).Elem()
+		if value == "" {
+			return result.Interface(), nil
+		}
+
+		switch typeOf.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			number, err := strconv.ParseInt(value, 10, typeOf.Bits())
+			if err != nil {
+				return nil, err
+			}
+			result.SetInt(number)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			number, err := strconv.ParseUint(value, 10, typeOf.Bits())
+			if err != nil {
+				return nil, err
+			}
+			result.SetUint(number)
+		case reflect.Float32, reflect.Float64:
+			// comma is used as decimal separator only if there is no other separator, so "1,234.5" stays invalid
+			if p.decimalComma && strings.Count(value, ",") == 1 && !strings.Contains(value, ".") {
+				value = strings.Replace(value, ",", ".", 1)
+			}
+			number, err := strconv.ParseFloat(value, typeOf.Bits())
+			if err != nil {
+				return nil, err
+			}
+			result.SetFloat(number)
+		}
+
+		return result.Interface(), nil
+	}
+}
+
+// decodeLenientBool decodes submitted boolean, by accepting "on/off", "yes/no", "1/0" and "true/false",
+// without case sensitivity and whitespaces around. Empty value is decoded as false.
+func (p *DefaultFormDataDecoderImpl) decodeLenientBool(values []string) (interface{}, error) {
+	switch strings.ToLower(strings.TrimSpace(values[0])) {
+	case "1", "on", "yes", "true":
+		return true, nil
+	case "", "0", "off", "no", "false":
+		return false, nil
+	}
+
+	return nil, fmt.Errorf("invalid boolean value %q", values[0])
+}
+
+// decodeTime parses submitted date into time.Time, by using configured date format, with RFC 3339 format as fallback.
+// Empty value is decoded as zero time.
+func (p *DefaultFormDataDecoderImpl) decodeTime(values []string) (interface{}, error) {
+	value := strings.TrimSpace(values[0])
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if p.dateFormat != "" {
+		if date, err := time.Parse(p.dateFormat, value); err == nil {
+			return date, nil
+		}
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+// GetJSONValues reads JSON object from http request body and transforms it into url values, in the same way as
+// DefaultFormDataDecoderImpl does before decoding. It returns nil values if http request body is not sent as JSON or it's empty.
+// Body is read up to the same size as url encoded body is read by net/http, and GetLimitedJSONValues should be used
+// when maximum body size is configured.
+func GetJSONValues(req *web.Request, formData interface{}) (url.Values, error) {
+	return GetLimitedJSONValues(req, formData, DefaultMaxBodySize)
+}
+
+// GetLimitedJSONValues reads JSON object from http request body in the same way as GetJSONValues, but only up to
+// passed maximum body size, and it returns ErrRequestTooLarge if body is bigger. Body is not limited if maximum
+// body size is not positive.
+func GetLimitedJSONValues(req *web.Request, formData interface{}, maxBodySize int64) (url.Values, error) {
+	return GetNamespacedJSONValues(req, formData, "", maxBodySize)
+}
+
+// GetNamespacedJSONValues reads JSON object from http request body in the same way as GetLimitedJSONValues, but in case
+// of namespace only nested JSON object under namespace key is used (like {"login": {"email": "..."}} for namespace "login").
+// Names of returned values contain namespace prefix (like "login.email"), same as names of url encoded values.
+func GetNamespacedJSONValues(req *web.Request, formData interface{}, namespace string, maxBodySize int64) (url.Values, error) {
+	decoder := &DefaultFormDataDecoderImpl{
+		maxBodySize: maxBodySize,
+	}
+	if decoder.getMediaType(req) != "application/json" {
+		return nil, nil
+	}
+
+	return decoder.getJSONValues(req, formData, namespace)
+}
+
+// ParseMultipartRequestForm parses multipart http request body, if it's not already parsed, by storing up to maximum
+// memory of its files in memory, and the rest on disk. It returns nil form if http request body is not sent as multipart form.
+// Form is parsed only once, so the same form is used later by DefaultFormDataDecoderImpl.
+func ParseMultipartRequestForm(req *web.Request, maxMemory int64) (*multipart.Form, error) {
+	decoder := &DefaultFormDataDecoderImpl{
+		maxMemory: maxMemory,
+	}
+	if decoder.getMediaType(req) != "multipart/form-data" {
+		return nil, nil
+	}
+
+	return decoder.getMultipartForm(req)
+}
+
+// ParseRequestForm parses url encoded http request body and query, like http.Request.ParseForm, but with body limited
+// to maximum body size, so body sent without declared length (like chunked one) is not read beyond it. It returns
+// ErrRequestTooLarge if body is bigger, and ErrTooManyFields if request contains more fields than maximum number
+// of form keys. Limits which are not positive are not checked, and then net/http limits body to 10MB.
+// Multipart and JSON http request bodies are not read, since they are limited during decoding.
+func ParseRequestForm(req *web.Request, maxBodySize int64, maxFormKeys int) error {
+	httpRequest := req.Request()
+	decoder := &DefaultFormDataDecoderImpl{}
+
+	// body is limited only before it's parsed for the first time, and net/http doesn't apply its own limit
+	// to body which is already wrapped with http.MaxBytesReader
+	if httpRequest.PostForm == nil && httpRequest.Body != nil && maxBodySize > 0 && decoder.getMediaType(req) != "multipart/form-data" {
+		httpRequest.Body = http.MaxBytesReader(nil, httpRequest.Body, maxBodySize)
+	}
+
+	if err := httpRequest.ParseForm(); err != nil {
+		if isBodyLimitError(err) {
+			return ErrRequestTooLarge
+		}
+
+		return err
+	}
+
+	if maxFormKeys > 0 && len(httpRequest.Form) > maxFormKeys {
+		return ErrTooManyFields
+	}
+
+	return nil
+}
+
+// isBodyLimitError checks if error is returned by net/http because http request body exceeds its limit, either
+// the one defined by http.MaxBytesReader or its default limit for url encoded body
+func isBodyLimitError(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		switch err.Error() {
+		case "http: request body too large", "http: POST too large":
+			return true
+		}
+	}
+
+	return false
+}
+
+// NormalizeValues transforms names of url values into notation used by DefaultFormDataDecoderImpl before decoding,
+// so fields posted in dot notation (like "items.0.sku") and bracket notation (like "address[street]") can be matched
+// with fields of form data in the same way.
+func NormalizeValues(values url.Values, formData interface{}) url.Values {
+	decoder := &DefaultFormDataDecoderImpl{}
+
+	return decoder.normalizeValues(values, reflect.TypeOf(formData))
+}
+
+// normalizeValues transforms names of url values into notation of go-playground form decoder, where nested struct fields
+// are separated by dots, while slice indices and map keys are placed inside brackets (like "items[0].sku" or "attributes[color]").
+// Values posted under different names of the same field (like "address.street" and "address[street]") are merged.
+func (p *DefaultFormDataDecoderImpl) normalizeValues(values url.Values, typeOf reflect.Type) url.Values {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	// names are sorted, so merged values are always in the same order
+	sort.Strings(names)
+
+	normalized := make(url.Values, len(values))
+	for _, name := range names {
+		normalizedName := p.normalizeName(name, typeOf)
+		normalized[normalizedName] = append(normalized[normalizedName], values[name]...)
+	}
+
+	return normalized
+}
+
+// normalizeName transforms field name into notation of go-playground form decoder, by following type of form data.
+// Parts of name with unknown type are kept in their original notation. Content of brackets is never split,
+// so map keys which contain dots have to be posted in bracket notation (like "attributes[color.dark]").
+func (p *DefaultFormDataDecoderImpl) normalizeName(name string, typeOf reflect.Type) string {
+	typeOf = p.indirectType(typeOf)
+
+	var result strings.Builder
+	for i, part := range p.splitName(name) {
+		switch {
+		case part.raw:
+			result.WriteString(part.name)
+		case i == 0 && part.bracket:
+			result.WriteString("[" + part.name + "]")
+		case i == 0:
+			result.WriteString(part.name)
+		case typeOf != nil && typeOf.Kind() == reflect.Struct:
+			result.WriteString("." + part.name)
+		case typeOf != nil && (typeOf.Kind() == reflect.Slice || typeOf.Kind() == reflect.Array || typeOf.Kind() == reflect.Map):
+			result.WriteString("[" + part.name + "]")
+		case part.bracket:
+			result.WriteString("[" + part.name + "]")
+		default:
+			result.WriteString("." + part.name)
+		}
+
+		if typeOf == nil {
+			continue
+		}
+
+		switch typeOf.Kind() {
+		case reflect.Struct:
+			typeOf = p.indirectType(p.getJSONChildType(typeOf, part.name))
+		case reflect.Slice, reflect.Array, reflect.Map:
+			typeOf = p.indirectType(typeOf.Elem())
+		default:
+			typeOf = nil
+		}
+	}
+
+	return result.String()
+}
+
+// splitName splits field name into parts separated either by dots or by brackets (like "items.0.sku" or "items[0][sku]")
+func (p *DefaultFormDataDecoderImpl) splitName(name string) []namePart {
+	var parts []namePart
+
+	for name != "" {
+		if name[0] == '[' {
+			end := strings.Index(name, "]")
+			if end < 0 {
+				// unclosed bracket is kept as it is
+				return append(parts, namePart{name: name, raw: true})
+			}
+			parts = append(parts, namePart{name: name[1:end], bracket: true})
+			name = name[end+1:]
+			continue
+		}
+
+		name = strings.TrimPrefix(name, ".")
+		end := strings.IndexAny(name, ".[")
+		if end < 0 {
+			end = len(name)
+		}
+		parts = append(parts, namePart{name: name[:end]})
+		name = name[end:]
+	}
+
+	return parts
+}
+
+// getMediaType returns media type of http request body. It returns empty string if it's not defined.
+func (p *DefaultFormDataDecoderImpl) getMediaType(req *web.Request) string {
+	if req == nil {
+		return ""
+	}
+
+	mediaType, _, err := mime.ParseMediaType(req.Request().Header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+
+	return mediaType
+}
+
+// getJSONValues reads JSON http request body and transforms it into url values, so it can be decoded
+// in the same way as url encoded body. It returns nil values in case of empty body. In case of namespace,
+// only nested JSON object under namespace key is used, and names of values contain namespace prefix.
+// Body is read only up to maximum body size, and ErrRequestTooLarge is returned if it's bigger.
+func (p *DefaultFormDataDecoderImpl) getJSONValues(req *web.Request, formData interface{}, namespace string) (url.Values, error) {
+	httpRequest := req.Request()
+	if httpRequest.Body == nil {
+		return nil, nil
+	}
+
+	var reader io.Reader = httpRequest.Body
+	if p.maxBodySize > 0 {
+		reader = io.LimitReader(httpRequest.Body, p.maxBodySize+1)
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	if isBodyLimitError(err) {
+		// body is already limited by ParseRequestForm
+		return nil, ErrRequestTooLarge
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// body is restored, so it can be decoded again for form extensions (together with part which is not read yet)
+	httpRequest.Body = ioutil.NopCloser(io.MultiReader(bytes.NewReader(body), httpRequest.Body))
+
+	if p.maxBodySize > 0 && int64(len(body)) > p.maxBodySize {
+		return nil, ErrRequestTooLarge
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
+
+	var content interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	err = decoder.Decode(&content)
+	if err != nil {
+		return nil, err
+	}
+
+	object, ok := content.(map[string]interface{})
+	if !ok {
+		return nil, errors.New("JSON request body must contain an object")
+	}
+
+	values := url.Values{}
+	if namespace == "" {
+		p.flattenJSONValue(values, "", object, reflect.TypeOf(formData))
+		return values, nil
+	}
+
+	// nested object under namespace key is decoded into form data, so it's flattened by following type of form data
+	if child, ok := object[namespace]; ok {
+		p.flattenJSONValue(values, namespace, child, reflect.TypeOf(formData))
+	}
+
+	return values, nil
+}
+
+// flattenJSONValue stores JSON value into url values by using the same namespace notation as go-playground form decoder.
+// Type of form data is followed to decide if nested JSON object should be stored by using struct or map notation.
+func (p *DefaultFormDataDecoderImpl) flattenJSONValue(values url.Values, namespace string, value interface{}, typeOf reflect.Type) {
+	typeOf = p.indirectType(typeOf)
+
+	switch converted := value.(type) {
+	case map[string]interface{}:
+		for key, child := range converted {
+			p.flattenJSONValue(values, p.getJSONChildNamespace(namespace, key, typeOf), child, p.getJSONChildType(typeOf, key))
+		}
+	case []interface{}:
+		var elemType reflect.Type
+		if typeOf != nil && (typeOf.Kind() == reflect.Slice || typeOf.Kind() == reflect.Array) {
+			elemType = typeOf.Elem()
+		}
+		for i, child := range converted {
+			switch child.(type) {
+			case map[string]interface{}, []interface{}:
+				p.flattenJSONValue(values, fmt.Sprintf("%s[%d]", namespace, i), child, elemType)
+			default:
+				p.flattenJSONValue(values, namespace, child, elemType)
+			}
+		}
+	case json.Number:
+		values.Add(namespace, converted.String())
+	case bool:
+		values.Add(namespace, strconv.FormatBool(converted))
+	case string:
+		values.Add(namespace, converted)
+	}
+}
+
+// getJSONChildNamespace returns namespace of nested JSON value, depending if parent is map or struct.
+func (p *DefaultFormDataDecoderImpl) getJSONChildNamespace(namespace string, key string, typeOf reflect.Type) string {
+	if namespace == "" {
+		return key
+	}
+
+	if typeOf != nil && typeOf.Kind() == reflect.Map {
+		return fmt.Sprintf("%s[%s]", namespace, key)
+	}
+
+	return fmt.Sprintf("%s.%s", namespace, key)
+}
+
+// getJSONChildType returns type of nested JSON value, by searching for struct field with the same form name,
+// or by using element type for maps. It returns nil if type is unknown.
+func (p *DefaultFormDataDecoderImpl) getJSONChildType(typeOf reflect.Type, key string) reflect.Type {
+	if typeOf == nil {
+		return nil
+	}
+
+	if typeOf.Kind() == reflect.Map {
+		return typeOf.Elem()
+	}
+
+	if typeOf.Kind() != reflect.Struct {
+		return nil
+	}
+
+	return p.getStructMetadata(typeOf).childTypes[key]
+}
+
+// indirectType returns element type in case of pointer type
+func (p *DefaultFormDataDecoderImpl) indirectType(typeOf reflect.Type) reflect.Type {
+	for typeOf != nil && typeOf.Kind() == reflect.Ptr {
+		typeOf = typeOf.Elem()
+	}
+
+	return typeOf
+}
+
+// isBodyTooLarge checks if declared length of http request body is bigger than maximum body size. Body without declared
+// length is limited while it's read instead, by ParseRequestForm and by reading of JSON body.
+func (p *DefaultFormDataDecoderImpl) isBodyTooLarge(req *web.Request) bool {
+	if p.maxBodySize <= 0 || req == nil {
+		return false
+	}
+
+	return req.Request().ContentLength > p.maxBodySize
+}
+
+// findTooLargeIndex returns first field namespace (like "items[999999].sku") which contains slice index bigger than
+// maximum array size. Type of form data is followed, so keys of maps (like "prices[123456]") are not treated as indices.
+func (p *DefaultFormDataDecoderImpl) findTooLargeIndex(values url.Values, typeOf reflect.Type) (string, bool) {
+	if p.maxArraySize <= 0 {
+		return "", false
+	}
+
+	namespaces := make([]string, 0, len(values))
+	for namespace := range values {
+		if strings.Contains(namespace, "[") {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+
+	for _, namespace := range namespaces {
+		if p.hasTooLargeIndex(namespace, typeOf) {
+			return namespace, true
+		}
+	}
+
+	return "", false
+}
+
+// hasTooLargeIndex checks if field namespace contains slice index bigger than maximum array size,
+// by resolving type of each part of namespace. Parts with unknown type are not checked.
+func (p *DefaultFormDataDecoderImpl) hasTooLargeIndex(namespace string, typeOf reflect.Type) bool {
+	typeOf = p.indirectType(typeOf)
+
+	for namespace != "" && typeOf != nil {
+		if namespace[0] != '[' {
+			namespace = strings.TrimPrefix(namespace, ".")
+			end := strings.IndexAny(namespace, ".[")
+			if end < 0 {
+				end = len(namespace)
+			}
+			typeOf = p.indirectType(p.getJSONChildType(typeOf, namespace[:end]))
+			namespace = namespace[end:]
+			continue
+		}
+
+		end := strings.Index(namespace, "]")
+		if end < 0 {
+			return false
+		}
+		key := namespace[1:end]
+		namespace = namespace[end+1:]
+
+		switch typeOf.Kind() {
+		case reflect.Slice:
+			index, err := strconv.ParseUint(key, 10, 64)
+			if errors.Is(err, strconv.ErrRange) || (err == nil && index >= uint64(p.maxArraySize)) {
+				return true
+			}
+		case reflect.Array, reflect.Map:
+			// indices of arrays are limited by their length, and keys of maps are not indices
+		default:
+			return false
+		}
+		typeOf = p.indirectType(typeOf.Elem())
+	}
+
+	return false
+}
+
+// newLimitError creates decoding error with general error for request which exceeds configured limits,
+// and logs it, since it's usually caused by malicious client instead of regular user
+func (p *DefaultFormDataDecoderImpl) newLimitError(messageKey string, defaultLabel string, params map[string]string) error {
+	var logger flamingo.Logger = &flamingo.NullLogger{}
+	if p.logger != nil {
+		logger = p.logger
+	}
+	logger.WithField("FormDataDecoder", messageKey).Warn(defaultLabel)
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddGeneralErrorWithParams(messageKey, defaultLabel, params)
+
+	return domain.NewDecodeError(validationInfo)
+}
+
+// getMultipartForm parses multipart http request body, if it's not already parsed.
+func (p *DefaultFormDataDecoderImpl) getMultipartForm(req *web.Request) (*multipart.Form, error) {
+	httpRequest := req.Request()
+	if httpRequest.MultipartForm == nil {
+		err := httpRequest.ParseMultipartForm(p.maxMemory)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return httpRequest.MultipartForm, nil
+}
+
+// mergeMultipartValues adds values from multipart http request body to passed url values, without duplicating existing ones.
+func (p *DefaultFormDataDecoderImpl) mergeMultipartValues(values url.Values, multipartValues map[string][]string) url.Values {
+	merged := make(url.Values, len(values)+len(multipartValues))
+
+	for k, v := range values {
+		merged[k] = v
+	}
+
+	for k, v := range multipartValues {
+		if _, ok := merged[k]; !ok {
+			merged[k] = v
+		}
+	}
+
+	return merged
+}
+
+// decodeFiles stores uploaded files into form data fields of type *multipart.FileHeader or []*multipart.FileHeader.
+// Files which exceed maximum file size are not stored, but they are reported as field errors.
+func (p *DefaultFormDataDecoderImpl) decodeFiles(files map[string][]*multipart.FileHeader, formData interface{}) (interface{}, error) {
+	valueOf := reflect.ValueOf(formData)
+	if valueOf.Kind() != reflect.Struct {
+		return formData, nil
+	}
+
+	addressable := reflect.New(valueOf.Type()).Elem()
+	addressable.Set(valueOf)
+
+	validationInfo := domain.ValidationInfo{}
+	p.bindFiles(addressable, "", files, &validationInfo)
+
+	if !validationInfo.IsValid() {
+		return addressable.Interface(), domain.NewDecodeError(validationInfo)
+	}
+
+	return addressable.Interface(), nil
+}
+
+// bindFiles stores uploaded files into struct fields by matching their form names, including nested structs.
+// Nil pointers to nested structs (like embedded *AddressFragment) are allocated only if any file is stored into them.
+// It returns true if any file is stored.
+func (p *DefaultFormDataDecoderImpl) bindFiles(valueOf reflect.Value, namespace string, files map[string][]*multipart.FileHeader, validationInfo *domain.ValidationInfo) bool {
+	typeOf := valueOf.Type()
+	bound := false
+
+	for _, metadata := range p.getStructMetadata(typeOf).fields {
+		field := metadata.field
+		fieldValue := valueOf.Field(metadata.index)
+		if !fieldValue.CanSet() || metadata.ignored {
+			continue
+		}
+
+		fieldNamespace := p.getFieldNamespace(namespace, metadata)
+
+		switch {
+		case field.Type == fileHeaderType:
+			headers := p.filterFiles(fieldNamespace, files[fieldNamespace], validationInfo)
+			if len(headers) > 0 {
+				fieldValue.Set(reflect.ValueOf(headers[0]))
+				bound = true
+			}
+		case field.Type == fileHeadersType:
+			headers := p.filterFiles(fieldNamespace, files[fieldNamespace], validationInfo)
+			if len(headers) > 0 {
+				fieldValue.Set(reflect.ValueOf(headers))
+				bound = true
+			}
+		case fieldValue.Kind() == reflect.Struct:
+			bound = p.bindFiles(fieldValue, fieldNamespace, files, validationInfo) || bound
+		case fieldValue.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct:
+			if !fieldValue.IsNil() {
+				bound = p.bindFiles(fieldValue.Elem(), fieldNamespace, files, validationInfo) || bound
+				break
+			}
+
+			// nil pointers are followed only if there are files for their fields, so recursive types are not allocated endlessly
+			if !p.hasFilesInNamespace(files, fieldNamespace) || (field.Anonymous && field.Type.Elem() == typeOf) {
+				break
+			}
+
+			allocated := reflect.New(field.Type.Elem())
+			if p.bindFiles(allocated.Elem(), fieldNamespace, files, validationInfo) {
+				fieldValue.Set(allocated)
+				bound = true
+			}
+		}
+	}
+
+	return bound
+}
+
+// getFieldNamespace returns full form name of struct field inside namespace. Fields of anonymous embedded structs
+// are decoded as fields of parent struct, so their namespace is the same as namespace of parent struct.
+func (p *DefaultFormDataDecoderImpl) getFieldNamespace(namespace string, field fieldMetadata) string {
+	if field.field.Anonymous {
+		return namespace
+	}
+	if namespace == "" {
+		return field.name
+	}
+
+	return namespace + "." + field.name
+}
+
+// hasFilesInNamespace checks if there are uploaded files for any field inside namespace, or for any field at all in case of empty namespace
+func (p *DefaultFormDataDecoderImpl) hasFilesInNamespace(files map[string][]*multipart.FileHeader, namespace string) bool {
+	for key := range files {
+		if namespace == "" || strings.HasPrefix(key, namespace+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filterFiles returns only uploaded files which don't exceed maximum file size, and reports field errors for all others.
+func (p *DefaultFormDataDecoderImpl) filterFiles(fieldName string, headers []*multipart.FileHeader, validationInfo *domain.ValidationInfo) []*multipart.FileHeader {
+	if p.maxFileSize <= 0 {
+		return headers
+	}
+
+	filtered := make([]*multipart.FileHeader, 0, len(headers))
+	for _, header := range headers {
+		if header.Size > p.maxFileSize {
+			validationInfo.AddFieldError(fieldName, "formError.fileTooLarge", fmt.Sprintf("file %s is too large", header.Filename))
+			continue
+		}
+
+		filtered = append(filtered, header)
 	}
 
-	return zeroFormData, nil
+	return filtered
 }
@@ -1,12 +1,46 @@
 package formdata
 
 import (
-	"github.com/stretchr/testify/suite"
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"mime/multipart"
+	"net/http"
 	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/go-playground/form"
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/modifiers"
 )
 
 type (
+	// formDataDecoderTestConfig as configuration injected into default form data decoder
+	formDataDecoderTestConfig = struct {
+		MaxMemory       float64    `inject:"config:form.decoder.maxMemory"`
+		MaxFileSize     float64    `inject:"config:form.decoder.maxFileSize"`
+		MaxBodySize     float64    `inject:"config:form.decoder.maxBodySize"`
+		MaxFormKeys     float64    `inject:"config:form.decoder.maxFormKeys"`
+		MaxArraySize    float64    `inject:"config:form.decoder.maxArraySize"`
+		DateFormat      string     `inject:"config:form.validator.dateFormat"`
+		DecimalComma    bool       `inject:"config:form.decoder.lenient.decimalComma"`
+		LenientBooleans bool       `inject:"config:form.decoder.lenient.booleans"`
+		TrimNumbers     bool       `inject:"config:form.decoder.lenient.trimNumbers"`
+		LocaleSource    string     `inject:"config:form.decoder.locale.source"`
+		LocaleKey       string     `inject:"config:form.decoder.locale.key"`
+		LocaleFormats   config.Map `inject:"config:form.decoder.locale.formats"`
+	}
+
 	DefaultFormDataDecoderImplTestSuite struct {
 		suite.Suite
 
@@ -18,8 +52,210 @@ type (
 		Number int       `form:"number"`
 		Slice  []float64 `form:"slice"`
 	}
+
+	formDataDecoderNestedTestData struct {
+		Name       string                         `form:"name" conform:"trim"`
+		Address    formDataDecoderAddressTestData `form:"address"`
+		Items      []formDataDecoderItemTestData  `form:"items"`
+		Tags       []string                       `form:"tags"`
+		Attributes map[string]string              `form:"attributes"`
+	}
+
+	formDataDecoderAddressTestData struct {
+		Street string `form:"street"`
+		City   string `form:"city" conform:"lower"`
+	}
+
+	formDataDecoderItemTestData struct {
+		Sku      string `form:"sku"`
+		Quantity int    `form:"quantity"`
+	}
+
+	formDataDecoderOrderTestData struct {
+		Items     []formDataDecoderItemTestData             `form:"items"`
+		Addresses map[string]formDataDecoderAddressTestData `form:"addresses"`
+	}
+
+	formDataDecoderFileTestData struct {
+		Text        string                          `form:"text"`
+		Avatar      *multipart.FileHeader           `form:"avatar"`
+		Attachments []*multipart.FileHeader         `form:"attachments"`
+		Document    formDataDecoderDocumentTestData `form:"document"`
+	}
+
+	formDataDecoderDocumentTestData struct {
+		File *multipart.FileHeader `form:"file"`
+	}
+
+	formDataDecoderTimeTestData struct {
+		Birthdate time.Time `form:"birthdate"`
+	}
+
+	formDataDecoderMoney struct {
+		Amount   int64
+		Currency string
+	}
+
+	formDataDecoderMoneyDecoder struct{}
+
+	formDataDecoderLenientTestData struct {
+		Name     string  `form:"name"`
+		Quantity int     `form:"quantity"`
+		Weight   float64 `form:"weight"`
+		Active   bool    `form:"active"`
+	}
+
+	formDataDecoderProductTestData struct {
+		Name  string               `form:"name"`
+		Price formDataDecoderMoney `form:"price"`
+	}
+
+	formDataDecoderReviewTestData struct {
+		Title    string                           `form:"title" mod:"striphtml"`
+		Rating   int                              `form:"rating" mod:"striphtml"`
+		Tags     []string                         `form:"tags" mod:"striphtml, nfc"`
+		Labels   map[string]string                `form:"labels" mod:"striphtml"`
+		Comments []formDataDecoderCommentTestData `form:"comments"`
+		Author   *formDataDecoderCommentTestData  `form:"author"`
+		Raw      string                           `form:"raw"`
+	}
+
+	formDataDecoderCommentTestData struct {
+		Text string `form:"text" conform:"trim" mod:"striphtml,nfc"`
+	}
+
+	formDataDecoderInvalidModifierTestData struct {
+		Text string `form:"text" mod:"unknown"`
+	}
+
+	formDataDecoderRowsTestData struct {
+		Items []formDataDecoderRowTestData `form:"items,omitempty-rows"`
+		Notes []string                     `form:"notes"`
+	}
+
+	formDataDecoderRowTestData struct {
+		Sku      string                          `form:"sku" validate:"required"`
+		Quantity int                             `form:"quantity"`
+		Options  []formDataDecoderOptionTestData `form:"options,omitempty-rows"`
+	}
+
+	formDataDecoderOptionTestData struct {
+		Name string `form:"name"`
+	}
+
+	formDataDecoderInterestsTestData struct {
+		Interests []string                          `form:"interests" absent:"empty"`
+		Sizes     []int                             `form:"sizes" absent:"empty"`
+		Labels    map[string]string                 `form:"labels" absent:"empty"`
+		Colors    []string                          `form:"colors"`
+		Details   *formDataDecoderInterestsTestData `form:"details"`
+	}
+
+	formDataDecoderProfileTestData struct {
+		ID      int                                   `form:"id" source:"path"`
+		Locale  string                                `form:"locale" source:"header:Accept-Language"`
+		Name    string                                `form:"name"`
+		Account formDataDecoderProfileAccountTestData `form:"account"`
+	}
+
+	formDataDecoderProfileAccountTestData struct {
+		Owner string `form:"owner" source:"path:owner"`
+		Token string `form:"token" source:"header:X-Token"`
+		Email string `form:"email"`
+	}
+
+	formDataDecoderUnknownSourceTestData struct {
+		Name  string `form:"name"`
+		Token string `form:"token" source:"cookie"`
+	}
+
+	formDataDecoderCatalogTestData struct {
+		Items     []formDataDecoderItemTestData `form:"items"`
+		Prices    map[int]float64               `form:"prices"`
+		Sizes     [3]int                        `form:"sizes"`
+		Matrix    [][]int                       `form:"matrix"`
+		Reference *formDataDecoderOrderTestData `form:"reference"`
+	}
+
+	formDataDecoderCheckoutTestData struct {
+		Email string `form:"email"`
+		formDataDecoderAddressTestData
+		*FormDataDecoderContactTestData
+	}
+
+	// FormDataDecoderContactTestData is exported, since only pointers of exported types can be allocated for embedded fields
+	FormDataDecoderContactTestData struct {
+		Phone  string                `form:"phone"`
+		Avatar *multipart.FileHeader `form:"avatar"`
+	}
+
+	formDataDecoderUnexportedEmbedTestData struct {
+		Email string `form:"email"`
+		*formDataDecoderAddressTestData
+	}
+
+	formDataDecoderNestedUnexportedEmbedTestData struct {
+		Rows []formDataDecoderUnexportedEmbedTestData `form:"rows"`
+	}
+
+	formDataDecoderOptionalTestData struct {
+		Nickname *string                              `form:"nickname"`
+		Age      *int                                 `form:"age"`
+		Active   *bool                                `form:"active"`
+		Birthday *time.Time                           `form:"birthday"`
+		Rows     []formDataDecoderOptionalRowTestData `form:"rows"`
+	}
+
+	formDataDecoderOptionalRowTestData struct {
+		Quantity *int `form:"quantity"`
+	}
+
+	// formDataDecoderEndlessReader as http request body which is never finished
+	formDataDecoderEndlessReader struct{}
 )
 
+func (r *formDataDecoderEndlessReader) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'a'
+	}
+
+	return len(p), nil
+}
+
+var _ domain.CustomTypeDecoder = &formDataDecoderMoneyDecoder{}
+
+// Type defines money as custom type
+func (d *formDataDecoderMoneyDecoder) Type() interface{} {
+	return formDataDecoderMoney{}
+}
+
+// Decode decodes money submitted as amount and currency, like "12.50 EUR"
+func (d *formDataDecoderMoneyDecoder) Decode(values []string) (interface{}, error) {
+	parts := strings.Fields(values[0])
+	if len(parts) != 2 || len(parts[1]) != 3 {
+		return nil, errors.New("money must contain amount and currency")
+	}
+
+	amount, err := strconv.ParseFloat(parts[0], 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return formDataDecoderMoney{
+		Amount:   int64(math.Round(amount * 100)),
+		Currency: parts[1],
+	}, nil
+}
+
+const formDataDecoderNestedTestJSON = `{
+	"name": " Name ",
+	"address": {"street": "Main Street", "city": "BERLIN"},
+	"items": [{"sku": "A1", "quantity": 2}, {"sku": "B2", "quantity": 1}],
+	"tags": ["first", "second"],
+	"attributes": {"color": "red"},
+	"ignored": null
+}`
+
 func TestDefaultFormDataDecoderImplTestSuite(t *testing.T) {
 	suite.Run(t, &DefaultFormDataDecoderImplTestSuite{})
 }
@@ -116,6 +352,615 @@ func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_FullWit
 	}, result)
 }
 
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_SparseIndices() {
+	result, err := t.decoder.decodeUnknownInterface(url.Values{
+		"items[0].sku":             []string{"A1"},
+		"items[0].quantity":        []string{"1"},
+		"items[2].sku":             []string{"C3"},
+		"items[2].quantity":        []string{"3"},
+		"addresses[home].street":   []string{"Main Street"},
+		"addresses[home].city":     []string{"berlin"},
+		"addresses[office].street": []string{"Second Street"},
+	}, formDataDecoderOrderTestData{})
+
+	t.NoError(err)
+	t.Equal(formDataDecoderOrderTestData{
+		Items: []formDataDecoderItemTestData{
+			{
+				Sku:      "A1",
+				Quantity: 1,
+			},
+			{},
+			{
+				Sku:      "C3",
+				Quantity: 3,
+			},
+		},
+		Addresses: map[string]formDataDecoderAddressTestData{
+			"home": {
+				Street: "Main Street",
+				City:   "berlin",
+			},
+			"office": {
+				Street: "Second Street",
+			},
+		},
+	}, result)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeTime() {
+	decoder := &DefaultFormDataDecoderImpl{}
+	decoder.Inject(&formDataDecoderTestConfig{
+		DateFormat: "02.01.2006",
+	}, nil, nil, nil)
+
+	result, err := decoder.decodeTime([]string{"24.12.1990"})
+	t.NoError(err)
+	t.Equal(time.Date(1990, 12, 24, 0, 0, 0, 0, time.UTC), result)
+
+	result, err = decoder.decodeTime([]string{"1990-12-24T10:00:00Z"})
+	t.NoError(err)
+	t.Equal(time.Date(1990, 12, 24, 10, 0, 0, 0, time.UTC), result)
+
+	result, err = decoder.decodeTime([]string{" "})
+	t.NoError(err)
+	t.Equal(time.Time{}, result)
+
+	_, err = decoder.decodeTime([]string{"wrong"})
+	t.Error(err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_Time() {
+	decoder := &DefaultFormDataDecoderImpl{
+		dateFormat: "2006-01-02",
+	}
+
+	result, err := decoder.decodeUnknownInterface(url.Values{
+		"birthdate": []string{"1990-12-24"},
+	}, formDataDecoderTimeTestData{})
+	t.NoError(err)
+	t.Equal(formDataDecoderTimeTestData{
+		Birthdate: time.Date(1990, 12, 24, 0, 0, 0, 0, time.UTC),
+	}, result)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestAllocateSubmittedPointers() {
+	formData := &formDataDecoderOptionalTestData{
+		Rows: []formDataDecoderOptionalRowTestData{{}, {}},
+	}
+
+	t.decoder.allocateSubmittedPointers(reflect.ValueOf(formData), "", url.Values{
+		"nickname":         []string{""},
+		"age":              []string{"wrong"},
+		"birthday":         []string{},
+		"rows[1].quantity": []string{""},
+	})
+
+	t.Equal(&formDataDecoderOptionalTestData{
+		Nickname: new(string),
+		Rows: []formDataDecoderOptionalRowTestData{
+			{},
+			{
+				Quantity: new(int),
+			},
+		},
+	}, formData)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_OptionalFields() {
+	decoder := &DefaultFormDataDecoderImpl{
+		dateFormat: "2006-01-02",
+	}
+
+	age := 42
+	active := true
+	nickname := "john"
+	birthday := time.Date(1990, 12, 24, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		Name     string
+		Values   url.Values
+		FormData formDataDecoderOptionalTestData
+	}{
+		{
+			Name:     "absent",
+			Values:   url.Values{},
+			FormData: formDataDecoderOptionalTestData{},
+		},
+		{
+			Name: "empty",
+			Values: url.Values{
+				"nickname": []string{""},
+				"age":      []string{""},
+				"active":   []string{""},
+				"birthday": []string{""},
+			},
+			FormData: formDataDecoderOptionalTestData{
+				Nickname: new(string),
+				Age:      new(int),
+				Active:   new(bool),
+				Birthday: &time.Time{},
+			},
+		},
+		{
+			Name: "filled",
+			Values: url.Values{
+				"nickname": []string{"john"},
+				"age":      []string{"42"},
+				"active":   []string{"true"},
+				"birthday": []string{"1990-12-24"},
+			},
+			FormData: formDataDecoderOptionalTestData{
+				Nickname: &nickname,
+				Age:      &age,
+				Active:   &active,
+				Birthday: &birthday,
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		result, err := decoder.decodeUnknownInterface(testCase.Values, formDataDecoderOptionalTestData{})
+		t.NoError(err, testCase.Name)
+		t.Equal(testCase.FormData, result, testCase.Name)
+	}
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_CustomType() {
+	decoder := &DefaultFormDataDecoderImpl{}
+	decoder.Inject(&formDataDecoderTestConfig{}, []domain.CustomTypeDecoder{
+		&formDataDecoderMoneyDecoder{},
+	}, nil, nil)
+
+	result, err := decoder.decodeUnknownInterface(url.Values{
+		"name":  []string{"Shirt"},
+		"price": []string{"12.50 EUR"},
+	}, formDataDecoderProductTestData{})
+	t.NoError(err)
+	t.Equal(formDataDecoderProductTestData{
+		Name: "Shirt",
+		Price: formDataDecoderMoney{
+			Amount:   1250,
+			Currency: "EUR",
+		},
+	}, result)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_CustomTypeInvalid() {
+	decoder := &DefaultFormDataDecoderImpl{}
+	decoder.Inject(&formDataDecoderTestConfig{}, []domain.CustomTypeDecoder{
+		&formDataDecoderMoneyDecoder{},
+	}, nil, nil)
+
+	result, err := decoder.decodeUnknownInterface(url.Values{
+		"name":  []string{"Shirt"},
+		"price": []string{"garbage"},
+	}, formDataDecoderProductTestData{})
+	t.Equal(formDataDecoderProductTestData{
+		Name: "Shirt",
+	}, result)
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldError("price", "formError.invalidFormat", "money must contain amount and currency")
+	t.Equal(domain.NewDecodeError(validationInfo), err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestGetDecodeValidationInfo() {
+	validationInfo, err := t.decoder.getDecodeValidationInfo(nil, nil)
+	t.NoError(err)
+	t.True(validationInfo.IsValid())
+
+	_, err = t.decoder.getDecodeValidationInfo(errors.New("error"), nil)
+	t.EqualError(err, "error")
+
+	validationInfo, err = t.decoder.getDecodeValidationInfo(form.DecodeErrors{
+		"price":          &customTypeError{err: errors.New("invalid price")},
+		"items[0].price": &customTypeError{err: errors.New("invalid item price")},
+		"number":         errors.New("invalid number"),
+		"slice[1]":       errors.New("invalid float"),
+	}, url.Values{
+		"number": []string{"abc"},
+		"slice":  []string{"1.5", "1,5"},
+	})
+	t.NoError(err)
+	t.False(validationInfo.IsValid())
+	t.Equal(map[string][]domain.Error{
+		"price": {
+			{
+				MessageKey:   "formError.invalidFormat",
+				DefaultLabel: "invalid price",
+			},
+		},
+		"items[0].price": {
+			{
+				MessageKey:   "formError.invalidFormat",
+				DefaultLabel: "invalid item price",
+			},
+		},
+		"number": {
+			{
+				MessageKey:   "formError.invalidValue",
+				DefaultLabel: "invalid value",
+				Parameters: map[string]string{
+					"value": "abc",
+				},
+			},
+		},
+		"slice[1]": {
+			{
+				MessageKey:   "formError.invalidValue",
+				DefaultLabel: "invalid value",
+				Parameters: map[string]string{
+					"value": "1,5",
+				},
+			},
+		},
+	}, validationInfo.GetErrorsForAllFields())
+
+	var fieldNames []string
+	for _, entry := range validationInfo.FieldErrorsSorted() {
+		fieldNames = append(fieldNames, entry.FieldName)
+	}
+	t.Equal([]string{"items[0].price", "number", "price", "slice[1]"}, fieldNames)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestGetRawValue() {
+	values := url.Values{
+		"number":      []string{"abc"},
+		"slice":       []string{"1.5", "1,5"},
+		"items[0].id": []string{"x"},
+	}
+
+	t.Equal("abc", t.decoder.getRawValue(values, "number"))
+	t.Equal("1,5", t.decoder.getRawValue(values, "slice[1]"))
+	t.Equal("x", t.decoder.getRawValue(values, "items[0].id"))
+	t.Equal("", t.decoder.getRawValue(values, "slice[2]"))
+	t.Equal("", t.decoder.getRawValue(values, "slice[key]"))
+	t.Equal("", t.decoder.getRawValue(values, "missing"))
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestLenientNumberFunc() {
+	decoder := &DefaultFormDataDecoderImpl{
+		decimalComma: true,
+		trimNumbers:  true,
+	}
+
+	result, err := decoder.lenientNumberFunc(reflect.TypeOf(float64(0)))([]string{" 1,5 "})
+	t.NoError(err)
+	t.Equal(1.5, result)
+
+	result, err = decoder.lenientNumberFunc(reflect.TypeOf(float32(0)))([]string{"2.25"})
+	t.NoError(err)
+	t.Equal(float32(2.25), result)
+
+	_, err = decoder.lenientNumberFunc(reflect.TypeOf(float64(0)))([]string{"1,234.5"})
+	t.Error(err)
+
+	result, err = decoder.lenientNumberFunc(reflect.TypeOf(int(0)))([]string{" 42\t"})
+	t.NoError(err)
+	t.Equal(42, result)
+
+	result, err = decoder.lenientNumberFunc(reflect.TypeOf(uint8(0)))([]string{" "})
+	t.NoError(err)
+	t.Equal(uint8(0), result)
+
+	_, err = decoder.lenientNumberFunc(reflect.TypeOf(uint8(0)))([]string{"300"})
+	t.Error(err)
+
+	_, err = decoder.lenientNumberFunc(reflect.TypeOf(int64(0)))([]string{"1,5"})
+	t.Error(err)
+
+	decoder = &DefaultFormDataDecoderImpl{
+		decimalComma: true,
+	}
+
+	_, err = decoder.lenientNumberFunc(reflect.TypeOf(float64(0)))([]string{" 1,5"})
+	t.Error(err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeLenientBool() {
+	for _, value := range []string{"1", "on", "Yes", " TRUE "} {
+		result, err := t.decoder.decodeLenientBool([]string{value})
+		t.NoError(err)
+		t.Equal(true, result, value)
+	}
+
+	for _, value := range []string{"", "0", "OFF", "no", "false"} {
+		result, err := t.decoder.decodeLenientBool([]string{value})
+		t.NoError(err)
+		t.Equal(false, result, value)
+	}
+
+	_, err := t.decoder.decodeLenientBool([]string{"maybe"})
+	t.EqualError(err, `invalid boolean value "maybe"`)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_InvalidValues() {
+	decoder := &DefaultFormDataDecoderImpl{}
+
+	result, err := decoder.decodeUnknownInterface(url.Values{
+		"name":     []string{"Shirt"},
+		"quantity": []string{"two"},
+		"weight":   []string{"1,5"},
+		"active":   []string{"maybe"},
+	}, formDataDecoderLenientTestData{})
+	t.Equal(formDataDecoderLenientTestData{
+		Name: "Shirt",
+	}, result)
+
+	// field errors are ordered by field names, since decoding errors don't have any order
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldErrorWithParams("active", "formError.invalidValue", "invalid value", map[string]string{
+		"value": "maybe",
+	})
+	validationInfo.AddFieldErrorWithParams("quantity", "formError.invalidValue", "invalid value", map[string]string{
+		"value": "two",
+	})
+	validationInfo.AddFieldErrorWithParams("weight", "formError.invalidValue", "invalid value", map[string]string{
+		"value": "1,5",
+	})
+	t.Equal(domain.NewDecodeError(validationInfo), err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_Lenient() {
+	decoder := &DefaultFormDataDecoderImpl{}
+	decoder.Inject(&formDataDecoderTestConfig{
+		DecimalComma:    true,
+		LenientBooleans: true,
+		TrimNumbers:     true,
+	}, nil, nil, nil)
+
+	result, err := decoder.decodeUnknownInterface(url.Values{
+		"name":     []string{"Shirt"},
+		"quantity": []string{" 2 "},
+		"weight":   []string{"1,5"},
+		"active":   []string{"Yes"},
+	}, formDataDecoderLenientTestData{})
+	t.NoError(err)
+	t.Equal(formDataDecoderLenientTestData{
+		Name:     "Shirt",
+		Quantity: 2,
+		Weight:   1.5,
+		Active:   true,
+	}, result)
+
+	result, err = decoder.decodeUnknownInterface(url.Values{
+		"name":     []string{"Shirt"},
+		"quantity": []string{"two"},
+		"weight":   []string{"1.5kg"},
+		"active":   []string{"maybe"},
+	}, formDataDecoderLenientTestData{})
+	t.Equal(formDataDecoderLenientTestData{
+		Name: "Shirt",
+	}, result)
+
+	// field errors are ordered by field names, since decoding errors don't have any order
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldErrorWithParams("active", "formError.invalidValue", "invalid value", map[string]string{
+		"value": "maybe",
+	})
+	validationInfo.AddFieldErrorWithParams("quantity", "formError.invalidValue", "invalid value", map[string]string{
+		"value": "two",
+	})
+	validationInfo.AddFieldErrorWithParams("weight", "formError.invalidValue", "invalid value", map[string]string{
+		"value": "1.5kg",
+	})
+	t.Equal(domain.NewDecodeError(validationInfo), err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestModifyValue() {
+	decoder := &DefaultFormDataDecoderImpl{}
+	decoder.Inject(&formDataDecoderTestConfig{}, nil, []domain.FieldModifier{
+		&modifiers.StripHTMLModifier{},
+		&modifiers.NFCModifier{},
+	}, nil)
+
+	review := &formDataDecoderReviewTestData{
+		Title:  "<b>Great</b>",
+		Rating: 5,
+		Tags:   []string{"<i>cafe\u0301</i>", "plain"},
+		Labels: map[string]string{"color": "<span>red</span>"},
+		Comments: []formDataDecoderCommentTestData{
+			{Text: "<script>alert(1)</script>first"},
+			{Text: "<p>cafe\u0301</p>"},
+		},
+		Author: &formDataDecoderCommentTestData{Text: "<a href=\"#\">John</a>"},
+		Raw:    "<b>raw</b>",
+	}
+
+	t.NoError(decoder.modifyValue(reflect.ValueOf(review), nil))
+	t.Equal(&formDataDecoderReviewTestData{
+		Title:  "Great",
+		Rating: 5,
+		Tags:   []string{"caf\u00e9", "plain"},
+		Labels: map[string]string{"color": "red"},
+		Comments: []formDataDecoderCommentTestData{
+			{Text: "first"},
+			{Text: "caf\u00e9"},
+		},
+		Author: &formDataDecoderCommentTestData{Text: "John"},
+		Raw:    "<b>raw</b>",
+	}, review)
+
+	err := decoder.modifyValue(reflect.ValueOf(&formDataDecoderInvalidModifierTestData{Text: "text"}), nil)
+	t.Equal(domain.NewFormErrorf("there is no FieldModifier with name %q", "unknown"), err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestGetModifierNames() {
+	typeOf := reflect.TypeOf(formDataDecoderReviewTestData{})
+
+	title, _ := typeOf.FieldByName("Title")
+	t.Equal([]string{"striphtml"}, t.decoder.getModifierNames(title))
+
+	tags, _ := typeOf.FieldByName("Tags")
+	t.Equal([]string{"striphtml", "nfc"}, t.decoder.getModifierNames(tags))
+
+	raw, _ := typeOf.FieldByName("Raw")
+	t.Empty(t.decoder.getModifierNames(raw))
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_Modifiers() {
+	decoder := &DefaultFormDataDecoderImpl{}
+	decoder.Inject(&formDataDecoderTestConfig{}, nil, []domain.FieldModifier{
+		&modifiers.StripHTMLModifier{},
+		&modifiers.NFCModifier{},
+	}, nil)
+
+	result, err := decoder.decodeUnknownInterface(url.Values{
+		"title":            []string{"<h1>Great</h1>"},
+		"rating":           []string{"5"},
+		"tags":             []string{"<b>first</b>", "second"},
+		"comments[0].text": []string{" <b>first</b> "},
+		"comments[1].text": []string{"<script>alert(1)</script>"},
+		"raw":              []string{"<b>raw</b>"},
+	}, formDataDecoderReviewTestData{})
+	t.NoError(err)
+	t.Equal(formDataDecoderReviewTestData{
+		Title:  "Great",
+		Rating: 5,
+		Tags:   []string{"first", "second"},
+		Comments: []formDataDecoderCommentTestData{
+			{Text: "first"},
+			{Text: ""},
+		},
+		Raw: "<b>raw</b>",
+	}, result)
+
+	// unknown modifier is reported for every decoding of type, also when field with modifier is not submitted
+	for _, values := range []url.Values{{"text": []string{"text"}}, {}} {
+		result, err = decoder.decodeUnknownInterface(values, formDataDecoderInvalidModifierTestData{})
+		t.Nil(result)
+		t.Equal(domain.NewFormErrorf("field text uses unknown FieldModifier %q", "unknown"), err)
+	}
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestRemoveEmptyRows() {
+	rows := &formDataDecoderRowsTestData{
+		Items: []formDataDecoderRowTestData{
+			{Sku: "A1"},
+			{},
+			{Quantity: 3},
+			{Options: []formDataDecoderOptionTestData{{}, {}}},
+			{Options: []formDataDecoderOptionTestData{{}, {Name: "gift"}}},
+			{},
+		},
+		Notes: []string{"", "first"},
+	}
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddGeneralError("formError.general", "general")
+	validationInfo.AddFieldError("items[2].quantity", "formError.invalidValue", "invalid value")
+	validationInfo.AddFieldError("items[5].quantity", "formError.invalidValue", "invalid value")
+
+	validationInfo = t.decoder.removeEmptyRows(reflect.ValueOf(rows), "", validationInfo)
+	t.Equal(&formDataDecoderRowsTestData{
+		Items: []formDataDecoderRowTestData{
+			{Sku: "A1"},
+			{Quantity: 3},
+			{Options: []formDataDecoderOptionTestData{{Name: "gift"}}},
+			{},
+		},
+		Notes: []string{"", "first"},
+	}, rows)
+
+	expected := domain.ValidationInfo{}
+	expected.AddGeneralError("formError.general", "general")
+	expected.AddFieldError("items[1].quantity", "formError.invalidValue", "invalid value")
+	expected.AddFieldError("items[3].quantity", "formError.invalidValue", "invalid value")
+	t.Equal(expected, validationInfo)
+
+	rows = &formDataDecoderRowsTestData{
+		Items: []formDataDecoderRowTestData{{}, {}},
+	}
+	t.decoder.removeEmptyRows(reflect.ValueOf(rows), "", domain.ValidationInfo{})
+	t.Nil(rows.Items)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_EmptyRows() {
+	result, err := t.decoder.decodeUnknownInterface(url.Values{
+		"items[0].sku":      []string{"A1"},
+		"items[0].quantity": []string{"1"},
+		"items[1].sku":      []string{""},
+		"items[2].sku":      []string{"C3"},
+		"items[2].quantity": []string{"3"},
+		"items[3].sku":      []string{""},
+		"items[3].quantity": []string{""},
+	}, formDataDecoderRowsTestData{})
+
+	t.NoError(err)
+	t.Equal(formDataDecoderRowsTestData{
+		Items: []formDataDecoderRowTestData{
+			{
+				Sku:      "A1",
+				Quantity: 1,
+			},
+			{
+				Sku:      "C3",
+				Quantity: 3,
+			},
+		},
+	}, result)
+
+	_, err = t.decoder.decodeUnknownInterface(url.Values{
+		"items[1].sku":      []string{""},
+		"items[2].quantity": []string{"three"},
+	}, formDataDecoderRowsTestData{})
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldErrorWithParams("items[0].quantity", "formError.invalidValue", "invalid value", map[string]string{
+		"value": "three",
+	})
+	t.Equal(domain.NewDecodeError(validationInfo), err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestEmptyAbsentValues() {
+	interests := &formDataDecoderInterestsTestData{
+		Sizes:   []int{36},
+		Details: &formDataDecoderInterestsTestData{},
+	}
+
+	t.decoder.emptyAbsentValues(reflect.ValueOf(interests))
+	t.Equal(&formDataDecoderInterestsTestData{
+		Interests: []string{},
+		Sizes:     []int{36},
+		Labels:    map[string]string{},
+		Details: &formDataDecoderInterestsTestData{
+			Interests: []string{},
+			Sizes:     []int{},
+			Labels:    map[string]string{},
+		},
+	}, interests)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_AbsentValues() {
+	result, err := t.decoder.decodeUnknownInterface(url.Values{
+		"sizes": []string{"36", "40"},
+	}, formDataDecoderInterestsTestData{
+		Interests: []string{"sports"},
+	})
+	t.NoError(err)
+	t.Equal(formDataDecoderInterestsTestData{
+		Interests: []string{},
+		Sizes:     []int{36, 40},
+		Labels:    map[string]string{},
+	}, result)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestWrapCustomTypeFunc() {
+	decode := t.decoder.wrapCustomTypeFunc((&formDataDecoderMoneyDecoder{}).Decode)
+
+	result, err := decode([]string{"1.99 USD"})
+	t.NoError(err)
+	t.Equal(formDataDecoderMoney{
+		Amount:   199,
+		Currency: "USD",
+	}, result)
+
+	result, err = decode([]string{"1.99"})
+	t.Nil(result)
+	t.Equal(&customTypeError{err: errors.New("money must contain amount and currency")}, err)
+}
+
 func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_StringMap() {
 	stringMap, err := t.decoder.Decode(nil, nil, url.Values{
 		"first":  []string{"11", "12"},
@@ -147,3 +992,871 @@ func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_UnknownInterface() {
 		Number: 10,
 	}, result)
 }
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_ContentTypes() {
+	urlEncodedRequest, _ := http.NewRequest(http.MethodPost, "/", nil)
+	urlEncodedRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	testCases := []struct {
+		Name    string
+		Request *web.Request
+		Values  url.Values
+	}{
+		{
+			Name:    "url encoded",
+			Request: web.CreateRequest(urlEncodedRequest, nil),
+			Values: url.Values{
+				"name":              []string{" Name "},
+				"address.street":    []string{"Main Street"},
+				"address.city":      []string{"BERLIN"},
+				"items[0].sku":      []string{"A1"},
+				"items[0].quantity": []string{"2"},
+				"items[1].sku":      []string{"B2"},
+				"items[1].quantity": []string{"1"},
+				"tags":              []string{"first", "second"},
+				"attributes[color]": []string{"red"},
+			},
+		},
+		{
+			Name:    "json",
+			Request: t.createJSONRequest(formDataDecoderNestedTestJSON),
+			Values:  url.Values{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		result, err := t.decoder.Decode(nil, testCase.Request, testCase.Values, formDataDecoderNestedTestData{})
+
+		t.NoError(err, testCase.Name)
+		t.Equal(formDataDecoderNestedTestData{
+			Name: "Name",
+			Address: formDataDecoderAddressTestData{
+				Street: "Main Street",
+				City:   "berlin",
+			},
+			Items: []formDataDecoderItemTestData{
+				{
+					Sku:      "A1",
+					Quantity: 2,
+				},
+				{
+					Sku:      "B2",
+					Quantity: 1,
+				},
+			},
+			Tags: []string{"first", "second"},
+			Attributes: map[string]string{
+				"color": "red",
+			},
+		}, result, testCase.Name)
+	}
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestGetJSONValues() {
+	values, err := t.decoder.getJSONValues(t.createJSONRequest(formDataDecoderNestedTestJSON), formDataDecoderNestedTestData{}, "")
+
+	t.NoError(err)
+	t.Equal(url.Values{
+		"name":              []string{" Name "},
+		"address.street":    []string{"Main Street"},
+		"address.city":      []string{"BERLIN"},
+		"items[0].sku":      []string{"A1"},
+		"items[0].quantity": []string{"2"},
+		"items[1].sku":      []string{"B2"},
+		"items[1].quantity": []string{"1"},
+		"tags":              []string{"first", "second"},
+		"attributes[color]": []string{"red"},
+	}, values)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_JSONEmptyBody() {
+	formData := formDataDecoderTestData{
+		Text: "some text",
+	}
+
+	// empty JSON body is decoded same as url encoded body without any value
+	result, err := t.decoder.Decode(nil, t.createJSONRequest(" "), url.Values{}, formData)
+
+	t.NoError(err)
+	t.Equal(formDataDecoderTestData{}, result)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_JSONNamespace() {
+	body := `{
+		"shop": {"name": "shop", "address": {"street": "Main Street"}, "items": [{"sku": "a"}], "attributes": {"color": "red"}},
+		"name": "other"
+	}`
+
+	values, err := GetNamespacedJSONValues(t.createJSONRequest(body), formDataDecoderNestedTestData{}, "shop", DefaultMaxBodySize)
+
+	t.NoError(err)
+	t.Equal(url.Values{
+		"shop.name":              []string{"shop"},
+		"shop.address.street":    []string{"Main Street"},
+		"shop.items[0].sku":      []string{"a"},
+		"shop.attributes[color]": []string{"red"},
+	}, values)
+
+	result, err := t.decoder.Decode(domain.ContextWithNamespace(context.Background(), "shop"), t.createJSONRequest(body), url.Values{}, formDataDecoderNestedTestData{})
+
+	t.NoError(err)
+	t.Equal(formDataDecoderNestedTestData{
+		Name: "shop",
+		Address: formDataDecoderAddressTestData{
+			Street: "Main Street",
+		},
+		Items: []formDataDecoderItemTestData{
+			{Sku: "a"},
+		},
+		Attributes: map[string]string{
+			"color": "red",
+		},
+	}, result)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_JSONMalformedBody() {
+	result, err := t.decoder.Decode(nil, t.createJSONRequest(`{"text": `), url.Values{}, formDataDecoderTestData{})
+
+	t.Nil(result)
+	t.IsType(&domain.DecodeError{}, err)
+	t.Equal("formError.invalidJSON", err.(*domain.DecodeError).ValidationInfo.GetGeneralErrors()[0].MessageKey)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_JSONNotObject() {
+	result, err := t.decoder.Decode(nil, t.createJSONRequest(`["text"]`), url.Values{}, formDataDecoderTestData{})
+
+	t.Nil(result)
+	t.IsType(&domain.DecodeError{}, err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_Multipart() {
+	req := t.createMultipartRequest(map[string]string{
+		"text": "some text",
+	}, map[string][]string{
+		"avatar":        {"avatar.png"},
+		"attachments":   {"first.pdf", "second.pdf"},
+		"document.file": {"document.pdf"},
+	}, []byte("content"))
+
+	result, err := t.decoder.Decode(nil, req, url.Values{}, formDataDecoderFileTestData{})
+
+	t.NoError(err)
+	t.IsType(formDataDecoderFileTestData{}, result)
+
+	formData := result.(formDataDecoderFileTestData)
+	t.Equal("some text", formData.Text)
+	t.Equal("avatar.png", formData.Avatar.Filename)
+	t.Len(formData.Attachments, 2)
+	t.Equal("first.pdf", formData.Attachments[0].Filename)
+	t.Equal("second.pdf", formData.Attachments[1].Filename)
+	t.Equal("document.pdf", formData.Document.File.Filename)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_MultipartNamespace() {
+	req := t.createMultipartRequest(map[string]string{
+		"login.text": "some text",
+		"text":       "other text",
+	}, map[string][]string{
+		"login.avatar":       {"avatar.png"},
+		"login[attachments]": {"first.pdf"},
+		"avatar":             {"other.png"},
+		"document.file":      {"document.pdf"},
+	}, []byte("content"))
+
+	result, err := t.decoder.Decode(domain.ContextWithNamespace(context.Background(), "login"), req, url.Values{}, formDataDecoderFileTestData{})
+
+	t.NoError(err)
+	t.IsType(formDataDecoderFileTestData{}, result)
+
+	formData := result.(formDataDecoderFileTestData)
+	t.Equal("some text", formData.Text)
+	t.Equal("avatar.png", formData.Avatar.Filename)
+	t.Len(formData.Attachments, 1)
+	t.Equal("first.pdf", formData.Attachments[0].Filename)
+	t.Nil(formData.Document.File)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_MultipartFileTooLarge() {
+	decoder := &DefaultFormDataDecoderImpl{}
+	decoder.Inject(&formDataDecoderTestConfig{
+		MaxMemory:   1024,
+		MaxFileSize: 4,
+	}, nil, nil, nil)
+
+	req := t.createMultipartRequest(nil, map[string][]string{
+		"avatar": {"avatar.png"},
+	}, []byte("content"))
+
+	result, err := decoder.Decode(nil, req, url.Values{}, formDataDecoderFileTestData{})
+
+	t.Equal(formDataDecoderFileTestData{}, result)
+	t.IsType(&domain.DecodeError{}, err)
+
+	validationInfo := err.(*domain.DecodeError).ValidationInfo
+	t.Equal([]domain.Error{
+		{
+			MessageKey:   "formError.fileTooLarge",
+			DefaultLabel: "file avatar.png is too large",
+		},
+	}, validationInfo.GetErrorsForField("avatar"))
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_MultipartMalformedBody() {
+	httpRequest, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader("malformed"))
+	httpRequest.Header.Set("Content-Type", "multipart/form-data; boundary=boundary")
+
+	result, err := t.decoder.Decode(nil, web.CreateRequest(httpRequest, nil), url.Values{}, formDataDecoderFileTestData{})
+
+	t.Nil(result)
+	t.IsType(&domain.DecodeError{}, err)
+	t.Equal("formError.invalidMultipart", err.(*domain.DecodeError).ValidationInfo.GetGeneralErrors()[0].MessageKey)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestNormalizeName() {
+	typeOf := reflect.TypeOf(formDataDecoderNestedTestData{})
+
+	testCases := []struct {
+		Name   string
+		Result string
+	}{
+		{
+			Name:   "name",
+			Result: "name",
+		},
+		{
+			Name:   "address.street",
+			Result: "address.street",
+		},
+		{
+			Name:   "address[street]",
+			Result: "address.street",
+		},
+		{
+			Name:   "items[0].sku",
+			Result: "items[0].sku",
+		},
+		{
+			Name:   "items.0.sku",
+			Result: "items[0].sku",
+		},
+		{
+			Name:   "items[0][sku]",
+			Result: "items[0].sku",
+		},
+		{
+			Name:   "items.1[quantity]",
+			Result: "items[1].quantity",
+		},
+		{
+			Name:   "tags.2",
+			Result: "tags[2]",
+		},
+		{
+			Name:   "attributes[color]",
+			Result: "attributes[color]",
+		},
+		{
+			Name:   "attributes.color",
+			Result: "attributes[color]",
+		},
+		{
+			Name:   "attributes[color.dark]",
+			Result: "attributes[color.dark]",
+		},
+		{
+			// map keys with dots can't be posted in dot notation
+			Name:   "attributes.color.dark",
+			Result: "attributes[color].dark",
+		},
+		{
+			Name:   "unknown[first].second",
+			Result: "unknown[first].second",
+		},
+		{
+			Name:   "items[0",
+			Result: "items[0",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Equal(testCase.Result, t.decoder.normalizeName(testCase.Name, typeOf), testCase.Name)
+	}
+
+	t.Equal("address[street]", t.decoder.normalizeName("address[street]", nil))
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestNormalizeValues() {
+	t.Equal(url.Values{
+		"address.street": []string{"Main Street", "Second Street"},
+		"items[0].sku":   []string{"A1"},
+	}, NormalizeValues(url.Values{
+		"address[street]": []string{"Second Street"},
+		"address.street":  []string{"Main Street"},
+		"items.0.sku":     []string{"A1"},
+	}, &formDataDecoderNestedTestData{}))
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_Notations() {
+	expected := formDataDecoderNestedTestData{
+		Name: "Name",
+		Address: formDataDecoderAddressTestData{
+			Street: "Main Street",
+			City:   "berlin",
+		},
+		Items: []formDataDecoderItemTestData{
+			{
+				Sku:      "A1",
+				Quantity: 2,
+			},
+		},
+		Tags: []string{"first", "second"},
+		Attributes: map[string]string{
+			"color.dark": "red",
+		},
+	}
+
+	testCases := []url.Values{
+		{
+			"name":                   []string{"Name"},
+			"address.street":         []string{"Main Street"},
+			"address.city":           []string{"Berlin"},
+			"items[0].sku":           []string{"A1"},
+			"items[0].quantity":      []string{"2"},
+			"tags[0]":                []string{"first"},
+			"tags[1]":                []string{"second"},
+			"attributes[color.dark]": []string{"red"},
+		},
+		{
+			"name":                   []string{"Name"},
+			"address[street]":        []string{"Main Street"},
+			"address[city]":          []string{"Berlin"},
+			"items[0][sku]":          []string{"A1"},
+			"items[0][quantity]":     []string{"2"},
+			"tags[0]":                []string{"first"},
+			"tags[1]":                []string{"second"},
+			"attributes[color.dark]": []string{"red"},
+		},
+		{
+			"name":                   []string{"Name"},
+			"address.street":         []string{"Main Street"},
+			"address[city]":          []string{"Berlin"},
+			"items.0.sku":            []string{"A1"},
+			"items.0[quantity]":      []string{"2"},
+			"tags.0":                 []string{"first"},
+			"tags.1":                 []string{"second"},
+			"attributes[color.dark]": []string{"red"},
+		},
+	}
+
+	for _, values := range testCases {
+		result, err := t.decoder.decodeUnknownInterface(values, formDataDecoderNestedTestData{})
+		t.NoError(err)
+		t.Equal(expected, result)
+	}
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_NotationErrors() {
+	_, err := t.decoder.decodeUnknownInterface(url.Values{
+		"items.0[quantity]": []string{"two"},
+	}, formDataDecoderNestedTestData{})
+
+	// field errors are named in dot notation, regardless of posted notation
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldErrorWithParams("items[0].quantity", "formError.invalidValue", "invalid value", map[string]string{
+		"value": "two",
+	})
+	t.Equal(domain.NewDecodeError(validationInfo), err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_BodyTooLarge() {
+	decoder := t.createLimitedDecoder(1024, 0, 0)
+
+	values := url.Values{
+		"text": []string{strings.Repeat("a", 2048)},
+	}
+	httpRequest, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(values.Encode()))
+	httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	result, err := decoder.Decode(nil, web.CreateRequest(httpRequest, nil), values, formDataDecoderTestData{})
+
+	t.Nil(result)
+	t.Equal(t.createLimitError("formError.requestTooLarge", "request body is too large", nil), err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_JSONBodyTooLarge() {
+	decoder := t.createLimitedDecoder(1024, 0, 0)
+
+	// body without declared length is read only up to maximum body size
+	httpRequest, _ := http.NewRequest(http.MethodPost, "/", &formDataDecoderEndlessReader{})
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.ContentLength = -1
+
+	result, err := decoder.Decode(nil, web.CreateRequest(httpRequest, nil), url.Values{}, formDataDecoderTestData{})
+
+	t.Nil(result)
+	t.Equal(t.createLimitError("formError.requestTooLarge", "request body is too large", nil), err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestGetJSONValues_BodyRestored() {
+	decoder := t.createLimitedDecoder(4, 0, 0)
+	req := t.createJSONRequest(`{"text": "some text"}`)
+
+	_, err := decoder.getJSONValues(req, formDataDecoderTestData{}, "")
+	t.Equal(ErrRequestTooLarge, err)
+
+	values, err := t.decoder.getJSONValues(req, formDataDecoderTestData{}, "")
+	t.NoError(err)
+	t.Equal(url.Values{
+		"text": []string{"some text"},
+	}, values)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestParseRequestForm() {
+	// body without declared length is not read beyond maximum body size
+	httpRequest, _ := http.NewRequest(http.MethodPost, "/", &formDataDecoderEndlessReader{})
+	httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpRequest.ContentLength = -1
+	t.Equal(ErrRequestTooLarge, ParseRequestForm(web.CreateRequest(httpRequest, nil), 1024, 0))
+
+	// configured maximum body size replaces default limit of net/http
+	body := "text=" + strings.Repeat("a", DefaultMaxBodySize)
+	httpRequest, _ = http.NewRequest(http.MethodPost, "/?number=1", strings.NewReader(body))
+	httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpRequest.ContentLength = -1
+	req := web.CreateRequest(httpRequest, nil)
+	t.NoError(ParseRequestForm(req, 2*DefaultMaxBodySize, 2))
+	t.Len(req.Request().Form.Get("text"), DefaultMaxBodySize)
+	t.Equal("1", req.Request().Form.Get("number"))
+
+	httpRequest, _ = http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	t.Equal(ErrRequestTooLarge, ParseRequestForm(web.CreateRequest(httpRequest, nil), 0, 0))
+
+	httpRequest, _ = http.NewRequest(http.MethodPost, "/?number=1", strings.NewReader("text=some+text&slice=1.5"))
+	httpRequest.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	t.Equal(ErrTooManyFields, ParseRequestForm(web.CreateRequest(httpRequest, nil), 1024, 2))
+
+	// multipart body is limited by maximum memory and maximum file size instead
+	req = t.createMultipartRequest(map[string]string{"text": strings.Repeat("a", 2048)}, nil, nil)
+	t.NoError(ParseRequestForm(req, 1024, 0))
+	t.NoError(req.Request().ParseMultipartForm(1 << 20))
+	t.Len(req.Request().MultipartForm.Value["text"][0], 2048)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestGetLimitedJSONValues() {
+	req := t.createJSONRequest(`{"text": "some text"}`)
+
+	_, err := GetLimitedJSONValues(req, formDataDecoderTestData{}, 4)
+	t.Equal(ErrRequestTooLarge, err)
+
+	// JSON body which is already limited while it's read
+	httpRequest, _ := http.NewRequest(http.MethodPost, "/", &formDataDecoderEndlessReader{})
+	httpRequest.Header.Set("Content-Type", "application/json")
+	httpRequest.ContentLength = -1
+	req = web.CreateRequest(httpRequest, nil)
+	t.NoError(ParseRequestForm(req, 1024, 0))
+
+	_, err = GetLimitedJSONValues(req, formDataDecoderTestData{}, 2048)
+	t.Equal(ErrRequestTooLarge, err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_TooManyFields() {
+	decoder := t.createLimitedDecoder(0, 2, 0)
+
+	result, err := decoder.Decode(nil, nil, url.Values{
+		"text":     []string{"some text"},
+		"number":   []string{"1"},
+		"slice[0]": []string{"1.5"},
+	}, formDataDecoderTestData{})
+
+	t.Nil(result)
+	t.Equal(t.createLimitError("formError.tooManyFields", "too many fields are submitted", map[string]string{
+		"max": "2",
+	}), err)
+
+	result, err = decoder.Decode(nil, nil, url.Values{
+		"text":   []string{"some text"},
+		"number": []string{"1"},
+	}, map[string]string{})
+
+	t.NoError(err)
+	t.Equal(map[string]string{
+		"text":   "some text",
+		"number": "1",
+	}, result)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_IndexTooLarge() {
+	decoder := t.createLimitedDecoder(0, 0, 100)
+
+	result, err := decoder.Decode(nil, nil, url.Values{
+		"items[0].sku":      []string{"A1"},
+		"items[999999].sku": []string{"B2"},
+	}, formDataDecoderCatalogTestData{})
+
+	t.Nil(result)
+	t.Equal(t.createLimitError("formError.indexTooLarge", "index of field items[999999].sku is too large", map[string]string{
+		"field": "items[999999].sku",
+		"max":   "99",
+	}), err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestHasTooLargeIndex() {
+	decoder := t.createLimitedDecoder(0, 0, 100)
+	typeOf := reflect.TypeOf(formDataDecoderCatalogTestData{})
+
+	testCases := []struct {
+		Namespace string
+		Result    bool
+	}{
+		{
+			Namespace: "items[99].sku",
+			Result:    false,
+		},
+		{
+			Namespace: "items[100].sku",
+			Result:    true,
+		},
+		{
+			Namespace: "items[99999999999999999999999].sku",
+			Result:    true,
+		},
+		{
+			Namespace: "prices[123456]",
+			Result:    false,
+		},
+		{
+			Namespace: "sizes[123456]",
+			Result:    false,
+		},
+		{
+			Namespace: "matrix[1][1000]",
+			Result:    true,
+		},
+		{
+			Namespace: "reference.items[1000].sku",
+			Result:    true,
+		},
+		{
+			Namespace: "reference.addresses[1000].street",
+			Result:    false,
+		},
+		{
+			Namespace: "unknown[1000]",
+			Result:    false,
+		},
+		{
+			Namespace: "items[1000",
+			Result:    false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Equal(testCase.Result, decoder.hasTooLargeIndex(testCase.Namespace, typeOf), testCase.Namespace)
+	}
+
+	// check is disabled without maximum array size
+	_, ok := t.decoder.findTooLargeIndex(url.Values{
+		"items[100].sku": []string{"A1"},
+	}, typeOf)
+	t.False(ok)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestGetSourceFields() {
+	t.Equal([]sourceField{
+		{
+			name:   "id",
+			source: "path",
+			key:    "id",
+		},
+		{
+			name:   "locale",
+			source: "header",
+			key:    "Accept-Language",
+		},
+		{
+			name:   "account.owner",
+			source: "path",
+			key:    "owner",
+		},
+		{
+			name:   "account.token",
+			source: "header",
+			key:    "X-Token",
+		},
+	}, t.decoder.getSourceFields(reflect.TypeOf(&formDataDecoderProfileTestData{}), "", map[reflect.Type]bool{}))
+
+	t.Empty(t.decoder.getSourceFields(reflect.TypeOf(formDataDecoderTestData{}), "", map[reflect.Type]bool{}))
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestApplySourceValues() {
+	req := web.CreateRequest(&http.Request{
+		Header: http.Header{
+			"Accept-Language": []string{"de-DE"},
+		},
+	}, nil)
+	req.Params = web.RequestParams{
+		"id":    "42",
+		"owner": "jane",
+	}
+
+	// submitted values of source fields are ignored, in both dot and bracket notation
+	values, err := t.decoder.applySourceValues(req, url.Values{
+		"id":             []string{"666"},
+		"locale":         []string{"en-US"},
+		"name":           []string{"John"},
+		"account[owner]": []string{"evil"},
+		"account.token":  []string{"secret"},
+		"account[email]": []string{"john@example.com"},
+	}, formDataDecoderProfileTestData{})
+
+	t.NoError(err)
+	t.Equal(url.Values{
+		"id":            []string{"42"},
+		"locale":        []string{"de-DE"},
+		"name":          []string{"John"},
+		"account.owner": []string{"jane"},
+		"account.email": []string{"john@example.com"},
+	}, values)
+
+	values, err = t.decoder.applySourceValues(nil, url.Values{
+		"id":   []string{"666"},
+		"name": []string{"John"},
+	}, formDataDecoderProfileTestData{})
+
+	t.NoError(err)
+	t.Equal(url.Values{
+		"name": []string{"John"},
+	}, values)
+
+	submitted := url.Values{
+		"text": []string{"some text"},
+	}
+	values, err = t.decoder.applySourceValues(req, submitted, formDataDecoderTestData{})
+
+	t.NoError(err)
+	t.Equal(submitted, values)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_SourceValues() {
+	req := web.CreateRequest(&http.Request{
+		Header: http.Header{
+			"Accept-Language": []string{"de-DE"},
+		},
+	}, nil)
+	req.Params = web.RequestParams{
+		"id":    "42",
+		"owner": "jane",
+	}
+
+	result, err := t.decoder.Decode(nil, req, url.Values{
+		"id":             []string{"666"},
+		"name":           []string{"John"},
+		"account[owner]": []string{"evil"},
+	}, formDataDecoderProfileTestData{})
+
+	t.NoError(err)
+	t.Equal(formDataDecoderProfileTestData{
+		ID:     42,
+		Locale: "de-DE",
+		Name:   "John",
+		Account: formDataDecoderProfileAccountTestData{
+			Owner: "jane",
+		},
+	}, result)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_UnknownSource() {
+	result, err := t.decoder.Decode(nil, web.CreateRequest(nil, nil), url.Values{
+		"token": []string{"secret"},
+	}, formDataDecoderUnknownSourceTestData{})
+
+	t.Nil(result)
+	t.EqualError(err, `FormError: field token has unknown source "cookie", only "path" and "header" are supported`)
+	t.Equal(err, t.decoder.getFormMetadata(reflect.TypeOf(formDataDecoderUnknownSourceTestData{})).sourceError)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_JSONEmptyBodySourceValues() {
+	req := t.createJSONRequest(" ")
+	req.Params = web.RequestParams{
+		"id":    "42",
+		"owner": "jane",
+	}
+
+	result, err := t.decoder.Decode(nil, req, url.Values{}, formDataDecoderProfileTestData{})
+
+	t.NoError(err)
+	t.Equal(formDataDecoderProfileTestData{
+		ID: 42,
+		Account: formDataDecoderProfileAccountTestData{
+			Owner: "jane",
+		},
+	}, result)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_InvalidSourceValue() {
+	req := web.CreateRequest(nil, nil)
+	req.Params = web.RequestParams{
+		"id": "abc",
+	}
+
+	_, err := t.decoder.Decode(nil, req, url.Values{
+		"id": []string{"1"},
+	}, formDataDecoderProfileTestData{})
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldErrorWithParams("id", "formError.invalidValue", "invalid value", map[string]string{
+		"value": "abc",
+	})
+	t.Equal(domain.NewDecodeError(validationInfo), err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_Embedded() {
+	result, err := t.decoder.decodeUnknownInterface(url.Values{
+		"email":  []string{"mail@example.com"},
+		"street": []string{"Main Street"},
+		"city":   []string{"BERLIN"},
+		"phone":  []string{"123"},
+	}, formDataDecoderCheckoutTestData{})
+
+	t.NoError(err)
+	t.Equal(formDataDecoderCheckoutTestData{
+		Email: "mail@example.com",
+		formDataDecoderAddressTestData: formDataDecoderAddressTestData{
+			Street: "Main Street",
+			City:   "berlin",
+		},
+		FormDataDecoderContactTestData: &FormDataDecoderContactTestData{
+			Phone: "123",
+		},
+	}, result)
+
+	// pointer to embedded struct stays nil, if none of its fields is posted
+	result, err = t.decoder.decodeUnknownInterface(url.Values{
+		"email": []string{"mail@example.com"},
+	}, formDataDecoderCheckoutTestData{})
+
+	t.NoError(err)
+	t.Equal(formDataDecoderCheckoutTestData{
+		Email: "mail@example.com",
+	}, result)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_UnexportedEmbedPointer() {
+	_, err := t.decoder.decodeUnknownInterface(url.Values{
+		"street": []string{"Main Street"},
+	}, formDataDecoderUnexportedEmbedTestData{})
+	t.EqualError(err, "FormError: embedded field formDataDecoderUnexportedEmbedTestData.formDataDecoderAddressTestData is pointer to unexported type, so it can't be allocated during decoding")
+
+	// error doesn't depend on posted fields
+	_, err = t.decoder.decodeUnknownInterface(url.Values{}, &formDataDecoderNestedUnexportedEmbedTestData{})
+	t.Error(err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestFindUnexportedEmbedPointer() {
+	testCases := []struct {
+		TypeOf reflect.Type
+		Name   string
+		Found  bool
+	}{
+		{
+			TypeOf: reflect.TypeOf(formDataDecoderCheckoutTestData{}),
+		},
+		{
+			TypeOf: reflect.TypeOf(formDataDecoderCatalogTestData{}),
+		},
+		{
+			TypeOf: reflect.TypeOf(&formDataDecoderUnexportedEmbedTestData{}),
+			Name:   "formDataDecoderUnexportedEmbedTestData.formDataDecoderAddressTestData",
+			Found:  true,
+		},
+		{
+			TypeOf: reflect.TypeOf(formDataDecoderNestedUnexportedEmbedTestData{}),
+			Name:   "formDataDecoderUnexportedEmbedTestData.formDataDecoderAddressTestData",
+			Found:  true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		name, found := t.decoder.findUnexportedEmbedPointer(testCase.TypeOf, map[reflect.Type]bool{})
+		t.Equal(testCase.Name, name, testCase.TypeOf.String())
+		t.Equal(testCase.Found, found, testCase.TypeOf.String())
+	}
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_MultipartEmbedded() {
+	req := t.createMultipartRequest(map[string]string{
+		"email": "mail@example.com",
+	}, map[string][]string{
+		"avatar": {"avatar.png"},
+	}, []byte("content"))
+
+	result, err := t.decoder.Decode(nil, req, url.Values{}, formDataDecoderCheckoutTestData{})
+
+	t.NoError(err)
+	formData := result.(formDataDecoderCheckoutTestData)
+	t.NotNil(formData.FormDataDecoderContactTestData)
+	t.Equal("avatar.png", formData.Avatar.Filename)
+
+	// files of other fields don't allocate pointer to embedded struct
+	req = t.createMultipartRequest(map[string]string{
+		"email": "mail@example.com",
+	}, map[string][]string{
+		"document": {"document.pdf"},
+	}, []byte("content"))
+
+	result, err = t.decoder.Decode(nil, req, url.Values{}, formDataDecoderCheckoutTestData{})
+
+	t.NoError(err)
+	t.Nil(result.(formDataDecoderCheckoutTestData).FormDataDecoderContactTestData)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) createLimitedDecoder(maxBodySize float64, maxFormKeys float64, maxArraySize float64) *DefaultFormDataDecoderImpl {
+	decoder := &DefaultFormDataDecoderImpl{}
+	decoder.Inject(&formDataDecoderTestConfig{
+		MaxBodySize:  maxBodySize,
+		MaxFormKeys:  maxFormKeys,
+		MaxArraySize: maxArraySize,
+	}, nil, nil, &flamingo.NullLogger{})
+
+	return decoder
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) createLimitError(messageKey string, defaultLabel string, params map[string]string) error {
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddGeneralErrorWithParams(messageKey, defaultLabel, params)
+
+	return domain.NewDecodeError(validationInfo)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) createMultipartRequest(values map[string]string, files map[string][]string, content []byte) *web.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for name, value := range values {
+		t.NoError(writer.WriteField(name, value))
+	}
+
+	for name, filenames := range files {
+		for _, filename := range filenames {
+			part, err := writer.CreateFormFile(name, filename)
+			t.NoError(err)
+			_, err = part.Write(content)
+			t.NoError(err)
+		}
+	}
+
+	t.NoError(writer.Close())
+
+	httpRequest, _ := http.NewRequest(http.MethodPost, "/", body)
+	httpRequest.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return web.CreateRequest(httpRequest, nil)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) createJSONRequest(body string) *web.Request {
+	httpRequest, _ := http.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	httpRequest.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	return web.CreateRequest(httpRequest, nil)
+}
@@ -0,0 +1,398 @@
+package formdata
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	// localeFormat contains formats of numbers and dates which are used by users of single locale
+	localeFormat struct {
+		decimalSeparator   string
+		thousandsSeparator string
+		dateFormats        []string
+		// location in which dates without time zone are parsed, UTC by default
+		location *time.Location
+	}
+)
+
+const (
+	// parseTag as name of tag which defines how submitted value of field is parsed before decoding
+	parseTag = "parse"
+	// parseLocalized as value of "parse" tag, which converts localized numbers and dates into canonical form before decoding
+	parseLocalized = "localized"
+	// localeSourceHeader as source of request locale, which takes locale from http request header (like "Accept-Language")
+	localeSourceHeader = "header"
+	// localeSourceSession as source of request locale, which takes locale from session value
+	localeSourceSession = "session"
+	// localeSourceParam as source of request locale, which takes locale from route param, or from query param as fallback
+	localeSourceParam = "param"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// parseLocaleFormats converts configured formats (like "de-DE": {decimalSeparator: ",", thousandsSeparator: ".",
+// dateFormats: ["02.01.2006"], timeZone: "Europe/Berlin"}) into locale formats keyed by normalized locale.
+// It returns names of all invalid entries, including ones with unknown time zone.
+func parseLocaleFormats(formats map[string]interface{}) (map[string]localeFormat, []string) {
+	var invalid []string
+	result := make(map[string]localeFormat, len(formats))
+
+	for locale, value := range formats {
+		entry, ok := toConfigMap(value)
+		if !ok {
+			invalid = append(invalid, locale)
+			continue
+		}
+
+		format := localeFormat{}
+		format.decimalSeparator, _ = entry["decimalSeparator"].(string)
+		format.thousandsSeparator, _ = entry["thousandsSeparator"].(string)
+		if format.decimalSeparator == "" {
+			format.decimalSeparator = "."
+		}
+
+		dateFormats, ok := toStringSlice(entry["dateFormats"])
+		if !ok || format.decimalSeparator == format.thousandsSeparator {
+			invalid = append(invalid, locale)
+			continue
+		}
+		format.dateFormats = dateFormats
+
+		format.location = time.UTC
+		if timeZone, _ := entry["timeZone"].(string); timeZone != "" {
+			location, err := time.LoadLocation(timeZone)
+			if err != nil {
+				invalid = append(invalid, locale)
+				continue
+			}
+			format.location = location
+		}
+
+		result[normalizeLocale(locale)] = format
+	}
+
+	sort.Strings(invalid)
+
+	return result, invalid
+}
+
+// toConfigMap converts configured value into map, since nested maps can be provided either as config.Map or as plain map
+func toConfigMap(value interface{}) (map[string]interface{}, bool) {
+	switch converted := value.(type) {
+	case config.Map:
+		return converted, true
+	case map[string]interface{}:
+		return converted, true
+	}
+
+	return nil, false
+}
+
+// toStringSlice converts configured list of strings into slice of strings. Missing list is presented as empty slice.
+func toStringSlice(value interface{}) ([]string, bool) {
+	var list []interface{}
+
+	switch converted := value.(type) {
+	case nil:
+		return nil, true
+	case []string:
+		return converted, true
+	case config.Slice:
+		list = converted
+	case []interface{}:
+		list = converted
+	default:
+		return nil, false
+	}
+
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		text, ok := item.(string)
+		if !ok || text == "" {
+			return nil, false
+		}
+		result = append(result, text)
+	}
+
+	return result, true
+}
+
+// normalizeLocale transforms locale into lower case with dash as separator (like "de_DE" into "de-de"),
+// so configured locales are matched regardless of their notation
+func normalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// getLocalizedFields returns types of all fields tagged with `parse:"localized"`, keyed by their form names without
+// slice indices and map keys (like "items.price" for "items[0].price"), including fields of nested structs and
+// structs inside slices. Only fields of numeric types and time.Time (or slices and pointers of them) are returned.
+func (p *DefaultFormDataDecoderImpl) getLocalizedFields(typeOf reflect.Type, namespace string, visited map[reflect.Type]bool, fields map[string]reflect.Type) {
+	typeOf = p.indirectType(typeOf)
+	if typeOf != nil && (typeOf.Kind() == reflect.Slice || typeOf.Kind() == reflect.Array) {
+		typeOf = p.indirectType(typeOf.Elem())
+	}
+	if typeOf == nil || typeOf.Kind() != reflect.Struct || typeOf == timeType || visited[typeOf] {
+		return
+	}
+
+	// recursive types are visited only once per branch
+	visited[typeOf] = true
+	defer delete(visited, typeOf)
+
+	for _, field := range p.getStructMetadata(typeOf).fields {
+		if field.ignored || (field.field.PkgPath != "" && !field.field.Anonymous) {
+			continue
+		}
+
+		fieldNamespace := p.getFieldNamespace(namespace, field)
+		if field.field.Tag.Get(parseTag) != parseLocalized {
+			p.getLocalizedFields(field.field.Type, fieldNamespace, visited, fields)
+			continue
+		}
+
+		if valueType, ok := p.getLocalizedValueType(field.field.Type); ok {
+			fields[fieldNamespace] = valueType
+		}
+	}
+}
+
+// getLocalizedValueType returns type of single submitted value of localized field, which is either number or time.Time
+func (p *DefaultFormDataDecoderImpl) getLocalizedValueType(typeOf reflect.Type) (reflect.Type, bool) {
+	typeOf = p.indirectType(typeOf)
+	switch typeOf.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		typeOf = p.indirectType(typeOf.Elem())
+	}
+
+	switch typeOf.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return typeOf, true
+	}
+
+	return typeOf, typeOf == timeType
+}
+
+// applyLocalizedValues converts submitted values of fields tagged with `parse:"localized"` from format of request
+// locale (like "1.234,56" and "31.12.2024" for "de-DE") into canonical form (like "1234.56" and "2024-12-31T00:00:00Z"),
+// so they are decoded in the same way as any other value. Values are not changed if request locale is not configured.
+// Values which can't be parsed are reported as field errors with submitted raw value and locale as parameters,
+// and they are decoded as empty values instead.
+func (p *DefaultFormDataDecoderImpl) applyLocalizedValues(req *web.Request, values url.Values, formData interface{}) (url.Values, domain.ValidationInfo) {
+	validationInfo := domain.ValidationInfo{}
+
+	typeOf := reflect.TypeOf(formData)
+	fields := p.getFormMetadata(typeOf).localizedFields
+	if len(fields) == 0 {
+		return values, validationInfo
+	}
+
+	locale := p.getLocale(req)
+	format, ok := p.findLocaleFormat(locale)
+	if !ok {
+		return values, validationInfo
+	}
+
+	// names are normalized and sorted, so field errors are always added in the same order
+	values = p.normalizeValues(values, typeOf)
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		valueType, ok := fields[p.stripIndices(name)]
+		if !ok {
+			continue
+		}
+
+		converted := make([]string, len(values[name]))
+		for i, raw := range values[name] {
+			value, err := p.canonicalizeValue(raw, valueType, format)
+			if err == nil {
+				converted[i] = value
+				continue
+			}
+
+			namespace := name
+			if len(values[name]) > 1 {
+				namespace = fmt.Sprintf("%s[%d]", name, i)
+			}
+			validationInfo.AddFieldErrorWithParams(namespace, "formError.invalidValue", "invalid value", map[string]string{
+				"value":  raw,
+				"locale": locale,
+			})
+		}
+		values[name] = converted
+	}
+
+	return values, validationInfo
+}
+
+// stripIndices removes slice indices and map keys from field name (like "items[0].price" into "items.price")
+func (p *DefaultFormDataDecoderImpl) stripIndices(name string) string {
+	var result strings.Builder
+
+	for {
+		start := strings.Index(name, "[")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(name[start:], "]")
+		if end < 0 {
+			break
+		}
+
+		result.WriteString(name[:start])
+		name = name[start+end+1:]
+	}
+	result.WriteString(name)
+
+	return result.String()
+}
+
+// getLocale returns locale of http request from configured source. For http request headers, only the first
+// language is used, without its quality (like "de-DE" for "de-DE,de;q=0.9,en;q=0.8").
+func (p *DefaultFormDataDecoderImpl) getLocale(req *web.Request) string {
+	if req == nil {
+		return ""
+	}
+
+	switch p.localeSource {
+	case localeSourceHeader:
+		value := req.Request().Header.Get(p.localeKey)
+		return strings.TrimSpace(strings.Split(strings.Split(value, ",")[0], ";")[0])
+	case localeSourceSession:
+		if req.Session() == nil {
+			return ""
+		}
+		value, _ := req.Session().Try(p.localeKey).(string)
+		return value
+	case localeSourceParam:
+		if value, ok := req.Params[p.localeKey]; ok {
+			return value
+		}
+		if req.Request().URL == nil {
+			return ""
+		}
+		return req.Request().URL.Query().Get(p.localeKey)
+	}
+
+	return ""
+}
+
+// findLocaleFormat returns configured format of locale, with format of its language (like "de" for "de-AT") as fallback
+func (p *DefaultFormDataDecoderImpl) findLocaleFormat(locale string) (localeFormat, bool) {
+	locale = normalizeLocale(locale)
+	if locale == "" {
+		return localeFormat{}, false
+	}
+
+	if format, ok := p.localeFormats[locale]; ok {
+		return format, true
+	}
+
+	format, ok := p.localeFormats[strings.Split(locale, "-")[0]]
+
+	return format, ok
+}
+
+// canonicalizeValue converts localized value into canonical form of its type. Empty value stays empty.
+func (p *DefaultFormDataDecoderImpl) canonicalizeValue(value string, typeOf reflect.Type, format localeFormat) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+
+	if typeOf == timeType {
+		return p.canonicalizeDate(value, format)
+	}
+
+	return p.canonicalizeNumber(value, typeOf, format)
+}
+
+// canonicalizeNumber converts localized number (like "1.234,56") into number with dot as decimal separator
+// and without thousands separators (like "1234.56"). Thousands separators are accepted only between groups of
+// three digits, so numbers in format of other locale (like "1,234.56" for "de-DE") are rejected instead of
+// being silently misread. Result is checked against numeric type, so decimals are rejected for integers.
+func (p *DefaultFormDataDecoderImpl) canonicalizeNumber(value string, typeOf reflect.Type, format localeFormat) (string, error) {
+	integer, fraction, hasFraction := strings.Cut(value, format.decimalSeparator)
+	if hasFraction && (fraction == "" || strings.Contains(fraction, format.decimalSeparator) ||
+		(format.thousandsSeparator != "" && strings.Contains(fraction, format.thousandsSeparator))) {
+		return "", fmt.Errorf("invalid number %q", value)
+	}
+
+	if format.thousandsSeparator != "" && strings.Contains(integer, format.thousandsSeparator) {
+		groups := strings.Split(integer, format.thousandsSeparator)
+		first := strings.TrimLeft(groups[0], "+-")
+		if first == "" || len(first) > 3 {
+			return "", fmt.Errorf("invalid number %q", value)
+		}
+		for _, group := range groups[1:] {
+			if len(group) != 3 {
+				return "", fmt.Errorf("invalid number %q", value)
+			}
+		}
+		integer = strings.Join(groups, "")
+	}
+
+	canonical := integer
+	if hasFraction {
+		canonical += "." + fraction
+	}
+
+	var err error
+	switch typeOf.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		_, err = strconv.ParseInt(canonical, 10, typeOf.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		_, err = strconv.ParseUint(canonical, 10, typeOf.Bits())
+	default:
+		_, err = strconv.ParseFloat(canonical, typeOf.Bits())
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return canonical, nil
+}
+
+// canonicalizeDate converts localized date (like "31.12.2024") into RFC 3339 format, which is always accepted by
+// time decoding. Localized dates and dates in configured date format (like values of HTML date inputs, which don't
+// depend on locale) are parsed in time zone of locale, so they present midnight of submitted day for user who submitted it.
+// Dates which are already in RFC 3339 format are kept as they are, since they contain their own time zone.
+func (p *DefaultFormDataDecoderImpl) canonicalizeDate(value string, format localeFormat) (string, error) {
+	location := format.location
+	if location == nil {
+		location = time.UTC
+	}
+
+	layouts := format.dateFormats
+	if p.dateFormat != "" {
+		layouts = append(append([]string{}, layouts...), p.dateFormat)
+	}
+
+	for _, layout := range layouts {
+		if date, err := time.ParseInLocation(layout, value, location); err == nil {
+			return date.Format(time.RFC3339), nil
+		}
+	}
+
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return "", err
+	}
+
+	return value, nil
+}
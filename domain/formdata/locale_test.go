@@ -0,0 +1,352 @@
+package formdata
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"time"
+
+	"flamingo.me/flamingo/v3/framework/config"
+	"flamingo.me/flamingo/v3/framework/flamingo"
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	formDataDecoderInvoiceTestData struct {
+		Total    float64                              `form:"total" parse:"localized"`
+		Due      time.Time                            `form:"due" parse:"localized"`
+		Quantity int                                  `form:"quantity" parse:"localized"`
+		Discount *float64                             `form:"discount" parse:"localized"`
+		Code     float64                              `form:"code"`
+		Note     string                               `form:"note" parse:"localized"`
+		Lines    []formDataDecoderInvoiceLineTestData `form:"lines"`
+	}
+
+	formDataDecoderInvoiceLineTestData struct {
+		Weights []float64 `form:"weights" parse:"localized"`
+	}
+)
+
+// newLocalizedDecoder creates decoder which takes request locale from "Accept-Language" header,
+// with formats configured for "de-DE" and "en-US"
+func (t *DefaultFormDataDecoderImplTestSuite) newLocalizedDecoder() *DefaultFormDataDecoderImpl {
+	decoder := &DefaultFormDataDecoderImpl{}
+	decoder.Inject(&formDataDecoderTestConfig{
+		DateFormat:   "2006-01-02",
+		LocaleSource: "header",
+		LocaleKey:    "Accept-Language",
+		LocaleFormats: config.Map{
+			"de_DE": config.Map{
+				"decimalSeparator":   ",",
+				"thousandsSeparator": ".",
+				"dateFormats":        config.Slice{"02.01.2006", "2.1.2006"},
+			},
+			"en-US": map[string]interface{}{
+				"decimalSeparator":   ".",
+				"thousandsSeparator": ",",
+				"dateFormats":        []interface{}{"01/02/2006"},
+			},
+		},
+	}, nil, nil, &flamingo.NullLogger{})
+
+	return decoder
+}
+
+// createLocalizedRequest creates request with provided locale in "Accept-Language" header
+func (t *DefaultFormDataDecoderImplTestSuite) createLocalizedRequest(locale string) *web.Request {
+	httpRequest, _ := http.NewRequest(http.MethodPost, "/", nil)
+	httpRequest.Header.Set("Accept-Language", locale)
+
+	return web.CreateRequest(httpRequest, web.EmptySession())
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestParseLocaleFormats() {
+	formats, invalid := parseLocaleFormats(config.Map{
+		"de-CH": config.Map{
+			"thousandsSeparator": "'",
+		},
+		"fr-FR": config.Map{
+			"decimalSeparator":   ",",
+			"thousandsSeparator": ",",
+		},
+		"it-IT": config.Map{
+			"dateFormats": config.Slice{"02/01/2006", 2},
+		},
+		"nl-NL": "02-01-2006",
+		"de-AT": config.Map{
+			"timeZone": "Europe/Vienna",
+		},
+		"es-ES": config.Map{
+			"timeZone": "Europe/Atlantis",
+		},
+	})
+
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	t.NoError(err)
+
+	t.Equal(map[string]localeFormat{
+		"de-ch": {
+			decimalSeparator:   ".",
+			thousandsSeparator: "'",
+			location:           time.UTC,
+		},
+		"de-at": {
+			decimalSeparator: ".",
+			location:         vienna,
+		},
+	}, formats)
+	t.Equal([]string{"es-ES", "fr-FR", "it-IT", "nl-NL"}, invalid)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestGetLocalizedFields() {
+	ResetMetadataCache()
+
+	t.Equal(map[string]reflect.Type{
+		"total":         reflect.TypeOf(float64(0)),
+		"due":           reflect.TypeOf(time.Time{}),
+		"quantity":      reflect.TypeOf(0),
+		"discount":      reflect.TypeOf(float64(0)),
+		"lines.weights": reflect.TypeOf(float64(0)),
+	}, t.decoder.getFormMetadata(reflect.TypeOf(formDataDecoderInvoiceTestData{})).localizedFields)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestApplyLocalizedValues() {
+	decoder := t.newLocalizedDecoder()
+	testCases := []struct {
+		Locale string
+		Values url.Values
+	}{
+		{
+			Locale: "de-DE,de;q=0.9,en;q=0.8",
+			Values: url.Values{
+				"total":             []string{"1.234,56"},
+				"due":               []string{"31.12.2024"},
+				"quantity":          []string{"1.000"},
+				"discount":          []string{"0,5"},
+				"code":              []string{"1.5"},
+				"lines[0].weights":  []string{"0,25", "12"},
+				"lines.1.weights.0": []string{"1,5"},
+			},
+		},
+		{
+			Locale: "en-US",
+			Values: url.Values{
+				"total":             []string{"1,234.56"},
+				"due":               []string{"12/31/2024"},
+				"quantity":          []string{"1,000"},
+				"discount":          []string{"0.5"},
+				"code":              []string{"1.5"},
+				"lines[0].weights":  []string{"0.25", "12"},
+				"lines.1.weights.0": []string{"1.5"},
+			},
+		},
+		{
+			// locale is matched regardless of its notation, and dates of HTML date inputs are accepted
+			Locale: "DE_de",
+			Values: url.Values{
+				"total":             []string{"1234,56"},
+				"due":               []string{"2024-12-31"},
+				"quantity":          []string{"1000"},
+				"discount":          []string{"0,5"},
+				"code":              []string{"1.5"},
+				"lines[0].weights":  []string{"0,25", "12"},
+				"lines.1.weights.0": []string{"1,5"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		values, validationInfo := decoder.applyLocalizedValues(t.createLocalizedRequest(testCase.Locale), testCase.Values, formDataDecoderInvoiceTestData{})
+		t.True(validationInfo.IsValid(), testCase.Locale)
+
+		t.Equal(url.Values{
+			"total":               []string{"1234.56"},
+			"due":                 []string{"2024-12-31T00:00:00Z"},
+			"quantity":            []string{"1000"},
+			"discount":            []string{"0.5"},
+			"code":                []string{"1.5"},
+			"lines[0].weights":    []string{"0.25", "12"},
+			"lines[1].weights[0]": []string{"1.5"},
+		}, values, testCase.Locale)
+	}
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestApplyLocalizedValues_TimeZone() {
+	decoder := &DefaultFormDataDecoderImpl{}
+	decoder.Inject(&formDataDecoderTestConfig{
+		DateFormat:   "2006-01-02",
+		LocaleSource: "header",
+		LocaleKey:    "Accept-Language",
+		LocaleFormats: config.Map{
+			"de-DE": config.Map{
+				"decimalSeparator":   ",",
+				"thousandsSeparator": ".",
+				"dateFormats":        config.Slice{"02.01.2006"},
+				"timeZone":           "Europe/Berlin",
+			},
+		},
+	}, nil, nil, &flamingo.NullLogger{})
+
+	// localized dates and dates of HTML date inputs are midnight in time zone of locale, while RFC 3339 dates keep their own
+	for value, due := range map[string]string{
+		"31.12.2024":                "2024-12-31T00:00:00+01:00",
+		"2024-07-01":                "2024-07-01T00:00:00+02:00",
+		"2024-12-31T08:00:00-05:00": "2024-12-31T08:00:00-05:00",
+	} {
+		values, validationInfo := decoder.applyLocalizedValues(t.createLocalizedRequest("de-DE"), url.Values{
+			"due": []string{value},
+		}, formDataDecoderInvoiceTestData{})
+
+		t.True(validationInfo.IsValid(), value)
+		t.Equal([]string{due}, values["due"], value)
+	}
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestApplyLocalizedValues_Invalid() {
+	decoder := t.newLocalizedDecoder()
+
+	// numbers and dates in format of other locale are rejected, instead of being misread
+	values, validationInfo := decoder.applyLocalizedValues(t.createLocalizedRequest("de-DE"), url.Values{
+		"total":            []string{"1,234.56"},
+		"due":              []string{"12/31/2024"},
+		"quantity":         []string{"1,5"},
+		"lines[0].weights": []string{"1,5", "1.5"},
+	}, formDataDecoderInvoiceTestData{})
+
+	expected := domain.ValidationInfo{}
+	for _, field := range []struct{ name, value string }{
+		{"due", "12/31/2024"},
+		{"lines[0].weights[1]", "1.5"},
+		{"quantity", "1,5"},
+		{"total", "1,234.56"},
+	} {
+		expected.AddFieldErrorWithParams(field.name, "formError.invalidValue", "invalid value", map[string]string{
+			"value":  field.value,
+			"locale": "de-DE",
+		})
+	}
+	t.Equal(expected, validationInfo)
+	t.Equal(url.Values{
+		"total":            []string{""},
+		"due":              []string{""},
+		"quantity":         []string{""},
+		"lines[0].weights": []string{"1.5", ""},
+	}, values)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestApplyLocalizedValues_UnknownLocale() {
+	decoder := t.newLocalizedDecoder()
+	values := url.Values{
+		"total": []string{"1.234,56"},
+	}
+
+	for _, locale := range []string{"", "fr-FR"} {
+		result, validationInfo := decoder.applyLocalizedValues(t.createLocalizedRequest(locale), values, formDataDecoderInvoiceTestData{})
+		t.True(validationInfo.IsValid(), locale)
+		t.Equal(values, result, locale)
+	}
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestFindLocaleFormat() {
+	decoder := &DefaultFormDataDecoderImpl{
+		localeFormats: map[string]localeFormat{
+			"de":    {decimalSeparator: ","},
+			"de-ch": {decimalSeparator: "."},
+		},
+	}
+
+	format, ok := decoder.findLocaleFormat("de-CH")
+	t.True(ok)
+	t.Equal(".", format.decimalSeparator)
+
+	// format of language is used for regions without their own format
+	format, ok = decoder.findLocaleFormat("de_AT")
+	t.True(ok)
+	t.Equal(",", format.decimalSeparator)
+
+	_, ok = decoder.findLocaleFormat("en-US")
+	t.False(ok)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestGetLocale() {
+	decoder := &DefaultFormDataDecoderImpl{}
+
+	req := t.createLocalizedRequest("de-DE;q=0.9")
+	req.Request().URL, _ = url.Parse("/checkout?lang=en-GB")
+	req.Session().Store("locale", "fr-FR")
+
+	testCases := []struct {
+		Source string
+		Key    string
+		Locale string
+	}{
+		{Source: "header", Key: "Accept-Language", Locale: "de-DE"},
+		{Source: "session", Key: "locale", Locale: "fr-FR"},
+		{Source: "param", Key: "lang", Locale: "en-GB"},
+		{Source: "cookie", Key: "lang"},
+	}
+
+	for _, testCase := range testCases {
+		decoder.localeSource, decoder.localeKey = testCase.Source, testCase.Key
+		t.Equal(testCase.Locale, decoder.getLocale(req), testCase.Source)
+	}
+
+	// route params take precedence over query params
+	req.Params = web.RequestParams{"lang": "it-IT"}
+	decoder.localeSource, decoder.localeKey = "param", "lang"
+	t.Equal("it-IT", decoder.getLocale(req))
+	t.Empty(decoder.getLocale(nil))
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_LocalizedValues() {
+	decoder := t.newLocalizedDecoder()
+	discount := 0.5
+
+	for locale, values := range map[string]url.Values{
+		"de-DE": {
+			"total":    []string{"1.234,56"},
+			"due":      []string{"31.12.2024"},
+			"discount": []string{"0,5"},
+		},
+		"en-US": {
+			"total":    []string{"1,234.56"},
+			"due":      []string{"12/31/2024"},
+			"discount": []string{"0.5"},
+		},
+	} {
+		result, err := decoder.Decode(nil, t.createLocalizedRequest(locale), values, formDataDecoderInvoiceTestData{})
+		t.NoError(err, locale)
+		t.Equal(formDataDecoderInvoiceTestData{
+			Total:    1234.56,
+			Due:      time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC),
+			Discount: &discount,
+		}, result, locale)
+	}
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_InvalidLocalizedValue() {
+	_, err := t.newLocalizedDecoder().Decode(nil, t.createLocalizedRequest("de-DE"), url.Values{
+		"total": []string{"1,234.56"},
+		"code":  []string{"abc"},
+	}, formDataDecoderInvoiceTestData{})
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldErrorWithParams("code", "formError.invalidValue", "invalid value", map[string]string{
+		"value": "abc",
+	})
+	validationInfo.AddFieldErrorWithParams("total", "formError.invalidValue", "invalid value", map[string]string{
+		"value":  "1,234.56",
+		"locale": "de-DE",
+	})
+	t.Equal(domain.NewDecodeError(validationInfo), err)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecode_LocalizedJSON() {
+	req := t.createJSONRequest(`{"total": 1234.56}`)
+	req.Request().Header.Set("Accept-Language", "de-DE")
+
+	result, err := t.newLocalizedDecoder().Decode(nil, req, nil, formDataDecoderInvoiceTestData{})
+	t.NoError(err)
+	t.Equal(1234.56, result.(formDataDecoderInvoiceTestData).Total)
+}
@@ -0,0 +1,134 @@
+package formdata
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+type (
+	// structMetadata contains parsed tags of all struct fields, which depend only on struct type,
+	// so they are parsed once per type, instead of once per decoded form data
+	structMetadata struct {
+		fields []fieldMetadata
+		// childTypes contains types of all fields keyed by their form names, including fields of anonymous embedded structs
+		childTypes map[string]reflect.Type
+	}
+
+	// fieldMetadata contains parsed tags of single struct field
+	fieldMetadata struct {
+		index       int
+		field       reflect.StructField
+		name        string
+		options     []string
+		ignored     bool
+		modifiers   []string
+		absentEmpty bool
+	}
+
+	// formMetadata contains metadata of form data type, which is collected from all its nested types
+	formMetadata struct {
+		sourceFields []sourceField
+		// sourceError is defined if any field is tagged with unknown source, so form data type can't be decoded at all
+		sourceError error
+		// localizedFields contains types of values of fields tagged with `parse:"localized"`, keyed by their form names without indices
+		localizedFields map[string]reflect.Type
+		// modifierFields contains names of field modifiers of fields tagged with "mod" tag, keyed by their form names without indices
+		modifierFields         map[string][]string
+		unexportedEmbedPointer string
+		hasUnexportedEmbed     bool
+	}
+)
+
+var (
+	// structMetadataCache contains *structMetadata for each struct type
+	structMetadataCache sync.Map
+	// formMetadataCache contains *formMetadata for each form data type
+	formMetadataCache sync.Map
+)
+
+// ResetMetadataCache removes cached metadata of all form data types, so they are parsed again on next decoding.
+// It's meant to be used by tests, which define different tags for the same type.
+func ResetMetadataCache() {
+	for _, cache := range []*sync.Map{&structMetadataCache, &formMetadataCache} {
+		cache.Range(func(key interface{}, _ interface{}) bool {
+			cache.Delete(key)
+			return true
+		})
+	}
+}
+
+// getStructMetadata returns parsed tags of struct type. Metadata is parsed only once per type, and since it's
+// never modified afterwards, it's safe to share it between concurrent requests.
+func (p *DefaultFormDataDecoderImpl) getStructMetadata(typeOf reflect.Type) *structMetadata {
+	if cached, ok := structMetadataCache.Load(typeOf); ok {
+		return cached.(*structMetadata)
+	}
+
+	metadata := &structMetadata{
+		fields:     make([]fieldMetadata, 0, typeOf.NumField()),
+		childTypes: map[string]reflect.Type{},
+	}
+
+	for i := 0; i < typeOf.NumField(); i++ {
+		field := typeOf.Field(i)
+
+		options := strings.Split(field.Tag.Get("form"), ",")
+		name := options[0]
+		ignored := name == "-"
+		if name == "" {
+			name = field.Name
+		}
+
+		metadata.fields = append(metadata.fields, fieldMetadata{
+			index:       i,
+			field:       field,
+			name:        name,
+			options:     options[1:],
+			ignored:     ignored,
+			modifiers:   p.getModifierNames(field),
+			absentEmpty: field.Tag.Get("absent") == absentTagEmpty,
+		})
+
+		if ignored {
+			continue
+		}
+		if _, ok := metadata.childTypes[name]; !ok {
+			metadata.childTypes[name] = field.Type
+		}
+
+		// fields are searched in order of their definition, so fields of embedded struct take precedence over following fields
+		if embeddedType := p.indirectType(field.Type); field.Anonymous && embeddedType.Kind() == reflect.Struct && embeddedType != typeOf {
+			for name, childType := range p.getStructMetadata(embeddedType).childTypes {
+				if _, ok := metadata.childTypes[name]; !ok {
+					metadata.childTypes[name] = childType
+				}
+			}
+		}
+	}
+
+	cached, _ := structMetadataCache.LoadOrStore(typeOf, metadata)
+
+	return cached.(*structMetadata)
+}
+
+// getFormMetadata returns metadata of form data type, which is collected only once per type
+func (p *DefaultFormDataDecoderImpl) getFormMetadata(typeOf reflect.Type) *formMetadata {
+	if cached, ok := formMetadataCache.Load(typeOf); ok {
+		return cached.(*formMetadata)
+	}
+
+	metadata := &formMetadata{
+		sourceFields:    p.getSourceFields(typeOf, "", map[reflect.Type]bool{}),
+		localizedFields: map[string]reflect.Type{},
+		modifierFields:  map[string][]string{},
+	}
+	metadata.sourceError = p.validateSourceFields(metadata.sourceFields)
+	p.getLocalizedFields(typeOf, "", map[reflect.Type]bool{}, metadata.localizedFields)
+	p.getModifierFields(typeOf, "", map[reflect.Type]bool{}, metadata.modifierFields)
+	metadata.unexportedEmbedPointer, metadata.hasUnexportedEmbed = p.findUnexportedEmbedPointer(typeOf, map[reflect.Type]bool{})
+
+	cached, _ := formMetadataCache.LoadOrStore(typeOf, metadata)
+
+	return cached.(*formMetadata)
+}
@@ -0,0 +1,317 @@
+package formdata
+
+import (
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"flamingo.me/flamingo/v3/framework/flamingo"
+)
+
+type (
+	formDataDecoderOrderedTestData struct {
+		formDataDecoderAddressTestData
+		Street  int    `form:"street"`
+		Ignored string `form:"-"`
+		Comment string
+	}
+
+	// formDataDecoderBenchmarkTestData as nested form with 30 fields, which is used for benchmarks
+	formDataDecoderBenchmarkTestData struct {
+		FirstName  string                                   `form:"firstName" conform:"trim"`
+		LastName   string                                   `form:"lastName" conform:"trim"`
+		Email      string                                   `form:"email" conform:"email"`
+		Phone      string                                   `form:"phone"`
+		Mobile     string                                   `form:"mobile"`
+		Title      string                                   `form:"title"`
+		Gender     string                                   `form:"gender"`
+		Website    string                                   `form:"website"`
+		Age        int                                      `form:"age"`
+		Birthday   time.Time                                `form:"birthday"`
+		Company    string                                   `form:"company"`
+		Newsletter bool                                     `form:"newsletter"`
+		Interests  []string                                 `form:"interests" absent:"empty"`
+		Shipping   formDataDecoderBenchmarkAddressTestData  `form:"shipping"`
+		Billing    *formDataDecoderBenchmarkAddressTestData `form:"billing"`
+		Items      []formDataDecoderBenchmarkItemTestData   `form:"items,omitempty-rows"`
+		Labels     map[string]string                        `form:"labels"`
+		Comment    string                                   `form:"comment" conform:"trim"`
+		formDataDecoderBenchmarkMetaTestData
+	}
+
+	formDataDecoderBenchmarkAddressTestData struct {
+		Street     string `form:"street" conform:"trim"`
+		Number     string `form:"number"`
+		PostalCode string `form:"postalCode"`
+		City       string `form:"city" conform:"trim"`
+		Country    string `form:"country" conform:"upper"`
+	}
+
+	formDataDecoderBenchmarkItemTestData struct {
+		Sku      string  `form:"sku"`
+		Quantity int     `form:"quantity"`
+		Price    float64 `form:"price"`
+	}
+
+	formDataDecoderBenchmarkMetaTestData struct {
+		Source  string `form:"source"`
+		Channel string `form:"channel"`
+	}
+)
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestGetStructMetadata() {
+	ResetMetadataCache()
+
+	metadata := t.decoder.getStructMetadata(reflect.TypeOf(formDataDecoderRowsTestData{}))
+	t.True(metadata == t.decoder.getStructMetadata(reflect.TypeOf(formDataDecoderRowsTestData{})), "metadata is parsed only once")
+
+	typeOf := reflect.TypeOf(formDataDecoderRowsTestData{})
+	t.Len(metadata.fields, typeOf.NumField())
+	for i, field := range metadata.fields {
+		t.Equal(i, field.index)
+		t.Equal(typeOf.Field(i), field.field)
+	}
+
+	metadata = t.decoder.getStructMetadata(reflect.TypeOf(formDataDecoderOrderedTestData{}))
+	t.Equal([]fieldMetadata{
+		{
+			index:   0,
+			field:   reflect.TypeOf(formDataDecoderOrderedTestData{}).Field(0),
+			name:    "formDataDecoderAddressTestData",
+			options: []string{},
+		},
+		{
+			index:   1,
+			field:   reflect.TypeOf(formDataDecoderOrderedTestData{}).Field(1),
+			name:    "street",
+			options: []string{},
+		},
+		{
+			index:   2,
+			field:   reflect.TypeOf(formDataDecoderOrderedTestData{}).Field(2),
+			name:    "-",
+			options: []string{},
+			ignored: true,
+		},
+		{
+			index:   3,
+			field:   reflect.TypeOf(formDataDecoderOrderedTestData{}).Field(3),
+			name:    "Comment",
+			options: []string{},
+		},
+	}, metadata.fields)
+
+	field := t.decoder.getStructMetadata(reflect.TypeOf(formDataDecoderInterestsTestData{})).fields[1]
+	t.True(field.absentEmpty)
+
+	field = t.decoder.getStructMetadata(reflect.TypeOf(formDataDecoderBenchmarkTestData{})).fields[15]
+	t.Equal("items", field.name)
+	t.Equal([]string{formOptionOmitEmptyRows}, field.options)
+
+	field = t.decoder.getStructMetadata(reflect.TypeOf(formDataDecoderReviewTestData{})).fields[2]
+	t.Equal([]string{"striphtml", "nfc"}, field.modifiers)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestGetModifierFields() {
+	ResetMetadataCache()
+
+	t.Equal(map[string][]string{
+		"title":         {"striphtml"},
+		"rating":        {"striphtml"},
+		"tags":          {"striphtml", "nfc"},
+		"labels":        {"striphtml"},
+		"comments.text": {"striphtml", "nfc"},
+		"author.text":   {"striphtml", "nfc"},
+	}, t.decoder.getFormMetadata(reflect.TypeOf(formDataDecoderReviewTestData{})).modifierFields)
+
+	t.Empty(t.decoder.getFormMetadata(reflect.TypeOf(formDataDecoderTestData{})).modifierFields)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestGetJSONChildType() {
+	ResetMetadataCache()
+
+	testCases := []struct {
+		TypeOf reflect.Type
+		Key    string
+		Result reflect.Type
+	}{
+		{
+			TypeOf: reflect.TypeOf(formDataDecoderNestedTestData{}),
+			Key:    "address",
+			Result: reflect.TypeOf(formDataDecoderAddressTestData{}),
+		},
+		{
+			TypeOf: reflect.TypeOf(formDataDecoderNestedTestData{}),
+			Key:    "Address",
+		},
+		{
+			TypeOf: reflect.TypeOf(formDataDecoderNestedTestData{}),
+			Key:    "attributes",
+			Result: reflect.TypeOf(map[string]string{}),
+		},
+		{
+			TypeOf: reflect.TypeOf(map[string]int{}),
+			Key:    "any",
+			Result: reflect.TypeOf(0),
+		},
+		{
+			TypeOf: reflect.TypeOf(formDataDecoderCheckoutTestData{}),
+			Key:    "phone",
+			Result: reflect.TypeOf(""),
+		},
+		{
+			TypeOf: reflect.TypeOf(formDataDecoderCheckoutTestData{}),
+			Key:    "FormDataDecoderContactTestData",
+			Result: reflect.TypeOf(&FormDataDecoderContactTestData{}),
+		},
+		{
+			TypeOf: reflect.TypeOf(formDataDecoderCheckoutTestData{}),
+			Key:    "avatar",
+			Result: reflect.TypeOf(&multipart.FileHeader{}),
+		},
+		// fields are resolved in order of their definition, including fields of embedded structs
+		{
+			TypeOf: reflect.TypeOf(formDataDecoderOrderedTestData{}),
+			Key:    "street",
+			Result: reflect.TypeOf(""),
+		},
+		{
+			TypeOf: reflect.TypeOf(formDataDecoderOrderedTestData{}),
+			Key:    "-",
+		},
+		{
+			TypeOf: reflect.TypeOf(formDataDecoderOrderedTestData{}),
+			Key:    "Comment",
+			Result: reflect.TypeOf(""),
+		},
+		{
+			TypeOf: reflect.TypeOf(""),
+			Key:    "any",
+		},
+		{
+			Key: "any",
+		},
+	}
+
+	for _, testCase := range testCases {
+		// second lookup uses cached metadata
+		for i := 0; i < 2; i++ {
+			t.Equal(testCase.Result, t.decoder.getJSONChildType(testCase.TypeOf, testCase.Key), testCase.Key)
+		}
+	}
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestGetFormMetadata() {
+	ResetMetadataCache()
+
+	metadata := t.decoder.getFormMetadata(reflect.TypeOf(formDataDecoderProfileTestData{}))
+	t.True(metadata == t.decoder.getFormMetadata(reflect.TypeOf(formDataDecoderProfileTestData{})), "metadata is collected only once")
+	t.Equal(t.decoder.getSourceFields(reflect.TypeOf(formDataDecoderProfileTestData{}), "", map[reflect.Type]bool{}), metadata.sourceFields)
+	t.False(metadata.hasUnexportedEmbed)
+
+	metadata = t.decoder.getFormMetadata(reflect.TypeOf(formDataDecoderUnexportedEmbedTestData{}))
+	t.True(metadata.hasUnexportedEmbed)
+	t.Equal("formDataDecoderUnexportedEmbedTestData.formDataDecoderAddressTestData", metadata.unexportedEmbedPointer)
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestResetMetadataCache() {
+	structMetadata := t.decoder.getStructMetadata(reflect.TypeOf(formDataDecoderRowsTestData{}))
+	formMetadata := t.decoder.getFormMetadata(reflect.TypeOf(formDataDecoderProfileTestData{}))
+
+	ResetMetadataCache()
+
+	t.True(structMetadata != t.decoder.getStructMetadata(reflect.TypeOf(formDataDecoderRowsTestData{})), "struct metadata is parsed again")
+	t.True(formMetadata != t.decoder.getFormMetadata(reflect.TypeOf(formDataDecoderProfileTestData{})), "form metadata is collected again")
+	t.Equal(structMetadata, t.decoder.getStructMetadata(reflect.TypeOf(formDataDecoderRowsTestData{})))
+}
+
+func (t *DefaultFormDataDecoderImplTestSuite) TestDecodeUnknownInterface_Concurrent() {
+	ResetMetadataCache()
+
+	values := url.Values{
+		"text":   []string{" text "},
+		"number": []string{"1"},
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			var formData interface{} = formDataDecoderTestData{}
+			if i%2 == 1 {
+				formData = formDataDecoderBenchmarkTestData{}
+			}
+
+			_, err := t.decoder.decodeUnknownInterface(values, formData)
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.NoError(err)
+	}
+}
+
+func BenchmarkDefaultFormDataDecoderImpl_Decode(b *testing.B) {
+	decoder := &DefaultFormDataDecoderImpl{}
+	decoder.Inject(&formDataDecoderTestConfig{
+		MaxArraySize: 100,
+		DateFormat:   "2006-01-02",
+	}, nil, nil, &flamingo.NullLogger{})
+
+	values := url.Values{
+		"firstName":           []string{" John "},
+		"lastName":            []string{" Doe "},
+		"email":               []string{"john@example.com"},
+		"phone":               []string{"030 1234567"},
+		"mobile":              []string{"0176 1234567"},
+		"title":               []string{"Dr."},
+		"gender":              []string{"male"},
+		"website":             []string{"https://example.com"},
+		"age":                 []string{"38"},
+		"birthday":            []string{"1985-02-01"},
+		"company":             []string{"ACME"},
+		"newsletter":          []string{"true"},
+		"interests":           []string{"sports", "music"},
+		"shipping.street":     []string{"Main Street"},
+		"shipping.number":     []string{"1"},
+		"shipping.postalCode": []string{"10115"},
+		"shipping.city":       []string{"Berlin"},
+		"shipping.country":    []string{"de"},
+		"billing[street]":     []string{"Second Street"},
+		"billing[city]":       []string{"Munich"},
+		"labels[color]":       []string{"red"},
+		"comment":             []string{" comment "},
+		"source":              []string{"web"},
+	}
+	for i := 0; i < 3; i++ {
+		values["items["+strconv.Itoa(i)+"].sku"] = []string{fmt.Sprintf("SKU-%d", i)}
+		values["items["+strconv.Itoa(i)+"].quantity"] = []string{"2"}
+	}
+
+	// uncached benchmark removes all metadata before each decoding, like it was parsed on every request before
+	for _, cached := range []bool{false, true} {
+		b.Run("cached="+strconv.FormatBool(cached), func(b *testing.B) {
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if !cached {
+					ResetMetadataCache()
+				}
+				if _, err := decoder.Decode(nil, nil, values, formDataDecoderBenchmarkTestData{}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -103,3 +103,49 @@ func (_m *FormHandler) HandleUnsubmittedForm(ctx context.Context, req *web.Reque
 
 	return r0, r1
 }
+
+// Schema provides a mock function with given fields: ctx
+func (_m *FormHandler) Schema(ctx context.Context) ([]byte, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []byte
+	if rf, ok := ret.Get(0).(func(context.Context) []byte); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateData provides a mock function with given fields: ctx, req, data
+func (_m *FormHandler) ValidateData(ctx context.Context, req *web.Request, data interface{}) (*domain.ValidationInfo, error) {
+	ret := _m.Called(ctx, req, data)
+
+	var r0 *domain.ValidationInfo
+	if rf, ok := ret.Get(0).(func(context.Context, *web.Request, interface{}) *domain.ValidationInfo); ok {
+		r0 = rf(ctx, req, data)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.ValidationInfo)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *web.Request, interface{}) error); ok {
+		r1 = rf(ctx, req, data)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -43,6 +43,11 @@ func (_m *ValidatorProvider) GetValidator() *validator.Validate {
 	return r0
 }
 
+// RegisterLabelFunc provides a mock function with given fields: fn
+func (_m *ValidatorProvider) RegisterLabelFunc(fn domain.LabelFunc) {
+	_m.Called(fn)
+}
+
 // Validate provides a mock function with given fields: ctx, req, value
 func (_m *ValidatorProvider) Validate(ctx context.Context, req *web.Request, value interface{}) domain.ValidationInfo {
 	ret := _m.Called(ctx, req, value)
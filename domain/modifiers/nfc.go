@@ -0,0 +1,33 @@
+package modifiers
+
+import (
+	"golang.org/x/text/unicode/norm"
+
+	"flamingo.me/form/domain"
+)
+
+type (
+	// NFCModifier defines field modifier which normalizes unicode value into canonical composition form (NFC),
+	// so the same text submitted with combining characters (like "e" followed by U+0301) and precomposed
+	// characters (like U+00E9) has the same value and the same length.
+	//
+	// Data struct {
+	//	 Name string `mod:"nfc" validate:"max=20"`
+	// }
+	//
+	NFCModifier struct{}
+)
+
+var (
+	_ domain.FieldModifier = &NFCModifier{}
+)
+
+// Name defines tag name of NFC modifier
+func (m *NFCModifier) Name() string {
+	return "nfc"
+}
+
+// Modify normalizes value into NFC form
+func (m *NFCModifier) Modify(value string) string {
+	return norm.NFC.String(value)
+}
@@ -0,0 +1,36 @@
+package modifiers
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	NFCModifierTestSuite struct {
+		suite.Suite
+
+		modifier *NFCModifier
+	}
+)
+
+func TestNFCModifierTestSuite(t *testing.T) {
+	suite.Run(t, &NFCModifierTestSuite{})
+}
+
+func (t *NFCModifierTestSuite) SetupTest() {
+	t.modifier = &NFCModifier{}
+}
+
+func (t *NFCModifierTestSuite) TestName() {
+	t.Equal("nfc", t.modifier.Name())
+}
+
+func (t *NFCModifierTestSuite) TestModify() {
+	t.Equal("", t.modifier.Modify(""))
+	t.Equal("plain text", t.modifier.Modify("plain text"))
+	t.Equal("Caf\u00e9", t.modifier.Modify("Caf\u00e9"))
+	t.Equal("Caf\u00e9", t.modifier.Modify("Cafe\u0301"))
+	t.Equal(4, utf8.RuneCountInString(t.modifier.Modify("Cafe\u0301")))
+}
@@ -0,0 +1,43 @@
+package modifiers
+
+import (
+	"regexp"
+
+	"flamingo.me/form/domain"
+)
+
+type (
+	// StripHTMLModifier defines field modifier which removes HTML tags, comments and content of script and style elements.
+	// Text between tags and HTML entities are kept unchanged.
+	//
+	// Data struct {
+	//	 Comment string `mod:"striphtml"`
+	// }
+	//
+	StripHTMLModifier struct{}
+)
+
+var (
+	_ domain.FieldModifier = &StripHTMLModifier{}
+
+	// htmlScriptRegex matches script and style elements with their content, including unclosed ones
+	htmlScriptRegex = regexp.MustCompile(`(?is)<script\b[^>]*>.*?(</script\s*>|$)|<style\b[^>]*>.*?(</style\s*>|$)`)
+	// htmlCommentRegex matches HTML comments, including unclosed ones
+	htmlCommentRegex = regexp.MustCompile(`(?s)<!--.*?(-->|$)`)
+	// htmlTagRegex matches opening and closing tags, doctype and processing instructions, including unclosed ones.
+	// Character "<" which is not followed by letter, "/", "!" or "?" (like in "a < b") is not a tag.
+	htmlTagRegex = regexp.MustCompile(`<[a-zA-Z/!?][^>]*(>|$)`)
+)
+
+// Name defines tag name of strip HTML modifier
+func (m *StripHTMLModifier) Name() string {
+	return "striphtml"
+}
+
+// Modify removes all HTML from value
+func (m *StripHTMLModifier) Modify(value string) string {
+	value = htmlScriptRegex.ReplaceAllString(value, "")
+	value = htmlCommentRegex.ReplaceAllString(value, "")
+
+	return htmlTagRegex.ReplaceAllString(value, "")
+}
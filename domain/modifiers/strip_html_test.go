@@ -0,0 +1,47 @@
+package modifiers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	StripHTMLModifierTestSuite struct {
+		suite.Suite
+
+		modifier *StripHTMLModifier
+	}
+)
+
+func TestStripHTMLModifierTestSuite(t *testing.T) {
+	suite.Run(t, &StripHTMLModifierTestSuite{})
+}
+
+func (t *StripHTMLModifierTestSuite) SetupTest() {
+	t.modifier = &StripHTMLModifier{}
+}
+
+func (t *StripHTMLModifierTestSuite) TestName() {
+	t.Equal("striphtml", t.modifier.Name())
+}
+
+func (t *StripHTMLModifierTestSuite) TestModify() {
+	for input, expected := range map[string]string{
+		"":                                      "",
+		"plain text":                            "plain text",
+		"<b>bold</b> text":                      "bold text",
+		`<a href="http://example.com">link</a>`: "link",
+		"before<script>alert(1)</script>after":  "beforeafter",
+		"before<SCRIPT type=\"text/javascript\">\nalert(1)\n</SCRIPT >after": "beforeafter",
+		"before<style>body { color: red; }</style>after":                     "beforeafter",
+		"before<script>alert(1)":                                             "before",
+		"before<!-- comment -->after":                                        "beforeafter",
+		"<!DOCTYPE html><p>text</p>":                                         "text",
+		`text<img src="x" onerror="alert(1)"`:                                "text",
+		"a < b and b > c":                                                    "a < b and b > c",
+		"Tom &amp; Jerry":                                                    "Tom &amp; Jerry",
+	} {
+		t.Equal(expected, t.modifier.Modify(input), input)
+	}
+}
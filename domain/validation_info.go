@@ -1,22 +1,51 @@
 package domain
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
 
 type (
 	// ValidationInfo - represents the complete Validation Informations of your form. It can contain GeneralErrors and form field related errors.
 	ValidationInfo struct {
 		// fieldErrors list of errors per form field.
 		fieldErrors map[string][]Error
+		// fieldNames list of fields with errors, in order in which their first errors are added
+		fieldNames []string
 		// generalErrors list of general form errors, that are not related to any field
 		generalErrors []Error
+		// fieldWarnings list of warnings per form field, which don't make form invalid
+		fieldWarnings map[string][]Error
+		// fieldWarningNames list of fields with warnings, in order in which their first warnings are added
+		fieldWarningNames []string
+		// generalWarnings list of general form warnings, that are not related to any field
+		generalWarnings []Error
 	}
 
+	// validationInfoEnodeAble defines stable JSON representation of ValidationInfo
 	validationInfoEnodeAble struct {
-		FieldErrors   map[string][]Error
-		GeneralErrors []Error
-		IsValid       bool
+		Valid         bool               `json:"valid"`
+		GeneralErrors []Error            `json:"generalErrors"`
+		FieldErrors   FieldErrorsEntries `json:"fieldErrors"`
+		// warnings are omitted if there are none, so representation of validation info without warnings stays the same
+		GeneralWarnings []Error            `json:"generalWarnings,omitempty"`
+		FieldWarnings   FieldErrorsEntries `json:"fieldWarnings,omitempty"`
+	}
+
+	// FieldErrorsEntry - contains all errors of single form field, used for iterating field errors in stable order
+	FieldErrorsEntry struct {
+		// FieldName - name of the form field (like "items[1].sku")
+		FieldName string
+		// Errors - list of field errors
+		Errors []Error
 	}
 
+	// FieldErrorsEntries - list of field errors in stable order, which is encoded into JSON object with the same order of keys
+	FieldErrorsEntries []FieldErrorsEntry
+
 	// ValidationRule - contains single validation rule for field. Name is mandatory (required|email|max|len|...), Value is optional and adds additional info (like "128" for "max=128" rule)
 	ValidationRule struct {
 		// Name validator tag name
@@ -28,13 +57,42 @@ type (
 	// Error - representation of an Error Message - intented usage is to display errors in the view to the end user
 	Error struct {
 		// MessageKey - a key of the error message. Often used to pass to translation func in the template
-		MessageKey string
+		MessageKey string `json:"messageKey"`
 		// DefaultLabel - a speaking error label. OFten used to show to end user - in case no translation exists
-		DefaultLabel string
+		DefaultLabel string `json:"defaultLabel"`
+		// Parameters - optional values which can be interpolated into translated message (like "min" for "min=8" rule)
+		Parameters map[string]string `json:"parameters,omitempty"`
+		// Severity - defines if error blocks the submission, or if it's only a warning. Empty severity means SeverityError
+		Severity Severity `json:"severity,omitempty"`
 	}
+
+	// Severity - level of validation error, which defines if error makes form invalid
+	Severity string
 )
 
-// IsValid method which defines if validation info is related to valid data or not
+const (
+	// SeverityError as severity of errors which make form invalid
+	SeverityError Severity = "error"
+	// SeverityWarning as severity of warnings, which are presented to the user, but don't make form invalid
+	// (like "this email domain looks like a typo")
+	SeverityWarning Severity = "warning"
+)
+
+// GetSeverity method which returns severity of error, where errors without defined severity are treated as SeverityError
+func (e Error) GetSeverity() Severity {
+	if e.Severity == "" {
+		return SeverityError
+	}
+
+	return e.Severity
+}
+
+// IsWarning method which defines if error is only a warning, which doesn't make form invalid
+func (e Error) IsWarning() bool {
+	return e.GetSeverity() == SeverityWarning
+}
+
+// IsValid method which defines if validation info is related to valid data or not. Warnings don't make data invalid.
 func (vi *ValidationInfo) IsValid() bool {
 	return !vi.HasGeneralErrors() && !vi.HasAnyFieldErrors()
 }
@@ -63,18 +121,47 @@ func (vi *ValidationInfo) HasErrorsForField(fieldName string) bool {
 	return vi.fieldErrors != nil && len(vi.fieldErrors[fieldName]) > 0
 }
 
-// AppendGeneralErrors method which appends all provided validation errors to general errors, without duplicating existing ones
+// HasWarnings method which defines if there is any general or field warning
+func (vi *ValidationInfo) HasWarnings() bool {
+	if len(vi.generalWarnings) > 0 {
+		return true
+	}
+
+	for fieldName := range vi.fieldWarnings {
+		if vi.HasWarningsForField(fieldName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasWarningsForField method which defines if there is any warning for specific field
+func (vi *ValidationInfo) HasWarningsForField(fieldName string) bool {
+	return vi.fieldWarnings != nil && len(vi.fieldWarnings[fieldName]) > 0
+}
+
+// AppendGeneralErrors method which appends all provided validation errors to general errors, without duplicating existing ones.
+// Errors with warning severity are appended to general warnings.
 func (vi *ValidationInfo) AppendGeneralErrors(errs []Error) {
 	for _, err := range errs {
-		vi.AddGeneralError(err.MessageKey, err.DefaultLabel)
+		if err.IsWarning() {
+			vi.AddGeneralWarningWithParams(err.MessageKey, err.DefaultLabel, err.Parameters)
+			continue
+		}
+		vi.AddGeneralErrorWithParams(err.MessageKey, err.DefaultLabel, err.Parameters)
 	}
 }
 
 // AddGeneralError method which adds a general error with the passed MessageKey and DefaultLabel
 func (vi *ValidationInfo) AddGeneralError(messageKey string, defaultLabel string) {
-	keys := vi.getExistingMessageKeys(vi.generalErrors)
+	vi.AddGeneralErrorWithParams(messageKey, defaultLabel, nil)
+}
 
-	if keys[messageKey] {
+// AddGeneralErrorWithParams method which adds a general error with the passed MessageKey, DefaultLabel and Parameters.
+// Since general errors aren't bound to any field, errors with the same MessageKey and different Parameters are all kept.
+func (vi *ValidationInfo) AddGeneralErrorWithParams(messageKey string, defaultLabel string, params map[string]string) {
+	if vi.hasError(vi.generalErrors, messageKey, params) {
 		return
 	}
 
@@ -82,6 +169,9 @@ func (vi *ValidationInfo) AddGeneralError(messageKey string, defaultLabel string
 		MessageKey:   messageKey,
 		DefaultLabel: defaultLabel,
 	}
+	if len(params) > 0 {
+		err.Parameters = params
+	}
 
 	vi.generalErrors = append(vi.generalErrors, err)
 }
@@ -91,24 +181,123 @@ func (vi *ValidationInfo) GetGeneralErrors() []Error {
 	return vi.generalErrors
 }
 
+// AddGeneralWarning method which adds a general warning with the passed MessageKey and DefaultLabel
+func (vi *ValidationInfo) AddGeneralWarning(messageKey string, defaultLabel string) {
+	vi.AddGeneralWarningWithParams(messageKey, defaultLabel, nil)
+}
+
+// AddGeneralWarningWithParams method which adds a general warning with the passed MessageKey, DefaultLabel and Parameters.
+// Like with general errors, warnings with the same MessageKey and different Parameters are all kept.
+func (vi *ValidationInfo) AddGeneralWarningWithParams(messageKey string, defaultLabel string, params map[string]string) {
+	if vi.hasError(vi.generalWarnings, messageKey, params) {
+		return
+	}
+
+	vi.generalWarnings = append(vi.generalWarnings, newWarning(messageKey, defaultLabel, params))
+}
+
+// GetGeneralWarnings method which returns list of all general warnings
+func (vi *ValidationInfo) GetGeneralWarnings() []Error {
+	return vi.generalWarnings
+}
+
 // AppendFieldErrors method which appends all provided validation errors to field errors, without duplicating existing ones
+// Since map doesn't have any order, fields are appended sorted by their names. Errors with warning severity
+// are appended to field warnings.
 func (vi *ValidationInfo) AppendFieldErrors(fieldErrors map[string][]Error) {
-	for fieldName, errs := range fieldErrors {
-		for _, err := range errs {
-			vi.AddFieldError(fieldName, err.MessageKey, err.DefaultLabel)
+	for _, fieldName := range sortedFieldNames(fieldErrors) {
+		for _, err := range fieldErrors[fieldName] {
+			if err.IsWarning() {
+				vi.AddFieldWarningWithParams(fieldName, err.MessageKey, err.DefaultLabel, err.Parameters)
+				continue
+			}
+			vi.AddFieldErrorWithParams(fieldName, err.MessageKey, err.DefaultLabel, err.Parameters)
 		}
 	}
 }
 
+// Merge method which appends all general and field errors from other validation info, without duplicating existing ones
+func (vi *ValidationInfo) Merge(other ValidationInfo) {
+	vi.AppendWithPrefix("", other)
+}
+
+// AppendWithPrefix method which appends all general and field errors from other validation info, without duplicating
+// existing ones, where names of fields are prefixed (like "street" into "billing.street" for prefix "billing").
+// Field errors are appended in the same order as they are ordered in other validation info.
+func (vi *ValidationInfo) AppendWithPrefix(prefix string, other ValidationInfo) {
+	vi.AppendGeneralErrors(other.GetGeneralErrors())
+
+	for _, entry := range other.FieldErrorsSorted() {
+		for _, err := range entry.Errors {
+			vi.AddFieldErrorWithParams(prefixFieldName(prefix, entry.FieldName), err.MessageKey, err.DefaultLabel, err.Parameters)
+		}
+	}
+
+	for _, warning := range other.GetGeneralWarnings() {
+		vi.AddGeneralWarningWithParams(warning.MessageKey, warning.DefaultLabel, warning.Parameters)
+	}
+
+	for _, entry := range other.FieldWarningsSorted() {
+		for _, warning := range entry.Errors {
+			vi.AddFieldWarningWithParams(prefixFieldName(prefix, entry.FieldName), warning.MessageKey, warning.DefaultLabel, warning.Parameters)
+		}
+	}
+}
+
+// ErrorsForPrefix method which returns new validation info with field errors of fields with prefix, where prefix is
+// removed from field names (like "billing.street" into "street" for prefix "billing"). Errors of field which is equal
+// to prefix are returned as general errors, so they can be presented in sub-template of that field. Field warnings
+// are returned in the same way.
+func (vi *ValidationInfo) ErrorsForPrefix(prefix string) ValidationInfo {
+	result := ValidationInfo{}
+
+	for _, entry := range vi.FieldErrorsSorted() {
+		fieldName, errs := entry.FieldName, entry.Errors
+		switch {
+		case fieldName == prefix:
+			result.AppendGeneralErrors(errs)
+		case strings.HasPrefix(fieldName, prefix+"."):
+			result.AppendFieldErrors(map[string][]Error{
+				strings.TrimPrefix(fieldName, prefix+"."): errs,
+			})
+		}
+	}
+
+	for _, entry := range vi.FieldWarningsSorted() {
+		for _, warning := range entry.Errors {
+			switch {
+			case entry.FieldName == prefix:
+				result.AddGeneralWarningWithParams(warning.MessageKey, warning.DefaultLabel, warning.Parameters)
+			case strings.HasPrefix(entry.FieldName, prefix+"."):
+				result.AddFieldWarningWithParams(strings.TrimPrefix(entry.FieldName, prefix+"."), warning.MessageKey, warning.DefaultLabel, warning.Parameters)
+			}
+		}
+	}
+
+	return result
+}
+
 // RemoveAllFieldError method which removes field errors again
 func (vi *ValidationInfo) RemoveAllFieldError(fieldName string) {
 	if _, ok := vi.fieldErrors[fieldName]; ok {
 		delete(vi.fieldErrors, fieldName)
 	}
+
+	for i, name := range vi.fieldNames {
+		if name == fieldName {
+			vi.fieldNames = append(vi.fieldNames[:i:i], vi.fieldNames[i+1:]...)
+			break
+		}
+	}
 }
 
 // AddFieldError method which adds a field error with the passed field name, message key and default label
 func (vi *ValidationInfo) AddFieldError(fieldName string, messageKey string, defaultLabel string) {
+	vi.AddFieldErrorWithParams(fieldName, messageKey, defaultLabel, nil)
+}
+
+// AddFieldErrorWithParams method which adds a field error with the passed field name, message key, default label and parameters
+func (vi *ValidationInfo) AddFieldErrorWithParams(fieldName string, messageKey string, defaultLabel string, params map[string]string) {
 	if vi.fieldErrors == nil {
 		vi.fieldErrors = map[string][]Error{}
 	}
@@ -119,30 +308,98 @@ func (vi *ValidationInfo) AddFieldError(fieldName string, messageKey string, def
 		return
 	}
 
+	if _, ok := vi.fieldErrors[fieldName]; !ok {
+		vi.fieldNames = append(vi.fieldNames, fieldName)
+	}
+
 	err := Error{
 		MessageKey:   messageKey,
 		DefaultLabel: defaultLabel,
 	}
+	if len(params) > 0 {
+		err.Parameters = params
+	}
 
 	vi.fieldErrors[fieldName] = append(vi.fieldErrors[fieldName], err)
 }
 
-// GetErrorsForAllFields method which returns list of all field validation errors for all fields
+// AddFieldWarning method which adds a field warning with the passed field name, message key and default label
+func (vi *ValidationInfo) AddFieldWarning(fieldName string, messageKey string, defaultLabel string) {
+	vi.AddFieldWarningWithParams(fieldName, messageKey, defaultLabel, nil)
+}
+
+// AddFieldWarningWithParams method which adds a field warning with the passed field name, message key, default label and parameters
+func (vi *ValidationInfo) AddFieldWarningWithParams(fieldName string, messageKey string, defaultLabel string, params map[string]string) {
+	if vi.fieldWarnings == nil {
+		vi.fieldWarnings = map[string][]Error{}
+	}
+
+	if vi.getExistingMessageKeys(vi.fieldWarnings[fieldName])[messageKey] {
+		return
+	}
+
+	if _, ok := vi.fieldWarnings[fieldName]; !ok {
+		vi.fieldWarningNames = append(vi.fieldWarningNames, fieldName)
+	}
+
+	vi.fieldWarnings[fieldName] = append(vi.fieldWarnings[fieldName], newWarning(messageKey, defaultLabel, params))
+}
+
+// GetWarningsForField method which returns list of all warnings for specific field
+func (vi *ValidationInfo) GetWarningsForField(fieldName string) []Error {
+	return vi.fieldWarnings[fieldName]
+}
+
+// FieldWarningsSorted method which returns warnings of all fields in order, in which the first warning of each field is added
+func (vi *ValidationInfo) FieldWarningsSorted() []FieldErrorsEntry {
+	entries := make([]FieldErrorsEntry, 0, len(vi.fieldWarningNames))
+	for _, fieldName := range vi.fieldWarningNames {
+		entries = append(entries, FieldErrorsEntry{FieldName: fieldName, Errors: vi.fieldWarnings[fieldName]})
+	}
+
+	return entries
+}
+
+// GetErrorsForAllFields method which returns list of all field validation errors for all fields.
+// Since map doesn't have any order, FieldErrorsSorted should be used when field errors are iterated.
 func (vi *ValidationInfo) GetErrorsForAllFields() map[string][]Error {
 	return vi.fieldErrors
 }
 
+// FieldErrorsSorted method which returns errors of all fields in stable order, in which the first error of each field is added.
+// For struct validation this means that fields are ordered as they are declared in validated struct.
+func (vi *ValidationInfo) FieldErrorsSorted() []FieldErrorsEntry {
+	entries := make([]FieldErrorsEntry, 0, len(vi.fieldErrors))
+	added := make(map[string]bool, len(vi.fieldErrors))
+
+	for _, fieldName := range vi.fieldNames {
+		if errs, ok := vi.fieldErrors[fieldName]; ok && !added[fieldName] {
+			entries = append(entries, FieldErrorsEntry{FieldName: fieldName, Errors: errs})
+			added[fieldName] = true
+		}
+	}
+
+	// field errors which are added directly into map returned by GetErrorsForAllFields are placed at the end
+	for _, fieldName := range sortedFieldNames(vi.fieldErrors) {
+		if !added[fieldName] {
+			entries = append(entries, FieldErrorsEntry{FieldName: fieldName, Errors: vi.fieldErrors[fieldName]})
+		}
+	}
+
+	return entries
+}
+
 // GetErrorsForField method which returns list of all general validation errors for specific field
 func (vi *ValidationInfo) GetErrorsForField(fieldName string) []Error {
 	return vi.fieldErrors[fieldName]
 }
 
-//GetValidationSummary - returns a string with all validation messages - useful for logging or other summarized needs
+// GetValidationSummary - returns a string with all validation messages - useful for logging or other summarized needs
 func (vi *ValidationInfo) GetValidationSummary() string {
 	result := "invalid form: "
-	for k, errors := range vi.GetErrorsForAllFields() {
-		result = result + " / " + k
-		for _, error := range errors {
+	for _, entry := range vi.FieldErrorsSorted() {
+		result = result + " / " + entry.FieldName
+		for _, error := range entry.Errors {
 			result = result + error.MessageKey
 		}
 	}
@@ -158,12 +415,179 @@ func (vi *ValidationInfo) getExistingMessageKeys(errs []Error) map[string]bool {
 	return keys
 }
 
-// MarshalJSON - implements MarshalJson interface - so that we can use response
+// hasError method which returns true if specific list of validation errors already contains error
+// with the same message key and parameters
+func (vi *ValidationInfo) hasError(errs []Error, messageKey string, params map[string]string) bool {
+	for _, err := range errs {
+		if err.MessageKey != messageKey || len(err.Parameters) != len(params) {
+			continue
+		}
+
+		same := true
+		for name, value := range params {
+			if existing, ok := err.Parameters[name]; !ok || existing != value {
+				same = false
+				break
+			}
+		}
+		if same {
+			return true
+		}
+	}
+	return false
+}
+
+// MarshalJSON - implements MarshalJson interface - so that we can use response.
+// JSON representation contains "valid", "generalErrors" and "fieldErrors", where field errors are mapped by field names.
 func (vi ValidationInfo) MarshalJSON() ([]byte, error) {
-	validationInfoEnodeAble := validationInfoEnodeAble{
-		FieldErrors:   vi.fieldErrors,
-		GeneralErrors: vi.generalErrors,
-		IsValid:       vi.IsValid(),
+	return json.Marshal(vi.toEncodeAble())
+}
+
+// UnmarshalJSON - implements UnmarshalJSON interface - so that JSON representation can be transformed back to ValidationInfo
+func (vi *ValidationInfo) UnmarshalJSON(data []byte) error {
+	decoded := validationInfoEnodeAble{}
+	err := json.Unmarshal(data, &decoded)
+	if err != nil {
+		return err
+	}
+
+	*vi = ValidationInfo{}
+	vi.AppendGeneralErrors(decoded.GeneralErrors)
+	for _, entry := range decoded.FieldErrors {
+		for _, err := range entry.Errors {
+			vi.AddFieldErrorWithParams(entry.FieldName, err.MessageKey, err.DefaultLabel, err.Parameters)
+		}
+	}
+	for _, warning := range decoded.GeneralWarnings {
+		vi.AddGeneralWarningWithParams(warning.MessageKey, warning.DefaultLabel, warning.Parameters)
+	}
+	for _, entry := range decoded.FieldWarnings {
+		for _, warning := range entry.Errors {
+			vi.AddFieldWarningWithParams(entry.FieldName, warning.MessageKey, warning.DefaultLabel, warning.Parameters)
+		}
+	}
+
+	return nil
+}
+
+// toEncodeAble method which transforms validation info into its JSON representation, with empty lists instead of nil values
+func (vi *ValidationInfo) toEncodeAble() validationInfoEnodeAble {
+	encodeAble := validationInfoEnodeAble{
+		Valid:           vi.IsValid(),
+		GeneralErrors:   vi.generalErrors,
+		FieldErrors:     vi.FieldErrorsSorted(),
+		GeneralWarnings: vi.generalWarnings,
+		FieldWarnings:   vi.FieldWarningsSorted(),
+	}
+
+	if encodeAble.GeneralErrors == nil {
+		encodeAble.GeneralErrors = []Error{}
 	}
-	return json.Marshal(&validationInfoEnodeAble)
+
+	return encodeAble
+}
+
+// MarshalJSON - implements MarshalJson interface, where field errors are encoded as JSON object with field names as keys,
+// in the same order as entries are ordered
+func (e FieldErrorsEntries) MarshalJSON() ([]byte, error) {
+	buffer := bytes.NewBufferString("{")
+
+	for i, entry := range e {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+
+		key, err := json.Marshal(entry.FieldName)
+		if err != nil {
+			return nil, err
+		}
+
+		errs := entry.Errors
+		if errs == nil {
+			errs = []Error{}
+		}
+		value, err := json.Marshal(errs)
+		if err != nil {
+			return nil, err
+		}
+
+		buffer.Write(key)
+		buffer.WriteString(":")
+		buffer.Write(value)
+	}
+
+	buffer.WriteString("}")
+
+	return buffer.Bytes(), nil
+}
+
+// UnmarshalJSON - implements UnmarshalJSON interface, where order of keys in JSON object is kept as order of entries
+func (e *FieldErrorsEntries) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+
+	*e = nil
+	if token == nil {
+		return nil
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return &json.UnmarshalTypeError{Value: "non-object", Type: reflect.TypeOf(e).Elem()}
+	}
+
+	for decoder.More() {
+		token, err = decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		var errs []Error
+		if err := decoder.Decode(&errs); err != nil {
+			return err
+		}
+
+		*e = append(*e, FieldErrorsEntry{FieldName: token.(string), Errors: errs})
+	}
+
+	_, err = decoder.Token()
+
+	return err
+}
+
+// newWarning returns new error with warning severity
+func newWarning(messageKey string, defaultLabel string, params map[string]string) Error {
+	warning := Error{
+		MessageKey:   messageKey,
+		DefaultLabel: defaultLabel,
+		Severity:     SeverityWarning,
+	}
+	if len(params) > 0 {
+		warning.Parameters = params
+	}
+
+	return warning
+}
+
+// prefixFieldName returns field name prefixed with dot separated prefix, or field name itself if there is no prefix
+func prefixFieldName(prefix string, fieldName string) string {
+	if prefix == "" {
+		return fieldName
+	}
+
+	return prefix + "." + fieldName
+}
+
+// sortedFieldNames returns names of fields from map of field errors, sorted by their names
+func sortedFieldNames(fieldErrors map[string][]Error) []string {
+	fieldNames := make([]string, 0, len(fieldErrors))
+	for fieldName := range fieldErrors {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	return fieldNames
 }
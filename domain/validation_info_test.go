@@ -306,9 +306,477 @@ func (t *ValidationInfoTestSuite) TestAppendFieldErrors() {
 	}, t.validationInfo.GetErrorsForAllFields())
 }
 
+func (t *ValidationInfoTestSuite) TestMerge() {
+	other := ValidationInfo{}
+	t.validationInfo.Merge(other)
+	t.True(t.validationInfo.IsValid())
+
+	other.AddGeneralError("general", "general error")
+	other.AddFieldError("street", "formError.street.required", "street required")
+
+	t.validationInfo.Merge(other)
+	t.False(t.validationInfo.IsValid())
+	t.Equal([]Error{
+		{
+			MessageKey:   "general",
+			DefaultLabel: "general error",
+		},
+	}, t.validationInfo.GetGeneralErrors())
+	t.Equal(map[string][]Error{
+		"street": {
+			{
+				MessageKey:   "formError.street.required",
+				DefaultLabel: "street required",
+			},
+		},
+	}, t.validationInfo.GetErrorsForAllFields())
+
+	t.validationInfo.Merge(other)
+	t.Len(t.validationInfo.GetGeneralErrors(), 1)
+	t.Len(t.validationInfo.GetErrorsForField("street"), 1)
+}
+
+func (t *ValidationInfoTestSuite) TestAppendWithPrefix() {
+	other := ValidationInfo{}
+	other.AddGeneralError("general", "general error")
+	other.AddFieldErrorWithParams("street", "formError.street.required", "street required", map[string]string{
+		"tag": "required",
+	})
+
+	t.validationInfo.AddGeneralError("general", "general error")
+	t.validationInfo.AddFieldError("street", "formError.street.required", "street required")
+
+	t.validationInfo.AppendWithPrefix("billing", other)
+	t.validationInfo.AppendWithPrefix("billing", other)
+	t.False(t.validationInfo.IsValid())
+	t.Equal([]Error{
+		{
+			MessageKey:   "general",
+			DefaultLabel: "general error",
+		},
+	}, t.validationInfo.GetGeneralErrors())
+	t.Equal(map[string][]Error{
+		"street": {
+			{
+				MessageKey:   "formError.street.required",
+				DefaultLabel: "street required",
+			},
+		},
+		"billing.street": {
+			{
+				MessageKey:   "formError.street.required",
+				DefaultLabel: "street required",
+				Parameters: map[string]string{
+					"tag": "required",
+				},
+			},
+		},
+	}, t.validationInfo.GetErrorsForAllFields())
+}
+
+func (t *ValidationInfoTestSuite) TestErrorsForPrefix() {
+	empty := t.validationInfo.ErrorsForPrefix("billing")
+	t.True(empty.IsValid())
+
+	t.validationInfo.AddGeneralError("general", "general error")
+	t.validationInfo.AddFieldError("billing", "formError.billing.required", "billing required")
+	t.validationInfo.AddFieldError("billing.street", "formError.billing.street.required", "street required")
+	t.validationInfo.AddFieldError("billing.address.city", "formError.billing.address.city.required", "city required")
+	t.validationInfo.AddFieldError("billingCountry", "formError.billingCountry.required", "country required")
+	t.validationInfo.AddFieldError("shipping.street", "formError.shipping.street.required", "street required")
+
+	result := t.validationInfo.ErrorsForPrefix("billing")
+	t.False(result.IsValid())
+	t.Equal([]Error{
+		{
+			MessageKey:   "formError.billing.required",
+			DefaultLabel: "billing required",
+		},
+	}, result.GetGeneralErrors())
+	t.Equal(map[string][]Error{
+		"street": {
+			{
+				MessageKey:   "formError.billing.street.required",
+				DefaultLabel: "street required",
+			},
+		},
+		"address.city": {
+			{
+				MessageKey:   "formError.billing.address.city.required",
+				DefaultLabel: "city required",
+			},
+		},
+	}, result.GetErrorsForAllFields())
+
+	empty = t.validationInfo.ErrorsForPrefix("payment")
+	t.True(empty.IsValid())
+	t.Len(t.validationInfo.GetErrorsForAllFields(), 5)
+}
+
+func (t *ValidationInfoTestSuite) TestAddErrorsWithParams() {
+	t.validationInfo.AddGeneralErrorWithParams("messageKey1", "defaultLabel1", map[string]string{
+		"min": "8",
+	})
+	t.validationInfo.AddGeneralErrorWithParams("messageKey2", "defaultLabel2", map[string]string{})
+	t.validationInfo.AddFieldErrorWithParams("fieldName1", "messageKey1", "defaultLabel1", map[string]string{
+		"max": "10",
+	})
+
+	t.Equal([]Error{
+		{
+			MessageKey:   "messageKey1",
+			DefaultLabel: "defaultLabel1",
+			Parameters: map[string]string{
+				"min": "8",
+			},
+		},
+		{
+			MessageKey:   "messageKey2",
+			DefaultLabel: "defaultLabel2",
+		},
+	}, t.validationInfo.GetGeneralErrors())
+	t.Equal([]Error{
+		{
+			MessageKey:   "messageKey1",
+			DefaultLabel: "defaultLabel1",
+			Parameters: map[string]string{
+				"max": "10",
+			},
+		},
+	}, t.validationInfo.GetErrorsForField("fieldName1"))
+}
+
+func (t *ValidationInfoTestSuite) TestAppendErrorsWithParams() {
+	source := ValidationInfo{}
+	source.AddGeneralErrorWithParams("messageKey1", "defaultLabel1", map[string]string{
+		"min": "8",
+	})
+	source.AddFieldErrorWithParams("fieldName1", "messageKey1", "defaultLabel1", map[string]string{
+		"max": "10",
+	})
+
+	t.validationInfo.AppendGeneralErrors(source.GetGeneralErrors())
+	t.validationInfo.AppendFieldErrors(source.GetErrorsForAllFields())
+
+	t.Equal(source.GetGeneralErrors(), t.validationInfo.GetGeneralErrors())
+	t.Equal(source.GetErrorsForAllFields(), t.validationInfo.GetErrorsForAllFields())
+}
+
 func (t *ValidationInfoTestSuite) TestMarshalJson() {
 	t.validationInfo.AddFieldError("key", "error", "error")
 	jsonString, _ := json.Marshal(t.validationInfo)
 	assert.Contains(t.T(), string(jsonString), "key")
+	assert.NotContains(t.T(), string(jsonString), "parameters")
+
+}
+
+func (t *ValidationInfoTestSuite) TestMarshalJson_Parameters() {
+	t.validationInfo.AddFieldErrorWithParams("key", "error", "error", map[string]string{
+		"min": "8",
+	})
+	jsonString, _ := json.Marshal(t.validationInfo)
+	assert.Contains(t.T(), string(jsonString), `"parameters":{"min":"8"}`)
+}
+
+func (t *ValidationInfoTestSuite) TestMarshalJson_Contract() {
+	jsonString, err := json.Marshal(t.validationInfo)
+	t.NoError(err)
+	t.JSONEq(`{"valid": true, "generalErrors": [], "fieldErrors": {}}`, string(jsonString))
+
+	t.validationInfo.AddGeneralError("general", "general error")
+	t.validationInfo.AddFieldErrorWithParams("email", "formError.email.min", "email min", map[string]string{
+		"min": "8",
+	})
+	jsonString, err = json.Marshal(t.validationInfo)
+	t.NoError(err)
+	t.JSONEq(`{
+		"valid": false,
+		"generalErrors": [{"messageKey": "general", "defaultLabel": "general error"}],
+		"fieldErrors": {"email": [{"messageKey": "formError.email.min", "defaultLabel": "email min", "parameters": {"min": "8"}}]}
+	}`, string(jsonString))
+}
+
+func (t *ValidationInfoTestSuite) TestUnmarshalJson() {
+	t.validationInfo.AddGeneralError("general", "general error")
+	t.validationInfo.AddFieldErrorWithParams("items[1].sku", "formError.items[1].sku.required", "sku required", map[string]string{
+		"tag": "required",
+	})
+
+	jsonString, err := json.Marshal(t.validationInfo)
+	t.NoError(err)
+
+	decoded := ValidationInfo{}
+	t.NoError(json.Unmarshal(jsonString, &decoded))
+	t.Equal(t.validationInfo, decoded)
+
+	t.Error(json.Unmarshal([]byte("wrong"), &decoded))
+}
+
+func (t *ValidationInfoTestSuite) TestFieldErrorsSorted() {
+	t.Empty(t.validationInfo.FieldErrorsSorted())
+
+	t.validationInfo.AddFieldError("zip", "formError.zip.required", "zip required")
+	t.validationInfo.AddFieldError("city", "formError.city.required", "city required")
+	t.validationInfo.AddFieldError("items[10].sku", "formError.items[10].sku.required", "sku required")
+	t.validationInfo.AddFieldError("items[2].sku", "formError.items[2].sku.required", "sku required")
+	t.validationInfo.AddFieldError("zip", "formError.zip.len", "zip len")
+	t.validationInfo.AddFieldError("street", "formError.street.required", "street required")
+	t.validationInfo.RemoveAllFieldError("street")
+	t.validationInfo.GetErrorsForAllFields()["country"] = []Error{
+		{
+			MessageKey:   "formError.country.required",
+			DefaultLabel: "country required",
+		},
+	}
+
+	t.Equal([]FieldErrorsEntry{
+		{
+			FieldName: "zip",
+			Errors: []Error{
+				{
+					MessageKey:   "formError.zip.required",
+					DefaultLabel: "zip required",
+				},
+				{
+					MessageKey:   "formError.zip.len",
+					DefaultLabel: "zip len",
+				},
+			},
+		},
+		{
+			FieldName: "city",
+			Errors: []Error{
+				{
+					MessageKey:   "formError.city.required",
+					DefaultLabel: "city required",
+				},
+			},
+		},
+		{
+			FieldName: "items[10].sku",
+			Errors: []Error{
+				{
+					MessageKey:   "formError.items[10].sku.required",
+					DefaultLabel: "sku required",
+				},
+			},
+		},
+		{
+			FieldName: "items[2].sku",
+			Errors: []Error{
+				{
+					MessageKey:   "formError.items[2].sku.required",
+					DefaultLabel: "sku required",
+				},
+			},
+		},
+		{
+			FieldName: "country",
+			Errors: []Error{
+				{
+					MessageKey:   "formError.country.required",
+					DefaultLabel: "country required",
+				},
+			},
+		},
+	}, t.validationInfo.FieldErrorsSorted())
+}
+
+func (t *ValidationInfoTestSuite) TestFieldErrorsSorted_MergedOrder() {
+	other := ValidationInfo{}
+	other.AddFieldError("street", "formError.street.required", "street required")
+	other.AddFieldError("city", "formError.city.required", "city required")
+
+	t.validationInfo.AddFieldError("name", "formError.name.required", "name required")
+	t.validationInfo.AppendWithPrefix("billing", other)
+	t.validationInfo.Merge(other)
+	t.validationInfo.AppendFieldErrors(map[string][]Error{
+		"phone": {{MessageKey: "formError.phone.required"}},
+		"email": {{MessageKey: "formError.email.required"}},
+	})
+
+	var fieldNames []string
+	for _, entry := range t.validationInfo.FieldErrorsSorted() {
+		fieldNames = append(fieldNames, entry.FieldName)
+	}
+	t.Equal([]string{"name", "billing.street", "billing.city", "street", "city", "email", "phone"}, fieldNames)
+
+	billing := t.validationInfo.ErrorsForPrefix("billing")
+	fieldNames = nil
+	for _, entry := range billing.FieldErrorsSorted() {
+		fieldNames = append(fieldNames, entry.FieldName)
+	}
+	t.Equal([]string{"street", "city"}, fieldNames)
+}
+
+func (t *ValidationInfoTestSuite) TestMarshalJson_StableOrder() {
+	var first string
+	for i := 0; i < 50; i++ {
+		validationInfo := ValidationInfo{}
+		validationInfo.AddGeneralError("formError.general", "general error")
+		validationInfo.AddGeneralError("formError.csrf", "csrf error")
+		for _, fieldName := range []string{"zip", "city", "street", "items[1].sku", "items[0].sku"} {
+			validationInfo.AddFieldError(fieldName, "formError."+fieldName+".required", fieldName+" required")
+		}
+
+		jsonString, err := json.Marshal(validationInfo)
+		t.NoError(err)
+
+		if i == 0 {
+			first = string(jsonString)
+		}
+		t.Equal(first, string(jsonString))
+	}
+
+	t.Equal(`{"valid":false,"generalErrors":[`+
+		`{"messageKey":"formError.general","defaultLabel":"general error"},`+
+		`{"messageKey":"formError.csrf","defaultLabel":"csrf error"}],"fieldErrors":{`+
+		`"zip":[{"messageKey":"formError.zip.required","defaultLabel":"zip required"}],`+
+		`"city":[{"messageKey":"formError.city.required","defaultLabel":"city required"}],`+
+		`"street":[{"messageKey":"formError.street.required","defaultLabel":"street required"}],`+
+		`"items[1].sku":[{"messageKey":"formError.items[1].sku.required","defaultLabel":"items[1].sku required"}],`+
+		`"items[0].sku":[{"messageKey":"formError.items[0].sku.required","defaultLabel":"items[0].sku required"}]}}`, first)
+}
+
+func (t *ValidationInfoTestSuite) TestUnmarshalJson_Order() {
+	t.validationInfo.AddFieldError("zip", "formError.zip.required", "zip required")
+	t.validationInfo.AddFieldError("city", "formError.city.required", "city required")
+	t.validationInfo.AddFieldError("street", "formError.street.required", "street required")
+
+	jsonString, err := json.Marshal(t.validationInfo)
+	t.NoError(err)
+
+	decoded := ValidationInfo{}
+	t.NoError(json.Unmarshal(jsonString, &decoded))
+	t.Equal(t.validationInfo, decoded)
+
+	t.NoError(json.Unmarshal([]byte(`{"valid": true, "generalErrors": null, "fieldErrors": null}`), &decoded))
+	t.Equal(ValidationInfo{}, decoded)
+
+	t.Error(json.Unmarshal([]byte(`{"fieldErrors": ["zip"]}`), &decoded))
+}
+
+func (t *ValidationInfoTestSuite) TestIsValid_OnlyWarnings() {
+	t.validationInfo.AddFieldWarning("email", "formError.email.domainTypo", "email domain looks like a typo")
+	t.validationInfo.AddGeneralWarning("formWarning.late", "order is placed late")
+
+	t.True(t.validationInfo.IsValid())
+	t.True(t.validationInfo.HasWarnings())
+	t.True(t.validationInfo.HasWarningsForField("email"))
+	t.False(t.validationInfo.HasWarningsForField("name"))
+	t.False(t.validationInfo.HasAnyFieldErrors())
+	t.False(t.validationInfo.HasGeneralErrors())
+	t.Empty(t.validationInfo.GetErrorsForField("email"))
+	t.Equal([]Error{
+		{
+			MessageKey:   "formError.email.domainTypo",
+			DefaultLabel: "email domain looks like a typo",
+			Severity:     SeverityWarning,
+		},
+	}, t.validationInfo.GetWarningsForField("email"))
+	t.Equal([]Error{
+		{
+			MessageKey:   "formWarning.late",
+			DefaultLabel: "order is placed late",
+			Severity:     SeverityWarning,
+		},
+	}, t.validationInfo.GetGeneralWarnings())
+}
+
+func (t *ValidationInfoTestSuite) TestIsValid_WarningsAndErrors() {
+	t.validationInfo.AddFieldWarning("email", "formError.email.domainTypo", "email domain looks like a typo")
+	t.validationInfo.AddFieldError("name", "formError.name.required", "name required")
+
+	t.False(t.validationInfo.IsValid())
+	t.True(t.validationInfo.HasWarnings())
+	t.False(t.validationInfo.HasErrorsForField("email"))
+	t.True(t.validationInfo.HasErrorsForField("name"))
+}
+
+func (t *ValidationInfoTestSuite) TestAddWarning_Duplicates() {
+	t.validationInfo.AddFieldWarning("email", "formError.email.domainTypo", "email domain looks like a typo")
+	t.validationInfo.AddFieldWarning("email", "formError.email.domainTypo", "email domain looks like a typo")
+	t.validationInfo.AddGeneralWarning("formWarning.late", "order is placed late")
+	t.validationInfo.AddGeneralWarning("formWarning.late", "order is placed late")
+
+	t.Len(t.validationInfo.GetWarningsForField("email"), 1)
+	t.Len(t.validationInfo.GetGeneralWarnings(), 1)
+}
+
+func (t *ValidationInfoTestSuite) TestAddGeneralError_DifferentParams() {
+	t.validationInfo.AddGeneralErrorWithParams("formError.validatorPanic", "taxid panicked", map[string]string{"field": "taxId"})
+	t.validationInfo.AddGeneralErrorWithParams("formError.validatorPanic", "taxid panicked", map[string]string{"field": "taxId"})
+	t.validationInfo.AddGeneralErrorWithParams("formError.validatorPanic", "blacklist panicked", map[string]string{"field": "company"})
+	t.validationInfo.AddGeneralWarningWithParams("formWarning.late", "order is placed late", map[string]string{"days": "2"})
+	t.validationInfo.AddGeneralWarningWithParams("formWarning.late", "order is placed late", map[string]string{"days": "3"})
+
+	t.Len(t.validationInfo.GetGeneralErrors(), 2)
+	t.Len(t.validationInfo.GetGeneralWarnings(), 2)
+}
+
+func (t *ValidationInfoTestSuite) TestErrorSeverity() {
+	t.Equal(SeverityError, Error{}.GetSeverity())
+	t.False(Error{}.IsWarning())
+	t.Equal(SeverityWarning, Error{Severity: SeverityWarning}.GetSeverity())
+	t.True(Error{Severity: SeverityWarning}.IsWarning())
+}
+
+func (t *ValidationInfoTestSuite) TestAppendWarnings() {
+	t.validationInfo.AppendGeneralErrors([]Error{
+		{
+			MessageKey:   "formWarning.late",
+			DefaultLabel: "order is placed late",
+			Severity:     SeverityWarning,
+		},
+	})
+	t.validationInfo.AppendFieldErrors(map[string][]Error{
+		"email": {
+			{
+				MessageKey:   "formError.email.domainTypo",
+				DefaultLabel: "email domain looks like a typo",
+				Severity:     SeverityWarning,
+			},
+		},
+	})
+
+	t.True(t.validationInfo.IsValid())
+	t.Len(t.validationInfo.GetGeneralWarnings(), 1)
+	t.Len(t.validationInfo.GetWarningsForField("email"), 1)
+}
+
+func (t *ValidationInfoTestSuite) TestAppendWithPrefix_Warnings() {
+	other := ValidationInfo{}
+	other.AddGeneralWarning("formWarning.late", "order is placed late")
+	other.AddFieldWarningWithParams("email", "formError.email.domainTypo", "email domain looks like a typo", map[string]string{
+		"suggestion": "gmail.com",
+	})
+
+	t.validationInfo.AppendWithPrefix("billing", other)
+	t.True(t.validationInfo.IsValid())
+	t.Equal(other.GetGeneralWarnings(), t.validationInfo.GetGeneralWarnings())
+	t.Equal(other.GetWarningsForField("email"), t.validationInfo.GetWarningsForField("billing.email"))
+
+	forPrefix := t.validationInfo.ErrorsForPrefix("billing")
+	t.Equal(other.GetWarningsForField("email"), forPrefix.GetWarningsForField("email"))
+	t.Empty(forPrefix.GetGeneralWarnings())
+}
 
+func (t *ValidationInfoTestSuite) TestMarshalJson_Warnings() {
+	t.validationInfo.AddFieldError("name", "formError.name.required", "name required")
+	t.validationInfo.AddFieldWarning("email", "formError.email.domainTypo", "email domain looks like a typo")
+	t.validationInfo.AddGeneralWarning("formWarning.late", "order is placed late")
+
+	jsonString, err := json.Marshal(t.validationInfo)
+	t.NoError(err)
+	t.JSONEq(`{
+		"valid": false,
+		"generalErrors": [],
+		"fieldErrors": {"name": [{"messageKey": "formError.name.required", "defaultLabel": "name required"}]},
+		"generalWarnings": [{"messageKey": "formWarning.late", "defaultLabel": "order is placed late", "severity": "warning"}],
+		"fieldWarnings": {"email": [{"messageKey": "formError.email.domainTypo", "defaultLabel": "email domain looks like a typo", "severity": "warning"}]}
+	}`, string(jsonString))
+
+	decoded := ValidationInfo{}
+	t.NoError(json.Unmarshal(jsonString, &decoded))
+	t.Equal(t.validationInfo, decoded)
 }
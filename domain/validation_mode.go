@@ -0,0 +1,82 @@
+package domain
+
+type (
+	// ValidationMode as struct which defines how submitted form data is validated.
+	// Zero value of ValidationMode is ValidationModeFull.
+	ValidationMode struct {
+		kind validationModeKind
+		tags map[string]bool
+	}
+
+	// validationModeKind as type for defining kinds of validation modes
+	validationModeKind int
+)
+
+const (
+	validationModeKindFull validationModeKind = iota
+	validationModeKindNone
+	validationModeKindPartial
+)
+
+var (
+	// ValidationModeFull as validation mode where form data is validated with all validation rules
+	ValidationModeFull = ValidationMode{kind: validationModeKindFull}
+	// ValidationModeNone as validation mode where form data is not validated at all
+	ValidationModeNone = ValidationMode{kind: validationModeKindNone}
+)
+
+// ValidationModePartial creates validation mode where only errors from validation rules with provided tags are kept (like "required").
+// Errors from other validation rules are discarded, while field errors without validation tag (like decoding errors
+// or errors added by struct validators), general errors and all warnings are always kept, since they aren't bound
+// to any validation rule which could be skipped.
+func ValidationModePartial(tags ...string) ValidationMode {
+	mode := ValidationMode{
+		kind: validationModeKindPartial,
+		tags: map[string]bool{},
+	}
+
+	for _, tag := range tags {
+		mode.tags[tag] = true
+	}
+
+	return mode
+}
+
+// ShouldValidate as method which defines if form data should be validated at all
+func (m ValidationMode) ShouldValidate() bool {
+	return m.kind != validationModeKindNone
+}
+
+// IsFull as method which defines if form data is validated with all validation rules
+func (m ValidationMode) IsFull() bool {
+	return m.kind == validationModeKindFull
+}
+
+// Filter as method which returns validation info with errors allowed by validation mode
+func (m ValidationMode) Filter(validationInfo ValidationInfo) ValidationInfo {
+	switch m.kind {
+	case validationModeKindNone:
+		return ValidationInfo{}
+	case validationModeKindPartial:
+		filtered := ValidationInfo{}
+		filtered.AppendGeneralErrors(validationInfo.GetGeneralErrors())
+		for _, entry := range validationInfo.FieldErrorsSorted() {
+			for _, err := range entry.Errors {
+				if tag, ok := err.Parameters["tag"]; !ok || m.tags[tag] {
+					filtered.AddFieldErrorWithParams(entry.FieldName, err.MessageKey, err.DefaultLabel, err.Parameters)
+				}
+			}
+		}
+
+		filtered.AppendGeneralErrors(validationInfo.GetGeneralWarnings())
+		for _, entry := range validationInfo.FieldWarningsSorted() {
+			for _, warning := range entry.Errors {
+				filtered.AddFieldWarningWithParams(entry.FieldName, warning.MessageKey, warning.DefaultLabel, warning.Parameters)
+			}
+		}
+
+		return filtered
+	}
+
+	return validationInfo
+}
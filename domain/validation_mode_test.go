@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	ValidationModeTestSuite struct {
+		suite.Suite
+
+		validationInfo ValidationInfo
+	}
+)
+
+func TestValidationModeTestSuite(t *testing.T) {
+	suite.Run(t, &ValidationModeTestSuite{})
+}
+
+func (t *ValidationModeTestSuite) SetupTest() {
+	t.validationInfo = ValidationInfo{}
+	t.validationInfo.AddGeneralError("formError.general", "general error")
+	t.validationInfo.AddFieldErrorWithParams("email", "formError.email.required", "Email required", map[string]string{
+		"tag": "required",
+	})
+	t.validationInfo.AddFieldErrorWithParams("email", "formError.email.email", "Email email", map[string]string{
+		"tag": "email",
+	})
+	t.validationInfo.AddFieldErrorWithParams("name", "formError.name.min", "Name min", map[string]string{
+		"tag": "min",
+		"min": "3",
+	})
+	t.validationInfo.AddFieldError("phone", "formError.phone.blocked", "phone blocked")
+}
+
+func (t *ValidationModeTestSuite) TestShouldValidate() {
+	t.True(ValidationMode{}.ShouldValidate())
+	t.True(ValidationModeFull.ShouldValidate())
+	t.True(ValidationModePartial("required").ShouldValidate())
+	t.False(ValidationModeNone.ShouldValidate())
+}
+
+func (t *ValidationModeTestSuite) TestIsFull() {
+	t.True(ValidationMode{}.IsFull())
+	t.True(ValidationModeFull.IsFull())
+	t.False(ValidationModePartial("required").IsFull())
+	t.False(ValidationModeNone.IsFull())
+}
+
+func (t *ValidationModeTestSuite) TestFilter_Full() {
+	t.Equal(t.validationInfo, ValidationModeFull.Filter(t.validationInfo))
+	t.Equal(t.validationInfo, ValidationMode{}.Filter(t.validationInfo))
+}
+
+func (t *ValidationModeTestSuite) TestFilter_None() {
+	filtered := ValidationModeNone.Filter(t.validationInfo)
+	t.True(filtered.IsValid())
+}
+
+func (t *ValidationModeTestSuite) TestFilter_Partial() {
+	filtered := ValidationModePartial("required", "min").Filter(t.validationInfo)
+
+	t.Equal([]Error{
+		{
+			MessageKey:   "formError.general",
+			DefaultLabel: "general error",
+		},
+	}, filtered.GetGeneralErrors())
+	t.Equal(map[string][]Error{
+		"email": {
+			{
+				MessageKey:   "formError.email.required",
+				DefaultLabel: "Email required",
+				Parameters: map[string]string{
+					"tag": "required",
+				},
+			},
+		},
+		"name": {
+			{
+				MessageKey:   "formError.name.min",
+				DefaultLabel: "Name min",
+				Parameters: map[string]string{
+					"tag": "min",
+					"min": "3",
+				},
+			},
+		},
+		"phone": {
+			{
+				MessageKey:   "formError.phone.blocked",
+				DefaultLabel: "phone blocked",
+			},
+		},
+	}, filtered.GetErrorsForAllFields())
+
+	// errors without validation tag are never skipped
+	filtered = ValidationModePartial().Filter(t.validationInfo)
+	t.True(filtered.HasGeneralErrors())
+	t.Equal(map[string][]Error{
+		"phone": {
+			{
+				MessageKey:   "formError.phone.blocked",
+				DefaultLabel: "phone blocked",
+			},
+		},
+	}, filtered.GetErrorsForAllFields())
+}
+
+func (t *ValidationModeTestSuite) TestFilter_PartialWarnings() {
+	t.validationInfo.AddGeneralWarning("formWarning.general", "general warning")
+	t.validationInfo.AddFieldWarningWithParams("email", "formWarning.email.disposable", "Email disposable", map[string]string{
+		"tag": "disposable",
+	})
+	t.validationInfo.AddFieldWarning("phone", "formWarning.phone.format", "Phone format")
+
+	filtered := ValidationModePartial("required").Filter(t.validationInfo)
+
+	t.Equal(t.validationInfo.GetGeneralWarnings(), filtered.GetGeneralWarnings())
+	t.Equal(t.validationInfo.FieldWarningsSorted(), filtered.FieldWarningsSorted())
+	t.True(filtered.HasWarnings())
+	t.False(filtered.HasErrorsForField("name"))
+	t.True(filtered.HasErrorsForField("phone"))
+}
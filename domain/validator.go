@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"reflect"
 
 	"flamingo.me/flamingo/v3/framework/web"
 	"gopkg.in/go-playground/validator.v9"
@@ -16,8 +17,15 @@ type (
 		GetValidator() *validator.Validate
 		// ErrorsToValidationInfo method which transforms errors into domain.ValidationInfo
 		ErrorsToValidationInfo(err error) ValidationInfo
+		// RegisterLabelFunc method which registers function for resolving field labels, used in errors' default labels.
+		// It should be called on application startup, since it's not thread safe.
+		RegisterLabelFunc(fn LabelFunc)
 	}
 
+	// LabelFunc as function type for resolving human readable label of struct field.
+	// If it returns an empty string, struct field name is used as label.
+	LabelFunc func(field reflect.StructField) string
+
 	// FieldValidator as interface for defining custom field validation
 	FieldValidator interface {
 		// ValidatorName defines validator name used in fields' tags inside structs
@@ -26,6 +34,41 @@ type (
 		ValidateField(ctx context.Context, fl validator.FieldLevel) bool
 	}
 
+	// ContextFieldValidator as interface for defining custom field validation, which depends on request context,
+	// like checking values against session data or external services.
+	// Context passed to validation contains *web.Request, which can be fetched by using web.RequestFromContext.
+	ContextFieldValidator interface {
+		// ValidatorName defines validator name used in fields' tags inside structs
+		ValidatorName() string
+		// ValidateWithContext defines validation method called with context of current validation when field is validated
+		ValidateWithContext(ctx context.Context, fl validator.FieldLevel) bool
+	}
+
+	// WarningFieldValidator as interface for defining custom field validation, whose failures are reported as field warnings
+	// instead of field errors, so they are presented to the user without making form invalid (like email domain typos).
+	// Since validation of field stops on its first failed tag, warning tags should be placed after all other tags.
+	WarningFieldValidator interface {
+		// ValidatorName defines validator name used in fields' tags inside structs
+		ValidatorName() string
+		// ValidateFieldWarning defines validation method called when field is validated, which returns false if warning should be reported
+		ValidateFieldWarning(ctx context.Context, fl validator.FieldLevel) bool
+	}
+
+	// ValidationRuleTranslator as interface which can be implemented by field validators, to define how their validation
+	// tag is exposed as validation rule for templates, like regex validators which expose their regex patterns
+	ValidationRuleTranslator interface {
+		// TranslateValidationRule returns validation rule for validation tag with provided parameter
+		TranslateValidationRule(param string) ValidationRule
+	}
+
+	// CriteriaFieldValidator as interface which can be implemented by field validators, whose validation rule consists
+	// of multiple criteria (like password policy). Instead of single error with validator's tag, field which fails
+	// validation gets one error for each failed criterion, with criterion's name as tag and its value as parameter.
+	CriteriaFieldValidator interface {
+		// FailedCriteria returns criteria which are not met by field value, for validation tag with provided parameter
+		FailedCriteria(value interface{}, param string) []ValidationRule
+	}
+
 	// StructValidator as interface for defining custom struct validation
 	StructValidator interface {
 		// StructType defines struct type which should be validated
@@ -0,0 +1,77 @@
+package validators
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType    = reflect.TypeOf(time.Time{})
+	timePtrType = reflect.TypeOf(&time.Time{})
+
+	errUnsupportedDateType = errors.New("field is not defined as string, time.Time or *time.Time")
+)
+
+// getDate returns date from field, which can be defined as string in date format, time.Time or *time.Time.
+// It returns false if date is not set (empty string, zero time or nil pointer), so it can be handled by "required" validation.
+// It returns error if string is not in date format or if field is of unsupported type.
+func getDate(field reflect.Value, dateFormat string) (time.Time, bool, error) {
+	switch {
+	case field.Kind() == reflect.String:
+		value := strings.TrimSpace(field.String())
+		if value == "" {
+			return time.Time{}, false, nil
+		}
+
+		date, err := time.Parse(dateFormat, value)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+
+		return date, true, nil
+	case field.Type() == timeType:
+		date := field.Interface().(time.Time)
+		return date, !date.IsZero(), nil
+	case field.Type() == timePtrType:
+		if field.IsNil() {
+			return time.Time{}, false, nil
+		}
+
+		date := field.Elem().Interface().(time.Time)
+		return date, !date.IsZero(), nil
+	}
+
+	return time.Time{}, false, errUnsupportedDateType
+}
+
+// getAge returns number of full years between birth date and now. Birth date is used as calendar date in its own location,
+// while current date is taken in location of now, so age doesn't depend on the time zone used for storing birth date.
+// Birthdays on February 29th are counted on March 1st in non leap years.
+func getAge(birthDate time.Time, now time.Time) int {
+	birthYear, birthMonth, birthDay := birthDate.Date()
+	year, month, day := now.Date()
+
+	age := year - birthYear
+
+	// time.Date normalizes February 29th in non leap years into March 1st
+	birthday := time.Date(year, birthMonth, birthDay, 0, 0, 0, 0, time.UTC)
+	today := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	if today.Before(birthday) {
+		age--
+	}
+
+	return age
+}
+
+// isBornAfter checks if birth date is on or after the date which is desired years before now, comparing only calendar dates.
+func isBornAfter(birthDate time.Time, now time.Time, years int) bool {
+	birthYear, birthMonth, birthDay := birthDate.Date()
+	year, month, day := now.Date()
+
+	birth := time.Date(birthYear, birthMonth, birthDay, 0, 0, 0, 0, time.UTC)
+	desired := time.Date(year-years, month, day, 0, 0, 0, 0, time.UTC)
+
+	return !birth.Before(desired)
+}
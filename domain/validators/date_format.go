@@ -2,8 +2,6 @@ package validators
 
 import (
 	"context"
-	"strings"
-	"time"
 
 	"flamingo.me/form/domain"
 
@@ -14,7 +12,8 @@ type (
 	// DateFormatValidator defines date format validator which validates date format depending on application's configuration
 	//
 	// Data struct {
-	//	 Date string `validate:"dateformat"`
+	//	 Date     string     `validate:"dateformat"`
+	//	 Birthday *time.Time `validate:"dateformat"`
 	// }
 	//
 	DateFormatValidator struct {
@@ -22,7 +21,10 @@ type (
 	}
 )
 
-var _ domain.FieldValidator = &DateFormatValidator{}
+var (
+	_ domain.FieldValidator           = &DateFormatValidator{}
+	_ domain.ValidationRuleTranslator = &DateFormatValidator{}
+)
 
 // Inject is method used to set all dependencies as local variables
 func (v *DateFormatValidator) Inject(cfg *struct {
@@ -37,16 +39,17 @@ func (v *DateFormatValidator) ValidatorName() string {
 }
 
 // ValidateField validates string for right date format. Valid if string is empty or in right date format.
+// Fields defined as time.Time or *time.Time are always valid, since they are already decoded as dates.
 func (v *DateFormatValidator) ValidateField(_ context.Context, fl validator.FieldLevel) bool {
-	converted, ok := fl.Field().Interface().(string)
-	if !ok {
-		return false
-	}
-
-	if len(strings.TrimSpace(converted)) == 0 {
-		return true
-	}
+	_, _, err := getDate(fl.Field(), v.dateFormat)
 
-	_, err := time.Parse(v.dateFormat, converted)
 	return err == nil
 }
+
+// TranslateValidationRule exposes date format from application's configuration as "dateformat" validation rule
+func (v *DateFormatValidator) TranslateValidationRule(string) domain.ValidationRule {
+	return domain.ValidationRule{
+		Name:  "dateformat",
+		Value: v.dateFormat,
+	}
+}
@@ -3,9 +3,11 @@ package validators
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 
+	"flamingo.me/form/domain"
 	"flamingo.me/form/domain/mocks"
 )
 
@@ -34,6 +36,13 @@ func (t *DateFormatValidatorTestSuite) TestValidatorName() {
 	t.Equal("dateformat", t.validator.ValidatorName())
 }
 
+func (t *DateFormatValidatorTestSuite) TestTranslateValidationRule() {
+	t.Equal(domain.ValidationRule{
+		Name:  "dateformat",
+		Value: "2006-01-02",
+	}, t.validator.TranslateValidationRule(""))
+}
+
 func (t *DateFormatValidatorTestSuite) TestValidateField() {
 	testCases := []struct {
 		Date   string
@@ -96,3 +105,40 @@ func (t *DateFormatValidatorTestSuite) TestValidateField() {
 		fieldLevel.AssertExpectations(t.T())
 	}
 }
+
+func (t *DateFormatValidatorTestSuite) TestValidateField_Dates() {
+	date := time.Date(2006, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		Date   interface{}
+		Result bool
+	}{
+		{
+			Date:   date,
+			Result: true,
+		},
+		{
+			Date:   &date,
+			Result: true,
+		},
+		{
+			Date:   time.Time{},
+			Result: true,
+		},
+		{
+			Date:   (*time.Time)(nil),
+			Result: true,
+		},
+		{
+			Date:   10,
+			Result: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fieldLevel := &mocks.FieldLevel{}
+		fieldLevel.On("Field").Return(reflect.ValueOf(testCase.Date)).Once()
+		t.Equal(testCase.Result, t.validator.ValidateField(nil, fieldLevel), "%v", testCase.Date)
+		fieldLevel.AssertExpectations(t.T())
+	}
+}
@@ -0,0 +1,69 @@
+package validators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	DateTestSuite struct {
+		suite.Suite
+	}
+)
+
+func TestDateTestSuite(t *testing.T) {
+	suite.Run(t, &DateTestSuite{})
+}
+
+func (t *DateTestSuite) TestGetAge() {
+	testCases := []struct {
+		BirthDate time.Time
+		Now       time.Time
+		Result    int
+	}{
+		{
+			BirthDate: time.Date(2000, 5, 10, 0, 0, 0, 0, time.UTC),
+			Now:       time.Date(2020, 5, 9, 23, 59, 0, 0, time.UTC),
+			Result:    19,
+		},
+		{
+			BirthDate: time.Date(2000, 5, 10, 0, 0, 0, 0, time.UTC),
+			Now:       time.Date(2020, 5, 10, 0, 0, 0, 0, time.UTC),
+			Result:    20,
+		},
+		{
+			BirthDate: time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC),
+			Now:       time.Date(2021, 2, 28, 0, 0, 0, 0, time.UTC),
+			Result:    20,
+		},
+		{
+			BirthDate: time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC),
+			Now:       time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC),
+			Result:    21,
+		},
+		{
+			BirthDate: time.Date(2000, 2, 29, 0, 0, 0, 0, time.UTC),
+			Now:       time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			Result:    24,
+		},
+		{
+			BirthDate: time.Date(2000, 5, 10, 23, 0, 0, 0, time.FixedZone("UTC-5", -5*60*60)),
+			Now:       time.Date(2020, 5, 10, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60)),
+			Result:    20,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Equal(testCase.Result, getAge(testCase.BirthDate, testCase.Now), "%v %v", testCase.BirthDate, testCase.Now)
+	}
+}
+
+func (t *DateTestSuite) TestIsBornAfter() {
+	now := time.Date(2020, 5, 10, 1, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+
+	t.True(isBornAfter(time.Date(1870, 5, 10, 0, 0, 0, 0, time.UTC), now, 150))
+	t.False(isBornAfter(time.Date(1870, 5, 9, 0, 0, 0, 0, time.UTC), now, 150))
+	t.True(isBornAfter(time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC), now, 150))
+}
@@ -0,0 +1,76 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"flamingo.me/form/domain"
+
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+type (
+	// EachOneOfValidator defines membership validator for slices of allowed values, like checkbox groups and select-multiple fields.
+	// Allowed values are defined as space separated list, same as for "oneof" validator, and each element of slice has to be one of them.
+	// It can be used for slices and arrays of strings and numbers, and for single values (like with "dive" validator).
+	// Since whole slice is validated, error is reported for field itself (like "interests") instead of its element.
+	//
+	// Data struct {
+	//	 Interests []string `validate:"eachoneof=sports music books"`
+	//	 Sizes     []int    `validate:"required,eachoneof=36 38 40"`
+	// }
+	//
+	EachOneOfValidator struct{}
+)
+
+var _ domain.FieldValidator = &EachOneOfValidator{}
+
+// ValidatorName defines tag name of membership validator
+func (v *EachOneOfValidator) ValidatorName() string {
+	return "eachoneof"
+}
+
+// ValidateField validates if each element of slice is one of allowed values.
+// Valid if slice is empty or nil pointer, so they can be handled by "required" validation.
+func (v *EachOneOfValidator) ValidateField(_ context.Context, fl validator.FieldLevel) bool {
+	allowed := map[string]bool{}
+	for _, value := range strings.Fields(fl.Param()) {
+		allowed[value] = true
+	}
+
+	return v.validateValue(fl.Field(), allowed)
+}
+
+// validateValue checks if value, or each element in case of slices and arrays, is one of allowed values.
+// It panics if value is not string or number, same as "oneof" validator.
+func (v *EachOneOfValidator) validateValue(field reflect.Value, allowed map[string]bool) bool {
+	switch field.Kind() {
+	case reflect.Ptr:
+		if field.IsNil() {
+			return true
+		}
+
+		return v.validateValue(field.Elem(), allowed)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < field.Len(); i++ {
+			if !v.validateValue(field.Index(i), allowed) {
+				return false
+			}
+		}
+
+		return true
+	case reflect.String:
+		return allowed[field.String()]
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return allowed[strconv.FormatInt(field.Int(), 10)]
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return allowed[strconv.FormatUint(field.Uint(), 10)]
+	case reflect.Float32, reflect.Float64:
+		return allowed[strconv.FormatFloat(field.Float(), 'f', -1, field.Type().Bits())]
+	}
+
+	panic(fmt.Sprintf("Bad field type %s", field.Type()))
+}
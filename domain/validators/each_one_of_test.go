@@ -0,0 +1,139 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/form/domain/mocks"
+)
+
+type (
+	EachOneOfValidatorTestSuite struct {
+		suite.Suite
+
+		validator *EachOneOfValidator
+	}
+)
+
+func TestEachOneOfValidatorTestSuite(t *testing.T) {
+	suite.Run(t, &EachOneOfValidatorTestSuite{})
+}
+
+func (t *EachOneOfValidatorTestSuite) SetupTest() {
+	t.validator = &EachOneOfValidator{}
+}
+
+func (t *EachOneOfValidatorTestSuite) TestValidatorName() {
+	t.Equal("eachoneof", t.validator.ValidatorName())
+}
+
+func (t *EachOneOfValidatorTestSuite) TestValidateField_Strings() {
+	interest := "music"
+	var nilInterest *string
+
+	testCases := []struct {
+		Value  interface{}
+		Result bool
+	}{
+		{
+			Value:  []string(nil),
+			Result: true,
+		},
+		{
+			Value:  []string{},
+			Result: true,
+		},
+		{
+			Value:  []string{"sports", "books"},
+			Result: true,
+		},
+		{
+			Value:  []string{"sports", "movies", "books"},
+			Result: false,
+		},
+		{
+			Value:  []string{""},
+			Result: false,
+		},
+		{
+			Value:  [2]string{"music", "sports"},
+			Result: true,
+		},
+		{
+			Value:  "music",
+			Result: true,
+		},
+		{
+			Value:  "movies",
+			Result: false,
+		},
+		{
+			Value:  &interest,
+			Result: true,
+		},
+		{
+			Value:  nilInterest,
+			Result: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fieldLevel := &mocks.FieldLevel{}
+		fieldLevel.On("Field").Return(reflect.ValueOf(testCase.Value)).Once()
+		fieldLevel.On("Param").Return("sports music books").Once()
+		t.Equal(testCase.Result, t.validator.ValidateField(nil, fieldLevel), testCase.Value)
+		fieldLevel.AssertExpectations(t.T())
+	}
+}
+
+func (t *EachOneOfValidatorTestSuite) TestValidateField_Numbers() {
+	testCases := []struct {
+		Value  interface{}
+		Result bool
+	}{
+		{
+			Value:  []int{36, 40},
+			Result: true,
+		},
+		{
+			Value:  []int{36, 42},
+			Result: false,
+		},
+		{
+			Value:  []uint8{38},
+			Result: true,
+		},
+		{
+			Value:  []float64{38.5},
+			Result: true,
+		},
+		{
+			Value:  []float64{38.25},
+			Result: false,
+		},
+		{
+			Value:  int64(40),
+			Result: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fieldLevel := &mocks.FieldLevel{}
+		fieldLevel.On("Field").Return(reflect.ValueOf(testCase.Value)).Once()
+		fieldLevel.On("Param").Return("36 38 38.5 40").Once()
+		t.Equal(testCase.Result, t.validator.ValidateField(nil, fieldLevel), testCase.Value)
+		fieldLevel.AssertExpectations(t.T())
+	}
+}
+
+func (t *EachOneOfValidatorTestSuite) TestValidateField_InvalidType() {
+	fieldLevel := &mocks.FieldLevel{}
+	fieldLevel.On("Field").Return(reflect.ValueOf([]bool{true})).Once()
+	fieldLevel.On("Param").Return("true").Once()
+
+	t.Panics(func() {
+		t.validator.ValidateField(nil, fieldLevel)
+	})
+}
@@ -3,7 +3,6 @@ package validators
 import (
 	"context"
 	"strconv"
-	"strings"
 	"time"
 
 	"flamingo.me/form/domain"
@@ -15,11 +14,13 @@ type (
 	// MaximumAgeValidator defines maximum age validator which validates if passed date is after than desired years ago
 	//
 	// Data struct {
-	//	 Date string `validate:"maximumage=150"`
+	//	 Date     string    `validate:"maximumage=150"`
+	//	 Birthday time.Time `validate:"maximumage=150"`
 	// }
 	//
 	MaximumAgeValidator struct {
 		dateFormat string
+		now        func() time.Time
 	}
 )
 
@@ -30,6 +31,7 @@ func (v *MaximumAgeValidator) Inject(cfg *struct {
 	DateFormat string `inject:"config:form.validator.dateFormat"`
 }) {
 	v.dateFormat = cfg.DateFormat
+	v.now = time.Now
 }
 
 // ValidatorName defines tag name of maximum age validator
@@ -37,7 +39,8 @@ func (v *MaximumAgeValidator) ValidatorName() string {
 	return "maximumage"
 }
 
-// ValidateField validates string in date format for maximum age. Valid if string is empty or in wrong date format or in date range.
+// ValidateField validates string in date format, time.Time or *time.Time for maximum age.
+// Valid if date is not set (empty string, zero time or nil pointer), or if it's in wrong date format or in date range.
 func (v *MaximumAgeValidator) ValidateField(_ context.Context, fl validator.FieldLevel) bool {
 	param := fl.Param()
 	years := 0
@@ -50,22 +53,10 @@ func (v *MaximumAgeValidator) ValidateField(_ context.Context, fl validator.Fiel
 		years = int(value)
 	}
 
-	converted, ok := fl.Field().Interface().(string)
-	if !ok {
+	date, ok, err := getDate(fl.Field(), v.dateFormat)
+	if err != nil || !ok {
 		return true
 	}
 
-	if len(strings.TrimSpace(converted)) == 0 {
-		return true
-	}
-
-	date, err := time.Parse(v.dateFormat, converted)
-	if err != nil {
-		return true
-	}
-
-	now := time.Now()
-	desired := time.Date(now.Year()-years, now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-
-	return date.After(desired) || date.Equal(desired)
+	return isBornAfter(date, v.now(), years)
 }
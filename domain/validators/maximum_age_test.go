@@ -83,3 +83,58 @@ func (t *MaximumAgeValidatorTestSuite) TestValidateField() {
 		fieldLevel.AssertExpectations(t.T())
 	}
 }
+
+func (t *MaximumAgeValidatorTestSuite) TestValidateField_Dates() {
+	t.validator.now = func() time.Time {
+		return time.Date(2022, 3, 1, 0, 30, 0, 0, time.FixedZone("UTC+1", 60*60))
+	}
+
+	cutoff := time.Date(1872, 3, 1, 0, 0, 0, 0, time.UTC)
+	beforeCutoff := time.Date(1872, 2, 29, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		Date   interface{}
+		Result bool
+	}{
+		{
+			Date:   cutoff.Format("2006-01-02"),
+			Result: true,
+		},
+		{
+			Date:   beforeCutoff.Format("2006-01-02"),
+			Result: false,
+		},
+		{
+			Date:   cutoff,
+			Result: true,
+		},
+		{
+			Date:   beforeCutoff,
+			Result: false,
+		},
+		{
+			Date:   &cutoff,
+			Result: true,
+		},
+		{
+			Date:   &beforeCutoff,
+			Result: false,
+		},
+		{
+			Date:   time.Time{},
+			Result: true,
+		},
+		{
+			Date:   (*time.Time)(nil),
+			Result: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fieldLevel := &mocks.FieldLevel{}
+		fieldLevel.On("Field").Return(reflect.ValueOf(testCase.Date)).Once()
+		fieldLevel.On("Param").Return("150").Once()
+		t.Equal(testCase.Result, t.validator.ValidateField(nil, fieldLevel), "%v", testCase.Date)
+		fieldLevel.AssertExpectations(t.T())
+	}
+}
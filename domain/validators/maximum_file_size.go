@@ -0,0 +1,101 @@
+package validators
+
+import (
+	"context"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"flamingo.me/form/domain"
+
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+type (
+	// MaximumFileSizeValidator defines maximum file size validator which validates if uploaded files are not bigger than desired size
+	//
+	// Data struct {
+	//	 Avatar *multipart.FileHeader `validate:"omitempty,maxfilesize=2MB"`
+	// }
+	//
+	MaximumFileSizeValidator struct{}
+)
+
+var (
+	_ domain.FieldValidator = &MaximumFileSizeValidator{}
+
+	fileSizeUnits = []struct {
+		suffix     string
+		multiplier int64
+	}{
+		{suffix: "KB", multiplier: 1 << 10},
+		{suffix: "MB", multiplier: 1 << 20},
+		{suffix: "GB", multiplier: 1 << 30},
+		{suffix: "B", multiplier: 1},
+	}
+)
+
+// ValidatorName defines tag name of maximum file size validator
+func (v *MaximumFileSizeValidator) ValidatorName() string {
+	return "maxfilesize"
+}
+
+// ValidateField validates uploaded files for maximum size. Valid if there is no uploaded file or all uploaded files are in size range.
+func (v *MaximumFileSizeValidator) ValidateField(_ context.Context, fl validator.FieldLevel) bool {
+	size, err := parseFileSize(fl.Param())
+	if err != nil {
+		panic(err.Error())
+	}
+
+	headers, ok := getFileHeaders(fl.Field())
+	if !ok {
+		return false
+	}
+
+	for _, header := range headers {
+		if header.Size > size {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseFileSize parses file size definition like "512", "512B", "100KB", "2MB" or "1GB" into number of bytes
+func parseFileSize(value string) (int64, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	multiplier := int64(1)
+
+	for _, unit := range fileSizeUnits {
+		if strings.HasSuffix(value, unit.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, unit.suffix))
+			multiplier = unit.multiplier
+			break
+		}
+	}
+
+	size, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	return size * multiplier, nil
+}
+
+// getFileHeaders extracts list of uploaded files from validated field. It returns false if field doesn't contain uploaded files.
+func getFileHeaders(field reflect.Value) ([]*multipart.FileHeader, bool) {
+	switch converted := field.Interface().(type) {
+	case *multipart.FileHeader:
+		if converted == nil {
+			return nil, true
+		}
+		return []*multipart.FileHeader{converted}, true
+	case multipart.FileHeader:
+		return []*multipart.FileHeader{&converted}, true
+	case []*multipart.FileHeader:
+		return converted, true
+	}
+
+	return nil, false
+}
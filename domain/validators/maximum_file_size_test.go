@@ -0,0 +1,156 @@
+package validators
+
+import (
+	"bytes"
+	"mime/multipart"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/form/domain/mocks"
+)
+
+type (
+	MaximumFileSizeValidatorTestSuite struct {
+		suite.Suite
+
+		validator *MaximumFileSizeValidator
+	}
+)
+
+func TestMaximumFileSizeValidatorTestSuite(t *testing.T) {
+	suite.Run(t, &MaximumFileSizeValidatorTestSuite{})
+}
+
+func (t *MaximumFileSizeValidatorTestSuite) SetupTest() {
+	t.validator = &MaximumFileSizeValidator{}
+}
+
+func (t *MaximumFileSizeValidatorTestSuite) TestValidatorName() {
+	t.Equal("maxfilesize", t.validator.ValidatorName())
+}
+
+func (t *MaximumFileSizeValidatorTestSuite) TestValidateField() {
+	small := createFileHeader(t.T(), "small.txt", bytes.Repeat([]byte("a"), 1024))
+	big := createFileHeader(t.T(), "big.txt", bytes.Repeat([]byte("a"), 1025))
+
+	testCases := []struct {
+		Value  interface{}
+		Result bool
+	}{
+		{
+			Value:  (*multipart.FileHeader)(nil),
+			Result: true,
+		},
+		{
+			Value:  []*multipart.FileHeader{},
+			Result: true,
+		},
+		{
+			Value:  small,
+			Result: true,
+		},
+		{
+			Value:  *small,
+			Result: true,
+		},
+		{
+			Value:  big,
+			Result: false,
+		},
+		{
+			Value:  []*multipart.FileHeader{small, small},
+			Result: true,
+		},
+		{
+			Value:  []*multipart.FileHeader{small, big},
+			Result: false,
+		},
+		{
+			Value:  "small.txt",
+			Result: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fieldLevel := &mocks.FieldLevel{}
+		fieldLevel.On("Field").Return(reflect.ValueOf(testCase.Value)).Once()
+		fieldLevel.On("Param").Return("1KB").Once()
+		t.Equal(testCase.Result, t.validator.ValidateField(nil, fieldLevel))
+		fieldLevel.AssertExpectations(t.T())
+	}
+}
+
+func (t *MaximumFileSizeValidatorTestSuite) TestValidateField_WrongParam() {
+	fieldLevel := &mocks.FieldLevel{}
+	fieldLevel.On("Param").Return("big").Once()
+	t.Panics(func() {
+		t.validator.ValidateField(nil, fieldLevel)
+	})
+	fieldLevel.AssertExpectations(t.T())
+}
+
+func (t *MaximumFileSizeValidatorTestSuite) TestParseFileSize() {
+	testCases := []struct {
+		Value  string
+		Result int64
+	}{
+		{
+			Value:  "512",
+			Result: 512,
+		},
+		{
+			Value:  "512B",
+			Result: 512,
+		},
+		{
+			Value:  "100KB",
+			Result: 100 * 1024,
+		},
+		{
+			Value:  "2MB",
+			Result: 2 * 1024 * 1024,
+		},
+		{
+			Value:  " 2 mb ",
+			Result: 2 * 1024 * 1024,
+		},
+		{
+			Value:  "1GB",
+			Result: 1024 * 1024 * 1024,
+		},
+	}
+
+	for _, testCase := range testCases {
+		result, err := parseFileSize(testCase.Value)
+		t.NoError(err)
+		t.Equal(testCase.Result, result)
+	}
+
+	_, err := parseFileSize("2TB")
+	t.Error(err)
+}
+
+func createFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile("file", filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	form, err := multipart.NewReader(body, writer.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return form.File["file"][0]
+}
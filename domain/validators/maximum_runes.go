@@ -0,0 +1,40 @@
+package validators
+
+import (
+	"context"
+
+	"flamingo.me/form/domain"
+
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+type (
+	// MaximumRunesValidator defines maximum length validator which counts runes instead of bytes, so umlauts and emoji
+	// are counted as single characters. Combining characters are counted separately, so values should be normalized
+	// with "nfc" modifier first. Same as "minrunes", it supports string, *string and []string fields.
+	//
+	// Data struct {
+	//	 Name     string   `validate:"maxrunes=20"`
+	//	 Nickname *string  `validate:"maxrunes=20"`
+	//	 Tags     []string `validate:"maxrunes=20"`
+	// }
+	//
+	MaximumRunesValidator struct{}
+)
+
+var _ domain.FieldValidator = &MaximumRunesValidator{}
+
+// ValidatorName defines tag name of maximum length validator
+func (v *MaximumRunesValidator) ValidatorName() string {
+	return "maxrunes"
+}
+
+// ValidateField validates if strings contain at most desired number of runes.
+// Valid if string is empty or nil pointer, so they can be handled by "required" validation.
+func (v *MaximumRunesValidator) ValidateField(_ context.Context, fl validator.FieldLevel) bool {
+	limit := getRuneLimit(fl.Param())
+
+	return validateRuneCounts(fl.Field(), func(count int) bool {
+		return count <= limit
+	})
+}
@@ -0,0 +1,98 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/form/domain/mocks"
+)
+
+type (
+	MaximumRunesValidatorTestSuite struct {
+		suite.Suite
+
+		validator *MaximumRunesValidator
+	}
+)
+
+func TestMaximumRunesValidatorTestSuite(t *testing.T) {
+	suite.Run(t, &MaximumRunesValidatorTestSuite{})
+}
+
+func (t *MaximumRunesValidatorTestSuite) SetupTest() {
+	t.validator = &MaximumRunesValidator{}
+}
+
+func (t *MaximumRunesValidatorTestSuite) TestValidatorName() {
+	t.Equal("maxrunes", t.validator.ValidatorName())
+}
+
+func (t *MaximumRunesValidatorTestSuite) TestValidateField() {
+	name := "Müller"
+	var nilName *string
+
+	testCases := []struct {
+		Value  interface{}
+		Result bool
+	}{
+		{
+			Value:  "",
+			Result: true,
+		},
+		{
+			Value:  "Müller",
+			Result: true,
+		},
+		{
+			Value:  "Müllers",
+			Result: false,
+		},
+		{
+			Value:  "Mu\u0308ller",
+			Result: false,
+		},
+		{
+			Value:  "😀😀😀😀😀😀",
+			Result: true,
+		},
+		{
+			Value:  "😀😀😀😀😀😀😀",
+			Result: false,
+		},
+		{
+			Value:  &name,
+			Result: true,
+		},
+		{
+			Value:  nilName,
+			Result: true,
+		},
+		{
+			Value:  []string{"Müller", "", "Bär"},
+			Result: true,
+		},
+		{
+			Value:  []string{"Müller", "Schmidt"},
+			Result: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fieldLevel := &mocks.FieldLevel{}
+		fieldLevel.On("Field").Return(reflect.ValueOf(testCase.Value)).Once()
+		fieldLevel.On("Param").Return("6").Once()
+		t.Equal(testCase.Result, t.validator.ValidateField(nil, fieldLevel), testCase.Value)
+		fieldLevel.AssertExpectations(t.T())
+	}
+}
+
+func (t *MaximumRunesValidatorTestSuite) TestValidateField_InvalidParam() {
+	fieldLevel := &mocks.FieldLevel{}
+	fieldLevel.On("Param").Return("wrong").Once()
+
+	t.Panics(func() {
+		t.validator.ValidateField(nil, fieldLevel)
+	})
+}
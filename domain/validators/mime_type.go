@@ -0,0 +1,88 @@
+package validators
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strings"
+
+	"flamingo.me/form/domain"
+
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+type (
+	// MimeTypeValidator defines mime type validator which validates if uploaded files are one of desired mime types.
+	// Mime type is detected from file content, so it doesn't depend on file name or content type sent by client.
+	//
+	// Data struct {
+	//	 Avatar *multipart.FileHeader `validate:"omitempty,mimetype=image/png image/jpeg"`
+	// }
+	//
+	MimeTypeValidator struct{}
+)
+
+var _ domain.FieldValidator = &MimeTypeValidator{}
+
+// ValidatorName defines tag name of mime type validator
+func (v *MimeTypeValidator) ValidatorName() string {
+	return "mimetype"
+}
+
+// ValidateField validates uploaded files for mime type. Valid if there is no uploaded file or all uploaded files have one of desired mime types.
+func (v *MimeTypeValidator) ValidateField(_ context.Context, fl validator.FieldLevel) bool {
+	allowed := strings.Fields(fl.Param())
+
+	headers, ok := getFileHeaders(fl.Field())
+	if !ok {
+		return false
+	}
+
+	for _, header := range headers {
+		mimeType, err := v.detectMimeType(header)
+		if err != nil || !v.isAllowed(mimeType, allowed) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// detectMimeType detects mime type of uploaded file by sniffing its content
+func (v *MimeTypeValidator) detectMimeType(header *multipart.FileHeader) (string, error) {
+	file, err := header.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	buffer := make([]byte, 512)
+	n, err := io.ReadFull(file, buffer)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return "", err
+	}
+
+	mimeType, _, err := mime.ParseMediaType(http.DetectContentType(buffer[:n]))
+	if err != nil {
+		return "", err
+	}
+
+	return mimeType, nil
+}
+
+// isAllowed checks if mime type matches one of desired mime types. Wildcards like "image/*" are supported.
+func (v *MimeTypeValidator) isAllowed(mimeType string, allowed []string) bool {
+	for _, value := range allowed {
+		value = strings.ToLower(value)
+		if value == mimeType {
+			return true
+		}
+		if strings.HasSuffix(value, "/*") && strings.HasPrefix(mimeType, strings.TrimSuffix(value, "*")) {
+			return true
+		}
+	}
+
+	return false
+}
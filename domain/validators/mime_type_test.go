@@ -0,0 +1,102 @@
+package validators
+
+import (
+	"mime/multipart"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/form/domain/mocks"
+)
+
+type (
+	MimeTypeValidatorTestSuite struct {
+		suite.Suite
+
+		validator *MimeTypeValidator
+	}
+)
+
+func TestMimeTypeValidatorTestSuite(t *testing.T) {
+	suite.Run(t, &MimeTypeValidatorTestSuite{})
+}
+
+func (t *MimeTypeValidatorTestSuite) SetupTest() {
+	t.validator = &MimeTypeValidator{}
+}
+
+func (t *MimeTypeValidatorTestSuite) TestValidatorName() {
+	t.Equal("mimetype", t.validator.ValidatorName())
+}
+
+func (t *MimeTypeValidatorTestSuite) TestValidateField() {
+	png := createFileHeader(t.T(), "image.png", []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\x0dIHDR"))
+	jpeg := createFileHeader(t.T(), "image.jpg", []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00"))
+	text := createFileHeader(t.T(), "image.png", []byte("this is not an image"))
+
+	testCases := []struct {
+		Value  interface{}
+		Param  string
+		Result bool
+	}{
+		{
+			Value:  (*multipart.FileHeader)(nil),
+			Param:  "image/png",
+			Result: true,
+		},
+		{
+			Value:  png,
+			Param:  "image/png",
+			Result: true,
+		},
+		{
+			Value:  jpeg,
+			Param:  "image/png",
+			Result: false,
+		},
+		{
+			Value:  jpeg,
+			Param:  "image/png image/jpeg",
+			Result: true,
+		},
+		{
+			Value:  jpeg,
+			Param:  "image/*",
+			Result: true,
+		},
+		{
+			Value:  text,
+			Param:  "image/*",
+			Result: false,
+		},
+		{
+			Value:  text,
+			Param:  "text/plain",
+			Result: true,
+		},
+		{
+			Value:  []*multipart.FileHeader{png, jpeg},
+			Param:  "image/png image/jpeg",
+			Result: true,
+		},
+		{
+			Value:  []*multipart.FileHeader{png, text},
+			Param:  "image/png image/jpeg",
+			Result: false,
+		},
+		{
+			Value:  "image.png",
+			Param:  "image/png",
+			Result: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fieldLevel := &mocks.FieldLevel{}
+		fieldLevel.On("Field").Return(reflect.ValueOf(testCase.Value)).Once()
+		fieldLevel.On("Param").Return(testCase.Param).Once()
+		t.Equal(testCase.Result, t.validator.ValidateField(nil, fieldLevel))
+		fieldLevel.AssertExpectations(t.T())
+	}
+}
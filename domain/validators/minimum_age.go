@@ -3,7 +3,6 @@ package validators
 import (
 	"context"
 	"strconv"
-	"strings"
 	"time"
 
 	"flamingo.me/form/domain"
@@ -15,11 +14,13 @@ type (
 	// MinimumAgeValidator defines minimum age validator which validates if passed date is before than desired years ago
 	//
 	// Data struct {
-	//	 Date string `validate:"minimumage=18"`
+	//	 Date     string    `validate:"minimumage=18"`
+	//	 Birthday time.Time `validate:"minimumage=18"`
 	// }
 	//
 	MinimumAgeValidator struct {
 		dateFormat string
+		now        func() time.Time
 	}
 )
 
@@ -30,6 +31,7 @@ func (v *MinimumAgeValidator) Inject(cfg *struct {
 	DateFormat string `inject:"config:form.validator.dateFormat"`
 }) {
 	v.dateFormat = cfg.DateFormat
+	v.now = time.Now
 }
 
 // ValidatorName defines tag name of minimum age validator
@@ -37,7 +39,8 @@ func (v *MinimumAgeValidator) ValidatorName() string {
 	return "minimumage"
 }
 
-// ValidateField validates string in date format for minimum age. Valid if string is empty or in wrong date format or in date range.
+// ValidateField validates string in date format, time.Time or *time.Time for minimum age.
+// Valid if date is not set (empty string, zero time or nil pointer), or if it's in wrong date format or in date range.
 func (v *MinimumAgeValidator) ValidateField(_ context.Context, fl validator.FieldLevel) bool {
 	param := fl.Param()
 	years := 0
@@ -50,22 +53,10 @@ func (v *MinimumAgeValidator) ValidateField(_ context.Context, fl validator.Fiel
 		years = int(value)
 	}
 
-	converted, ok := fl.Field().Interface().(string)
-	if !ok {
+	date, ok, err := getDate(fl.Field(), v.dateFormat)
+	if err != nil || !ok {
 		return true
 	}
 
-	if len(strings.TrimSpace(converted)) == 0 {
-		return true
-	}
-
-	date, err := time.Parse(v.dateFormat, converted)
-	if err != nil {
-		return true
-	}
-
-	now := time.Now()
-	desired := time.Date(now.Year()-years, now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
-
-	return date.Before(desired)
+	return getAge(date, v.now()) >= years
 }
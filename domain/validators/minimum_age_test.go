@@ -84,3 +84,83 @@ func (t *MinimumAgeValidatorTestSuite) TestValidateField() {
 		fieldLevel.AssertExpectations(t.T())
 	}
 }
+
+func (t *MinimumAgeValidatorTestSuite) TestValidateField_Dates() {
+	location := time.FixedZone("UTC+1", 60*60)
+	t.validator.now = func() time.Time {
+		return time.Date(2022, 3, 1, 0, 30, 0, 0, location)
+	}
+
+	justAdult := time.Date(2004, 3, 1, 0, 0, 0, 0, time.UTC)
+	almostAdult := time.Date(2004, 3, 2, 0, 0, 0, 0, time.UTC)
+	leapDayAdult := time.Date(2004, 2, 29, 0, 0, 0, 0, time.UTC)
+	lateEvening := time.Date(2004, 3, 1, 23, 30, 0, 0, time.FixedZone("UTC-5", -5*60*60))
+
+	testCases := []struct {
+		Date   interface{}
+		Result bool
+	}{
+		{
+			Date:   justAdult.Format("2006-01-02"),
+			Result: true,
+		},
+		{
+			Date:   almostAdult.Format("2006-01-02"),
+			Result: false,
+		},
+		{
+			Date:   justAdult,
+			Result: true,
+		},
+		{
+			Date:   almostAdult,
+			Result: false,
+		},
+		{
+			Date:   &justAdult,
+			Result: true,
+		},
+		{
+			Date:   &almostAdult,
+			Result: false,
+		},
+		{
+			Date:   leapDayAdult,
+			Result: true,
+		},
+		{
+			Date:   lateEvening,
+			Result: true,
+		},
+		{
+			Date:   time.Time{},
+			Result: true,
+		},
+		{
+			Date:   (*time.Time)(nil),
+			Result: true,
+		},
+		{
+			Date:   10,
+			Result: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fieldLevel := &mocks.FieldLevel{}
+		fieldLevel.On("Field").Return(reflect.ValueOf(testCase.Date)).Once()
+		fieldLevel.On("Param").Return("18").Once()
+		t.Equal(testCase.Result, t.validator.ValidateField(nil, fieldLevel), "%v", testCase.Date)
+		fieldLevel.AssertExpectations(t.T())
+	}
+
+	// birthday on February 29th is counted on March 1st in non leap years
+	t.validator.now = func() time.Time {
+		return time.Date(2022, 2, 28, 12, 0, 0, 0, time.UTC)
+	}
+	fieldLevel := &mocks.FieldLevel{}
+	fieldLevel.On("Field").Return(reflect.ValueOf(leapDayAdult)).Once()
+	fieldLevel.On("Param").Return("18").Once()
+	t.False(t.validator.ValidateField(nil, fieldLevel))
+	fieldLevel.AssertExpectations(t.T())
+}
@@ -0,0 +1,40 @@
+package validators
+
+import (
+	"context"
+
+	"flamingo.me/form/domain"
+
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+type (
+	// MinimumRunesValidator defines minimum length validator which counts unicode characters (runes) instead of bytes,
+	// so strings with multi-byte characters (like "Müller") are validated by their visible length.
+	// It can be used for string, *string and []string fields, where each string in slice is validated.
+	//
+	// Data struct {
+	//	 Name     string   `validate:"minrunes=2"`
+	//	 Nickname *string  `validate:"minrunes=2"`
+	//	 Tags     []string `validate:"minrunes=2"`
+	// }
+	//
+	MinimumRunesValidator struct{}
+)
+
+var _ domain.FieldValidator = &MinimumRunesValidator{}
+
+// ValidatorName defines tag name of minimum length validator
+func (v *MinimumRunesValidator) ValidatorName() string {
+	return "minrunes"
+}
+
+// ValidateField validates if strings contain at least desired number of runes.
+// Valid if string is empty or nil pointer, so they can be handled by "required" validation.
+func (v *MinimumRunesValidator) ValidateField(_ context.Context, fl validator.FieldLevel) bool {
+	limit := getRuneLimit(fl.Param())
+
+	return validateRuneCounts(fl.Field(), func(count int) bool {
+		return count >= limit
+	})
+}
@@ -0,0 +1,98 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/form/domain/mocks"
+)
+
+type (
+	MinimumRunesValidatorTestSuite struct {
+		suite.Suite
+
+		validator *MinimumRunesValidator
+	}
+)
+
+func TestMinimumRunesValidatorTestSuite(t *testing.T) {
+	suite.Run(t, &MinimumRunesValidatorTestSuite{})
+}
+
+func (t *MinimumRunesValidatorTestSuite) SetupTest() {
+	t.validator = &MinimumRunesValidator{}
+}
+
+func (t *MinimumRunesValidatorTestSuite) TestValidatorName() {
+	t.Equal("minrunes", t.validator.ValidatorName())
+}
+
+func (t *MinimumRunesValidatorTestSuite) TestValidateField() {
+	name := "Müller"
+	var nilName *string
+
+	testCases := []struct {
+		Value  interface{}
+		Result bool
+	}{
+		{
+			Value:  "",
+			Result: true,
+		},
+		{
+			Value:  "Mülle",
+			Result: false,
+		},
+		{
+			Value:  "Müller",
+			Result: true,
+		},
+		{
+			Value:  "Mu\u0308lle",
+			Result: true,
+		},
+		{
+			Value:  "😀😀😀😀😀",
+			Result: false,
+		},
+		{
+			Value:  "😀😀😀😀😀😀",
+			Result: true,
+		},
+		{
+			Value:  &name,
+			Result: true,
+		},
+		{
+			Value:  nilName,
+			Result: true,
+		},
+		{
+			Value:  []string{"Müller", "", "Schmidt"},
+			Result: true,
+		},
+		{
+			Value:  []string{"Müller", "Bär"},
+			Result: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fieldLevel := &mocks.FieldLevel{}
+		fieldLevel.On("Field").Return(reflect.ValueOf(testCase.Value)).Once()
+		fieldLevel.On("Param").Return("6").Once()
+		t.Equal(testCase.Result, t.validator.ValidateField(nil, fieldLevel), testCase.Value)
+		fieldLevel.AssertExpectations(t.T())
+	}
+}
+
+func (t *MinimumRunesValidatorTestSuite) TestValidateField_InvalidParam() {
+	fieldLevel := &mocks.FieldLevel{}
+	fieldLevel.On("Param").Return("wrong").Once()
+
+	t.Panics(func() {
+		t.validator.ValidateField(nil, fieldLevel)
+	})
+}
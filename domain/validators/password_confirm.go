@@ -0,0 +1,82 @@
+package validators
+
+import (
+	"context"
+	"reflect"
+
+	"flamingo.me/form/domain"
+
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+type (
+	// PasswordConfirmValidator defines password confirmation validator which validates if confirmation field contains
+	// the same value as password field of the same struct. Since it's defined on confirmation field, mismatch error
+	// is reported for confirmation field, with message key "formError.passwordMismatch". Password field is passed
+	// by its struct field name as parameter, or taken from application's configuration if parameter is not defined.
+	//
+	// Data struct {
+	//	 Password          string `validate:"required,passwordstrength"`
+	//	 PasswordConfirm   string `validate:"passwordconfirm"`
+	//	 NewPassword       string `validate:"passwordstrength"`
+	//	 NewPasswordRepeat string `validate:"passwordconfirm=NewPassword"`
+	// }
+	//
+	PasswordConfirmValidator struct {
+		passwordField string
+	}
+)
+
+var _ domain.FieldValidator = &PasswordConfirmValidator{}
+
+// Inject is method used to set all dependencies as local variables
+func (v *PasswordConfirmValidator) Inject(cfg *struct {
+	PasswordField string `inject:"config:form.validator.passwordField"`
+}) {
+	v.passwordField = cfg.PasswordField
+}
+
+// ValidatorName defines tag name of password confirmation validator
+func (v *PasswordConfirmValidator) ValidatorName() string {
+	return "passwordconfirm"
+}
+
+// ValidateField validates if confirmation in string or *string field is equal to password in password field,
+// where empty confirmation and nil pointer are equal to empty password. It panics if password field doesn't exist.
+func (v *PasswordConfirmValidator) ValidateField(_ context.Context, fl validator.FieldLevel) bool {
+	passwordField := fl.Param()
+	if passwordField == "" {
+		passwordField = v.passwordField
+	}
+
+	parent := fl.Parent()
+	for parent.Kind() == reflect.Ptr && !parent.IsNil() {
+		parent = parent.Elem()
+	}
+
+	var password reflect.Value
+	if parent.Kind() == reflect.Struct {
+		password = parent.FieldByName(passwordField)
+	}
+	if !password.IsValid() {
+		panic(domain.NewFormErrorf("password field %q doesn't exist", passwordField).Error())
+	}
+
+	return v.getString(password) == v.getString(fl.Field())
+}
+
+// getString returns value of string or *string field, where nil pointer is presented as empty string
+func (v *PasswordConfirmValidator) getString(field reflect.Value) string {
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return ""
+		}
+		field = field.Elem()
+	}
+
+	if field.Kind() != reflect.String {
+		return ""
+	}
+
+	return field.String()
+}
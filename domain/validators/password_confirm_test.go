@@ -0,0 +1,112 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/form/domain/mocks"
+)
+
+type (
+	PasswordConfirmValidatorTestSuite struct {
+		suite.Suite
+
+		validator *PasswordConfirmValidator
+	}
+
+	passwordConfirmTestData struct {
+		Password          string
+		NewPassword       *string
+		PasswordConfirm   string
+		NewPasswordRepeat *string
+	}
+)
+
+func TestPasswordConfirmValidatorTestSuite(t *testing.T) {
+	suite.Run(t, &PasswordConfirmValidatorTestSuite{})
+}
+
+func (t *PasswordConfirmValidatorTestSuite) SetupTest() {
+	t.validator = &PasswordConfirmValidator{}
+	t.validator.Inject(&struct {
+		PasswordField string `inject:"config:form.validator.passwordField"`
+	}{
+		PasswordField: "Password",
+	})
+}
+
+func (t *PasswordConfirmValidatorTestSuite) TestValidatorName() {
+	t.Equal("passwordconfirm", t.validator.ValidatorName())
+}
+
+func (t *PasswordConfirmValidatorTestSuite) TestValidateField() {
+	newPassword := "secret"
+	otherPassword := "other"
+
+	testCases := []struct {
+		Param  string
+		Parent interface{}
+		Value  interface{}
+		Result bool
+	}{
+		{
+			Parent: passwordConfirmTestData{Password: "secret"},
+			Value:  "secret",
+			Result: true,
+		},
+		{
+			Parent: &passwordConfirmTestData{Password: "secret"},
+			Value:  "Secret",
+			Result: false,
+		},
+		{
+			Parent: passwordConfirmTestData{Password: "secret"},
+			Value:  "",
+			Result: false,
+		},
+		{
+			Parent: passwordConfirmTestData{},
+			Value:  "",
+			Result: true,
+		},
+		{
+			Param:  "NewPassword",
+			Parent: passwordConfirmTestData{Password: "other", NewPassword: &newPassword},
+			Value:  &newPassword,
+			Result: true,
+		},
+		{
+			Param:  "NewPassword",
+			Parent: passwordConfirmTestData{NewPassword: &newPassword},
+			Value:  &otherPassword,
+			Result: false,
+		},
+		{
+			Param:  "NewPassword",
+			Parent: passwordConfirmTestData{},
+			Value:  (*string)(nil),
+			Result: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fieldLevel := &mocks.FieldLevel{}
+		fieldLevel.On("Param").Return(testCase.Param).Once()
+		fieldLevel.On("Parent").Return(reflect.ValueOf(testCase.Parent)).Once()
+		fieldLevel.On("Field").Return(reflect.ValueOf(testCase.Value)).Once()
+		t.Equal(testCase.Result, t.validator.ValidateField(nil, fieldLevel), testCase)
+		fieldLevel.AssertExpectations(t.T())
+	}
+}
+
+func (t *PasswordConfirmValidatorTestSuite) TestValidateField_UnknownPasswordField() {
+	fieldLevel := &mocks.FieldLevel{}
+	fieldLevel.On("Param").Return("Secret").Once()
+	fieldLevel.On("Parent").Return(reflect.ValueOf(passwordConfirmTestData{})).Once()
+
+	t.Panics(func() {
+		t.validator.ValidateField(nil, fieldLevel)
+	})
+}
@@ -0,0 +1,249 @@
+package validators
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"flamingo.me/form/domain"
+
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+type (
+	// PasswordPolicy defines criteria which password has to meet, to be accepted by password strength validator
+	PasswordPolicy struct {
+		// MinLength as minimal number of characters (runes)
+		MinLength int
+		// RequireUppercase defines if password must contain at least one uppercase letter
+		RequireUppercase bool
+		// RequireLowercase defines if password must contain at least one lowercase letter
+		RequireLowercase bool
+		// RequireDigit defines if password must contain at least one digit
+		RequireDigit bool
+		// RequireSpecial defines if password must contain at least one character which is neither letter nor digit
+		RequireSpecial bool
+		// MinEntropy as minimal estimated entropy in bits, where zero disables entropy check
+		MinEntropy float64
+	}
+
+	// PasswordStrengthValidator defines password strength validator which validates passwords against password policy
+	// from application's configuration. Each failed criterion of policy is reported as separate field error,
+	// with criterion as tag (like "formError.password.passwordlength"), so each of them can be translated on its own.
+	// It can be used for string and *string fields.
+	//
+	// Data struct {
+	//	 Password string `validate:"required,passwordstrength"`
+	// }
+	//
+	PasswordStrengthValidator struct {
+		policy PasswordPolicy
+	}
+)
+
+const (
+	// PasswordCriterionLength as criterion of minimal password length, with minimal length as parameter
+	PasswordCriterionLength = "passwordlength"
+	// PasswordCriterionUppercase as criterion of required uppercase letter
+	PasswordCriterionUppercase = "passworduppercase"
+	// PasswordCriterionLowercase as criterion of required lowercase letter
+	PasswordCriterionLowercase = "passwordlowercase"
+	// PasswordCriterionDigit as criterion of required digit
+	PasswordCriterionDigit = "passworddigit"
+	// PasswordCriterionSpecial as criterion of required special character
+	PasswordCriterionSpecial = "passwordspecial"
+	// PasswordCriterionEntropy as criterion of minimal estimated entropy, with minimal entropy in bits as parameter
+	PasswordCriterionEntropy = "passwordentropy"
+)
+
+var (
+	_ domain.FieldValidator         = &PasswordStrengthValidator{}
+	_ domain.CriteriaFieldValidator = &PasswordStrengthValidator{}
+)
+
+// NewPasswordPolicy creates password policy from configuration map with keys "minLength", "requireUppercase",
+// "requireLowercase", "requireDigit", "requireSpecial" and "minEntropy". It returns error which lists all invalid
+// or unknown configuration values.
+func NewPasswordPolicy(config map[string]interface{}) (PasswordPolicy, error) {
+	var invalid []string
+	policy := PasswordPolicy{}
+
+	for name, value := range config {
+		var err error
+
+		switch name {
+		case "minLength":
+			var number float64
+			number, err = getPolicyNumber(value)
+			if err == nil && number != math.Trunc(number) {
+				err = fmt.Errorf("value is not an integer")
+			}
+			policy.MinLength = int(number)
+		case "requireUppercase":
+			policy.RequireUppercase, err = getPolicyFlag(value)
+		case "requireLowercase":
+			policy.RequireLowercase, err = getPolicyFlag(value)
+		case "requireDigit":
+			policy.RequireDigit, err = getPolicyFlag(value)
+		case "requireSpecial":
+			policy.RequireSpecial, err = getPolicyFlag(value)
+		case "minEntropy":
+			policy.MinEntropy, err = getPolicyNumber(value)
+		default:
+			err = fmt.Errorf("unknown criterion")
+		}
+
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s (%s)", name, err.Error()))
+		}
+	}
+
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return PasswordPolicy{}, domain.NewFormErrorf("invalid password policy: %s", strings.Join(invalid, ", "))
+	}
+
+	return policy, nil
+}
+
+// getPolicyNumber converts configured value into non-negative number
+func getPolicyNumber(value interface{}) (float64, error) {
+	var number float64
+
+	switch typed := value.(type) {
+	case float64:
+		number = typed
+	case int:
+		number = float64(typed)
+	default:
+		return 0, fmt.Errorf("value is not a number")
+	}
+
+	if number < 0 {
+		return 0, fmt.Errorf("value is negative")
+	}
+
+	return number, nil
+}
+
+// getPolicyFlag converts configured value into boolean
+func getPolicyFlag(value interface{}) (bool, error) {
+	flag, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("value is not a boolean")
+	}
+
+	return flag, nil
+}
+
+// NewPasswordStrengthValidator creates instance of PasswordStrengthValidator with password policy
+func NewPasswordStrengthValidator(policy PasswordPolicy) *PasswordStrengthValidator {
+	return &PasswordStrengthValidator{
+		policy: policy,
+	}
+}
+
+// ValidatorName defines tag name of password strength validator
+func (v *PasswordStrengthValidator) ValidatorName() string {
+	return "passwordstrength"
+}
+
+// ValidateField validates if password meets all criteria of password policy.
+// Valid if string is empty or nil pointer, so they can be handled by "required" validation.
+func (v *PasswordStrengthValidator) ValidateField(_ context.Context, fl validator.FieldLevel) bool {
+	return len(v.getFailedCriteria(fl.Field())) == 0
+}
+
+// FailedCriteria returns all criteria of password policy which are not met by password, with their parameters
+// (like minimal length), in order of their definition in password policy
+func (v *PasswordStrengthValidator) FailedCriteria(value interface{}, _ string) []domain.ValidationRule {
+	return v.getFailedCriteria(reflect.ValueOf(value))
+}
+
+// getFailedCriteria returns failed criteria of password in string or *string field
+func (v *PasswordStrengthValidator) getFailedCriteria(field reflect.Value) []domain.ValidationRule {
+	for field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil
+		}
+		field = field.Elem()
+	}
+
+	if field.Kind() != reflect.String || field.String() == "" {
+		return nil
+	}
+	password := field.String()
+
+	var failed []domain.ValidationRule
+
+	if len([]rune(password)) < v.policy.MinLength {
+		failed = append(failed, domain.ValidationRule{Name: PasswordCriterionLength, Value: strconv.Itoa(v.policy.MinLength)})
+	}
+	if v.policy.RequireUppercase && strings.IndexFunc(password, unicode.IsUpper) < 0 {
+		failed = append(failed, domain.ValidationRule{Name: PasswordCriterionUppercase})
+	}
+	if v.policy.RequireLowercase && strings.IndexFunc(password, unicode.IsLower) < 0 {
+		failed = append(failed, domain.ValidationRule{Name: PasswordCriterionLowercase})
+	}
+	if v.policy.RequireDigit && strings.IndexFunc(password, unicode.IsDigit) < 0 {
+		failed = append(failed, domain.ValidationRule{Name: PasswordCriterionDigit})
+	}
+	if v.policy.RequireSpecial && strings.IndexFunc(password, isSpecialCharacter) < 0 {
+		failed = append(failed, domain.ValidationRule{Name: PasswordCriterionSpecial})
+	}
+	if v.policy.MinEntropy > 0 && EstimatePasswordEntropy(password) < v.policy.MinEntropy {
+		failed = append(failed, domain.ValidationRule{Name: PasswordCriterionEntropy, Value: strconv.FormatFloat(v.policy.MinEntropy, 'f', -1, 64)})
+	}
+
+	return failed
+}
+
+// EstimatePasswordEntropy returns estimated entropy of password in bits. Each character adds entropy of pool
+// of all character classes used in password, except characters which repeat previous one or continue
+// sequence (like "aaa", "abc" or "321"), which add only one bit, since they are guessed first.
+func EstimatePasswordEntropy(password string) float64 {
+	pool := 0
+	for _, class := range []struct {
+		size    int
+		matches func(r rune) bool
+	}{
+		{size: 26, matches: func(r rune) bool { return r <= unicode.MaxASCII && unicode.IsLower(r) }},
+		{size: 26, matches: func(r rune) bool { return r <= unicode.MaxASCII && unicode.IsUpper(r) }},
+		{size: 10, matches: func(r rune) bool { return r <= unicode.MaxASCII && unicode.IsDigit(r) }},
+		{size: 33, matches: func(r rune) bool { return r <= unicode.MaxASCII && isSpecialCharacter(r) }},
+		{size: 100, matches: func(r rune) bool { return r > unicode.MaxASCII }},
+	} {
+		if strings.IndexFunc(password, class.matches) >= 0 {
+			pool += class.size
+		}
+	}
+
+	if pool == 0 {
+		return 0
+	}
+
+	bitsPerCharacter := math.Log2(float64(pool))
+	entropy := 0.0
+
+	runes := []rune(password)
+	for i, r := range runes {
+		if i > 0 && r-runes[i-1] >= -1 && r-runes[i-1] <= 1 {
+			entropy++
+			continue
+		}
+
+		entropy += bitsPerCharacter
+	}
+
+	return entropy
+}
+
+// isSpecialCharacter defines if character is neither letter, digit nor whitespace
+func isSpecialCharacter(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r)
+}
@@ -0,0 +1,230 @@
+package validators
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/mocks"
+)
+
+type (
+	PasswordStrengthValidatorTestSuite struct {
+		suite.Suite
+
+		validator *PasswordStrengthValidator
+	}
+)
+
+func TestPasswordStrengthValidatorTestSuite(t *testing.T) {
+	suite.Run(t, &PasswordStrengthValidatorTestSuite{})
+}
+
+func (t *PasswordStrengthValidatorTestSuite) SetupTest() {
+	t.validator = NewPasswordStrengthValidator(PasswordPolicy{
+		MinLength:        8,
+		RequireUppercase: true,
+		RequireDigit:     true,
+	})
+}
+
+func (t *PasswordStrengthValidatorTestSuite) TestValidatorName() {
+	t.Equal("passwordstrength", t.validator.ValidatorName())
+}
+
+func (t *PasswordStrengthValidatorTestSuite) TestNewPasswordPolicy() {
+	policy, err := NewPasswordPolicy(map[string]interface{}{
+		"minLength":        float64(12),
+		"requireUppercase": true,
+		"requireLowercase": false,
+		"requireDigit":     true,
+		"requireSpecial":   true,
+		"minEntropy":       float64(40),
+	})
+	t.NoError(err)
+	t.Equal(PasswordPolicy{
+		MinLength:        12,
+		RequireUppercase: true,
+		RequireDigit:     true,
+		RequireSpecial:   true,
+		MinEntropy:       40,
+	}, policy)
+
+	policy, err = NewPasswordPolicy(nil)
+	t.NoError(err)
+	t.Equal(PasswordPolicy{}, policy)
+}
+
+func (t *PasswordStrengthValidatorTestSuite) TestNewPasswordPolicy_Invalid() {
+	_, err := NewPasswordPolicy(map[string]interface{}{
+		"minLength":      7.5,
+		"requireDigit":   "yes",
+		"minEntropy":     float64(-1),
+		"requireSymbols": true,
+	})
+	t.EqualError(err, "FormError: invalid password policy: minEntropy (value is negative), minLength (value is not an integer), requireDigit (value is not a boolean), requireSymbols (unknown criterion)")
+
+	_, err = NewPasswordPolicy(map[string]interface{}{
+		"minLength": "8",
+	})
+	t.EqualError(err, "FormError: invalid password policy: minLength (value is not a number)")
+}
+
+func (t *PasswordStrengthValidatorTestSuite) TestFailedCriteria() {
+	testCases := []struct {
+		Policy   PasswordPolicy
+		Password string
+		Result   []domain.ValidationRule
+	}{
+		{
+			Policy:   PasswordPolicy{MinLength: 8},
+			Password: "Müller12",
+			Result:   nil,
+		},
+		{
+			Policy:   PasswordPolicy{MinLength: 8},
+			Password: "Müller1",
+			Result:   []domain.ValidationRule{{Name: PasswordCriterionLength, Value: "8"}},
+		},
+		{
+			Policy:   PasswordPolicy{RequireUppercase: true},
+			Password: "Ölwechsel",
+			Result:   nil,
+		},
+		{
+			Policy:   PasswordPolicy{RequireUppercase: true},
+			Password: "secret",
+			Result:   []domain.ValidationRule{{Name: PasswordCriterionUppercase}},
+		},
+		{
+			Policy:   PasswordPolicy{RequireLowercase: true},
+			Password: "SECRETß",
+			Result:   nil,
+		},
+		{
+			Policy:   PasswordPolicy{RequireLowercase: true},
+			Password: "SECRET",
+			Result:   []domain.ValidationRule{{Name: PasswordCriterionLowercase}},
+		},
+		{
+			Policy:   PasswordPolicy{RequireDigit: true},
+			Password: "secret1",
+			Result:   nil,
+		},
+		{
+			Policy:   PasswordPolicy{RequireDigit: true},
+			Password: "secret",
+			Result:   []domain.ValidationRule{{Name: PasswordCriterionDigit}},
+		},
+		{
+			Policy:   PasswordPolicy{RequireSpecial: true},
+			Password: "secret!",
+			Result:   nil,
+		},
+		{
+			Policy:   PasswordPolicy{RequireSpecial: true},
+			Password: "secret 123",
+			Result:   []domain.ValidationRule{{Name: PasswordCriterionSpecial}},
+		},
+		{
+			Policy:   PasswordPolicy{MinEntropy: 40},
+			Password: "Tr0ub4dor&3",
+			Result:   nil,
+		},
+		{
+			Policy:   PasswordPolicy{MinEntropy: 40.5},
+			Password: "abcdefghijklmnop",
+			Result:   []domain.ValidationRule{{Name: PasswordCriterionEntropy, Value: "40.5"}},
+		},
+		{
+			Policy: PasswordPolicy{
+				MinLength:        8,
+				RequireUppercase: true,
+				RequireLowercase: true,
+				RequireDigit:     true,
+				RequireSpecial:   true,
+				MinEntropy:       30,
+			},
+			Password: "aaaa",
+			Result: []domain.ValidationRule{
+				{Name: PasswordCriterionLength, Value: "8"},
+				{Name: PasswordCriterionUppercase},
+				{Name: PasswordCriterionDigit},
+				{Name: PasswordCriterionSpecial},
+				{Name: PasswordCriterionEntropy, Value: "30"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		validator := NewPasswordStrengthValidator(testCase.Policy)
+		t.Equal(testCase.Result, validator.FailedCriteria(testCase.Password, ""), testCase.Password)
+	}
+}
+
+func (t *PasswordStrengthValidatorTestSuite) TestValidateField() {
+	password := "Secret12"
+	weakPassword := "secret"
+	var nilPassword *string
+
+	testCases := []struct {
+		Value  interface{}
+		Result bool
+	}{
+		{
+			Value:  "",
+			Result: true,
+		},
+		{
+			Value:  "Secret12",
+			Result: true,
+		},
+		{
+			Value:  "Secret1",
+			Result: false,
+		},
+		{
+			Value:  "secret12",
+			Result: false,
+		},
+		{
+			Value:  &password,
+			Result: true,
+		},
+		{
+			Value:  &weakPassword,
+			Result: false,
+		},
+		{
+			Value:  nilPassword,
+			Result: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fieldLevel := &mocks.FieldLevel{}
+		fieldLevel.On("Field").Return(reflect.ValueOf(testCase.Value)).Once()
+		t.Equal(testCase.Result, t.validator.ValidateField(nil, fieldLevel), testCase.Value)
+		fieldLevel.AssertExpectations(t.T())
+	}
+}
+
+func (t *PasswordStrengthValidatorTestSuite) TestEstimatePasswordEntropy() {
+	round := func(entropy float64) float64 {
+		return math.Round(entropy*100) / 100
+	}
+
+	t.Equal(0.0, EstimatePasswordEntropy(""))
+
+	// repeated and sequential characters add only one bit each
+	t.Equal(11.70, round(EstimatePasswordEntropy("aaaaaaaa")))
+	t.Equal(11.70, round(EstimatePasswordEntropy("abcdefgh")))
+	t.Equal(8.32, round(EstimatePasswordEntropy("654321")))
+
+	// pool contains all character classes used in password
+	t.Equal(23.82, round(EstimatePasswordEntropy("aZ3z")))
+	t.Equal(72.27, round(EstimatePasswordEntropy("Tr0ub4dor&3")))
+}
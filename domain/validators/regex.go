@@ -2,7 +2,12 @@ package validators
 
 import (
 	"context"
+	"fmt"
+	"reflect"
 	"regexp"
+	"sort"
+	"strings"
+	"sync"
 
 	"flamingo.me/form/domain"
 	validator "gopkg.in/go-playground/validator.v9"
@@ -23,9 +28,31 @@ type (
 		name  string
 		regex *regexp.Regexp
 	}
+
+	// RegexPatternValidator defines regex validator where regex pattern is passed as validation parameter.
+	// Since validator uses comma as separator between validations, pattern can't contain comma,
+	// so it's recommended to use named regex validators for more complex patterns.
+	//
+	// Data struct {
+	//	 PostCode string `validate:"regex=^[0-9]{5}$"`
+	// }
+	//
+	RegexPatternValidator struct {
+		patterns sync.Map
+	}
 )
 
-var _ domain.FieldValidator = &RegexValidator{}
+const (
+	// NamedRegexValidatorPrefix defines prefix of tag names for regex validators defined in configuration
+	NamedRegexValidatorPrefix = "regex_"
+)
+
+var (
+	_ domain.FieldValidator           = &RegexValidator{}
+	_ domain.ValidationRuleTranslator = &RegexValidator{}
+	_ domain.FieldValidator           = &RegexPatternValidator{}
+	_ domain.ValidationRuleTranslator = &RegexPatternValidator{}
+)
 
 // NewRegexValidator creates new instance of RegexValidator by defining it's tag name and regex pattern
 func NewRegexValidator(name string, regex string) *RegexValidator {
@@ -35,14 +62,51 @@ func NewRegexValidator(name string, regex string) *RegexValidator {
 	}
 }
 
+// NewNamedRegexValidators creates instances of RegexValidator from map of regex patterns, where each validator
+// is registered under name with prefix "regex_" (like "regex_zipDE"). It returns error which lists all invalid patterns.
+func NewNamedRegexValidators(patterns map[string]interface{}) ([]*RegexValidator, error) {
+	var invalid []string
+	regexValidators := make([]*RegexValidator, 0, len(patterns))
+
+	for name, value := range patterns {
+		pattern, ok := value.(string)
+		if !ok {
+			invalid = append(invalid, fmt.Sprintf("%s (pattern is not a string)", name))
+			continue
+		}
+
+		regex, err := regexp.Compile(pattern)
+		if err != nil {
+			invalid = append(invalid, fmt.Sprintf("%s (%s)", name, err.Error()))
+			continue
+		}
+
+		regexValidators = append(regexValidators, &RegexValidator{
+			name:  NamedRegexValidatorPrefix + name,
+			regex: regex,
+		})
+	}
+
+	if len(invalid) > 0 {
+		sort.Strings(invalid)
+		return nil, domain.NewFormErrorf("invalid regex patterns: %s", strings.Join(invalid, ", "))
+	}
+
+	sort.Slice(regexValidators, func(i, j int) bool {
+		return regexValidators[i].name < regexValidators[j].name
+	})
+
+	return regexValidators, nil
+}
+
 // ValidatorName defines tag name of regex validator
 func (v *RegexValidator) ValidatorName() string {
 	return v.name
 }
 
-// ValidateField validates string if match right regex. Valid if string is empty or match defined regex pattern.
+// ValidateField validates string if match right regex. Valid if string is empty, nil pointer, or match defined regex pattern.
 func (v *RegexValidator) ValidateField(_ context.Context, fl validator.FieldLevel) bool {
-	converted, ok := fl.Field().Interface().(string)
+	converted, ok := getRegexValue(fl.Field())
 	if !ok {
 		return false
 	}
@@ -53,3 +117,68 @@ func (v *RegexValidator) ValidateField(_ context.Context, fl validator.FieldLeve
 
 	return v.regex.MatchString(converted)
 }
+
+// TranslateValidationRule exposes regex pattern of validator as "pattern" validation rule
+func (v *RegexValidator) TranslateValidationRule(string) domain.ValidationRule {
+	return domain.ValidationRule{
+		Name:  "pattern",
+		Value: v.regex.String(),
+	}
+}
+
+// ValidatorName defines tag name of regex pattern validator
+func (v *RegexPatternValidator) ValidatorName() string {
+	return "regex"
+}
+
+// ValidateField validates string if match regex pattern passed as parameter. Valid if string is empty, nil pointer, or match regex pattern.
+// It panics if regex pattern is not valid, in the same way as other validators do for invalid parameters.
+func (v *RegexPatternValidator) ValidateField(_ context.Context, fl validator.FieldLevel) bool {
+	converted, ok := getRegexValue(fl.Field())
+	if !ok {
+		return false
+	}
+
+	if len(converted) == 0 {
+		return true
+	}
+
+	return v.getRegex(fl.Param()).MatchString(converted)
+}
+
+// TranslateValidationRule exposes regex pattern passed as parameter as "pattern" validation rule
+func (v *RegexPatternValidator) TranslateValidationRule(param string) domain.ValidationRule {
+	return domain.ValidationRule{
+		Name:  "pattern",
+		Value: param,
+	}
+}
+
+// getRegex returns compiled regex pattern, which is compiled only once for each pattern
+func (v *RegexPatternValidator) getRegex(pattern string) *regexp.Regexp {
+	if regex, ok := v.patterns.Load(pattern); ok {
+		return regex.(*regexp.Regexp)
+	}
+
+	regex := regexp.MustCompile(pattern)
+	v.patterns.Store(pattern, regex)
+
+	return regex
+}
+
+// getRegexValue returns string which is matched with regex pattern. Pointers are dereferenced, and nil pointer is handled
+// as empty string, so it can be handled by "required" validation. It returns false for fields of all other types.
+func getRegexValue(field reflect.Value) (string, bool) {
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return "", true
+		}
+		field = field.Elem()
+	}
+
+	if field.Kind() != reflect.String {
+		return "", false
+	}
+
+	return field.String(), true
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 
+	"flamingo.me/form/domain"
 	"flamingo.me/form/domain/mocks"
 )
 
@@ -29,6 +30,18 @@ func (t *RegexValidatorTestSuite) TestValidatorName() {
 	t.Equal("onlynumber", t.validator.ValidatorName())
 }
 
+func (t *RegexValidatorTestSuite) TestTranslateValidationRule() {
+	t.Equal(domain.ValidationRule{
+		Name:  "pattern",
+		Value: "^[0-9]{1}$",
+	}, t.validator.TranslateValidationRule(""))
+
+	t.Equal(domain.ValidationRule{
+		Name:  "pattern",
+		Value: "^[0-9]{5}$",
+	}, (&RegexPatternValidator{}).TranslateValidationRule("^[0-9]{5}$"))
+}
+
 func (t *RegexValidatorTestSuite) TestValidateField() {
 	testCases := []struct {
 		Value  string
@@ -67,3 +80,131 @@ func (t *RegexValidatorTestSuite) TestValidateField() {
 		fieldLevel.AssertExpectations(t.T())
 	}
 }
+
+func (t *RegexValidatorTestSuite) TestNewNamedRegexValidators() {
+	regexValidators, err := NewNamedRegexValidators(map[string]interface{}{
+		"zipDE": "^[0-9]{5}$",
+		"phone": `^\+?[0-9 ]{6,}$`,
+	})
+	t.NoError(err)
+	t.Len(regexValidators, 2)
+	t.Equal("regex_phone", regexValidators[0].ValidatorName())
+	t.Equal("regex_zipDE", regexValidators[1].ValidatorName())
+
+	testCases := []struct {
+		Validator *RegexValidator
+		Value     string
+		Result    bool
+	}{
+		{
+			Validator: regexValidators[0],
+			Value:     "+49 176 1234567",
+			Result:    true,
+		},
+		{
+			Validator: regexValidators[0],
+			Value:     "12345",
+			Result:    false,
+		},
+		{
+			Validator: regexValidators[1],
+			Value:     "12345",
+			Result:    true,
+		},
+		{
+			Validator: regexValidators[1],
+			Value:     "1234",
+			Result:    false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fieldLevel := &mocks.FieldLevel{}
+		fieldLevel.On("Field").Return(reflect.ValueOf(testCase.Value)).Once()
+		t.Equal(testCase.Result, testCase.Validator.ValidateField(nil, fieldLevel))
+		fieldLevel.AssertExpectations(t.T())
+	}
+
+	regexValidators, err = NewNamedRegexValidators(nil)
+	t.NoError(err)
+	t.Empty(regexValidators)
+}
+
+func (t *RegexValidatorTestSuite) TestNewNamedRegexValidators_Invalid() {
+	regexValidators, err := NewNamedRegexValidators(map[string]interface{}{
+		"zipDE":  "^[0-9]{5}$",
+		"phone":  "^[0-9",
+		"number": 5,
+	})
+	t.Nil(regexValidators)
+	t.EqualError(err, "FormError: invalid regex patterns: number (pattern is not a string), phone (error parsing regexp: missing closing ]: `[0-9`)")
+}
+
+func (t *RegexValidatorTestSuite) TestRegexPatternValidator() {
+	regexPatternValidator := &RegexPatternValidator{}
+	t.Equal("regex", regexPatternValidator.ValidatorName())
+
+	letters, digits := "abc", "123"
+
+	testCases := []struct {
+		Value  interface{}
+		Param  string
+		Result bool
+	}{
+		{
+			Value:  "",
+			Param:  "^[0-9]{5}$",
+			Result: true,
+		},
+		{
+			Value:  "12345",
+			Param:  "^[0-9]{5}$",
+			Result: true,
+		},
+		{
+			Value:  "1234a",
+			Param:  "^[0-9]{5}$",
+			Result: false,
+		},
+		{
+			Value:  "abc",
+			Param:  "^[a-z]+$",
+			Result: true,
+		},
+		{
+			Value:  5,
+			Param:  "^[0-9]+$",
+			Result: false,
+		},
+		{
+			Value:  (*string)(nil),
+			Param:  "^[0-9]+$",
+			Result: true,
+		},
+		{
+			Value:  &letters,
+			Param:  "^[0-9]+$",
+			Result: false,
+		},
+		{
+			Value:  &digits,
+			Param:  "^[0-9]+$",
+			Result: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fieldLevel := &mocks.FieldLevel{}
+		fieldLevel.On("Field").Return(reflect.ValueOf(testCase.Value)).Once()
+		fieldLevel.On("Param").Return(testCase.Param).Maybe()
+		t.Equal(testCase.Result, regexPatternValidator.ValidateField(nil, fieldLevel))
+		fieldLevel.AssertExpectations(t.T())
+	}
+
+	fieldLevel := &mocks.FieldLevel{}
+	fieldLevel.On("Field").Return(reflect.ValueOf("value")).Once()
+	fieldLevel.On("Param").Return("^[0-9").Once()
+	t.Panics(func() {
+		regexPatternValidator.ValidateField(nil, fieldLevel)
+	})
+}
@@ -0,0 +1,39 @@
+package validators
+
+import (
+	"context"
+
+	"flamingo.me/form/domain"
+
+	validator "gopkg.in/go-playground/validator.v9"
+)
+
+type (
+	// RuneLengthValidator defines exact length validator which counts runes instead of bytes,
+	// for values with fixed number of characters, like codes. It supports string, *string and []string fields.
+	//
+	// Data struct {
+	//	 Code   string   `validate:"runelen=5"`
+	//	 Backup *string  `validate:"runelen=5"`
+	//	 Codes  []string `validate:"runelen=5"`
+	// }
+	//
+	RuneLengthValidator struct{}
+)
+
+var _ domain.FieldValidator = &RuneLengthValidator{}
+
+// ValidatorName defines tag name of exact length validator
+func (v *RuneLengthValidator) ValidatorName() string {
+	return "runelen"
+}
+
+// ValidateField validates if strings contain exactly desired number of runes.
+// Valid if string is empty or nil pointer, so they can be handled by "required" validation.
+func (v *RuneLengthValidator) ValidateField(_ context.Context, fl validator.FieldLevel) bool {
+	limit := getRuneLimit(fl.Param())
+
+	return validateRuneCounts(fl.Field(), func(count int) bool {
+		return count == limit
+	})
+}
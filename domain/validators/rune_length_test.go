@@ -0,0 +1,98 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/form/domain/mocks"
+)
+
+type (
+	RuneLengthValidatorTestSuite struct {
+		suite.Suite
+
+		validator *RuneLengthValidator
+	}
+)
+
+func TestRuneLengthValidatorTestSuite(t *testing.T) {
+	suite.Run(t, &RuneLengthValidatorTestSuite{})
+}
+
+func (t *RuneLengthValidatorTestSuite) SetupTest() {
+	t.validator = &RuneLengthValidator{}
+}
+
+func (t *RuneLengthValidatorTestSuite) TestValidatorName() {
+	t.Equal("runelen", t.validator.ValidatorName())
+}
+
+func (t *RuneLengthValidatorTestSuite) TestValidateField() {
+	name := "Müller"
+	var nilName *string
+
+	testCases := []struct {
+		Value  interface{}
+		Result bool
+	}{
+		{
+			Value:  "",
+			Result: true,
+		},
+		{
+			Value:  "Mülle",
+			Result: false,
+		},
+		{
+			Value:  "Müller",
+			Result: true,
+		},
+		{
+			Value:  "Müllers",
+			Result: false,
+		},
+		{
+			Value:  "Mu\u0308lle",
+			Result: true,
+		},
+		{
+			Value:  "😀😀😀😀😀😀",
+			Result: true,
+		},
+		{
+			Value:  &name,
+			Result: true,
+		},
+		{
+			Value:  nilName,
+			Result: true,
+		},
+		{
+			Value:  []string{"Müller", "", "Bäcker"},
+			Result: true,
+		},
+		{
+			Value:  []string{"Müller", "Bär"},
+			Result: false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		fieldLevel := &mocks.FieldLevel{}
+		fieldLevel.On("Field").Return(reflect.ValueOf(testCase.Value)).Once()
+		fieldLevel.On("Param").Return("6").Once()
+		t.Equal(testCase.Result, t.validator.ValidateField(nil, fieldLevel), testCase.Value)
+		fieldLevel.AssertExpectations(t.T())
+	}
+}
+
+func (t *RuneLengthValidatorTestSuite) TestValidateField_InvalidParam() {
+	fieldLevel := &mocks.FieldLevel{}
+	fieldLevel.On("Param").Return("wrong").Once()
+
+	t.Panics(func() {
+		t.validator.ValidateField(nil, fieldLevel)
+	})
+}
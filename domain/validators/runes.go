@@ -0,0 +1,61 @@
+package validators
+
+import (
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+)
+
+// getRuneCounts returns number of runes of all non empty strings in field, which can be defined as string, *string or []string.
+// Empty strings and nil pointers are skipped, so they can be handled by "required" validation.
+// Fields of all other types don't contain any strings.
+func getRuneCounts(field reflect.Value) []int {
+	switch field.Kind() {
+	case reflect.String:
+		if field.String() == "" {
+			return nil
+		}
+
+		return []int{utf8.RuneCountInString(field.String())}
+	case reflect.Ptr:
+		if field.IsNil() {
+			return nil
+		}
+
+		return getRuneCounts(field.Elem())
+	case reflect.Slice, reflect.Array:
+		if field.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+
+		counts := make([]int, 0, field.Len())
+		for i := 0; i < field.Len(); i++ {
+			counts = append(counts, getRuneCounts(field.Index(i))...)
+		}
+
+		return counts
+	}
+
+	return nil
+}
+
+// getRuneLimit returns number of runes defined as validator's parameter. It panics if parameter is not a valid number.
+func getRuneLimit(param string) int {
+	value, err := strconv.ParseInt(param, 10, 64)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return int(value)
+}
+
+// validateRuneCounts checks if all non empty strings in field have number of runes accepted by passed function
+func validateRuneCounts(field reflect.Value, accept func(count int) bool) bool {
+	for _, count := range getRuneCounts(field) {
+		if !accept(count) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,89 @@
+package validators
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	RunesTestSuite struct {
+		suite.Suite
+	}
+)
+
+func TestRunesTestSuite(t *testing.T) {
+	suite.Run(t, &RunesTestSuite{})
+}
+
+func (t *RunesTestSuite) TestGetRuneCounts() {
+	name := "Müller"
+	empty := ""
+	var nilName *string
+
+	testCases := []struct {
+		Value  interface{}
+		Result []int
+	}{
+		{
+			Value:  "",
+			Result: nil,
+		},
+		{
+			Value:  "Muller",
+			Result: []int{6},
+		},
+		{
+			Value:  "Müller",
+			Result: []int{6},
+		},
+		{
+			Value:  "Mu\u0308ller",
+			Result: []int{7},
+		},
+		{
+			Value:  "😀👍",
+			Result: []int{2},
+		},
+		{
+			Value:  &name,
+			Result: []int{6},
+		},
+		{
+			Value:  &empty,
+			Result: nil,
+		},
+		{
+			Value:  nilName,
+			Result: nil,
+		},
+		{
+			Value:  []string{"Müller", "", "Bär"},
+			Result: []int{6, 3},
+		},
+		{
+			Value:  [2]string{"ä", "ö"},
+			Result: []int{1, 1},
+		},
+		{
+			Value:  []int{1, 2},
+			Result: nil,
+		},
+		{
+			Value:  10,
+			Result: nil,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Equal(testCase.Result, getRuneCounts(reflect.ValueOf(testCase.Value)), testCase.Value)
+	}
+}
+
+func (t *RunesTestSuite) TestGetRuneLimit() {
+	t.Equal(6, getRuneLimit("6"))
+	t.Panics(func() {
+		getRuneLimit("six")
+	})
+}
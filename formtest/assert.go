@@ -0,0 +1,100 @@
+package formtest
+
+import (
+	"testing"
+
+	"flamingo.me/form/domain"
+)
+
+// AssertFieldError asserts that validation info contains field error with message key for field.
+// It returns true if assertion succeeds.
+func AssertFieldError(t testing.TB, validationInfo domain.ValidationInfo, fieldName string, messageKey string) bool {
+	t.Helper()
+
+	errs := validationInfo.GetErrorsForField(fieldName)
+	for _, err := range errs {
+		if err.MessageKey == messageKey {
+			return true
+		}
+	}
+
+	t.Errorf("expected field error %q for field %q, got %v", messageKey, fieldName, messageKeys(errs))
+
+	return false
+}
+
+// AssertNoFieldError asserts that validation info doesn't contain any field error for field.
+// It returns true if assertion succeeds.
+func AssertNoFieldError(t testing.TB, validationInfo domain.ValidationInfo, fieldName string) bool {
+	t.Helper()
+
+	if errs := validationInfo.GetErrorsForField(fieldName); len(errs) > 0 {
+		t.Errorf("expected no field errors for field %q, got %v", fieldName, messageKeys(errs))
+		return false
+	}
+
+	return true
+}
+
+// AssertGeneralError asserts that validation info contains general error with message key.
+// It returns true if assertion succeeds.
+func AssertGeneralError(t testing.TB, validationInfo domain.ValidationInfo, messageKey string) bool {
+	t.Helper()
+
+	errs := validationInfo.GetGeneralErrors()
+	for _, err := range errs {
+		if err.MessageKey == messageKey {
+			return true
+		}
+	}
+
+	t.Errorf("expected general error %q, got %v", messageKey, messageKeys(errs))
+
+	return false
+}
+
+// AssertValid asserts that validation info doesn't contain any error.
+// It returns true if assertion succeeds.
+func AssertValid(t testing.TB, validationInfo domain.ValidationInfo) bool {
+	t.Helper()
+
+	if !validationInfo.IsValid() {
+		t.Errorf("expected valid validation info, got general errors %v and field errors %v", messageKeys(validationInfo.GetGeneralErrors()), fieldMessageKeys(validationInfo))
+		return false
+	}
+
+	return true
+}
+
+// AssertInvalid asserts that validation info contains at least one error.
+// It returns true if assertion succeeds.
+func AssertInvalid(t testing.TB, validationInfo domain.ValidationInfo) bool {
+	t.Helper()
+
+	if validationInfo.IsValid() {
+		t.Errorf("expected invalid validation info, got no errors")
+		return false
+	}
+
+	return true
+}
+
+// messageKeys returns message keys of all errors, used in assertion messages
+func messageKeys(errs []domain.Error) []string {
+	keys := make([]string, 0, len(errs))
+	for _, err := range errs {
+		keys = append(keys, err.MessageKey)
+	}
+
+	return keys
+}
+
+// fieldMessageKeys returns message keys of all field errors grouped by field names, used in assertion messages
+func fieldMessageKeys(validationInfo domain.ValidationInfo) map[string][]string {
+	keys := map[string][]string{}
+	for fieldName, errs := range validationInfo.GetErrorsForAllFields() {
+		keys[fieldName] = messageKeys(errs)
+	}
+
+	return keys
+}
@@ -0,0 +1,89 @@
+package formtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/form/domain"
+)
+
+type (
+	AssertTestSuite struct {
+		suite.Suite
+
+		validationInfo domain.ValidationInfo
+	}
+
+	// recordingT records failed assertions instead of failing test
+	recordingT struct {
+		testing.TB
+		errors []string
+	}
+)
+
+func TestAssertTestSuite(t *testing.T) {
+	suite.Run(t, &AssertTestSuite{})
+}
+
+func (t *AssertTestSuite) SetupTest() {
+	t.validationInfo = domain.ValidationInfo{}
+	t.validationInfo.AddGeneralError("formError.address", "address is invalid")
+	t.validationInfo.AddFieldError("email", "formError.email.required", "email required")
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *AssertTestSuite) TestAssertFieldError() {
+	recorder := &recordingT{TB: t.T()}
+
+	t.True(AssertFieldError(recorder, t.validationInfo, "email", "formError.email.required"))
+	t.Empty(recorder.errors)
+
+	t.False(AssertFieldError(recorder, t.validationInfo, "email", "formError.email.email"))
+	t.False(AssertFieldError(recorder, t.validationInfo, "name", "formError.name.required"))
+	t.Equal([]string{
+		`expected field error "formError.email.email" for field "email", got [formError.email.required]`,
+		`expected field error "formError.name.required" for field "name", got []`,
+	}, recorder.errors)
+}
+
+func (t *AssertTestSuite) TestAssertNoFieldError() {
+	recorder := &recordingT{TB: t.T()}
+
+	t.True(AssertNoFieldError(recorder, t.validationInfo, "name"))
+	t.False(AssertNoFieldError(recorder, t.validationInfo, "email"))
+	t.Equal([]string{
+		`expected no field errors for field "email", got [formError.email.required]`,
+	}, recorder.errors)
+}
+
+func (t *AssertTestSuite) TestAssertGeneralError() {
+	recorder := &recordingT{TB: t.T()}
+
+	t.True(AssertGeneralError(recorder, t.validationInfo, "formError.address"))
+	t.False(AssertGeneralError(recorder, t.validationInfo, "formError.payment"))
+	t.Equal([]string{
+		`expected general error "formError.payment", got [formError.address]`,
+	}, recorder.errors)
+}
+
+func (t *AssertTestSuite) TestAssertValid() {
+	recorder := &recordingT{TB: t.T()}
+
+	t.True(AssertValid(recorder, domain.ValidationInfo{}))
+	t.False(AssertInvalid(recorder, domain.ValidationInfo{}))
+
+	t.False(AssertValid(recorder, t.validationInfo))
+	t.True(AssertInvalid(recorder, t.validationInfo))
+
+	t.Equal([]string{
+		"expected invalid validation info, got no errors",
+		"expected valid validation info, got general errors [formError.address] and field errors map[email:[formError.email.required]]",
+	}, recorder.errors)
+}
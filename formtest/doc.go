@@ -0,0 +1,4 @@
+// Package formtest provides helpers for unit testing of controllers and services which use form package,
+// without dingo injector and real validation. It contains FakeFormHandler which returns prepared forms,
+// RequestBuilder for creating requests with url encoded or multipart bodies, and assertions for validation info.
+package formtest
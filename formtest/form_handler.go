@@ -0,0 +1,159 @@
+package formtest
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/application"
+	"flamingo.me/form/application/fake"
+	"flamingo.me/form/domain"
+)
+
+type (
+	// FakeFormHandler as faked implementation of domain.FormHandler, which returns prepared forms instead of processing requests.
+	// Form returned by specific method can be defined by its own field, otherwise Form field is used.
+	// All calls are recorded by method names, so it can be checked which handling is used by tested code.
+	FakeFormHandler struct {
+		// Form is returned from all methods, which don't have their own form defined
+		Form *domain.Form
+		// SubmittedForm is returned from HandleSubmittedForm and HandleSubmittedGETForm methods
+		SubmittedForm *domain.Form
+		// UnsubmittedForm is returned from HandleUnsubmittedForm method
+		UnsubmittedForm *domain.Form
+		// DataValidationInfo is returned from ValidateData method, otherwise empty validation info is returned
+		DataValidationInfo *domain.ValidationInfo
+		// JSONSchema is returned from Schema method
+		JSONSchema []byte
+		// Err is returned from all methods, in which case no form is returned
+		Err error
+
+		mutex sync.Mutex
+		calls []string
+	}
+)
+
+var _ domain.FormHandler = &FakeFormHandler{}
+
+// NewFakeFormHandler returns new instance of FakeFormHandler which returns provided form from all methods
+func NewFakeFormHandler(form *domain.Form) *FakeFormHandler {
+	return &FakeFormHandler{
+		Form: form,
+	}
+}
+
+// NewFormHandlerFactory returns faked implementation of application.FormHandlerFactory, together with faked
+// application.FormHandlerBuilder, which always deliver provided form handler
+func NewFormHandlerFactory(formHandler domain.FormHandler) application.FormHandlerFactory {
+	return fake.New(formHandler)
+}
+
+// NewSubmittedForm returns submitted form with provided form data and validation info
+func NewSubmittedForm(formData interface{}, validationInfo domain.ValidationInfo) *domain.Form {
+	form := domain.NewForm(true, nil)
+	form.Data = formData
+	form.ValidationInfo = validationInfo
+
+	return &form
+}
+
+// NewUnsubmittedForm returns unsubmitted form with provided form data
+func NewUnsubmittedForm(formData interface{}) *domain.Form {
+	form := domain.NewForm(false, nil)
+	form.Data = formData
+
+	return &form
+}
+
+// NewInvalidForm returns submitted form with provided form data, which contains single field error for each
+// provided field name, with provided message key (like "email": "formError.email.required"). Field errors are sorted by field names.
+func NewInvalidForm(formData interface{}, fieldErrors map[string]string) *domain.Form {
+	fieldNames := make([]string, 0, len(fieldErrors))
+	for fieldName := range fieldErrors {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+
+	validationInfo := domain.ValidationInfo{}
+	for _, fieldName := range fieldNames {
+		validationInfo.AddFieldError(fieldName, fieldErrors[fieldName], fieldErrors[fieldName])
+	}
+
+	return NewSubmittedForm(formData, validationInfo)
+}
+
+// HandleUnsubmittedForm returns prepared unsubmitted form
+func (h *FakeFormHandler) HandleUnsubmittedForm(_ context.Context, _ *web.Request) (*domain.Form, error) {
+	return h.handle("HandleUnsubmittedForm", h.UnsubmittedForm)
+}
+
+// HandleSubmittedForm returns prepared submitted form
+func (h *FakeFormHandler) HandleSubmittedForm(_ context.Context, _ *web.Request) (*domain.Form, error) {
+	return h.handle("HandleSubmittedForm", h.SubmittedForm)
+}
+
+// HandleSubmittedGETForm returns prepared submitted form
+func (h *FakeFormHandler) HandleSubmittedGETForm(_ context.Context, _ *web.Request) (*domain.Form, error) {
+	return h.handle("HandleSubmittedGETForm", h.SubmittedForm)
+}
+
+// HandleForm returns prepared form
+func (h *FakeFormHandler) HandleForm(_ context.Context, _ *web.Request) (*domain.Form, error) {
+	return h.handle("HandleForm", nil)
+}
+
+// ValidateData returns prepared validation info for changed form data
+func (h *FakeFormHandler) ValidateData(_ context.Context, _ *web.Request, _ interface{}) (*domain.ValidationInfo, error) {
+	h.mutex.Lock()
+	h.calls = append(h.calls, "ValidateData")
+	h.mutex.Unlock()
+
+	if h.Err != nil {
+		return nil, h.Err
+	}
+
+	if h.DataValidationInfo != nil {
+		return h.DataValidationInfo, nil
+	}
+
+	return &domain.ValidationInfo{}, nil
+}
+
+// Schema returns prepared JSON Schema document
+func (h *FakeFormHandler) Schema(context.Context) ([]byte, error) {
+	h.mutex.Lock()
+	h.calls = append(h.calls, "Schema")
+	h.mutex.Unlock()
+
+	if h.Err != nil {
+		return nil, h.Err
+	}
+
+	return h.JSONSchema, nil
+}
+
+// Calls returns names of all called methods, in order of calls
+func (h *FakeFormHandler) Calls() []string {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	return append([]string(nil), h.calls...)
+}
+
+// handle records method call and returns specific form if it's defined, otherwise default one
+func (h *FakeFormHandler) handle(method string, form *domain.Form) (*domain.Form, error) {
+	h.mutex.Lock()
+	h.calls = append(h.calls, method)
+	h.mutex.Unlock()
+
+	if h.Err != nil {
+		return nil, h.Err
+	}
+
+	if form != nil {
+		return form, nil
+	}
+
+	return h.Form, nil
+}
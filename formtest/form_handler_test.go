@@ -0,0 +1,178 @@
+package formtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/application"
+	"flamingo.me/form/domain"
+)
+
+type (
+	FakeFormHandlerTestSuite struct {
+		suite.Suite
+
+		context context.Context
+		request *web.Request
+	}
+
+	formTestController struct {
+		formHandlerFactory application.FormHandlerFactory
+	}
+
+	formTestAddressData struct {
+		Email string `form:"email"`
+	}
+)
+
+func TestFakeFormHandlerTestSuite(t *testing.T) {
+	suite.Run(t, &FakeFormHandlerTestSuite{})
+}
+
+func (t *FakeFormHandlerTestSuite) SetupTest() {
+	t.context = context.Background()
+	t.request = NewPostRequest(nil)
+}
+
+// Action returns status of form handling, like controller would decide which response to render
+func (c *formTestController) Action(ctx context.Context, req *web.Request) (string, error) {
+	form, err := c.formHandlerFactory.GetFormHandlerBuilder().
+		SetNamespace("address").
+		Build().
+		HandleForm(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	if !form.IsValidAndSubmitted() {
+		return "invalid", nil
+	}
+
+	return "success", nil
+}
+
+func (t *FakeFormHandlerTestSuite) TestController_InvalidForm() {
+	handler := NewFakeFormHandler(NewInvalidForm(formTestAddressData{}, map[string]string{
+		"email": "formError.email.required",
+	}))
+	controller := &formTestController{
+		formHandlerFactory: NewFormHandlerFactory(handler),
+	}
+
+	result, err := controller.Action(t.context, NewPostRequest(map[string]string{"email": ""}))
+	t.NoError(err)
+	t.Equal("invalid", result)
+	t.Equal([]string{"HandleForm"}, handler.Calls())
+}
+
+func (t *FakeFormHandlerTestSuite) TestHandle_DefaultForm() {
+	form := NewSubmittedForm(formTestAddressData{Email: "mail@example.com"}, domain.ValidationInfo{})
+	handler := NewFakeFormHandler(form)
+
+	for _, handle := range []func(context.Context, *web.Request) (*domain.Form, error){
+		handler.HandleForm,
+		handler.HandleSubmittedForm,
+		handler.HandleSubmittedGETForm,
+		handler.HandleUnsubmittedForm,
+	} {
+		result, err := handle(t.context, t.request)
+		t.NoError(err)
+		t.Equal(form, result)
+	}
+
+	t.Equal([]string{"HandleForm", "HandleSubmittedForm", "HandleSubmittedGETForm", "HandleUnsubmittedForm"}, handler.Calls())
+}
+
+func (t *FakeFormHandlerTestSuite) TestHandle_SpecificForms() {
+	form := NewSubmittedForm(formTestAddressData{}, domain.ValidationInfo{})
+	submitted := NewInvalidForm(formTestAddressData{}, map[string]string{
+		"email": "formError.email.required",
+	})
+	unsubmitted := NewUnsubmittedForm(formTestAddressData{})
+
+	handler := &FakeFormHandler{
+		Form:            form,
+		SubmittedForm:   submitted,
+		UnsubmittedForm: unsubmitted,
+	}
+
+	result, err := handler.HandleForm(t.context, t.request)
+	t.NoError(err)
+	t.Equal(form, result)
+
+	result, err = handler.HandleSubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.Equal(submitted, result)
+	t.True(result.IsSubmitted())
+	t.False(result.IsValid())
+
+	result, err = handler.HandleSubmittedGETForm(t.context, t.request)
+	t.NoError(err)
+	t.Equal(submitted, result)
+
+	result, err = handler.HandleUnsubmittedForm(t.context, t.request)
+	t.NoError(err)
+	t.Equal(unsubmitted, result)
+	t.False(result.IsSubmitted())
+}
+
+func (t *FakeFormHandlerTestSuite) TestHandle_Error() {
+	handler := NewFakeFormHandler(NewUnsubmittedForm(nil))
+	handler.Err = errors.New("error")
+
+	result, err := handler.HandleForm(t.context, t.request)
+	t.EqualError(err, "error")
+	t.Nil(result)
+}
+
+func (t *FakeFormHandlerTestSuite) TestValidateData() {
+	handler := NewFakeFormHandler(nil)
+
+	result, err := handler.ValidateData(t.context, t.request, formTestAddressData{})
+	t.NoError(err)
+	t.True(result.IsValid())
+
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldError("email", "formError.email.email", "email email")
+	handler.DataValidationInfo = &validationInfo
+
+	result, err = handler.ValidateData(t.context, t.request, formTestAddressData{Email: "wrong"})
+	t.NoError(err)
+	t.Equal(&validationInfo, result)
+	t.Equal([]string{"ValidateData", "ValidateData"}, handler.Calls())
+
+	handler.Err = errors.New("error")
+	result, err = handler.ValidateData(t.context, t.request, formTestAddressData{})
+	t.EqualError(err, "error")
+	t.Nil(result)
+}
+
+func (t *FakeFormHandlerTestSuite) TestSchema() {
+	handler := NewFakeFormHandler(nil)
+	handler.JSONSchema = []byte(`{"type": "object"}`)
+
+	result, err := handler.Schema(t.context)
+	t.NoError(err)
+	t.JSONEq(`{"type": "object"}`, string(result))
+	t.Equal([]string{"Schema"}, handler.Calls())
+
+	handler.Err = errors.New("error")
+	result, err = handler.Schema(t.context)
+	t.EqualError(err, "error")
+	t.Nil(result)
+}
+
+func (t *FakeFormHandlerTestSuite) TestNewFormHandlerFactory() {
+	handler := NewFakeFormHandler(nil)
+	factory := NewFormHandlerFactory(handler)
+
+	t.Equal(handler, factory.CreateSimpleFormHandler())
+	t.Equal(handler, factory.GetFormHandlerBuilder().
+		SetSubmitDetector(application.NewDefaultSubmitDetector("submitted")).
+		SetValidationMode(domain.ValidationModeNone).
+		Build())
+}
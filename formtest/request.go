@@ -0,0 +1,201 @@
+package formtest
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	// RequestBuilder as helper for creating instances of web.Request with submitted form values,
+	// which are sent as url encoded body, as multipart body, or as query in case of GET request
+	RequestBuilder struct {
+		method    string
+		path      string
+		values    url.Values
+		query     url.Values
+		headers   http.Header
+		files     []requestFile
+		multipart bool
+		session   *web.Session
+	}
+
+	// requestFile as struct for storing uploaded file of multipart body
+	requestFile struct {
+		fieldName string
+		fileName  string
+		content   []byte
+	}
+)
+
+// NewRequestBuilder returns new instance of RequestBuilder for request with provided http method
+func NewRequestBuilder(method string) *RequestBuilder {
+	return &RequestBuilder{
+		method:  method,
+		path:    "/",
+		values:  url.Values{},
+		query:   url.Values{},
+		headers: http.Header{},
+	}
+}
+
+// NewPostRequest returns instance of web.Request with provided values sent as url encoded body
+func NewPostRequest(values map[string]string) *web.Request {
+	return NewRequestBuilder(http.MethodPost).WithValues(values).Build()
+}
+
+// NewGetRequest returns instance of web.Request with provided values sent as query
+func NewGetRequest(values map[string]string) *web.Request {
+	return NewRequestBuilder(http.MethodGet).WithValues(values).Build()
+}
+
+// WithPath sets path of request
+func (b *RequestBuilder) WithPath(path string) *RequestBuilder {
+	b.path = path
+
+	return b
+}
+
+// WithValues adds submitted values, which are sent as body, or as query in case of GET request
+func (b *RequestBuilder) WithValues(values map[string]string) *RequestBuilder {
+	for name, value := range values {
+		b.values.Add(name, value)
+	}
+
+	return b
+}
+
+// WithValue adds single submitted value, so same field can be submitted multiple times
+func (b *RequestBuilder) WithValue(name string, value string) *RequestBuilder {
+	b.values.Add(name, value)
+
+	return b
+}
+
+// WithQuery adds query values, which are sent together with body
+func (b *RequestBuilder) WithQuery(values map[string]string) *RequestBuilder {
+	for name, value := range values {
+		b.query.Add(name, value)
+	}
+
+	return b
+}
+
+// WithHeader sets http header of request
+func (b *RequestBuilder) WithHeader(name string, value string) *RequestBuilder {
+	b.headers.Set(name, value)
+
+	return b
+}
+
+// WithFile adds uploaded file, so request body is sent as multipart body
+func (b *RequestBuilder) WithFile(fieldName string, fileName string, content []byte) *RequestBuilder {
+	b.files = append(b.files, requestFile{
+		fieldName: fieldName,
+		fileName:  fileName,
+		content:   content,
+	})
+	b.multipart = true
+
+	return b
+}
+
+// AsMultipart defines that request body is sent as multipart body, even if there are no uploaded files
+func (b *RequestBuilder) AsMultipart() *RequestBuilder {
+	b.multipart = true
+
+	return b
+}
+
+// WithSession sets session of request. By default, empty session is used.
+func (b *RequestBuilder) WithSession(session *web.Session) *RequestBuilder {
+	b.session = session
+
+	return b
+}
+
+// Build creates new instance of web.Request. It panics if request can't be created, since it's used only in tests.
+func (b *RequestBuilder) Build() *web.Request {
+	query := url.Values{}
+	for name, values := range b.query {
+		query[name] = append(query[name], values...)
+	}
+
+	body := &bytes.Buffer{}
+	contentType := ""
+
+	switch {
+	case b.method == http.MethodGet || b.method == http.MethodHead:
+		for name, values := range b.values {
+			query[name] = append(query[name], values...)
+		}
+	case b.multipart:
+		contentType = b.writeMultipart(body)
+	default:
+		body.WriteString(b.values.Encode())
+		contentType = "application/x-www-form-urlencoded"
+	}
+
+	target := b.path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	httpRequest, err := http.NewRequest(b.method, target, body)
+	if err != nil {
+		panic(err)
+	}
+
+	for name, values := range b.headers {
+		httpRequest.Header[name] = values
+	}
+	if contentType != "" && httpRequest.Header.Get("Content-Type") == "" {
+		httpRequest.Header.Set("Content-Type", contentType)
+	}
+
+	session := b.session
+	if session == nil {
+		session = web.EmptySession()
+	}
+
+	return web.CreateRequest(httpRequest, session)
+}
+
+// writeMultipart writes values and files into multipart body, and returns its content type
+func (b *RequestBuilder) writeMultipart(body *bytes.Buffer) string {
+	writer := multipart.NewWriter(body)
+
+	names := make([]string, 0, len(b.values))
+	for name := range b.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range b.values[name] {
+			if err := writer.WriteField(name, value); err != nil {
+				panic(err)
+			}
+		}
+	}
+
+	for _, file := range b.files {
+		part, err := writer.CreateFormFile(file.fieldName, file.fileName)
+		if err == nil {
+			_, err = part.Write(file.content)
+		}
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		panic(err)
+	}
+
+	return writer.FormDataContentType()
+}
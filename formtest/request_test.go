@@ -0,0 +1,101 @@
+package formtest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/flamingo/v3/framework/web"
+)
+
+type (
+	RequestBuilderTestSuite struct {
+		suite.Suite
+	}
+)
+
+func TestRequestBuilderTestSuite(t *testing.T) {
+	suite.Run(t, &RequestBuilderTestSuite{})
+}
+
+func (t *RequestBuilderTestSuite) TestNewPostRequest() {
+	req := NewPostRequest(map[string]string{
+		"email": "mail@example.com",
+		"name":  "John",
+	})
+
+	t.Equal(http.MethodPost, req.Request().Method)
+	t.Equal("application/x-www-form-urlencoded", req.Request().Header.Get("Content-Type"))
+	t.NotNil(req.Session())
+
+	t.NoError(req.Request().ParseForm())
+	t.Equal(url.Values{
+		"email": []string{"mail@example.com"},
+		"name":  []string{"John"},
+	}, req.Request().PostForm)
+}
+
+func (t *RequestBuilderTestSuite) TestNewGetRequest() {
+	req := NewGetRequest(map[string]string{
+		"query": "shoes",
+	})
+
+	t.Equal(http.MethodGet, req.Request().Method)
+	t.Empty(req.Request().Header.Get("Content-Type"))
+	t.Equal(url.Values{
+		"query": []string{"shoes"},
+	}, req.Request().URL.Query())
+}
+
+func (t *RequestBuilderTestSuite) TestBuild_Full() {
+	session := web.EmptySession()
+
+	req := NewRequestBuilder(http.MethodPost).
+		WithPath("/checkout").
+		WithValue("tags", "first").
+		WithValue("tags", "second").
+		WithQuery(map[string]string{
+			"step": "address",
+		}).
+		WithHeader("X-Requested-With", "XMLHttpRequest").
+		WithSession(session).
+		Build()
+
+	t.Equal("/checkout", req.Request().URL.Path)
+	t.Equal("address", req.Request().URL.Query().Get("step"))
+	t.Equal("XMLHttpRequest", req.Request().Header.Get("X-Requested-With"))
+	t.Equal(session, req.Session())
+
+	body, err := ioutil.ReadAll(req.Request().Body)
+	t.NoError(err)
+	t.Equal("tags=first&tags=second", string(body))
+}
+
+func (t *RequestBuilderTestSuite) TestBuild_Multipart() {
+	req := NewRequestBuilder(http.MethodPost).
+		WithValues(map[string]string{
+			"text": "value",
+		}).
+		WithFile("avatar", "avatar.png", []byte("content")).
+		Build()
+
+	t.NoError(req.Request().ParseMultipartForm(1 << 20))
+	t.Equal([]string{"value"}, req.Request().MultipartForm.Value["text"])
+	t.Len(req.Request().MultipartForm.File["avatar"], 1)
+	t.Equal("avatar.png", req.Request().MultipartForm.File["avatar"][0].Filename)
+	t.Equal(int64(7), req.Request().MultipartForm.File["avatar"][0].Size)
+}
+
+func (t *RequestBuilderTestSuite) TestBuild_AsMultipart() {
+	req := NewRequestBuilder(http.MethodPut).
+		WithValue("text", "value").
+		AsMultipart().
+		Build()
+
+	t.NoError(req.Request().ParseMultipartForm(1 << 20))
+	t.Equal([]string{"value"}, req.Request().MultipartForm.Value["text"])
+	t.Empty(req.Request().MultipartForm.File)
+}
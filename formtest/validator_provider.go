@@ -0,0 +1,93 @@
+package formtest
+
+import (
+	"context"
+	"sync"
+
+	validator "gopkg.in/go-playground/validator.v9"
+
+	"flamingo.me/flamingo/v3/framework/web"
+	"flamingo.me/form/domain"
+)
+
+type (
+	// FakeValidatorProvider as faked implementation of domain.ValidatorProvider, which returns prepared validation info
+	// instead of validating values, so custom form data validators can be tested without real validation rules.
+	FakeValidatorProvider struct {
+		// ValidationInfo is returned as result of each validation
+		ValidationInfo domain.ValidationInfo
+
+		mutex     sync.Mutex
+		validated []interface{}
+		validate  *validator.Validate
+		labelFunc domain.LabelFunc
+	}
+)
+
+var _ domain.ValidatorProvider = &FakeValidatorProvider{}
+
+// NewFakeValidatorProvider returns new instance of FakeValidatorProvider which returns provided validation info
+func NewFakeValidatorProvider(validationInfo domain.ValidationInfo) *FakeValidatorProvider {
+	return &FakeValidatorProvider{
+		ValidationInfo: validationInfo,
+	}
+}
+
+// Validate records validated value and returns prepared validation info
+func (p *FakeValidatorProvider) Validate(_ context.Context, _ *web.Request, value interface{}) domain.ValidationInfo {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.validated = append(p.validated, value)
+
+	result := domain.ValidationInfo{}
+	result.Merge(p.ValidationInfo)
+
+	return result
+}
+
+// GetValidator returns instance of validator.Validate struct without any custom field and struct validations
+func (p *FakeValidatorProvider) GetValidator() *validator.Validate {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.validate == nil {
+		p.validate = validator.New()
+	}
+
+	return p.validate
+}
+
+// ErrorsToValidationInfo returns validation info with general error "formError.invalidValidation" in case of error
+func (p *FakeValidatorProvider) ErrorsToValidationInfo(err error) domain.ValidationInfo {
+	validationInfo := domain.ValidationInfo{}
+	if err != nil {
+		validationInfo.AddGeneralError("formError.invalidValidation", err.Error())
+	}
+
+	return validationInfo
+}
+
+// RegisterLabelFunc stores function for resolving field labels, so it can be checked by LabelFunc method
+func (p *FakeValidatorProvider) RegisterLabelFunc(fn domain.LabelFunc) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.labelFunc = fn
+}
+
+// LabelFunc returns registered function for resolving field labels
+func (p *FakeValidatorProvider) LabelFunc() domain.LabelFunc {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.labelFunc
+}
+
+// Validated returns all validated values, in order of validation
+func (p *FakeValidatorProvider) Validated() []interface{} {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]interface{}(nil), p.validated...)
+}
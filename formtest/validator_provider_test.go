@@ -0,0 +1,62 @@
+package formtest
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"flamingo.me/form/domain"
+)
+
+type (
+	FakeValidatorProviderTestSuite struct {
+		suite.Suite
+
+		provider *FakeValidatorProvider
+	}
+)
+
+func TestFakeValidatorProviderTestSuite(t *testing.T) {
+	suite.Run(t, &FakeValidatorProviderTestSuite{})
+}
+
+func (t *FakeValidatorProviderTestSuite) SetupTest() {
+	validationInfo := domain.ValidationInfo{}
+	validationInfo.AddFieldError("email", "formError.email.required", "email required")
+
+	t.provider = NewFakeValidatorProvider(validationInfo)
+}
+
+func (t *FakeValidatorProviderTestSuite) TestValidate() {
+	result := t.provider.Validate(context.Background(), NewPostRequest(nil), formTestAddressData{})
+	AssertFieldError(t.T(), result, "email", "formError.email.required")
+
+	// returned validation info is a copy, so changing it doesn't change prepared one
+	result.AddGeneralError("formError.address", "address is invalid")
+	t.False(t.provider.ValidationInfo.HasGeneralErrors())
+
+	t.provider.Validate(context.Background(), nil, "second")
+	t.Equal([]interface{}{formTestAddressData{}, "second"}, t.provider.Validated())
+}
+
+func (t *FakeValidatorProviderTestSuite) TestGetValidator() {
+	t.NotNil(t.provider.GetValidator())
+	t.Equal(t.provider.GetValidator(), t.provider.GetValidator())
+}
+
+func (t *FakeValidatorProviderTestSuite) TestErrorsToValidationInfo() {
+	AssertValid(t.T(), t.provider.ErrorsToValidationInfo(nil))
+	AssertGeneralError(t.T(), t.provider.ErrorsToValidationInfo(errors.New("error")), "formError.invalidValidation")
+}
+
+func (t *FakeValidatorProviderTestSuite) TestRegisterLabelFunc() {
+	t.Nil(t.provider.LabelFunc())
+
+	t.provider.RegisterLabelFunc(func(field reflect.StructField) string {
+		return "label"
+	})
+	t.Equal("label", t.provider.LabelFunc()(reflect.StructField{}))
+}
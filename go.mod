@@ -1,5 +1,7 @@
 module flamingo.me/form
 
+go 1.18
+
 require (
 	flamingo.me/dingo v0.1.6
 	flamingo.me/flamingo/v3 v3.0.1
@@ -12,6 +14,8 @@ require (
 	github.com/leebenson/conform v0.0.0-20180615210222-bc2e0311fd85
 	github.com/leodido/go-urn v1.1.0 // indirect
 	github.com/stretchr/testify v1.4.0
+	go.opencensus.io v0.20.2
+	golang.org/x/text v0.3.0
 	gopkg.in/go-playground/assert.v1 v1.2.1 // indirect
 	gopkg.in/go-playground/validator.v9 v9.27.0
 )
@@ -5,14 +5,21 @@ import (
 	"flamingo.me/flamingo/v3/framework/config"
 	"flamingo.me/form/application"
 	"flamingo.me/form/domain"
+	"flamingo.me/form/domain/extensions"
 	"flamingo.me/form/domain/formdata"
+	"flamingo.me/form/domain/modifiers"
 	"flamingo.me/form/domain/validators"
 )
 
 type (
 	// Module is struct for defining form2 module dependencies
 	Module struct {
-		CustomRegex config.Map `inject:"config:form.validator.customRegex"`
+		CustomRegex         config.Map `inject:"config:form.validator.customRegex"`
+		Regex               config.Map `inject:"config:form.validator.regex"`
+		MessageKeyPrefix    string     `inject:"config:form.validator.messageKeyPrefix"`
+		MessageKeyMapping   config.Map `inject:"config:form.validator.messageKeyMapping"`
+		DefaultLabelMapping config.Map `inject:"config:form.validator.defaultLabelMapping"`
+		PasswordPolicy      config.Map `inject:"config:form.validator.passwordPolicy"`
 	}
 )
 
@@ -26,10 +33,41 @@ func (m *Module) Configure(injector *dingo.Injector) {
 		regexValidator := validators.NewRegexValidator(name, regex)
 		injector.BindMulti(new(domain.FieldValidator)).ToInstance(regexValidator)
 	}
+	namedRegexValidators, err := validators.NewNamedRegexValidators(m.Regex)
+	if err != nil {
+		panic("form.validator.regex: " + err.Error())
+	}
+	for _, regexValidator := range namedRegexValidators {
+		injector.BindMulti(new(domain.FieldValidator)).ToInstance(regexValidator)
+	}
+	injector.BindMulti(new(domain.FieldValidator)).To(validators.RegexPatternValidator{})
 	injector.BindMulti(new(domain.FieldValidator)).To(validators.DateFormatValidator{})
 	injector.BindMulti(new(domain.FieldValidator)).To(validators.MinimumAgeValidator{})
 	injector.BindMulti(new(domain.FieldValidator)).To(validators.MaximumAgeValidator{})
+	injector.BindMulti(new(domain.FieldValidator)).To(validators.MinimumRunesValidator{})
+	injector.BindMulti(new(domain.FieldValidator)).To(validators.MaximumRunesValidator{})
+	injector.BindMulti(new(domain.FieldValidator)).To(validators.RuneLengthValidator{})
+	injector.BindMulti(new(domain.FieldValidator)).To(validators.EachOneOfValidator{})
+	injector.BindMulti(new(domain.FieldValidator)).To(validators.MaximumFileSizeValidator{})
+	injector.BindMulti(new(domain.FieldValidator)).To(validators.MimeTypeValidator{})
+	passwordPolicy, err := validators.NewPasswordPolicy(m.PasswordPolicy)
+	if err != nil {
+		panic("form.validator.passwordPolicy: " + err.Error())
+	}
+	injector.BindMulti(new(domain.FieldValidator)).ToInstance(validators.NewPasswordStrengthValidator(passwordPolicy))
+	injector.BindMulti(new(domain.FieldValidator)).To(validators.PasswordConfirmValidator{})
 
+	injector.BindMulti(new(domain.FieldModifier)).To(modifiers.StripHTMLModifier{})
+	injector.BindMulti(new(domain.FieldModifier)).To(modifiers.NFCModifier{})
+
+	injector.BindMap(new(domain.FormExtension), extensions.CsrfTokenFormExtensionName).To(extensions.CsrfTokenFormExtension{})
+	injector.BindMap(new(domain.FormExtension), extensions.SpamProtectionFormExtensionName).To(extensions.SpamProtectionFormExtension{})
+
+	messageKeys, err := application.NewValidationMessageKeys(m.MessageKeyPrefix, m.MessageKeyMapping, m.DefaultLabelMapping)
+	if err != nil {
+		panic("form.validator: " + err.Error())
+	}
+	injector.Bind(new(application.ValidationMessageKeys)).ToInstance(messageKeys)
 	injector.Bind(new(domain.ValidatorProvider)).To(application.ValidatorProviderImpl{}).AsEagerSingleton()
 
 	injector.Bind(new(domain.DefaultFormDataProvider)).To(formdata.DefaultFormDataProviderImpl{})
@@ -38,14 +76,90 @@ func (m *Module) Configure(injector *dingo.Injector) {
 	injector.Bind(new(domain.DefaultFormDataValidator)).To(formdata.DefaultFormDataValidatorImpl{})
 	injector.Bind(new(application.FormHandlerFactory)).To(application.FormHandlerFactoryImpl{}).AsEagerSingleton()
 	injector.Bind(new(application.FormDataEncoderFactory)).To(application.FormDataEncoderFactoryImpl{}).AsEagerSingleton()
+	injector.Bind(new(application.FormSessionStore)).To(application.FormSessionStoreImpl{})
+	injector.Bind(new(domain.FormStateStore)).To(application.FormStateStoreImpl{})
+	injector.Bind(new(application.FormResponder)).To(application.FormResponderImpl{})
+	// submission guard remembers accepted submission IDs in memory, so there is only one instance of it
+	injector.Bind(new(application.SubmissionGuard)).To(application.SubmissionGuardImpl{}).In(dingo.Singleton)
 }
 
 // DefaultConfig is method which is responsible for setting up default module configuration
 func (m *Module) DefaultConfig() config.Map {
 	return config.Map{
 		"form.validator": config.Map{
-			"dateFormat":  "2006-01-02",
-			"customRegex": config.Map{},
+			"dateFormat":          "2006-01-02",
+			"customRegex":         config.Map{},
+			"regex":               config.Map{},
+			"messageKeyPrefix":    application.DefaultMessageKeyPrefix,
+			"messageKeyMapping":   config.Map{},
+			"defaultLabelMapping": config.Map{},
+			"firstErrorOnly":      false,
+			"concurrency":         float64(0),
+			"passwordPolicy": config.Map{
+				"minLength":        float64(8),
+				"requireUppercase": false,
+				"requireLowercase": false,
+				"requireDigit":     false,
+				"requireSpecial":   false,
+				"minEntropy":       float64(0),
+			},
+			"passwordField": "Password",
+		},
+		"form.csrf": config.Map{
+			"secret": "",
+			"ttl":    "30m",
+		},
+		"form.spamProtection": config.Map{
+			"secret":      "",
+			"minFillTime": "2s",
+			"maxAge":      "24h",
+			"clockSkew":   "1s",
+		},
+		"form.decoder": config.Map{
+			"maxMemory":    float64(32 << 20),
+			"maxFileSize":  float64(0),
+			"maxBodySize":  float64(10 << 20),
+			"maxFormKeys":  float64(10000),
+			"maxArraySize": float64(10000),
+			"lenient": config.Map{
+				"decimalComma": false,
+				"booleans":     false,
+				"trimNumbers":  false,
+			},
+			"locale": config.Map{
+				"source":  "header",
+				"key":     "Accept-Language",
+				"formats": config.Map{},
+			},
+		},
+		"form.fieldNotation": string(domain.FieldNotationDot),
+		"form.handler": config.Map{
+			"provideTimeout":    "0s",
+			"decodeTimeout":     "0s",
+			"validateTimeout":   "0s",
+			"extensionsTimeout": "0s",
+		},
+		"form.metrics": config.Map{
+			"enabled": false,
+		},
+		"form.sessionStore": config.Map{
+			"ttl":          "5m",
+			"maxSize":      float64(64 << 10),
+			"maxValueSize": float64(4 << 10),
+		},
+		"form.stateStore": config.Map{
+			"ttl":     "168h",
+			"maxSize": float64(64 << 10),
+		},
+		"form.responder": config.Map{
+			"templateDataKey": "form",
+			"validStatus":     float64(200),
+			"invalidStatus":   float64(422),
+		},
+		"form.submissionGuard": config.Map{
+			"ttl":       "30m",
+			"maxIDs":    float64(10),
+			"fieldName": "_submissionId",
 		},
 	}
 }